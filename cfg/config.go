@@ -36,6 +36,13 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 			Value:      int64(DefaultCongestionThreshold()),
 		},
 	},
+}, "read.enable-buffered-read": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-inference",
+			Value: bool(true),
+		},
+	},
 }, "file-system.enable-kernel-reader": {
 	BucketTypeOptimization: []shared.BucketTypeOptimization{
 		{
@@ -89,6 +96,10 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 			Name:  "aiml-checkpointing",
 			Value: bool(true),
 		},
+		{
+			Name:  "aiml-inference",
+			Value: bool(true),
+		},
 	},
 }, "file-system.kernel-list-cache-ttl-secs": {
 	Profiles: []shared.ProfileOptimization{
@@ -139,6 +150,10 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 			Name:  "aiml-checkpointing",
 			Value: int64(0),
 		},
+		{
+			Name:  "aiml-inference",
+			Value: int64(0),
+		},
 	},
 }, "metadata-cache.ttl-secs": {
 	MachineBasedOptimization: []shared.MachineBasedOptimization{
@@ -160,6 +175,73 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 			Name:  "aiml-checkpointing",
 			Value: int64(-1),
 		},
+		{
+			Name:  "aiml-inference",
+			Value: int64(-1),
+		},
+	},
+}, "read.download-deadline-secs": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-serving",
+			Value: int64(10),
+		},
+		{
+			Name:  "aiml-checkpointing",
+			Value: int64(600),
+		},
+	},
+}, "read.download-max-retries": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-serving",
+			Value: int64(1),
+		},
+		{
+			Name:  "aiml-checkpointing",
+			Value: int64(5),
+		},
+	},
+}, "read.global-max-blocks": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-training",
+			Value: int64(200),
+		},
+		{
+			Name:  "aiml-serving",
+			Value: int64(200),
+		},
+		{
+			Name:  "aiml-inference",
+			Value: int64(200),
+		},
+	},
+}, "read.max-blocks-per-handle": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-inference",
+			Value: int64(40),
+		},
+	},
+}, "read.prefetch-policy": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-training",
+			Value: string("sequential"),
+		},
+		{
+			Name:  "aiml-inference",
+			Value: string("sequential"),
+		},
+		{
+			Name:  "bigdata-analytics",
+			Value: string("footer-first"),
+		},
+		{
+			Name:  "metadata-heavy",
+			Value: string("none"),
+		},
 	},
 }, "file-system.rename-dir-limit": {
 	MachineBasedOptimization: []shared.MachineBasedOptimization{
@@ -184,15 +266,38 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 	Profiles: []shared.ProfileOptimization{
 		{
 			Name:  "aiml-training",
-			Value: int64(-1),
+			Value: int64(4096),
 		},
 		{
 			Name:  "aiml-serving",
-			Value: int64(-1),
+			Value: int64(2048),
 		},
 		{
 			Name:  "aiml-checkpointing",
-			Value: int64(-1),
+			Value: int64(64),
+		},
+		{
+			Name:  "aiml-inference",
+			Value: int64(2048),
+		},
+	},
+}, "metadata-cache.type-cache-max-size-mb": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-training",
+			Value: int64(256),
+		},
+		{
+			Name:  "aiml-serving",
+			Value: int64(128),
+		},
+		{
+			Name:  "aiml-checkpointing",
+			Value: int64(16),
+		},
+		{
+			Name:  "aiml-inference",
+			Value: int64(128),
 		},
 	},
 }, "write.global-max-blocks": {
@@ -202,6 +307,13 @@ var AllFlagOptimizationRules = map[string]shared.OptimizationRules{"file-system.
 			Value: int64(1600),
 		},
 	},
+}, "write.upload-strategy": {
+	Profiles: []shared.ProfileOptimization{
+		{
+			Name:  "aiml-checkpointing",
+			Value: string("parallel-composite"),
+		},
+	},
 },
 }
 
@@ -264,6 +376,18 @@ func (c *Config) ApplyOptimizations(v *viper.Viper, input *OptimizationInput) ma
 			}
 		}
 	}
+	if !v.IsSet("read.enable-buffered-read") {
+		rules := AllFlagOptimizationRules["read.enable-buffered-read"]
+		result := getOptimizedValue(&rules, c.Read.EnableBufferedRead, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(bool); ok {
+				if c.Read.EnableBufferedRead != val {
+					c.Read.EnableBufferedRead = val
+					optimizedFlags["read.enable-buffered-read"] = result
+				}
+			}
+		}
+	}
 	if !v.IsSet("file-system.enable-kernel-reader") {
 		rules := AllFlagOptimizationRules["file-system.enable-kernel-reader"]
 		result := getOptimizedValue(&rules, c.FileSystem.EnableKernelReader, profileName, machineType, input, machineTypeToGroupMap)
@@ -372,6 +496,66 @@ func (c *Config) ApplyOptimizations(v *viper.Viper, input *OptimizationInput) ma
 			}
 		}
 	}
+	if !v.IsSet("read.download-deadline-secs") {
+		rules := AllFlagOptimizationRules["read.download-deadline-secs"]
+		result := getOptimizedValue(&rules, c.Read.DownloadDeadlineSecs, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(int64); ok {
+				if c.Read.DownloadDeadlineSecs != val {
+					c.Read.DownloadDeadlineSecs = val
+					optimizedFlags["read.download-deadline-secs"] = result
+				}
+			}
+		}
+	}
+	if !v.IsSet("read.download-max-retries") {
+		rules := AllFlagOptimizationRules["read.download-max-retries"]
+		result := getOptimizedValue(&rules, c.Read.DownloadMaxRetries, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(int64); ok {
+				if c.Read.DownloadMaxRetries != val {
+					c.Read.DownloadMaxRetries = val
+					optimizedFlags["read.download-max-retries"] = result
+				}
+			}
+		}
+	}
+	if !v.IsSet("read.global-max-blocks") {
+		rules := AllFlagOptimizationRules["read.global-max-blocks"]
+		result := getOptimizedValue(&rules, c.Read.GlobalMaxBlocks, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(int64); ok {
+				if c.Read.GlobalMaxBlocks != val {
+					c.Read.GlobalMaxBlocks = val
+					optimizedFlags["read.global-max-blocks"] = result
+				}
+			}
+		}
+	}
+	if !v.IsSet("read.max-blocks-per-handle") {
+		rules := AllFlagOptimizationRules["read.max-blocks-per-handle"]
+		result := getOptimizedValue(&rules, c.Read.MaxBlocksPerHandle, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(int64); ok {
+				if c.Read.MaxBlocksPerHandle != val {
+					c.Read.MaxBlocksPerHandle = val
+					optimizedFlags["read.max-blocks-per-handle"] = result
+				}
+			}
+		}
+	}
+	if !v.IsSet("read.prefetch-policy") {
+		rules := AllFlagOptimizationRules["read.prefetch-policy"]
+		result := getOptimizedValue(&rules, c.Read.PrefetchPolicy, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(string); ok {
+				if c.Read.PrefetchPolicy != val {
+					c.Read.PrefetchPolicy = val
+					optimizedFlags["read.prefetch-policy"] = result
+				}
+			}
+		}
+	}
 	if !v.IsSet("file-system.rename-dir-limit") {
 		rules := AllFlagOptimizationRules["file-system.rename-dir-limit"]
 		result := getOptimizedValue(&rules, c.FileSystem.RenameDirLimit, profileName, machineType, input, machineTypeToGroupMap)
@@ -396,6 +580,18 @@ func (c *Config) ApplyOptimizations(v *viper.Viper, input *OptimizationInput) ma
 			}
 		}
 	}
+	if !v.IsSet("metadata-cache.type-cache-max-size-mb") {
+		rules := AllFlagOptimizationRules["metadata-cache.type-cache-max-size-mb"]
+		result := getOptimizedValue(&rules, c.MetadataCache.TypeCacheMaxSizeMb, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(int64); ok {
+				if c.MetadataCache.TypeCacheMaxSizeMb != val {
+					c.MetadataCache.TypeCacheMaxSizeMb = val
+					optimizedFlags["metadata-cache.type-cache-max-size-mb"] = result
+				}
+			}
+		}
+	}
 	if !v.IsSet("write.global-max-blocks") {
 		rules := AllFlagOptimizationRules["write.global-max-blocks"]
 		result := getOptimizedValue(&rules, c.Write.GlobalMaxBlocks, profileName, machineType, input, machineTypeToGroupMap)
@@ -408,6 +604,18 @@ func (c *Config) ApplyOptimizations(v *viper.Viper, input *OptimizationInput) ma
 			}
 		}
 	}
+	if !v.IsSet("write.upload-strategy") {
+		rules := AllFlagOptimizationRules["write.upload-strategy"]
+		result := getOptimizedValue(&rules, c.Write.UploadStrategy, profileName, machineType, input, machineTypeToGroupMap)
+		if result.Optimized {
+			if val, ok := result.FinalValue.(string); ok {
+				if c.Write.UploadStrategy != val {
+					c.Write.UploadStrategy = val
+					optimizedFlags["write.upload-strategy"] = result
+				}
+			}
+		}
+	}
 	return optimizedFlags
 }
 
@@ -464,6 +672,8 @@ type Config struct {
 
 	Foreground bool `yaml:"foreground"`
 
+	GarbageCollection GarbageCollectionConfig `yaml:"garbage-collection"`
+
 	GcsAuth GcsAuthConfig `yaml:"gcs-auth"`
 
 	GcsConnection GcsConnectionConfig `yaml:"gcs-connection"`
@@ -544,6 +754,8 @@ type FileCacheConfig struct {
 
 	ParallelDownloadsPerFile int64 `yaml:"parallel-downloads-per-file"`
 
+	PreferAfterFullDownload bool `yaml:"prefer-after-full-download"`
+
 	SharedCacheChunkSizeMb int64 `yaml:"shared-cache-chunk-size-mb"`
 
 	WriteBufferSize int64 `yaml:"write-buffer-size"`
@@ -558,12 +770,16 @@ type FileSystemConfig struct {
 
 	EnableKernelReader bool `yaml:"enable-kernel-reader"`
 
+	EnableStatusFile bool `yaml:"enable-status-file"`
+
 	ExperimentalEnableDentryCache bool `yaml:"experimental-enable-dentry-cache"`
 
 	ExperimentalEnablePirlo bool `yaml:"experimental-enable-pirlo"`
 
 	ExperimentalEnableReaddirplus bool `yaml:"experimental-enable-readdirplus"`
 
+	ExperimentalEnableStreamingReaddir bool `yaml:"experimental-enable-streaming-readdir"`
+
 	ExperimentalODirect bool `yaml:"experimental-o-direct"`
 
 	FileMode Octal `yaml:"file-mode"`
@@ -582,15 +798,39 @@ type FileSystemConfig struct {
 
 	MaxBackground int64 `yaml:"max-background"`
 
+	MaxHandlesPerFile int64 `yaml:"max-handles-per-file"`
+
 	MaxReadAheadKb int64 `yaml:"max-read-ahead-kb"`
 
 	RenameDirLimit int64 `yaml:"rename-dir-limit"`
 
+	RenameDirOverLimitMode string `yaml:"rename-dir-over-limit-mode"`
+
 	TempDir ResolvedPath `yaml:"temp-dir"`
 
 	Uid int64 `yaml:"uid"`
 }
 
+type GarbageCollectionConfig struct {
+	DeleteOpsPerSec float64 `yaml:"delete-ops-per-sec"`
+
+	DeleteParallelism int64 `yaml:"delete-parallelism"`
+
+	DryRun bool `yaml:"dry-run"`
+
+	ExcludePatterns []string `yaml:"exclude-patterns"`
+
+	FinalSweep bool `yaml:"final-sweep"`
+
+	MaxDeleteBacklog int64 `yaml:"max-delete-backlog"`
+
+	Mode string `yaml:"mode"`
+
+	TempObjectPeriod time.Duration `yaml:"temp-object-period"`
+
+	TempObjectStaleness time.Duration `yaml:"temp-object-staleness"`
+}
+
 type GcsAuthConfig struct {
 	AnonymousAccess bool `yaml:"anonymous-access"`
 
@@ -628,6 +868,8 @@ type GcsConnectionConfig struct {
 
 	MaxIdleConnsPerHost int64 `yaml:"max-idle-conns-per-host"`
 
+	RegionAwareReadEndpoint bool `yaml:"region-aware-read-endpoint"`
+
 	SequentialReadSizeMb int64 `yaml:"sequential-read-size-mb"`
 }
 
@@ -649,6 +891,8 @@ type GcsRetriesConfig struct {
 
 type ListConfig struct {
 	EnableEmptyManagedFolders bool `yaml:"enable-empty-managed-folders"`
+
+	VanishedEntryMode string `yaml:"vanished-entry-mode"`
 }
 
 type LogRotateLoggingConfig struct {
@@ -704,6 +948,10 @@ type MetricsConfig struct {
 
 	ExperimentalEnableGrpcMetrics bool `yaml:"experimental-enable-grpc-metrics"`
 
+	Exporter string `yaml:"exporter"`
+
+	OtlpEndpoint string `yaml:"otlp-endpoint"`
+
 	PrometheusPort int64 `yaml:"prometheus-port"`
 
 	StackdriverExportInterval time.Duration `yaml:"stackdriver-export-interval"`
@@ -718,21 +966,105 @@ type MrdConfig struct {
 }
 
 type ReadConfig struct {
+	BlockAlignment string `yaml:"block-alignment"`
+
+	BlockChecksumManifest ResolvedPath `yaml:"block-checksum-manifest"`
+
 	BlockSizeMb int64 `yaml:"block-size-mb"`
 
+	CancelledDownloadPolicy string `yaml:"cancelled-download-policy"`
+
+	CircuitBreakerCooldown time.Duration `yaml:"circuit-breaker-cooldown"`
+
+	CircuitBreakerFailureThreshold int64 `yaml:"circuit-breaker-failure-threshold"`
+
+	DemandBlockSizeKb int64 `yaml:"demand-block-size-kb"`
+
+	DownloadDeadlineSecs int64 `yaml:"download-deadline-secs"`
+
+	DownloadLimitBytesPerSec float64 `yaml:"download-limit-bytes-per-sec"`
+
+	DownloadMaxRetries int64 `yaml:"download-max-retries"`
+
+	DownloadRescheduleBackoff time.Duration `yaml:"download-reschedule-backoff"`
+
+	DownloadReschedules int64 `yaml:"download-reschedules"`
+
+	DownloadRetryBackoff time.Duration `yaml:"download-retry-backoff"`
+
+	EfficiencySummaryInterval time.Duration `yaml:"efficiency-summary-interval"`
+
 	EnableBufferedRead bool `yaml:"enable-buffered-read"`
 
+	FailureDiagnosticInterval time.Duration `yaml:"failure-diagnostic-interval"`
+
+	FallbackOnDownloadFailure bool `yaml:"fallback-on-download-failure"`
+
+	FanOutMaxBlocks int64 `yaml:"fan-out-max-blocks"`
+
+	GenerationChangeMode string `yaml:"generation-change-mode"`
+
+	GenerationStrategy string `yaml:"generation-strategy"`
+
 	GlobalMaxBlocks int64 `yaml:"global-max-blocks"`
 
+	GlobalMaxMemoryFraction float64 `yaml:"global-max-memory-fraction"`
+
+	HotFileMinOpens int64 `yaml:"hot-file-min-opens"`
+
+	HotFileResidentBlocks int64 `yaml:"hot-file-resident-blocks"`
+
+	HotFileWindow time.Duration `yaml:"hot-file-window"`
+
 	InactiveStreamTimeout time.Duration `yaml:"inactive-stream-timeout"`
 
 	MaxBlocksPerHandle int64 `yaml:"max-blocks-per-handle"`
 
+	MaxBlocksPerObject int64 `yaml:"max-blocks-per-object"`
+
+	MaxDownloadsPerObject int64 `yaml:"max-downloads-per-object"`
+
+	MaxInflightDownloads int64 `yaml:"max-inflight-downloads"`
+
+	MaxPrefetchFiles int64 `yaml:"max-prefetch-files"`
+
 	MinBlocksPerHandle int64 `yaml:"min-blocks-per-handle"`
 
+	ObjectMetadataKeys []string `yaml:"object-metadata-keys"`
+
+	PhaseDetectionWindow int64 `yaml:"phase-detection-window"`
+
+	PhaseRandomThreshold float64 `yaml:"phase-random-threshold"`
+
+	PhaseSequentialThreshold float64 `yaml:"phase-sequential-threshold"`
+
+	PinnedMaxBlocks int64 `yaml:"pinned-max-blocks"`
+
+	PinnedObjects []string `yaml:"pinned-objects"`
+
+	PreallocateBlocks bool `yaml:"preallocate-blocks"`
+
+	PrefetchPolicy string `yaml:"prefetch-policy"`
+
 	RandomSeekThreshold int64 `yaml:"random-seek-threshold"`
 
+	SlidingWindowBlocks int64 `yaml:"sliding-window-blocks"`
+
+	SourceOrder []string `yaml:"source-order"`
+
 	StartBlocksPerHandle int64 `yaml:"start-blocks-per-handle"`
+
+	Timeout time.Duration `yaml:"timeout"`
+
+	VerifyObjectChecksum bool `yaml:"verify-object-checksum"`
+
+	WarmStateFile ResolvedPath `yaml:"warm-state-file"`
+
+	WarmStateParallelism int64 `yaml:"warm-state-parallelism"`
+
+	WorkerPoolWatchdogCancel bool `yaml:"worker-pool-watchdog-cancel"`
+
+	WorkerPoolWatchdogTimeout time.Duration `yaml:"worker-pool-watchdog-timeout"`
 }
 
 type ReadStallGcsRetriesConfig struct {
@@ -781,6 +1113,12 @@ type WriteConfig struct {
 	GlobalMaxBlocks int64 `yaml:"global-max-blocks"`
 
 	MaxBlocksPerFile int64 `yaml:"max-blocks-per-file"`
+
+	ParallelCompositeComponentSizeMb int64 `yaml:"parallel-composite-component-size-mb"`
+
+	TmpObjectNameTemplate string `yaml:"tmp-object-name-template"`
+
+	UploadStrategy string `yaml:"upload-strategy"`
 }
 
 func BuildFlagSet(flagSet *pflag.FlagSet) error {
@@ -1009,6 +1347,12 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.BoolP("enable-status-file", "", false, "Exposes the read-only file .gcsfuse/status.json under the mount root, holding the resolved configuration, profile, machine type and key buffered read and garbage collection parameters as JSON. The .gcsfuse directory isn't listed, and shadows any objects under the same prefix.")
+
+	if err := flagSet.MarkHidden("enable-status-file"); err != nil {
+		return err
+	}
+
 	flagSet.BoolP("enable-streaming-writes", "", true, "Enables streaming uploads during write file operation.")
 
 	flagSet.BoolP("enable-type-cache-deprecation", "", true, "Enables support to deprecate type cache.")
@@ -1053,6 +1397,12 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.BoolP("experimental-enable-streaming-readdir", "", false, "Streams directory listings to the kernel one GCS listing page at a time, rather than buffering the names of all the entries of a directory before responding. This bounds the memory used to list directories with millions of entries, at the cost of relisting the directory from the start on a backward seekdir. Only applies to ReadDir, not to ReadDirPlus.")
+
+	if err := flagSet.MarkHidden("experimental-enable-streaming-readdir"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("experimental-grpc-conn-pool-size", "", 1, "The number of gRPC channel in grpc client.")
 
 	if err := flagSet.MarkDeprecated("experimental-grpc-conn-pool-size", "Experimental flag: can be removed in a minor release."); err != nil {
@@ -1129,6 +1479,12 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 
 	flagSet.IntP("file-cache-parallel-downloads-per-file", "", 16, "Number of concurrent download requests per file.")
 
+	flagSet.BoolP("file-cache-prefer-after-full-download", "", false, "Serves the reads of an object from the file cache once a read downloaded it in full, freeing the buffered read blocks of the file handles reading it. The first read of an object not fully cached downloads it in full into the file cache, even if random. Requires the file cache and buffered read to be enabled.")
+
+	if err := flagSet.MarkHidden("file-cache-prefer-after-full-download"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("file-cache-shared-cache-chunk-size-mb", "", 8, "Chunk size in MiBs for shared chunk cache. Each chunk is downloaded on-demand.")
 
 	if err := flagSet.MarkHidden("file-cache-shared-cache-chunk-size-mb"); err != nil {
@@ -1151,6 +1507,60 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 
 	flagSet.BoolP("foreground", "", false, "Stay in the foreground after mounting.")
 
+	flagSet.Float64P("garbage-collection-delete-ops-per-sec", "", -1, "Limits the rate at which garbage collection deletes the stale temporary objects, in deletions per second across all the buckets of the mount, so that the deletions don't use up the write quota of shared buckets (use -1 for no limit).")
+
+	if err := flagSet.MarkHidden("garbage-collection-delete-ops-per-sec"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("garbage-collection-delete-parallelism", "", 4, "The number of stale temporary objects that garbage collection deletes concurrently per bucket. The run stops at the first failed deletion.")
+
+	if err := flagSet.MarkHidden("garbage-collection-delete-parallelism"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("garbage-collection-dry-run", "", false, "Makes garbage collection only log the stale temporary objects it would delete, along with their age, leaving them in place. Useful to audit the cleanup before enabling it on shared buckets.")
+
+	if err := flagSet.MarkHidden("garbage-collection-dry-run"); err != nil {
+		return err
+	}
+
+	flagSet.StringSliceP("garbage-collection-exclude-patterns", "", []string{}, "Comma separated names, or glob patterns like \".gcsfuse_tmp/scratch-*\", of the temporary objects which garbage collection never deletes, however stale, e.g. those of other tooling sharing the temporary object prefix. The patterns are those of Go's path.Match, where * doesn't match /.")
+
+	if err := flagSet.MarkHidden("garbage-collection-exclude-patterns"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("garbage-collection-final-sweep", "", false, "Runs garbage collection one last time on unmount, so that the temporary objects which became stale since the last run aren't left behind until the next mount or a bucket lifecycle rule deletes them. Like the other runs, the sweep only deletes the objects older than the staleness, the fresher ones possibly belonging to writes in progress on other mounts. The sweep is given 30 seconds. It is skipped if the mount fails.")
+
+	if err := flagSet.MarkHidden("garbage-collection-final-sweep"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("garbage-collection-max-delete-backlog", "", 100, "The maximum number of stale temporary objects that garbage collection has listed but not yet deleted. Listing pauses while the backlog is full, so that slow deletions hold back the listing.")
+
+	if err := flagSet.MarkHidden("garbage-collection-max-delete-backlog"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("garbage-collection-mode", "", "client", "Specifies how the stale temporary objects left behind by interrupted writes are deleted. With \"client\", gcsfuse periodically lists and deletes them itself. With \"lifecycle\", gcsfuse relies on a bucket lifecycle rule deleting them, and only checks at mount time that such a rule exists. Supported values: client, lifecycle.")
+
+	if err := flagSet.MarkHidden("garbage-collection-mode"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("gc-temp-object-period", "", 600000000000*time.Nanosecond, "The interval between the garbage collection runs deleting the stale temporary objects left behind by interrupted writes.")
+
+	if err := flagSet.MarkHidden("gc-temp-object-period"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("gc-temp-object-staleness", "", 1800000000000*time.Nanosecond, "The age beyond which garbage collection deletes the temporary objects left behind by interrupted writes. Must be at least 1s.")
+
+	if err := flagSet.MarkHidden("gc-temp-object-staleness"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("gid", "", -1, "GID owner of all inodes.")
 
 	flagSet.StringP("grpc-path-strategy", "", "direct-path-with-fallback", "Strategy for DirectPath connectivity when client-protocol=grpc. Options: 'direct-path-only' (fail if unavailable), 'direct-path-with-fallback' (always fallback to HTTP/1 when direct path is not available).")
@@ -1185,6 +1595,12 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 
 	flagSet.Float64P("limit-ops-per-sec", "", -1, "Operations per second limit, measured over a 30-second window (use -1 for no limit)")
 
+	flagSet.StringP("list-vanished-entry-mode", "", "skip", "Specifies how directory listings with attributes handle the entries which vanish between being listed and being looked up, like objects replaced or local files removed meanwhile. With \"skip\", the vanished entries are left out of the listing. With \"error\", the listing fails. Supported values: skip, error.")
+
+	if err := flagSet.MarkHidden("list-vanished-entry-mode"); err != nil {
+		return err
+	}
+
 	flagSet.StringP("log-file", "", "", "The file for storing logs that can be parsed by fluentd. When not provided, plain text logs are printed to stdout when Cloud Storage FUSE is run in the foreground, or to syslog when Cloud Storage FUSE is run in the background.")
 
 	flagSet.StringP("log-format", "", "json", "The format of the log file: 'text' or 'json'.")
@@ -1211,6 +1627,12 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 
 	flagSet.IntP("max-conns-per-host", "", 0, "The max number of TCP connections allowed per server. This is effective when client-protocol is set to 'http1'. A value of 0 indicates no limit on TCP connections (limited by the machine specifications).")
 
+	flagSet.IntP("max-handles-per-file", "", 0, "Specifies the maximum number of concurrently open handles of a file, beyond which opens fail with EMFILE. This protects memory and connection pools from applications opening the same object thousands of times. The value should be >= 0, 0 meaning no limit.")
+
+	if err := flagSet.MarkHidden("max-handles-per-file"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("max-idle-conns-per-host", "", 100, "The number of maximum idle connections allowed per server.")
 
 	flagSet.IntP("max-read-ahead-kb", "", 0, "Sets max kernel-read-ahead for the mount in KiB. 0 means system default. Requires sudo permission to set this value, otherwise the value will be ignored and system default will be used.")
@@ -1243,6 +1665,10 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.StringP("metrics-exporter", "", "", "Specifies an exporter the metrics are exported with, besides Prometheus and Cloud Monitoring. Only \"otlp\" is supported, which exports the metrics to the OpenTelemetry collector at metrics-otlp-endpoint over OTLP/gRPC.")
+
+	flagSet.StringP("metrics-otlp-endpoint", "", "", "URL of the OpenTelemetry collector the metrics are exported to with the otlp metrics exporter, e.g. http://localhost:4317. The http scheme disables TLS.")
+
 	flagSet.BoolP("metrics-use-new-names", "", false, "Use the new metric names.")
 
 	if err := flagSet.MarkHidden("metrics-use-new-names"); err != nil {
@@ -1265,27 +1691,179 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 
 	flagSet.StringP("only-dir", "", "", "Mount only a specific directory within the bucket. See docs/mounting for more information")
 
-	flagSet.StringP("profile", "", "", "The name of the profile to apply. e.g. aiml-training, aiml-serving, aiml-checkpointing")
+	flagSet.StringP("profile", "", "", "The name of the profile to apply. e.g. aiml-training, aiml-serving, aiml-inference, aiml-checkpointing, bigdata-analytics, metadata-heavy")
 
 	flagSet.IntP("prometheus-port", "", 0, "Expose Prometheus metrics endpoint on this port and a path of /metrics.")
 
-	flagSet.IntP("read-block-size-mb", "", 16, "Specifies the block size for buffered reads. The value should be more than 0. This is used to read data in chunks from GCS.")
+	flagSet.StringP("read-block-alignment", "", "none", "Specifies how buffered reads align blocks to the object size. With \"none\", every block has the configured block size and the last block holds the remainder, however small. With \"object-size\", the blocks of an object are evenly sized, at most the configured block size, so that the last one isn't pathologically small. Supported values: none, object-size.")
 
-	if err := flagSet.MarkHidden("read-block-size-mb"); err != nil {
+	if err := flagSet.MarkHidden("read-block-alignment"); err != nil {
 		return err
 	}
 
-	flagSet.IntP("read-global-max-blocks", "", 40, "Specifies the maximum number of blocks available for buffered reads across all file-handles. The value should be >= 0 or -1 (for infinite blocks). A value of 0 disables buffered reads.")
-
-	flagSet.DurationP("read-inactive-stream-timeout", "", 10000000000*time.Nanosecond, "Duration of inactivity after which an open GCS read stream is automatically closed. This helps conserve resources when a file handle remains open without active Read calls. A value of '0s' disables this timeout.")
+	flagSet.StringP("read-block-checksum-manifest", "", "", "Path to a JSON manifest of the expected CRC32C checksums of object blocks, e.g. [{\"object\": \"a/b\", \"start\": 0, \"end\": 16777216, \"crc32c\": 1234}]. When set, each block downloaded by buffered reads that is listed in the manifest is validated against it, and reads of a block whose checksum doesn't match fail with a corruption error.")
 
-	if err := flagSet.MarkHidden("read-inactive-stream-timeout"); err != nil {
+	if err := flagSet.MarkHidden("read-block-checksum-manifest"); err != nil {
 		return err
 	}
 
-	flagSet.IntP("read-max-blocks-per-handle", "", 20, "Specifies the maximum number of blocks to be used by a single file handle for buffered reads. The value should be >= 0 or -1 (for infinite blocks). A value of 0 disables buffered reads.")
+	flagSet.IntP("read-block-size-mb", "", 16, "Specifies the block size for buffered reads. The value should be more than 0. This is used to read data in chunks from GCS.")
 
-	if err := flagSet.MarkHidden("read-max-blocks-per-handle"); err != nil {
+	if err := flagSet.MarkHidden("read-block-size-mb"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("read-cancelled-download-policy", "", "discard", "Specifies what happens to the bytes of a buffered read block whose download is cancelled midway, e.g. when the reader it was prefetched for seeks away while another reader borrows it. With \"discard\", the block is dropped and downloaded afresh when needed. With \"keep-partial\", the bytes downloaded until the cancellation are kept and serve the reads they cover, the rest of the block being downloaded afresh. Blocks validated against a checksum manifest are always discarded. Supported values: discard, keep-partial.")
+
+	if err := flagSet.MarkHidden("read-cancelled-download-policy"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-circuit-breaker-cooldown", "", 30000000000*time.Nanosecond, "Specifies how long buffered reads fail fast once the circuit breaker opened, see \"read-circuit-breaker-failure-threshold\", before letting a read through to probe whether GCS recovered. The circuit closes on the first successful download, and a read is let through every cooldown until then.")
+
+	if err := flagSet.MarkHidden("read-circuit-breaker-cooldown"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-circuit-breaker-failure-threshold", "", 0, "Specifies the number of consecutive failed buffered read downloads, across all the files of the mount, after which the circuit breaker opens and buffered reads fail fast with an input/output error rather than each waiting out the retries and timeouts of its downloads, e.g. during a GCS outage. 0 disables the circuit breaker.")
+
+	if err := flagSet.MarkHidden("read-circuit-breaker-failure-threshold"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-demand-block-size-kb", "", 0, "Specifies the size, in KiB, of the block downloaded first when a buffered read starts reading at a new offset, so that the read gets its first bytes sooner than from a whole block of \"read-block-size-mb\". The block holding the offset is prefetched as usual, alongside the smaller one, while readers not allowed to prefetch read in blocks of this size. It must not exceed the block size. 0 means demand reads use the block size.")
+
+	if err := flagSet.MarkHidden("read-demand-block-size-kb"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-download-deadline-secs", "", 0, "Specifies the deadline, in seconds, of each attempt to download a block for buffered reads. An attempt exceeding it is cancelled and counts as failed. 0 means no deadline.")
+
+	if err := flagSet.MarkHidden("read-download-deadline-secs"); err != nil {
+		return err
+	}
+
+	flagSet.Float64P("read-download-limit-bytes-per-sec", "", -1, "Specifies the aggregate bandwidth limit, in bytes per second, of the buffered read block downloads of the mount, e.g. so that prefetches don't saturate the network of a node shared with other workloads. Unlike \"limit-bytes-per-sec\", it leaves the other reads unthrottled. -1 means no limit.")
+
+	if err := flagSet.MarkHidden("read-download-limit-bytes-per-sec"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-download-max-retries", "", 0, "Specifies the number of times a failed block download for buffered reads is retried, resuming from the last downloaded byte, before the block is marked as failed. 0 means no retries.")
+
+	if err := flagSet.MarkHidden("read-download-max-retries"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-download-reschedule-backoff", "", 100000000*time.Nanosecond, "Specifies how long buffered reads wait before re-scheduling the download of a block that failed, see \"read-download-reschedules\".")
+
+	if err := flagSet.MarkHidden("read-download-reschedule-backoff"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-download-reschedules", "", 1, "Specifies the number of times a buffered read re-schedules the download of a block that failed once its own retries, see \"read-download-max-retries\", are exhausted, before failing the read. 0 means the read fails on the first failed download.")
+
+	if err := flagSet.MarkHidden("read-download-reschedules"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-download-retry-backoff", "", 100000000*time.Nanosecond, "Specifies how long a failed block download for buffered reads waits before its first retry, see \"read-download-max-retries\", the wait doubling after each retry.")
+
+	if err := flagSet.MarkHidden("read-download-retry-backoff"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-efficiency-summary-interval", "", 0*time.Nanosecond, "Interval at which a summary of buffered read efficiency (bytes read, prefetch hit rate, waste rate, average block latency and block pool utilization) over the last interval is logged at INFO severity. Only applies when buffered read is enabled. A value of 0 disables the summary.")
+
+	flagSet.DurationP("read-failure-diagnostic-interval", "", 3600000000000*time.Nanosecond, "On a read failure, a diagnostic bundle (profile, machine-type, bucket type, block pool and worker pool utilization, TCP stats and the failing object and range) is logged at ERROR severity, at most once per this interval. A value of 0 disables the diagnostic.")
+
+	if err := flagSet.MarkHidden("read-failure-diagnostic-interval"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("read-fallback-on-download-failure", "", false, "Enables a buffered read whose block download failed, once the download is no longer re-scheduled, see \"read-download-reschedules\", to fall back to reading the requested range directly from GCS rather than failing. The failures due to the object being clobbered, the read being cancelled or the permission being denied still fail the read.")
+
+	if err := flagSet.MarkHidden("read-fallback-on-download-failure"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-fan-out-max-blocks", "", 4, "Specifies the maximum number of blocks covering a single buffered read that are scheduled for download at once, in parallel, when the read spans several blocks, rather than downloading them one after the other as the read progresses. 0 disables the fan-out.")
+
+	if err := flagSet.MarkHidden("read-fan-out-max-blocks"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("read-generation-change-mode", "", "estale", "Specifies how reads of an open file react to the object being replaced by a new generation: \"estale\" fails the reads with ESTALE, \"reopen\" carries on reading the new generation from the current position.")
+
+	if err := flagSet.MarkHidden("read-generation-change-mode"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("read-generation-strategy", "", "latest-at-open", "Specifies which generation of its object an open file reads: \"latest-at-open\" reads the generation current when the file was opened, \"latest-always\" downloads each block of buffered reads from the latest generation, possibly mixing the contents of several generations, and \"snapshot\" reads the generation current when the file was opened and fails with ESTALE once it's replaced, whatever read-generation-change-mode says.")
+
+	if err := flagSet.MarkHidden("read-generation-strategy"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-global-max-blocks", "", 40, "Specifies the maximum number of blocks available for buffered reads across all file-handles. The value should be >= 0 or -1 (for infinite blocks). A value of 0 disables buffered reads. When set explicitly, it takes precedence over the value set by the profile, e.g. to shrink the block pool on a shared node.")
+
+	flagSet.Float64P("read-global-max-memory-fraction", "", 0, "Sizes the block pool of buffered reads as a fraction, in (0, 1], of the memory limit of the container, detected from its cgroup at startup, so that the same config fits pods of any size. It takes precedence over \"read-global-max-blocks\", which is used instead off a container or if the limit can't be detected. 0 disables it.")
+
+	if err := flagSet.MarkHidden("read-global-max-memory-fraction"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-hot-file-min-opens", "", 3, "Specifies the number of times a file must be opened for buffered reads within \"read-hot-file-window\" to be considered hot, see \"read-hot-file-resident-blocks\". The value should be >= 1.")
+
+	if err := flagSet.MarkHidden("read-hot-file-min-opens"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-hot-file-resident-blocks", "", 0, "Specifies the minimum number of blocks kept prefetched ahead of the read position of hot files, re-prefetched as soon as they are consumed or evicted, so that frequently re-accessed files don't stall on cold blocks. It complements the prefetch window, which only grows as reads progress. 0 disables the floor.")
+
+	if err := flagSet.MarkHidden("read-hot-file-resident-blocks"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-hot-file-window", "", 600000000000*time.Nanosecond, "Specifies the window within which the opens of a file are counted to decide whether it is hot, see \"read-hot-file-min-opens\".")
+
+	if err := flagSet.MarkHidden("read-hot-file-window"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-inactive-stream-timeout", "", 10000000000*time.Nanosecond, "Duration of inactivity after which an open GCS read stream is automatically closed. This helps conserve resources when a file handle remains open without active Read calls. A value of '0s' disables this timeout.")
+
+	if err := flagSet.MarkHidden("read-inactive-stream-timeout"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-max-blocks-per-handle", "", 20, "Specifies the maximum number of blocks to be used by a single file handle for buffered reads. The value should be >= 0 or -1 (for infinite blocks). A value of 0 disables buffered reads.")
+
+	if err := flagSet.MarkHidden("read-max-blocks-per-handle"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-max-blocks-per-object", "", 0, "Specifies the maximum number of blocks of a single object held in the buffered read block pool at once, across all the file handles of the object, so that a huge file read sequentially can't fill up the pool and starve the other files read concurrently. An object at this limit gets no more blocks until the ones already read are released. A value of 0 disables the limit.")
+
+	if err := flagSet.MarkHidden("read-max-blocks-per-object"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-max-downloads-per-object", "", 0, "Specifies the maximum number of blocks of a single object downloaded concurrently via buffered reads, across all the file handles of the object, so that a hot object can't monopolize the download workers or hammer GCS. Downloads beyond this limit wait for a slot. A value of 0 disables the limit.")
+
+	if err := flagSet.MarkHidden("read-max-downloads-per-object"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-max-inflight-downloads", "", 0, "Specifies the maximum number of block downloads of buffered reads in flight at once across all the files of the mount, counting the downloads scheduled but not yet running, so as to bound the connections and memory they use however many files are open. Downloads beyond this limit are queued until one completes. Unlike the worker pool size, which bounds the downloads running, this bounds the downloads scheduled. A value of 0 disables the limit.")
+
+	if err := flagSet.MarkHidden("read-max-inflight-downloads"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-max-prefetch-files", "", -1, "Specifies the maximum number of files that can prefetch concurrently via buffered reads. Files opened beyond this limit are served demand-only (no read-ahead) until a slot frees up. The value should be >= 0 or -1 (for infinite).")
+
+	if err := flagSet.MarkHidden("read-max-prefetch-files"); err != nil {
 		return err
 	}
 
@@ -1295,12 +1873,72 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.StringSliceP("read-object-metadata-keys", "", []string{}, "Comma separated custom metadata keys of objects made available to buffered reads, e.g. gcsfuse-prefetch-policy to select the prefetch policy of an object. Other custom metadata keys are ignored by buffered reads.")
+
+	if err := flagSet.MarkHidden("read-object-metadata-keys"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-phase-detection-window", "", 0, "Specifies the number of recent reads of a file-handle over which buffered reads detect whether the file is being read randomly or sequentially, for the adaptive prefetch policy to stop prefetching during random phases and resume once reads turn sequential again. 0 disables the detection, the prefetch policy being then kept for the lifetime of the file-handle.")
+
+	if err := flagSet.MarkHidden("read-phase-detection-window"); err != nil {
+		return err
+	}
+
+	flagSet.Float64P("read-phase-random-threshold", "", 0.75, "Specifies the fraction of random reads, out of the last \"read-phase-detection-window\" reads, at which a sequential phase switches to a random one. The value should be > 0.5 and <= 1.")
+
+	if err := flagSet.MarkHidden("read-phase-random-threshold"); err != nil {
+		return err
+	}
+
+	flagSet.Float64P("read-phase-sequential-threshold", "", 0.75, "Specifies the fraction of sequential reads, out of the last \"read-phase-detection-window\" reads, at which a random phase switches back to a sequential one. The value should be > 0.5 and <= 1.")
+
+	if err := flagSet.MarkHidden("read-phase-sequential-threshold"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-pinned-max-blocks", "", 0, "Specifies the number of blocks, out of \"read-global-max-blocks\", reserved for the blocks of the pinned objects. The blocks of the other objects can't use this reservation, and the pinned blocks can't use the rest, so that neither starves the other. The value should be >= 0, and less than \"read-global-max-blocks\" if pinned objects are configured.")
+
+	if err := flagSet.MarkHidden("read-pinned-max-blocks"); err != nil {
+		return err
+	}
+
+	flagSet.StringSliceP("read-pinned-objects", "", []string{}, "Comma separated names of objects whose buffered read blocks stay in memory for the lifetime of the mount once fetched, e.g. latency critical model files. Pinned blocks are never evicted, and count against \"read-pinned-max-blocks\".")
+
+	if err := flagSet.MarkHidden("read-pinned-objects"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("read-preallocate-blocks", "", false, "Allocates all the buffered read blocks allowed by read-global-max-blocks at mount time, with their memory resident, so that reads never wait on block allocation. Trades mount time and steady memory usage for predictable read latency. Requires a finite read-global-max-blocks.")
+
+	if err := flagSet.MarkHidden("read-preallocate-blocks"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("read-prefetch-policy", "", "adaptive", "Specifies the policy used by buffered reads to decide which blocks to prefetch. Supported values: sequential, adaptive, footer-first, none.")
+
+	if err := flagSet.MarkHidden("read-prefetch-policy"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("read-random-seek-threshold", "", 3, "Specifies the random seek threshold to switch to another reader when random reads are detected.")
 
 	if err := flagSet.MarkHidden("read-random-seek-threshold"); err != nil {
 		return err
 	}
 
+	flagSet.IntP("read-sliding-window-blocks", "", 0, "Specifies the maximum number of blocks of a file held in memory by a buffered reader, downloaded or being downloaded, for streaming reads of large objects. The window slides as the reads progress: each block consumed is evicted and the download of the next one scheduled in its place, so that the memory of each file is bounded whatever the size of the object while the downloads keep ahead of the reads. It caps \"read-max-blocks-per-handle\". 0 disables the window.")
+
+	if err := flagSet.MarkHidden("read-sliding-window-blocks"); err != nil {
+		return err
+	}
+
+	flagSet.StringSliceP("read-source-order", "", []string{"cache", "pool", "gcs"}, "Comma separated order in which the sources of the data of a file are looked up to serve a read, until one of them holds it: cache (the file cache), pool (the buffered read blocks) and gcs, which always serves the read and so must come last, e.g. \"pool,cache,gcs\" to prefer the blocks in memory over the cache files.")
+
+	if err := flagSet.MarkHidden("read-source-order"); err != nil {
+		return err
+	}
+
 	flagSet.DurationP("read-stall-initial-req-timeout", "", 20000000000*time.Nanosecond, "Initial value of the read-request dynamic timeout.")
 
 	if err := flagSet.MarkHidden("read-stall-initial-req-timeout"); err != nil {
@@ -1337,8 +1975,56 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.DurationP("read-timeout", "", 0*time.Nanosecond, "Specifies how long a buffered read waits for the blocks it reads to be downloaded before failing with EIO, whatever the deadline of each download, see \"read-download-deadline-secs\", so that applications don't hang on reads of a wedged mount. 0 disables the timeout.")
+
+	if err := flagSet.MarkHidden("read-timeout"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("read-verify-object-checksum", "", false, "Validates the buffered read blocks holding whole objects against the CRC32C checksum of the object, reads of a block whose checksum doesn't match failing with a corruption error. Costs the CPU of checksumming the blocks. Blocks of objects larger than a block, and of objects without a checksum, e.g. in CMEK buckets, aren't validated.")
+
+	if err := flagSet.MarkHidden("read-verify-object-checksum"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("read-warm-state-file", "", "", "Path to a JSON file where the byte ranges of the pinned buffered read blocks are saved on unmount. When the file exists at mount, the blocks it lists are downloaded and pinned again in the background, so that a restarted job finds its working set in memory. Only the ranges are saved, not the data.")
+
+	if err := flagSet.MarkHidden("read-warm-state-file"); err != nil {
+		return err
+	}
+
+	flagSet.IntP("read-warm-state-parallelism", "", 4, "Specifies the number of blocks of the warm state file, see \"read-warm-state-file\", downloaded concurrently when they are pinned again at mount. These downloads don't go through the buffered read worker pool, so that they don't delay the reads. The value should be >= 1.")
+
+	if err := flagSet.MarkHidden("read-warm-state-parallelism"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("read-worker-pool-watchdog-cancel", "", false, "Cancels the buffered read downloads running in a stuck worker pool, see \"read-worker-pool-watchdog-timeout\", oldest first, so that the workers serve the queued downloads again. The reads of the cancelled blocks fail. Otherwise the stuck downloads are only logged.")
+
+	if err := flagSet.MarkHidden("read-worker-pool-watchdog-cancel"); err != nil {
+		return err
+	}
+
+	flagSet.DurationP("read-worker-pool-watchdog-timeout", "", 0*time.Nanosecond, "Specifies how long the buffered read worker pool may go without completing any download, while downloads are queued, before it is deemed stuck, e.g. with all its workers blocked on a hung backend. A stuck pool is logged along with the downloads it runs. 0 disables the watchdog.")
+
+	if err := flagSet.MarkHidden("read-worker-pool-watchdog-timeout"); err != nil {
+		return err
+	}
+
+	flagSet.BoolP("region-aware-read-endpoint", "", false, "With this option, reads from dual-region and multi-region buckets are sent to the endpoint of the region gcsfuse runs in, as detected from the GCE metadata server, rather than to the global endpoint, if the bucket stores its data in that region. Only applies to the HTTP client protocols. Off GCE, or if the region can't be detected, reads use the default endpoint, as do the reads failing through the regional endpoint.")
+
+	if err := flagSet.MarkHidden("region-aware-read-endpoint"); err != nil {
+		return err
+	}
+
 	flagSet.IntP("rename-dir-limit", "", 0, "Allow rename a directory containing fewer descendants than this limit.")
 
+	flagSet.StringP("rename-dir-over-limit-mode", "", "fail", "Specifies how renaming a directory with more descendants than rename-dir-limit is handled in buckets without hierarchical namespace: \"fail\" fails the rename, \"copy-delete\" renames the descendants one by one, copying and deleting them, with progress logs. A rename failing midway leaves the descendants split between the two directories.")
+
+	if err := flagSet.MarkHidden("rename-dir-over-limit-mode"); err != nil {
+		return err
+	}
+
 	flagSet.Float64P("retry-multiplier", "", 2, "Param for exponential backoff algorithm, which is used to increase waiting time b/w two consecutive retries.")
 
 	flagSet.BoolP("reuse-token-from-url", "", true, "If false, the token acquired from token-url is not reused.")
@@ -1431,6 +2117,24 @@ func BuildFlagSet(flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	flagSet.IntP("write-parallel-composite-component-size-mb", "", 64, "Specifies the size of the component objects of parallel composite uploads, see \"write-upload-strategy\". Files spanning fewer than two components are uploaded at once. The value should be more than 0.")
+
+	if err := flagSet.MarkHidden("write-parallel-composite-component-size-mb"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("write-tmp-object-name-template", "", "", "Specifies the template of the names of the temporary objects staged by writes, under the temporary object prefix, so that garbage collection and audit tooling can parse them. The placeholders {instance}, {timestamp}, {type} and {random} are replaced with the ID of the mount instance, the UTC creation time, the type of the object, upload for the components of parallel composite uploads or compose for the contents appended to objects, and a random hexadecimal number, which the template must contain so that names are unique, e.g. \"{instance}/{type}-{timestamp}-{random}\". Garbage collection takes the age of the objects from their timestamp. Empty names the temporary objects with a random number only.")
+
+	if err := flagSet.MarkHidden("write-tmp-object-name-template"); err != nil {
+		return err
+	}
+
+	flagSet.StringP("write-upload-strategy", "", "simple", "Specifies how the files written without streaming writes are uploaded: \"simple\" uploads a file at once, \"parallel-composite\" uploads large files as temporary component objects in parallel, composed into the object. The components are deleted once composed, or by garbage collection if the upload is interrupted.")
+
+	if err := flagSet.MarkHidden("write-upload-strategy"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1616,6 +2320,10 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("file-system.enable-status-file", flagSet.Lookup("enable-status-file")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("write.enable-streaming-writes", flagSet.Lookup("enable-streaming-writes")); err != nil {
 		return err
 	}
@@ -1648,6 +2356,10 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("file-system.experimental-enable-streaming-readdir", flagSet.Lookup("experimental-enable-streaming-readdir")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("gcs-connection.grpc-conn-pool-size", flagSet.Lookup("experimental-grpc-conn-pool-size")); err != nil {
 		return err
 	}
@@ -1720,6 +2432,10 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("file-cache.prefer-after-full-download", flagSet.Lookup("file-cache-prefer-after-full-download")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("file-cache.shared-cache-chunk-size-mb", flagSet.Lookup("file-cache-shared-cache-chunk-size-mb")); err != nil {
 		return err
 	}
@@ -1740,6 +2456,42 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("garbage-collection.delete-ops-per-sec", flagSet.Lookup("garbage-collection-delete-ops-per-sec")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.delete-parallelism", flagSet.Lookup("garbage-collection-delete-parallelism")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.dry-run", flagSet.Lookup("garbage-collection-dry-run")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.exclude-patterns", flagSet.Lookup("garbage-collection-exclude-patterns")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.final-sweep", flagSet.Lookup("garbage-collection-final-sweep")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.max-delete-backlog", flagSet.Lookup("garbage-collection-max-delete-backlog")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.mode", flagSet.Lookup("garbage-collection-mode")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.temp-object-period", flagSet.Lookup("gc-temp-object-period")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("garbage-collection.temp-object-staleness", flagSet.Lookup("gc-temp-object-staleness")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("file-system.gid", flagSet.Lookup("gid")); err != nil {
 		return err
 	}
@@ -1784,6 +2536,10 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("list.vanished-entry-mode", flagSet.Lookup("list-vanished-entry-mode")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("logging.file-path", flagSet.Lookup("log-file")); err != nil {
 		return err
 	}
@@ -1820,6 +2576,10 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("file-system.max-handles-per-file", flagSet.Lookup("max-handles-per-file")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("gcs-connection.max-idle-conns-per-host", flagSet.Lookup("max-idle-conns-per-host")); err != nil {
 		return err
 	}
@@ -1856,6 +2616,14 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("metrics.exporter", flagSet.Lookup("metrics-exporter")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("metrics.otlp-endpoint", flagSet.Lookup("metrics-otlp-endpoint")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("metrics.use-new-names", flagSet.Lookup("metrics-use-new-names")); err != nil {
 		return err
 	}
@@ -1884,14 +2652,102 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("read.block-alignment", flagSet.Lookup("read-block-alignment")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.block-checksum-manifest", flagSet.Lookup("read-block-checksum-manifest")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("read.block-size-mb", flagSet.Lookup("read-block-size-mb")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("read.cancelled-download-policy", flagSet.Lookup("read-cancelled-download-policy")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.circuit-breaker-cooldown", flagSet.Lookup("read-circuit-breaker-cooldown")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.circuit-breaker-failure-threshold", flagSet.Lookup("read-circuit-breaker-failure-threshold")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.demand-block-size-kb", flagSet.Lookup("read-demand-block-size-kb")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-deadline-secs", flagSet.Lookup("read-download-deadline-secs")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-limit-bytes-per-sec", flagSet.Lookup("read-download-limit-bytes-per-sec")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-max-retries", flagSet.Lookup("read-download-max-retries")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-reschedule-backoff", flagSet.Lookup("read-download-reschedule-backoff")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-reschedules", flagSet.Lookup("read-download-reschedules")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.download-retry-backoff", flagSet.Lookup("read-download-retry-backoff")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.efficiency-summary-interval", flagSet.Lookup("read-efficiency-summary-interval")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.failure-diagnostic-interval", flagSet.Lookup("read-failure-diagnostic-interval")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.fallback-on-download-failure", flagSet.Lookup("read-fallback-on-download-failure")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.fan-out-max-blocks", flagSet.Lookup("read-fan-out-max-blocks")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.generation-change-mode", flagSet.Lookup("read-generation-change-mode")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.generation-strategy", flagSet.Lookup("read-generation-strategy")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("read.global-max-blocks", flagSet.Lookup("read-global-max-blocks")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("read.global-max-memory-fraction", flagSet.Lookup("read-global-max-memory-fraction")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.hot-file-min-opens", flagSet.Lookup("read-hot-file-min-opens")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.hot-file-resident-blocks", flagSet.Lookup("read-hot-file-resident-blocks")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.hot-file-window", flagSet.Lookup("read-hot-file-window")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("read.inactive-stream-timeout", flagSet.Lookup("read-inactive-stream-timeout")); err != nil {
 		return err
 	}
@@ -1900,14 +2756,70 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("read.max-blocks-per-object", flagSet.Lookup("read-max-blocks-per-object")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.max-downloads-per-object", flagSet.Lookup("read-max-downloads-per-object")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.max-inflight-downloads", flagSet.Lookup("read-max-inflight-downloads")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.max-prefetch-files", flagSet.Lookup("read-max-prefetch-files")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("read.min-blocks-per-handle", flagSet.Lookup("read-min-blocks-per-handle")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("read.object-metadata-keys", flagSet.Lookup("read-object-metadata-keys")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.phase-detection-window", flagSet.Lookup("read-phase-detection-window")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.phase-random-threshold", flagSet.Lookup("read-phase-random-threshold")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.phase-sequential-threshold", flagSet.Lookup("read-phase-sequential-threshold")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.pinned-max-blocks", flagSet.Lookup("read-pinned-max-blocks")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.pinned-objects", flagSet.Lookup("read-pinned-objects")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.preallocate-blocks", flagSet.Lookup("read-preallocate-blocks")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.prefetch-policy", flagSet.Lookup("read-prefetch-policy")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("read.random-seek-threshold", flagSet.Lookup("read-random-seek-threshold")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("read.sliding-window-blocks", flagSet.Lookup("read-sliding-window-blocks")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.source-order", flagSet.Lookup("read-source-order")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("gcs-retries.read-stall.initial-req-timeout", flagSet.Lookup("read-stall-initial-req-timeout")); err != nil {
 		return err
 	}
@@ -1932,10 +2844,42 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("read.timeout", flagSet.Lookup("read-timeout")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.verify-object-checksum", flagSet.Lookup("read-verify-object-checksum")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.warm-state-file", flagSet.Lookup("read-warm-state-file")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.warm-state-parallelism", flagSet.Lookup("read-warm-state-parallelism")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.worker-pool-watchdog-cancel", flagSet.Lookup("read-worker-pool-watchdog-cancel")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("read.worker-pool-watchdog-timeout", flagSet.Lookup("read-worker-pool-watchdog-timeout")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("gcs-connection.region-aware-read-endpoint", flagSet.Lookup("region-aware-read-endpoint")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("file-system.rename-dir-limit", flagSet.Lookup("rename-dir-limit")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("file-system.rename-dir-over-limit-mode", flagSet.Lookup("rename-dir-over-limit-mode")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("gcs-retries.multiplier", flagSet.Lookup("retry-multiplier")); err != nil {
 		return err
 	}
@@ -2024,5 +2968,17 @@ func BindFlags(v *viper.Viper, flagSet *pflag.FlagSet) error {
 		return err
 	}
 
+	if err := v.BindPFlag("write.parallel-composite-component-size-mb", flagSet.Lookup("write-parallel-composite-component-size-mb")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("write.tmp-object-name-template", flagSet.Lookup("write-tmp-object-name-template")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("write.upload-strategy", flagSet.Lookup("write-upload-strategy")); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -101,6 +101,77 @@ func TestApplyOptimizations(t *testing.T) {
 			})
 		}
 	})
+	// Tests for read.enable-buffered-read
+	t.Run("read.enable-buffered-read", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-inference",
+				},
+				userSetFlags: map[string]any{
+					"read.enable-buffered-read": true,
+					"machine-type":              "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   true,
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   false,
+			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   true,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.Read.EnableBufferedRead = tc.expectedValue.(bool)
+				} else {
+					c.Read.EnableBufferedRead = false
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "read.enable-buffered-read")
+				} else {
+					assert.NotContains(t, optimizedFlags, "read.enable-buffered-read")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.Read.EnableBufferedRead)
+			})
+		}
+	})
 	// Tests for file-system.enable-kernel-reader
 	t.Run("file-system.enable-kernel-reader", func(t *testing.T) {
 		testCases := []struct {
@@ -391,6 +462,14 @@ func TestApplyOptimizations(t *testing.T) {
 				expectOptimized: true,
 				expectedValue:   true,
 			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   true,
+			},
 			{
 				name:   "machine_group_high-performance",
 				config: Config{Profile: ""},
@@ -732,6 +811,14 @@ func TestApplyOptimizations(t *testing.T) {
 				expectOptimized: true,
 				expectedValue:   0,
 			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   0,
+			},
 			{
 				name:   "machine_group_high-performance",
 				config: Config{Profile: ""},
@@ -848,6 +935,14 @@ func TestApplyOptimizations(t *testing.T) {
 				expectOptimized: true,
 				expectedValue:   -1,
 			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   -1,
+			},
 			{
 				name:   "machine_group_high-performance",
 				config: Config{Profile: ""},
@@ -907,8 +1002,8 @@ func TestApplyOptimizations(t *testing.T) {
 			})
 		}
 	})
-	// Tests for file-system.rename-dir-limit
-	t.Run("file-system.rename-dir-limit", func(t *testing.T) {
+	// Tests for read.download-deadline-secs
+	t.Run("read.download-deadline-secs", func(t *testing.T) {
 		testCases := []struct {
 			name            string
 			config          Config
@@ -920,11 +1015,11 @@ func TestApplyOptimizations(t *testing.T) {
 			{
 				name: "user_set",
 				config: Config{
-					Profile: "aiml-checkpointing",
+					Profile: "aiml-serving",
 				},
 				userSetFlags: map[string]any{
-					"file-system.rename-dir-limit": 98765,
-					"machine-type":                 "a2-megagpu-16g",
+					"read.download-deadline-secs": 98765,
+					"machine-type":                "a2-megagpu-16g",
 				},
 				input:           nil,
 				expectOptimized: false,
@@ -941,50 +1036,99 @@ func TestApplyOptimizations(t *testing.T) {
 				expectedValue:   0,
 			},
 			{
-				name:            "profile_aiml-checkpointing",
-				config:          Config{Profile: "aiml-checkpointing"},
+				name:            "profile_aiml-serving",
+				config:          Config{Profile: "aiml-serving"},
 				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   200000,
+				expectedValue:   10,
 			},
 			{
-				name:   "machine_group_high-performance",
-				config: Config{Profile: ""},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   200000,
+				expectedValue:   600,
 			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.Read.DownloadDeadlineSecs = tc.expectedValue.(int64)
+				} else {
+					c.Read.DownloadDeadlineSecs = 0
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "read.download-deadline-secs")
+				} else {
+					assert.NotContains(t, optimizedFlags, "read.download-deadline-secs")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.Read.DownloadDeadlineSecs)
+			})
+		}
+	})
+	// Tests for read.download-max-retries
+	t.Run("read.download-max-retries", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
 			{
-				name:   "profile_overrides_machine_type",
-				config: Config{Profile: "aiml-checkpointing"},
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-serving",
+				},
 				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
+					"read.download-max-retries": 98765,
+					"machine-type":              "a2-megagpu-16g",
 				},
 				input:           nil,
-				expectOptimized: true,
-				expectedValue:   200000,
-			}, {
-				name:   "fallback_to_machine_type_with_non_existent_profile",
+				expectOptimized: false,
+				expectedValue:   int64(98765),
+			},
+			{
+				name:   "no_optimization",
 				config: Config{Profile: "non_existent_profile"},
 				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
+					"machine-type": "low-end-machine",
 				},
 				input:           nil,
+				expectOptimized: false,
+				expectedValue:   0,
+			},
+			{
+				name:            "profile_aiml-serving",
+				config:          Config{Profile: "aiml-serving"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
 				expectOptimized: true,
-				expectedValue:   200000,
-			}, {
-				name:   "fallback_to_machine_type_when_aiml-training_is_unrelated",
-				config: Config{Profile: "aiml-training"},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
+				expectedValue:   1,
+			},
+			{
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   200000,
+				expectedValue:   5,
 			},
 		}
 
@@ -994,9 +1138,9 @@ func TestApplyOptimizations(t *testing.T) {
 				c := tc.config
 				// Set the default or non-default value on the config object.
 				if tc.name == "user_set" {
-					c.FileSystem.RenameDirLimit = tc.expectedValue.(int64)
+					c.Read.DownloadMaxRetries = tc.expectedValue.(int64)
 				} else {
-					c.FileSystem.RenameDirLimit = 0
+					c.Read.DownloadMaxRetries = 0
 				}
 
 				v := viper.New()
@@ -1007,17 +1151,17 @@ func TestApplyOptimizations(t *testing.T) {
 				optimizedFlags := c.ApplyOptimizations(v, tc.input)
 
 				if tc.expectOptimized {
-					assert.Contains(t, optimizedFlags, "file-system.rename-dir-limit")
+					assert.Contains(t, optimizedFlags, "read.download-max-retries")
 				} else {
-					assert.NotContains(t, optimizedFlags, "file-system.rename-dir-limit")
+					assert.NotContains(t, optimizedFlags, "read.download-max-retries")
 				}
 				// Use EqualValues to handle the int vs int64 type mismatch for default values.
-				assert.EqualValues(t, tc.expectedValue, c.FileSystem.RenameDirLimit)
+				assert.EqualValues(t, tc.expectedValue, c.Read.DownloadMaxRetries)
 			})
 		}
 	})
-	// Tests for metadata-cache.stat-cache-max-size-mb
-	t.Run("metadata-cache.stat-cache-max-size-mb", func(t *testing.T) {
+	// Tests for read.global-max-blocks
+	t.Run("read.global-max-blocks", func(t *testing.T) {
 		testCases := []struct {
 			name            string
 			config          Config
@@ -1032,8 +1176,8 @@ func TestApplyOptimizations(t *testing.T) {
 					Profile: "aiml-training",
 				},
 				userSetFlags: map[string]any{
-					"metadata-cache.stat-cache-max-size-mb": 98765,
-					"machine-type":                          "a2-megagpu-16g",
+					"read.global-max-blocks": 98765,
+					"machine-type":           "a2-megagpu-16g",
 				},
 				input:           nil,
 				expectOptimized: false,
@@ -1047,7 +1191,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: false,
-				expectedValue:   34,
+				expectedValue:   40,
 			},
 			{
 				name:            "profile_aiml-training",
@@ -1055,7 +1199,7 @@ func TestApplyOptimizations(t *testing.T) {
 				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   -1,
+				expectedValue:   200,
 			},
 			{
 				name:            "profile_aiml-serving",
@@ -1063,44 +1207,15 @@ func TestApplyOptimizations(t *testing.T) {
 				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   -1,
+				expectedValue:   200,
 			},
 			{
-				name:            "profile_aiml-checkpointing",
-				config:          Config{Profile: "aiml-checkpointing"},
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
 				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   -1,
-			},
-			{
-				name:   "machine_group_high-performance",
-				config: Config{Profile: ""},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
-				input:           nil,
-				expectOptimized: true,
-				expectedValue:   1024,
-			},
-			{
-				name:   "profile_overrides_machine_type",
-				config: Config{Profile: "aiml-training"},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
-				input:           nil,
-				expectOptimized: true,
-				expectedValue:   -1,
-			}, {
-				name:   "fallback_to_machine_type_with_non_existent_profile",
-				config: Config{Profile: "non_existent_profile"},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
-				input:           nil,
-				expectOptimized: true,
-				expectedValue:   1024,
+				expectedValue:   200,
 			},
 		}
 
@@ -1110,9 +1225,9 @@ func TestApplyOptimizations(t *testing.T) {
 				c := tc.config
 				// Set the default or non-default value on the config object.
 				if tc.name == "user_set" {
-					c.MetadataCache.StatCacheMaxSizeMb = tc.expectedValue.(int64)
+					c.Read.GlobalMaxBlocks = tc.expectedValue.(int64)
 				} else {
-					c.MetadataCache.StatCacheMaxSizeMb = 34
+					c.Read.GlobalMaxBlocks = 40
 				}
 
 				v := viper.New()
@@ -1123,17 +1238,17 @@ func TestApplyOptimizations(t *testing.T) {
 				optimizedFlags := c.ApplyOptimizations(v, tc.input)
 
 				if tc.expectOptimized {
-					assert.Contains(t, optimizedFlags, "metadata-cache.stat-cache-max-size-mb")
+					assert.Contains(t, optimizedFlags, "read.global-max-blocks")
 				} else {
-					assert.NotContains(t, optimizedFlags, "metadata-cache.stat-cache-max-size-mb")
+					assert.NotContains(t, optimizedFlags, "read.global-max-blocks")
 				}
 				// Use EqualValues to handle the int vs int64 type mismatch for default values.
-				assert.EqualValues(t, tc.expectedValue, c.MetadataCache.StatCacheMaxSizeMb)
+				assert.EqualValues(t, tc.expectedValue, c.Read.GlobalMaxBlocks)
 			})
 		}
 	})
-	// Tests for write.global-max-blocks
-	t.Run("write.global-max-blocks", func(t *testing.T) {
+	// Tests for read.max-blocks-per-handle
+	t.Run("read.max-blocks-per-handle", func(t *testing.T) {
 		testCases := []struct {
 			name            string
 			config          Config
@@ -1143,11 +1258,13 @@ func TestApplyOptimizations(t *testing.T) {
 			expectedValue   any
 		}{
 			{
-				name:   "user_set",
-				config: Config{},
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-inference",
+				},
 				userSetFlags: map[string]any{
-					"write.global-max-blocks": 98765,
-					"machine-type":            "a2-megagpu-16g",
+					"read.max-blocks-per-handle": 98765,
+					"machine-type":               "a2-megagpu-16g",
 				},
 				input:           nil,
 				expectOptimized: false,
@@ -1161,35 +1278,15 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: false,
-				expectedValue:   4,
+				expectedValue:   20,
 			},
 			{
-				name:   "machine_group_high-performance",
-				config: Config{Profile: ""},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
-				input:           nil,
-				expectOptimized: true,
-				expectedValue:   1600,
-			}, {
-				name:   "fallback_to_machine_type_with_non_existent_profile",
-				config: Config{Profile: "non_existent_profile"},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
-				input:           nil,
-				expectOptimized: true,
-				expectedValue:   1600,
-			}, {
-				name:   "fallback_to_machine_type_when_aiml-training_is_unrelated",
-				config: Config{Profile: "aiml-training"},
-				userSetFlags: map[string]any{
-					"machine-type": "a2-megagpu-16g",
-				},
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: true,
-				expectedValue:   1600,
+				expectedValue:   40,
 			},
 		}
 
@@ -1199,7 +1296,519 @@ func TestApplyOptimizations(t *testing.T) {
 				c := tc.config
 				// Set the default or non-default value on the config object.
 				if tc.name == "user_set" {
-					c.Write.GlobalMaxBlocks = tc.expectedValue.(int64)
+					c.Read.MaxBlocksPerHandle = tc.expectedValue.(int64)
+				} else {
+					c.Read.MaxBlocksPerHandle = 20
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "read.max-blocks-per-handle")
+				} else {
+					assert.NotContains(t, optimizedFlags, "read.max-blocks-per-handle")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.Read.MaxBlocksPerHandle)
+			})
+		}
+	})
+	// Tests for read.prefetch-policy
+	t.Run("read.prefetch-policy", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-training",
+				},
+				userSetFlags: map[string]any{
+					"read.prefetch-policy": "adaptive" + "-non-default",
+					"machine-type":         "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   "adaptive" + "-non-default",
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   "adaptive",
+			},
+			{
+				name:            "profile_aiml-training",
+				config:          Config{Profile: "aiml-training"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   "sequential",
+			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   "sequential",
+			},
+			{
+				name:            "profile_bigdata-analytics",
+				config:          Config{Profile: "bigdata-analytics"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   "footer-first",
+			},
+			{
+				name:            "profile_metadata-heavy",
+				config:          Config{Profile: "metadata-heavy"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   "none",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.Read.PrefetchPolicy = tc.expectedValue.(string)
+				} else {
+					c.Read.PrefetchPolicy = "adaptive"
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "read.prefetch-policy")
+				} else {
+					assert.NotContains(t, optimizedFlags, "read.prefetch-policy")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.Read.PrefetchPolicy)
+			})
+		}
+	})
+	// Tests for file-system.rename-dir-limit
+	t.Run("file-system.rename-dir-limit", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-checkpointing",
+				},
+				userSetFlags: map[string]any{
+					"file-system.rename-dir-limit": 98765,
+					"machine-type":                 "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   int64(98765),
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   0,
+			},
+			{
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   200000,
+			},
+			{
+				name:   "machine_group_high-performance",
+				config: Config{Profile: ""},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   200000,
+			},
+			{
+				name:   "profile_overrides_machine_type",
+				config: Config{Profile: "aiml-checkpointing"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   200000,
+			}, {
+				name:   "fallback_to_machine_type_with_non_existent_profile",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   200000,
+			}, {
+				name:   "fallback_to_machine_type_when_aiml-training_is_unrelated",
+				config: Config{Profile: "aiml-training"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   200000,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.FileSystem.RenameDirLimit = tc.expectedValue.(int64)
+				} else {
+					c.FileSystem.RenameDirLimit = 0
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "file-system.rename-dir-limit")
+				} else {
+					assert.NotContains(t, optimizedFlags, "file-system.rename-dir-limit")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.FileSystem.RenameDirLimit)
+			})
+		}
+	})
+	// Tests for metadata-cache.stat-cache-max-size-mb
+	t.Run("metadata-cache.stat-cache-max-size-mb", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-training",
+				},
+				userSetFlags: map[string]any{
+					"metadata-cache.stat-cache-max-size-mb": 98765,
+					"machine-type":                          "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   int64(98765),
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   34,
+			},
+			{
+				name:            "profile_aiml-training",
+				config:          Config{Profile: "aiml-training"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   4096,
+			},
+			{
+				name:            "profile_aiml-serving",
+				config:          Config{Profile: "aiml-serving"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   2048,
+			},
+			{
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   64,
+			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   2048,
+			},
+			{
+				name:   "machine_group_high-performance",
+				config: Config{Profile: ""},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   1024,
+			},
+			{
+				name:   "profile_overrides_machine_type",
+				config: Config{Profile: "aiml-training"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   4096,
+			}, {
+				name:   "fallback_to_machine_type_with_non_existent_profile",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   1024,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.MetadataCache.StatCacheMaxSizeMb = tc.expectedValue.(int64)
+				} else {
+					c.MetadataCache.StatCacheMaxSizeMb = 34
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "metadata-cache.stat-cache-max-size-mb")
+				} else {
+					assert.NotContains(t, optimizedFlags, "metadata-cache.stat-cache-max-size-mb")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.MetadataCache.StatCacheMaxSizeMb)
+			})
+		}
+	})
+	// Tests for metadata-cache.type-cache-max-size-mb
+	t.Run("metadata-cache.type-cache-max-size-mb", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-training",
+				},
+				userSetFlags: map[string]any{
+					"metadata-cache.type-cache-max-size-mb": 98765,
+					"machine-type":                          "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   int64(98765),
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   4,
+			},
+			{
+				name:            "profile_aiml-training",
+				config:          Config{Profile: "aiml-training"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   256,
+			},
+			{
+				name:            "profile_aiml-serving",
+				config:          Config{Profile: "aiml-serving"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   128,
+			},
+			{
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   16,
+			},
+			{
+				name:            "profile_aiml-inference",
+				config:          Config{Profile: "aiml-inference"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   128,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.MetadataCache.TypeCacheMaxSizeMb = tc.expectedValue.(int64)
+				} else {
+					c.MetadataCache.TypeCacheMaxSizeMb = 4
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "metadata-cache.type-cache-max-size-mb")
+				} else {
+					assert.NotContains(t, optimizedFlags, "metadata-cache.type-cache-max-size-mb")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.MetadataCache.TypeCacheMaxSizeMb)
+			})
+		}
+	})
+	// Tests for write.global-max-blocks
+	t.Run("write.global-max-blocks", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name:   "user_set",
+				config: Config{},
+				userSetFlags: map[string]any{
+					"write.global-max-blocks": 98765,
+					"machine-type":            "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   int64(98765),
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   4,
+			},
+			{
+				name:   "machine_group_high-performance",
+				config: Config{Profile: ""},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   1600,
+			}, {
+				name:   "fallback_to_machine_type_with_non_existent_profile",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   1600,
+			}, {
+				name:   "fallback_to_machine_type_when_aiml-training_is_unrelated",
+				config: Config{Profile: "aiml-training"},
+				userSetFlags: map[string]any{
+					"machine-type": "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   1600,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.Write.GlobalMaxBlocks = tc.expectedValue.(int64)
 				} else {
 					c.Write.GlobalMaxBlocks = 4
 				}
@@ -1221,4 +1830,75 @@ func TestApplyOptimizations(t *testing.T) {
 			})
 		}
 	})
+	// Tests for write.upload-strategy
+	t.Run("write.upload-strategy", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			config          Config
+			userSetFlags    map[string]any
+			input           *OptimizationInput
+			expectOptimized bool
+			expectedValue   any
+		}{
+			{
+				name: "user_set",
+				config: Config{
+					Profile: "aiml-checkpointing",
+				},
+				userSetFlags: map[string]any{
+					"write.upload-strategy": "simple" + "-non-default",
+					"machine-type":          "a2-megagpu-16g",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   "simple" + "-non-default",
+			},
+			{
+				name:   "no_optimization",
+				config: Config{Profile: "non_existent_profile"},
+				userSetFlags: map[string]any{
+					"machine-type": "low-end-machine",
+				},
+				input:           nil,
+				expectOptimized: false,
+				expectedValue:   "simple",
+			},
+			{
+				name:            "profile_aiml-checkpointing",
+				config:          Config{Profile: "aiml-checkpointing"},
+				userSetFlags:    map[string]any{},
+				input:           nil,
+				expectOptimized: true,
+				expectedValue:   "parallel-composite",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// We need a copy of the config for each test case.
+				c := tc.config
+				// Set the default or non-default value on the config object.
+				if tc.name == "user_set" {
+					c.Write.UploadStrategy = tc.expectedValue.(string)
+				} else {
+					c.Write.UploadStrategy = "simple"
+				}
+
+				v := viper.New()
+				for key, val := range tc.userSetFlags {
+					v.Set(key, val)
+				}
+
+				optimizedFlags := c.ApplyOptimizations(v, tc.input)
+
+				if tc.expectOptimized {
+					assert.Contains(t, optimizedFlags, "write.upload-strategy")
+				} else {
+					assert.NotContains(t, optimizedFlags, "write.upload-strategy")
+				}
+				// Use EqualValues to handle the int vs int64 type mismatch for default values.
+				assert.EqualValues(t, tc.expectedValue, c.Write.UploadStrategy)
+			})
+		}
+	})
 }
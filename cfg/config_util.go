@@ -70,7 +70,7 @@ func ListCacheTTLSecsToDuration(secs int64) time.Duration {
 
 // IsMetricsEnabled returns true if metrics are enabled.
 func IsMetricsEnabled(c *MetricsConfig) bool {
-	return c.CloudMetricsExportIntervalSecs > 0 || c.PrometheusPort > 0
+	return c.CloudMetricsExportIntervalSecs > 0 || c.PrometheusPort > 0 || c.Exporter == MetricsExporterOTLP
 }
 
 // IsGKEEnvironment returns true for /dev/fd/N mountpoints.
@@ -204,6 +204,7 @@ func TestIsMetricsEnabled(t *testing.T) {
 	}{
 		{"cloud_metrics_export_interval_set", &MetricsConfig{CloudMetricsExportIntervalSecs: 100}, true},
 		{"prom_port_set", &MetricsConfig{PrometheusPort: 10000}, true},
+		{"otlp_exporter_set", &MetricsConfig{Exporter: MetricsExporterOTLP, OtlpEndpoint: "http://localhost:4317"}, true},
 		{"none_set", &MetricsConfig{CloudMetricsExportIntervalSecs: 0, PrometheusPort: 0}, false},
 	}
 
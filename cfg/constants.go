@@ -48,6 +48,90 @@ const (
 	ExperimentalMetadataPrefetchOnMountAsynchronous = "async"
 )
 
+const (
+	// PrefetchPolicySequential prefetches the maximum number of blocks per handle from the first read.
+	PrefetchPolicySequential = "sequential"
+	// PrefetchPolicyAdaptive starts with a small prefetch window and grows it multiplicatively on sequential reads.
+	PrefetchPolicyAdaptive = "adaptive"
+	// PrefetchPolicyFooterFirst expects the first read of a file to target its footer and doesn't prefetch beyond it.
+	PrefetchPolicyFooterFirst = "footer-first"
+	// PrefetchPolicyNone downloads only the blocks needed to serve the current read.
+	PrefetchPolicyNone = "none"
+)
+
+const (
+	// BlockAlignmentNone uses the configured block size for every block, the last block holding the remainder.
+	BlockAlignmentNone = "none"
+	// BlockAlignmentObjectSize evenly sizes the blocks of an object, at most the configured block size.
+	BlockAlignmentObjectSize = "object-size"
+)
+
+const (
+	// CancelledDownloadPolicyDiscard drops the block of a download cancelled midway.
+	CancelledDownloadPolicyDiscard = "discard"
+	// CancelledDownloadPolicyKeepPartial keeps the bytes downloaded until the cancellation for the reads they cover.
+	CancelledDownloadPolicyKeepPartial = "keep-partial"
+)
+
+const (
+	// GarbageCollectionModeClient lists and deletes stale temporary objects from the client.
+	GarbageCollectionModeClient = "client"
+	// GarbageCollectionModeLifecycle relies on a bucket lifecycle rule to delete stale temporary objects.
+	GarbageCollectionModeLifecycle = "lifecycle"
+)
+
+const (
+	// VanishedEntryModeSkip leaves the entries vanished between being listed and being looked up out of listings.
+	VanishedEntryModeSkip = "skip"
+	// VanishedEntryModeError fails the listings with entries vanished between being listed and being looked up.
+	VanishedEntryModeError = "error"
+)
+
+const (
+	// UploadStrategySimple uploads the full contents of a file at once.
+	UploadStrategySimple = "simple"
+	// UploadStrategyParallelComposite uploads the full contents of large files as component objects in parallel, composed into the object.
+	UploadStrategyParallelComposite = "parallel-composite"
+)
+
+const (
+	// ReadSourceCache is the file cache in the read source order.
+	ReadSourceCache = "cache"
+	// ReadSourcePool is the buffered read block pool in the read source order.
+	ReadSourcePool = "pool"
+	// ReadSourceGCS is GCS in the read source order.
+	ReadSourceGCS = "gcs"
+)
+
+const (
+	// GenerationChangeModeEstale fails the reads of a file whose object was replaced with ESTALE.
+	GenerationChangeModeEstale = "estale"
+	// GenerationChangeModeReopen carries on reading the new generation of a file whose object was replaced.
+	GenerationChangeModeReopen = "reopen"
+)
+
+const (
+	// GenerationStrategyLatestAtOpen reads the generation of the object current when the file was opened.
+	GenerationStrategyLatestAtOpen = "latest-at-open"
+	// GenerationStrategyLatestAlways downloads each block of buffered reads from the latest generation of the object.
+	GenerationStrategyLatestAlways = "latest-always"
+	// GenerationStrategySnapshot reads the generation current when the file was opened and fails once it's replaced.
+	GenerationStrategySnapshot = "snapshot"
+)
+
+const (
+	// MetricsExporterOTLP exports the metrics to an OpenTelemetry collector over OTLP/gRPC.
+	MetricsExporterOTLP = "otlp"
+)
+
+const (
+	// RenameDirOverLimitModeFail fails the rename of a directory with more descendants than the rename dir limit.
+	RenameDirOverLimitModeFail = "fail"
+	// RenameDirOverLimitModeCopyDelete renames the descendants of a directory with more descendants than the rename
+	// dir limit one by one, copying and deleting them.
+	RenameDirOverLimitModeCopyDelete = "copy-delete"
+)
+
 const (
 	// maxSequentialReadSizeMb is the max value supported by sequential-read-size-mb flag.
 	maxSequentialReadSizeMB = 1024
@@ -95,10 +179,19 @@ const (
 	// StatCacheMaxSizeConfigKey is the Viper configuration key for the maximum
 	//size of the metadata stat cache in megabytes.
 	StatCacheMaxSizeConfigKey = "metadata-cache.stat-cache-max-size-mb"
+	// TypeCacheMaxSizeConfigKey is the Viper configuration key for the maximum
+	//size of the per-directory type caches in megabytes.
+	TypeCacheMaxSizeConfigKey = "metadata-cache.type-cache-max-size-mb"
 	// FileCacheParallelDownloadsConfigKey is the Viper configuration key for the
 	//parallel-downloads enablement.
 	FileCacheParallelDownloadsConfigKey = "file-cache.enable-parallel-downloads"
-	maxSupportedStatCacheMaxSizeMB      = util.MaxMiBsInUint64
+	// ReadGlobalMaxBlocksConfigKey is the Viper configuration key for the
+	//maximum number of buffered read blocks.
+	ReadGlobalMaxBlocksConfigKey   = "read.global-max-blocks"
+	maxSupportedStatCacheMaxSizeMB = util.MaxMiBsInUint64
+	// shrunkProfileBlockPoolFactor is the factor by which an explicitly set
+	// read.global-max-blocks must be below the profile's to be warned about.
+	shrunkProfileBlockPoolFactor = 4
 )
 
 // CacheUtilMinimumAlignSizeForWriting is the minimum buffer size used for memory-aligned
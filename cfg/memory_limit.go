@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// unlimitedCgroupMemory is the smallest limit a cgroup v1 reports when it has no
+// memory limit, the page-aligned maximum int64.
+const unlimitedCgroupMemory = 1 << 62
+
+// cgroupMemoryLimitFiles are the files holding the memory limit of the
+// container, for cgroup v2 and v1, in the order they are tried.
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// containerMemoryLimit returns the memory limit of the container in bytes, as
+// set by its cgroup. It returns an error off a container, or if the container
+// has no memory limit.
+func containerMemoryLimit() (int64, error) {
+	var errs []error
+	for _, path := range cgroupMemoryLimitFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		value := strings.TrimSpace(string(content))
+		if value == "max" {
+			return 0, fmt.Errorf("no memory limit set in %s", path)
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed memory limit in %s: %w", path, err)
+		}
+		if limit <= 0 || limit >= unlimitedCgroupMemory {
+			return 0, fmt.Errorf("no memory limit set in %s", path)
+		}
+		return limit, nil
+	}
+	return 0, fmt.Errorf("no cgroup memory limit found: %w", errors.Join(errs...))
+}
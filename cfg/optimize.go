@@ -15,6 +15,7 @@ package cfg
 import (
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
@@ -214,3 +215,27 @@ func CreateHierarchicalOptimizedFlags(flatMap map[string]OptimizationResult) (ma
 	}
 	return nestedMap, nil
 }
+
+// envVarName returns the name of the environment variable for a config path,
+// e.g. GCSFUSE_METADATA_CACHE_TTL_SECS for metadata-cache.ttl-secs.
+func envVarName(path string) string {
+	return "GCSFUSE_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(path))
+}
+
+// ResolvedEnv returns the profile and machine type of the config, followed by
+// the flags optimized for them, as KEY=value lines fit for an env file, sorted
+// by config path. Each optimized flag is preceded by a comment line holding the
+// optimization which set it.
+func ResolvedEnv(c *Config, optimizedFlags map[string]OptimizationResult) []string {
+	lines := []string{
+		fmt.Sprintf("%s=%s", envVarName("profile"), c.Profile),
+		fmt.Sprintf("%s=%s", envVarName(machineTypeFlg), c.MachineType),
+	}
+	for _, path := range slices.Sorted(maps.Keys(optimizedFlags)) {
+		result := optimizedFlags[path]
+		lines = append(lines,
+			fmt.Sprintf("# %s: %s", path, result.OptimizationReason),
+			fmt.Sprintf("%s=%v", envVarName(path), result.FinalValue))
+	}
+	return lines
+}
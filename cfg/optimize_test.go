@@ -287,6 +287,25 @@ func TestApplyOptimizations_Success(t *testing.T) {
 	assert.EqualValues(t, 200000, cfg.FileSystem.RenameDirLimit)
 }
 
+func TestApplyOptimizations_AimlServingKeepsSimpleUploads(t *testing.T) {
+	resetMetadataEndpoints(t)
+	// Create a test server that returns an error.
+	server := createTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer closeTestServer(t, server)
+	// Override metadataEndpoints for testing.
+	metadataEndpoints = []string{server.URL}
+	cfg := defaultConfig()
+	cfg.Profile = "aiml-serving"
+	cfg.Write.UploadStrategy = "simple"
+
+	optimizedFlags := cfg.ApplyOptimizations(viper.New(), nil)
+
+	assert.False(t, isFlagPresentInOptimizationResults(optimizedFlags, "write.upload-strategy"))
+	assert.Equal(t, "simple", cfg.Write.UploadStrategy)
+}
+
 func TestCreateHierarchicalOptimizedFlags_Positive(t *testing.T) {
 	testCases := []struct {
 		name     string
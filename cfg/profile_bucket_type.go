@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfg
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// profileFlagBucketTypeMismatch describes a flag whose value set by a profile
+// has no effect, or the opposite of the intended one, on buckets of the given
+// types.
+type profileFlagBucketTypeMismatch struct {
+	configKey   string
+	bucketTypes []BucketType
+	reason      string
+}
+
+// hierarchicalBucketTypes are the types of the buckets with hierarchical
+// namespace, zonal buckets always having one.
+var hierarchicalBucketTypes = []BucketType{BucketTypeHierarchical, BucketTypeZonal}
+
+var profileFlagBucketTypeMismatches = []profileFlagBucketTypeMismatch{
+	{
+		configKey:   "file-system.rename-dir-limit",
+		bucketTypes: hierarchicalBucketTypes,
+		reason:      "directories of buckets with hierarchical namespace are renamed atomically, whatever the number of their descendants",
+	},
+	{
+		configKey:   "implicit-dirs",
+		bucketTypes: hierarchicalBucketTypes,
+		reason:      "buckets with hierarchical namespace have no implicit directories",
+	},
+}
+
+// ProfileBucketTypeWarnings returns a warning for each flag set by the profile
+// of the config which has no effect, or a counterproductive one, on buckets of
+// the given type, as detected at mount. The flags set by the user are left
+// out, the user being assumed to know better.
+func ProfileBucketTypeWarnings(v *viper.Viper, c *Config, bucketType BucketType) []string {
+	if c.Profile == "" || !bucketType.IsValid() {
+		return nil
+	}
+	var warnings []string
+	for _, m := range profileFlagBucketTypeMismatches {
+		if !slices.Contains(m.bucketTypes, bucketType) || v.IsSet(m.configKey) {
+			continue
+		}
+		for _, p := range AllFlagOptimizationRules[m.configKey].Profiles {
+			if p.Name == c.Profile {
+				warnings = append(warnings, fmt.Sprintf("profile %q sets %s to %v, which has no effect on %s buckets: %s.", c.Profile, m.configKey, p.Value, bucketType, m.reason))
+				break
+			}
+		}
+	}
+	return warnings
+}
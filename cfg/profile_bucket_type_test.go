@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// warnedConfigKeys returns the config keys of the mismatches warned about.
+func warnedConfigKeys(warnings []string) []string {
+	var keys []string
+	for _, w := range warnings {
+		for _, m := range profileFlagBucketTypeMismatches {
+			if strings.Contains(w, " sets "+m.configKey+" to ") {
+				keys = append(keys, m.configKey)
+			}
+		}
+	}
+	return keys
+}
+
+func TestProfileBucketTypeWarnings(t *testing.T) {
+	testCases := []struct {
+		profile          string
+		wantHierarchical []string
+	}{
+		{profile: ""},
+		{profile: ProfileAIMLTraining, wantHierarchical: []string{"implicit-dirs"}},
+		{profile: ProfileAIMLServing, wantHierarchical: []string{"implicit-dirs"}},
+		{profile: ProfileAIMLInference, wantHierarchical: []string{"implicit-dirs"}},
+		{profile: ProfileAIMLCheckpointing, wantHierarchical: []string{"file-system.rename-dir-limit", "implicit-dirs"}},
+		{profile: ProfileBigdataAnalytics},
+		{profile: ProfileMetadataHeavy},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.profile, func(t *testing.T) {
+			c := &Config{Profile: tc.profile}
+
+			assert.ElementsMatch(t, tc.wantHierarchical, warnedConfigKeys(ProfileBucketTypeWarnings(viper.New(), c, BucketTypeHierarchical)))
+			assert.ElementsMatch(t, tc.wantHierarchical, warnedConfigKeys(ProfileBucketTypeWarnings(viper.New(), c, BucketTypeZonal)))
+			assert.Empty(t, ProfileBucketTypeWarnings(viper.New(), c, BucketTypeFlat))
+		})
+	}
+}
+
+func TestProfileBucketTypeWarningsLeaveOutUserSetFlags(t *testing.T) {
+	v := viper.New()
+	v.Set("implicit-dirs", true)
+	c := &Config{Profile: ProfileAIMLCheckpointing}
+
+	warnings := ProfileBucketTypeWarnings(v, c, BucketTypeHierarchical)
+
+	assert.Equal(t, []string{"file-system.rename-dir-limit"}, warnedConfigKeys(warnings))
+	assert.Contains(t, warnings[0], `profile "aiml-checkpointing" sets file-system.rename-dir-limit to 200000, which has no effect on hierarchical buckets`)
+}
+
+func TestProfileBucketTypeWarningsWithUnknownBucketType(t *testing.T) {
+	c := &Config{Profile: ProfileAIMLCheckpointing}
+
+	assert.Empty(t, ProfileBucketTypeWarnings(viper.New(), c, ""))
+}
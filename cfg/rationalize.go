@@ -135,6 +135,41 @@ func resolveFileCacheAndBufferedReadConflict(v *viper.Viper, c *Config) {
 	}
 }
 
+// warnOnShrunkProfileBlockPool warns if the buffered read block pool is
+// explicitly capped far below the cap set by the profile, which the explicit
+// cap takes precedence over, as the profile's reads may then rarely be
+// prefetched.
+func warnOnShrunkProfileBlockPool(v *viper.Viper, c *Config) {
+	if c.Profile == "" || !v.IsSet(ReadGlobalMaxBlocksConfigKey) || c.Read.GlobalMaxBlocks == -1 {
+		return
+	}
+	for _, p := range AllFlagOptimizationRules[ReadGlobalMaxBlocksConfigKey].Profiles {
+		profileMaxBlocks, ok := p.Value.(int64)
+		if p.Name != c.Profile || !ok {
+			continue
+		}
+		if c.Read.GlobalMaxBlocks*shrunkProfileBlockPoolFactor <= profileMaxBlocks {
+			log.Printf("Warning: read-global-max-blocks is set to %d, overriding the %d blocks recommended by profile %q. Buffered reads may rarely be prefetched.", c.Read.GlobalMaxBlocks, profileMaxBlocks, c.Profile)
+		}
+		return
+	}
+}
+
+// resolveReadGlobalMaxMemoryFraction sizes the buffered read block pool as the
+// configured fraction of the memory limit of the container, keeping
+// read-global-max-blocks if the limit can't be detected.
+func resolveReadGlobalMaxMemoryFraction(r *ReadConfig) {
+	if r.GlobalMaxMemoryFraction <= 0 || r.BlockSizeMb <= 0 {
+		return
+	}
+	limit, err := containerMemoryLimit()
+	if err != nil {
+		log.Printf("Warning: read-global-max-memory-fraction is set to %v, but the container memory limit couldn't be detected: %v. Using read-global-max-blocks %d instead.", r.GlobalMaxMemoryFraction, err, r.GlobalMaxBlocks)
+		return
+	}
+	r.GlobalMaxBlocks = int64(r.GlobalMaxMemoryFraction*float64(limit)) / (r.BlockSizeMb * util.MiB)
+}
+
 func resolveReadConfig(r *ReadConfig) {
 	if r.GlobalMaxBlocks == -1 {
 		r.GlobalMaxBlocks = math.MaxInt32
@@ -179,6 +214,8 @@ func Rationalize(v *viper.Viper, c *Config, optimizedFlags []string) error {
 
 	resolveLoggingConfig(c)
 	resolveTraceConfig(&c.Trace)
+	warnOnShrunkProfileBlockPool(v, c)
+	resolveReadGlobalMaxMemoryFraction(&c.Read)
 	resolveReadConfig(&c.Read)
 	resolveStreamingWriteConfig(&c.Write)
 	resolveMetadataCacheConfig(v, &c.MetadataCache, optimizedFlags)
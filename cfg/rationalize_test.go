@@ -17,8 +17,11 @@ package cfg
 import (
 	"bytes"
 	"log"
+	"maps"
 	"math"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -807,6 +810,128 @@ func TestRationalize_FileCacheAndBufferedReadConflict(t *testing.T) {
 	}
 }
 
+func TestRationalize_ReadGlobalMaxMemoryFraction(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		cgroupLimit             string
+		expectedGlobalMaxBlocks int64
+		expectWarning           bool
+	}{
+		{
+			name:                    "container memory limit",
+			cgroupLimit:             "4294967296\n",
+			expectedGlobalMaxBlocks: 64,
+			expectWarning:           false,
+		},
+		{
+			name:                    "no container memory limit",
+			cgroupLimit:             "max\n",
+			expectedGlobalMaxBlocks: 40,
+			expectWarning:           true,
+		},
+		{
+			name:                    "cgroup v1 without memory limit",
+			cgroupLimit:             "9223372036854771712\n",
+			expectedGlobalMaxBlocks: 40,
+			expectWarning:           true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+			limitFile := filepath.Join(t.TempDir(), "memory.max")
+			require.NoError(t, os.WriteFile(limitFile, []byte(tc.cgroupLimit), 0644))
+			defer func(files []string) { cgroupMemoryLimitFiles = files }(cgroupMemoryLimitFiles)
+			cgroupMemoryLimitFiles = []string{limitFile}
+			c := &Config{Read: ReadConfig{BlockSizeMb: 16, GlobalMaxBlocks: 40, GlobalMaxMemoryFraction: 0.25}}
+
+			err := Rationalize(viper.New(), c, []string{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGlobalMaxBlocks, c.Read.GlobalMaxBlocks)
+			if tc.expectWarning {
+				assert.Contains(t, buf.String(), "Using read-global-max-blocks 40 instead.")
+			} else {
+				assert.NotContains(t, buf.String(), "read-global-max-memory-fraction")
+			}
+		})
+	}
+}
+
+func TestRationalize_ReadGlobalMaxMemoryFractionOffContainer(t *testing.T) {
+	defer func(files []string) { cgroupMemoryLimitFiles = files }(cgroupMemoryLimitFiles)
+	cgroupMemoryLimitFiles = []string{filepath.Join(t.TempDir(), "memory.max")}
+	c := &Config{Read: ReadConfig{BlockSizeMb: 16, GlobalMaxBlocks: -1, GlobalMaxMemoryFraction: 0.25}}
+
+	err := Rationalize(viper.New(), c, []string{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt32), c.Read.GlobalMaxBlocks)
+}
+
+func TestRationalize_ProfileBlockPoolOverride(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		userSetFlags            map[string]any
+		expectedGlobalMaxBlocks int64
+		expectWarning           bool
+	}{
+		{
+			name:                    "profile cap",
+			userSetFlags:            map[string]any{},
+			expectedGlobalMaxBlocks: 200,
+			expectWarning:           false,
+		},
+		{
+			name:                    "explicit cap slightly below profile's",
+			userSetFlags:            map[string]any{ReadGlobalMaxBlocksConfigKey: 100},
+			expectedGlobalMaxBlocks: 100,
+			expectWarning:           false,
+		},
+		{
+			name:                    "explicit cap far below profile's",
+			userSetFlags:            map[string]any{ReadGlobalMaxBlocksConfigKey: 20},
+			expectedGlobalMaxBlocks: 20,
+			expectWarning:           true,
+		},
+		{
+			name:                    "explicit unlimited cap",
+			userSetFlags:            map[string]any{ReadGlobalMaxBlocksConfigKey: -1},
+			expectedGlobalMaxBlocks: math.MaxInt32,
+			expectWarning:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+			v := viper.New()
+			v.Set("machine-type", "n2-standard-4")
+			c := &Config{Profile: "aiml-training", Read: ReadConfig{GlobalMaxBlocks: 40}}
+			for key, val := range tc.userSetFlags {
+				v.Set(key, val)
+				c.Read.GlobalMaxBlocks = int64(val.(int))
+			}
+			optimizedFlags := c.ApplyOptimizations(v, nil)
+
+			err := Rationalize(v, c, slices.Collect(maps.Keys(optimizedFlags)))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGlobalMaxBlocks, c.Read.GlobalMaxBlocks)
+			if tc.expectWarning {
+				assert.Contains(t, buf.String(), `Warning: read-global-max-blocks is set to 20, overriding the 200 blocks recommended by profile "aiml-training".`)
+			} else {
+				assert.NotContains(t, buf.String(), "read-global-max-blocks")
+			}
+		})
+	}
+}
+
 func TestResolveLoggingConfig(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -18,9 +18,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/spf13/viper"
@@ -34,9 +37,16 @@ const (
 	MaxParallelDownloadsCantBeZeroError       = "the value of max-parallel-downloads for file-cache must not be 0 when enable-parallel-downloads is true"
 	ProfileAIMLTraining                       = "aiml-training"
 	ProfileAIMLServing                        = "aiml-serving"
+	ProfileAIMLInference                      = "aiml-inference"
 	ProfileAIMLCheckpointing                  = "aiml-checkpointing"
+	ProfileBigdataAnalytics                   = "bigdata-analytics"
+	ProfileMetadataHeavy                      = "metadata-heavy"
 )
 
+// minGarbageCollectionTempObjectStaleness is the smallest age beyond which
+// garbage collection may delete temporary objects.
+const minGarbageCollectionTempObjectStaleness = time.Second
+
 func isValidLogRotateConfig(config *LogRotateLoggingConfig) error {
 	if config.MaxFileSizeMb <= 0 {
 		return fmt.Errorf("max-file-size-mb should be atleast 1")
@@ -131,6 +141,13 @@ func isValidKernelListCacheTTL(TTLSecs int64) error {
 	return nil
 }
 
+func isValidMaxHandlesPerFile(maxHandles int64) error {
+	if maxHandles < 0 {
+		return fmt.Errorf("invalid value of max-handles-per-file: %d; should be >= 0 (0 for no limit)", maxHandles)
+	}
+	return nil
+}
+
 func isValidMetadataCache(v *viper.Viper, c *MetadataCacheConfig) error {
 	// Validate ttl-secs.
 	if v.IsSet(MetadataCacheTTLConfigKey) {
@@ -201,6 +218,21 @@ func isValidWriteStreamingConfig(wc *WriteConfig) error {
 	return nil
 }
 
+func isValidUploadConfig(wc *WriteConfig) error {
+	switch wc.UploadStrategy {
+	// An unset strategy is the default simple strategy.
+	case "", UploadStrategySimple:
+		return nil
+	case UploadStrategyParallelComposite:
+		if wc.ParallelCompositeComponentSizeMb <= 0 || wc.ParallelCompositeComponentSizeMb > util.MaxMiBsInInt64 {
+			return fmt.Errorf("invalid value of write-parallel-composite-component-size-mb; can't be less than 1 or more than %d", util.MaxMiBsInInt64)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid value of write-upload-strategy: %q; should be one of %q or %q", wc.UploadStrategy, UploadStrategySimple, UploadStrategyParallelComposite)
+	}
+}
+
 func isValidReadStallGcsRetriesConfig(rsrc *ReadStallGcsRetriesConfig) error {
 	if rsrc == nil {
 		return nil
@@ -230,6 +262,16 @@ func isValidMetricsConfig(m *MetricsConfig) error {
 	if m.BufferSize < 1 {
 		return fmt.Errorf("metrics buffer size cannot be less than 1")
 	}
+	switch m.Exporter {
+	case "":
+	case MetricsExporterOTLP:
+		u, err := url.Parse(m.OtlpEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("metrics-otlp-endpoint must be an http or https URL with a host, e.g. http://localhost:4317, but received: %q", m.OtlpEndpoint)
+		}
+	default:
+		return fmt.Errorf("unsupported metrics-exporter: %q; only %q is supported", m.Exporter, MetricsExporterOTLP)
+	}
 	return nil
 }
 
@@ -280,6 +322,10 @@ func isValidBufferedReadConfig(rc *ReadConfig) error {
 		return fmt.Errorf("invalid value of read-global-max-blocks: %d; should be >=0 or -1 (for infinite)", rc.GlobalMaxBlocks)
 	}
 
+	if rc.GlobalMaxMemoryFraction < 0 || rc.GlobalMaxMemoryFraction > 1 {
+		return fmt.Errorf("invalid value of read-global-max-memory-fraction: %v; should be between 0 and 1", rc.GlobalMaxMemoryFraction)
+	}
+
 	if rc.StartBlocksPerHandle < 1 && rc.StartBlocksPerHandle != -1 {
 		return fmt.Errorf("invalid value of read-start-blocks-per-handle: %d; should be >=1 or -1 (for infinite)", rc.StartBlocksPerHandle)
 	}
@@ -292,6 +338,132 @@ func isValidBufferedReadConfig(rc *ReadConfig) error {
 		return fmt.Errorf("invalid value of read-min-blocks-per-handle: %d; should be >=1 or less than or equal to read-max-blocks-per-handle: %d", rc.MinBlocksPerHandle, rc.MaxBlocksPerHandle)
 	}
 
+	if rc.MaxPrefetchFiles < -1 {
+		return fmt.Errorf("invalid value of read-max-prefetch-files: %d; should be >=0 or -1 (for infinite)", rc.MaxPrefetchFiles)
+	}
+
+	if rc.PhaseDetectionWindow < 0 {
+		return fmt.Errorf("invalid value of read-phase-detection-window: %d; can't be negative", rc.PhaseDetectionWindow)
+	}
+
+	if rc.PhaseDetectionWindow > 0 && (rc.PhaseRandomThreshold <= 0.5 || rc.PhaseRandomThreshold > 1) {
+		return fmt.Errorf("invalid value of read-phase-random-threshold: %v; should be > 0.5 and <= 1", rc.PhaseRandomThreshold)
+	}
+
+	if rc.PhaseDetectionWindow > 0 && (rc.PhaseSequentialThreshold <= 0.5 || rc.PhaseSequentialThreshold > 1) {
+		return fmt.Errorf("invalid value of read-phase-sequential-threshold: %v; should be > 0.5 and <= 1", rc.PhaseSequentialThreshold)
+	}
+
+	if rc.CircuitBreakerFailureThreshold < 0 {
+		return fmt.Errorf("invalid value of read-circuit-breaker-failure-threshold: %d; can't be negative", rc.CircuitBreakerFailureThreshold)
+	}
+
+	if rc.CircuitBreakerFailureThreshold > 0 && rc.CircuitBreakerCooldown <= 0 {
+		return fmt.Errorf("invalid value of read-circuit-breaker-cooldown: %v; should be positive", rc.CircuitBreakerCooldown)
+	}
+
+	if rc.MaxBlocksPerObject < 0 {
+		return fmt.Errorf("invalid value of read-max-blocks-per-object: %d; can't be negative", rc.MaxBlocksPerObject)
+	}
+
+	if rc.MaxDownloadsPerObject < 0 {
+		return fmt.Errorf("invalid value of read-max-downloads-per-object: %d; can't be negative", rc.MaxDownloadsPerObject)
+	}
+
+	if rc.MaxInflightDownloads < 0 {
+		return fmt.Errorf("invalid value of read-max-inflight-downloads: %d; can't be negative", rc.MaxInflightDownloads)
+	}
+
+	if rc.DemandBlockSizeKb < 0 || rc.DemandBlockSizeKb > rc.BlockSizeMb*1024 {
+		return fmt.Errorf("invalid value of read-demand-block-size-kb: %d; should be >=0 and at most read-block-size-mb in KiB: %d", rc.DemandBlockSizeKb, rc.BlockSizeMb*1024)
+	}
+
+	if rc.DownloadDeadlineSecs < 0 {
+		return fmt.Errorf("invalid value of read-download-deadline-secs: %d; can't be negative", rc.DownloadDeadlineSecs)
+	}
+
+	if rc.DownloadMaxRetries < 0 {
+		return fmt.Errorf("invalid value of read-download-max-retries: %d; can't be negative", rc.DownloadMaxRetries)
+	}
+
+	if rc.DownloadReschedules < 0 {
+		return fmt.Errorf("invalid value of read-download-reschedules: %d; can't be negative", rc.DownloadReschedules)
+	}
+
+	if rc.FanOutMaxBlocks < 0 {
+		return fmt.Errorf("invalid value of read-fan-out-max-blocks: %d; can't be negative", rc.FanOutMaxBlocks)
+	}
+
+	if rc.SlidingWindowBlocks < 0 {
+		return fmt.Errorf("invalid value of read-sliding-window-blocks: %d; can't be negative", rc.SlidingWindowBlocks)
+	}
+
+	if rc.HotFileResidentBlocks < 0 {
+		return fmt.Errorf("invalid value of read-hot-file-resident-blocks: %d; can't be negative", rc.HotFileResidentBlocks)
+	}
+
+	if rc.HotFileResidentBlocks > 0 && rc.HotFileMinOpens < 1 {
+		return fmt.Errorf("invalid value of read-hot-file-min-opens: %d; should be >= 1", rc.HotFileMinOpens)
+	}
+
+	if rc.HotFileResidentBlocks > 0 && rc.HotFileWindow <= 0 {
+		return fmt.Errorf("invalid value of read-hot-file-window: %v; should be positive", rc.HotFileWindow)
+	}
+
+	if rc.Timeout < 0 {
+		return fmt.Errorf("invalid value of read-timeout: %v; can't be negative", rc.Timeout)
+	}
+
+	if rc.WorkerPoolWatchdogTimeout < 0 {
+		return fmt.Errorf("invalid value of read-worker-pool-watchdog-timeout: %v; can't be negative", rc.WorkerPoolWatchdogTimeout)
+	}
+
+	if rc.DownloadRescheduleBackoff < 0 {
+		return fmt.Errorf("invalid value of read-download-reschedule-backoff: %v; can't be negative", rc.DownloadRescheduleBackoff)
+	}
+
+	if rc.DownloadRetryBackoff < 0 {
+		return fmt.Errorf("invalid value of read-download-retry-backoff: %v; can't be negative", rc.DownloadRetryBackoff)
+	}
+
+	if rc.WarmStateFile != "" && rc.WarmStateParallelism < 1 {
+		return fmt.Errorf("invalid value of read-warm-state-parallelism: %d; should be >= 1", rc.WarmStateParallelism)
+	}
+
+	if rc.EfficiencySummaryInterval < 0 {
+		return fmt.Errorf("invalid value of read-efficiency-summary-interval: %v; can't be negative", rc.EfficiencySummaryInterval)
+	}
+
+	switch rc.PrefetchPolicy {
+	case PrefetchPolicySequential, PrefetchPolicyAdaptive, PrefetchPolicyFooterFirst, PrefetchPolicyNone:
+	default:
+		return fmt.Errorf("invalid value of read-prefetch-policy: %q; should be one of %q, %q, %q or %q", rc.PrefetchPolicy, PrefetchPolicySequential, PrefetchPolicyAdaptive, PrefetchPolicyFooterFirst, PrefetchPolicyNone)
+	}
+
+	switch rc.BlockAlignment {
+	case BlockAlignmentNone, BlockAlignmentObjectSize:
+	default:
+		return fmt.Errorf("invalid value of read-block-alignment: %q; should be one of %q or %q", rc.BlockAlignment, BlockAlignmentNone, BlockAlignmentObjectSize)
+	}
+
+	switch rc.CancelledDownloadPolicy {
+	case CancelledDownloadPolicyDiscard, CancelledDownloadPolicyKeepPartial:
+	default:
+		return fmt.Errorf("invalid value of read-cancelled-download-policy: %q; should be one of %q or %q", rc.CancelledDownloadPolicy, CancelledDownloadPolicyDiscard, CancelledDownloadPolicyKeepPartial)
+	}
+
+	if rc.PinnedMaxBlocks < 0 {
+		return fmt.Errorf("invalid value of read-pinned-max-blocks: %d; can't be negative", rc.PinnedMaxBlocks)
+	}
+
+	if len(rc.PinnedObjects) > 0 && (rc.PinnedMaxBlocks == 0 || (rc.GlobalMaxBlocks != -1 && rc.PinnedMaxBlocks >= rc.GlobalMaxBlocks)) {
+		return fmt.Errorf("invalid value of read-pinned-max-blocks: %d; should be >= 1 and less than read-global-max-blocks: %d when read-pinned-objects are configured", rc.PinnedMaxBlocks, rc.GlobalMaxBlocks)
+	}
+
+	if rc.PreallocateBlocks && rc.GlobalMaxBlocks == -1 {
+		return fmt.Errorf("read-preallocate-blocks requires a finite read-global-max-blocks")
+	}
+
 	return nil
 }
 
@@ -302,16 +474,134 @@ func isValidMRDConfig(mrdConfig *MrdConfig) error {
 	return nil
 }
 
+func isValidGarbageCollectionConfig(gcConfig *GarbageCollectionConfig) error {
+	if gcConfig.MaxDeleteBacklog < 1 {
+		return fmt.Errorf("invalid value of garbage-collection-max-delete-backlog: %d; should be >=1", gcConfig.MaxDeleteBacklog)
+	}
+
+	if gcConfig.TempObjectPeriod <= 0 {
+		return fmt.Errorf("invalid value of gc-temp-object-period: %v; should be >0", gcConfig.TempObjectPeriod)
+	}
+
+	if gcConfig.TempObjectStaleness < minGarbageCollectionTempObjectStaleness {
+		return fmt.Errorf("invalid value of gc-temp-object-staleness: %v; should be >=%v", gcConfig.TempObjectStaleness, minGarbageCollectionTempObjectStaleness)
+	}
+
+	for _, p := range gcConfig.ExcludePatterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern of garbage-collection-exclude-patterns: %q: %w", p, err)
+		}
+	}
+
+	switch gcConfig.Mode {
+	// An unset mode is the default client mode.
+	case "", GarbageCollectionModeClient, GarbageCollectionModeLifecycle:
+		return nil
+	default:
+		return fmt.Errorf("invalid value of garbage-collection-mode: %q; should be one of %q or %q", gcConfig.Mode, GarbageCollectionModeClient, GarbageCollectionModeLifecycle)
+	}
+}
+
+func isValidRenameDirOverLimitMode(mode string) error {
+	switch mode {
+	// An unset mode is the default fail mode.
+	case "", RenameDirOverLimitModeFail, RenameDirOverLimitModeCopyDelete:
+		return nil
+	default:
+		return fmt.Errorf("invalid value of rename-dir-over-limit-mode: %q; should be one of %q or %q", mode, RenameDirOverLimitModeFail, RenameDirOverLimitModeCopyDelete)
+	}
+}
+
+func isValidVanishedEntryMode(mode string) error {
+	switch mode {
+	// An unset mode is the default skip mode.
+	case "", VanishedEntryModeSkip, VanishedEntryModeError:
+		return nil
+	default:
+		return fmt.Errorf("invalid value of list-vanished-entry-mode: %q; should be one of %q or %q", mode, VanishedEntryModeSkip, VanishedEntryModeError)
+	}
+}
+
+func isValidReadFailureDiagnosticInterval(interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("invalid value of read-failure-diagnostic-interval: %v; can't be negative", interval)
+	}
+	return nil
+}
+
+func isValidGenerationChangeMode(mode string) error {
+	switch mode {
+	// An unset mode is the default estale mode.
+	case "", GenerationChangeModeEstale, GenerationChangeModeReopen:
+		return nil
+	default:
+		return fmt.Errorf("invalid value of read-generation-change-mode: %q; should be one of %q or %q", mode, GenerationChangeModeEstale, GenerationChangeModeReopen)
+	}
+}
+
+func isValidGenerationStrategy(strategy string) error {
+	switch strategy {
+	// An unset strategy is the default latest-at-open strategy.
+	case "", GenerationStrategyLatestAtOpen, GenerationStrategyLatestAlways, GenerationStrategySnapshot:
+		return nil
+	default:
+		return fmt.Errorf("invalid value of read-generation-strategy: %q; should be one of %q, %q or %q", strategy, GenerationStrategyLatestAtOpen, GenerationStrategyLatestAlways, GenerationStrategySnapshot)
+	}
+}
+
+func isValidReadSourceOrder(order []string) error {
+	// An unset order is the default order.
+	if len(order) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(order))
+	for _, source := range order {
+		switch source {
+		case ReadSourceCache, ReadSourcePool, ReadSourceGCS:
+		default:
+			return fmt.Errorf("invalid source in read-source-order: %q; should be one of %q, %q or %q", source, ReadSourceCache, ReadSourcePool, ReadSourceGCS)
+		}
+		if seen[source] {
+			return fmt.Errorf("source %q is repeated in read-source-order", source)
+		}
+		seen[source] = true
+	}
+	if len(order) != 3 {
+		return fmt.Errorf("read-source-order should list each of %q, %q and %q", ReadSourceCache, ReadSourcePool, ReadSourceGCS)
+	}
+	if order[len(order)-1] != ReadSourceGCS {
+		return fmt.Errorf("read-source-order should end with %q, which always serves the read", ReadSourceGCS)
+	}
+	return nil
+}
+
 func isValidOptimizationProfile(config *Config) error {
 	if config.Profile == "" {
 		return nil
 	}
 
-	switch config.Profile {
-	case ProfileAIMLServing, ProfileAIMLCheckpointing, ProfileAIMLTraining:
-		// Supported profiles.
-	default:
-		return fmt.Errorf("Unknown profile: %q", config.Profile)
+	validProfiles := []string{ProfileAIMLTraining, ProfileAIMLServing, ProfileAIMLInference, ProfileAIMLCheckpointing, ProfileBigdataAnalytics, ProfileMetadataHeavy}
+	if !slices.Contains(validProfiles, config.Profile) {
+		quoted := make([]string, len(validProfiles))
+		for i, p := range validProfiles {
+			quoted[i] = fmt.Sprintf("%q", p)
+		}
+		last := len(quoted) - 1
+		return fmt.Errorf("invalid value of profile: %q; should be one of %s or %s", config.Profile, strings.Join(quoted[:last], ", "), quoted[last])
+	}
+
+	// A profile sizes the metadata caches for its workload, it never disables or
+	// unbounds them.
+	for _, key := range []string{StatCacheMaxSizeConfigKey, TypeCacheMaxSizeConfigKey} {
+		rules := AllFlagOptimizationRules[key]
+		for _, p := range rules.Profiles {
+			if p.Name != config.Profile {
+				continue
+			}
+			if size, ok := p.Value.(int64); !ok || size <= 0 {
+				return fmt.Errorf("profile %q sets %s to %v, must be positive", config.Profile, key, p.Value)
+			}
+		}
 	}
 
 	return nil
@@ -349,6 +639,10 @@ func ValidateConfig(v *viper.Viper, config *Config) error {
 		return fmt.Errorf("error parsing kernel-list-cache-ttl-secs config: %w", err)
 	}
 
+	if err = isValidMaxHandlesPerFile(config.FileSystem.MaxHandlesPerFile); err != nil {
+		return fmt.Errorf("error parsing file-system config: %w", err)
+	}
+
 	if err = isValidMetadataCache(v, &config.MetadataCache); err != nil {
 		return fmt.Errorf("error parsing metadata-cache config: %w", err)
 	}
@@ -357,6 +651,10 @@ func ValidateConfig(v *viper.Viper, config *Config) error {
 		return fmt.Errorf("error parsing write config: %w", err)
 	}
 
+	if err = isValidUploadConfig(&config.Write); err != nil {
+		return fmt.Errorf("error parsing write config: %w", err)
+	}
+
 	if err = isValidReadStallGcsRetriesConfig(&config.GcsRetries.ReadStall); err != nil {
 		return fmt.Errorf("error parsing read-stall-gcs-retries config: %w", err)
 	}
@@ -385,10 +683,38 @@ func ValidateConfig(v *viper.Viper, config *Config) error {
 		return fmt.Errorf("error parsing buffered read config: %w", err)
 	}
 
+	if err = isValidGenerationChangeMode(config.Read.GenerationChangeMode); err != nil {
+		return fmt.Errorf("error parsing read config: %w", err)
+	}
+
+	if err = isValidGenerationStrategy(config.Read.GenerationStrategy); err != nil {
+		return fmt.Errorf("error parsing read config: %w", err)
+	}
+
+	if err = isValidReadFailureDiagnosticInterval(config.Read.FailureDiagnosticInterval); err != nil {
+		return fmt.Errorf("error parsing read config: %w", err)
+	}
+
+	if err = isValidRenameDirOverLimitMode(config.FileSystem.RenameDirOverLimitMode); err != nil {
+		return fmt.Errorf("error parsing file system config: %w", err)
+	}
+
+	if err = isValidVanishedEntryMode(config.List.VanishedEntryMode); err != nil {
+		return fmt.Errorf("error parsing list config: %w", err)
+	}
+
+	if err = isValidReadSourceOrder(config.Read.SourceOrder); err != nil {
+		return fmt.Errorf("error parsing read config: %w", err)
+	}
+
 	if err = isValidMRDConfig(&config.Mrd); err != nil {
 		return fmt.Errorf("error parsing mrd config: %w", err)
 	}
 
+	if err = isValidGarbageCollectionConfig(&config.GarbageCollection); err != nil {
+		return fmt.Errorf("error parsing garbage collection config: %w", err)
+	}
+
 	if err = isValidOptimizationProfile(config); err != nil {
 		return fmt.Errorf("error parsing optimize profile config: %w", err)
 	}
@@ -15,12 +15,15 @@
 package cfg
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg/shared"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func validLogRotateConfig() LogRotateLoggingConfig {
@@ -81,6 +84,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -102,6 +110,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -122,6 +135,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -142,6 +160,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -162,6 +185,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -182,6 +210,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -202,6 +235,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -223,6 +261,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					BufferSize: 256,
 				},
 				FileSystem: FileSystemConfig{KernelListCacheTtlSecs: 30},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -252,6 +295,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -274,6 +322,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -296,6 +349,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 					Workers:    3,
 					BufferSize: 256,
 				},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -318,6 +376,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 				},
 				FileSystem: FileSystemConfig{KernelListCacheTtlSecs: 30},
 				GcsRetries: GcsRetriesConfig{ChunkRetryDeadlineSecs: 60},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -340,6 +403,11 @@ func TestValidateConfigSuccessful(t *testing.T) {
 				},
 				FileSystem: FileSystemConfig{KernelListCacheTtlSecs: 30},
 				GcsRetries: GcsRetriesConfig{ChunkTransferTimeoutSecs: 15},
+				GarbageCollection: GarbageCollectionConfig{
+					MaxDeleteBacklog:    100,
+					TempObjectPeriod:    10 * time.Minute,
+					TempObjectStaleness: 30 * time.Minute,
+				},
 				Mrd: MrdConfig{
 					PoolSize: 4,
 				},
@@ -752,6 +820,360 @@ func Test_isValidBufferedReadConfig_ErrorScenarios(t *testing.T) {
 			StartBlocksPerHandle: 1,
 			MinBlocksPerHandle:   0,
 		}},
+		{"negative_max_prefetch_files", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			MaxPrefetchFiles:     -2,
+		}},
+		{"global_max_memory_fraction_above_one", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			GlobalMaxMemoryFraction: 1.5,
+		}},
+		{"negative_circuit_breaker_failure_threshold", ReadConfig{
+			BlockSizeMb:                    16,
+			EnableBufferedRead:             true,
+			GlobalMaxBlocks:                -1,
+			MaxBlocksPerHandle:             -1,
+			StartBlocksPerHandle:           1,
+			MinBlocksPerHandle:             4,
+			CircuitBreakerFailureThreshold: -1,
+		}},
+		{"circuit_breaker_without_cooldown", ReadConfig{
+			BlockSizeMb:                    16,
+			EnableBufferedRead:             true,
+			GlobalMaxBlocks:                -1,
+			MaxBlocksPerHandle:             -1,
+			StartBlocksPerHandle:           1,
+			MinBlocksPerHandle:             4,
+			CircuitBreakerFailureThreshold: 5,
+		}},
+		{"negative_phase_detection_window", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			PhaseDetectionWindow: -1,
+		}},
+		{"phase_random_threshold_too_low", ReadConfig{
+			BlockSizeMb:              16,
+			EnableBufferedRead:       true,
+			GlobalMaxBlocks:          -1,
+			MaxBlocksPerHandle:       -1,
+			StartBlocksPerHandle:     1,
+			MinBlocksPerHandle:       4,
+			PhaseDetectionWindow:     8,
+			PhaseRandomThreshold:     0.5,
+			PhaseSequentialThreshold: 0.75,
+		}},
+		{"negative_max_blocks_per_object", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			MaxBlocksPerObject:   -1,
+		}},
+		{"negative_max_downloads_per_object", ReadConfig{
+			BlockSizeMb:           16,
+			EnableBufferedRead:    true,
+			GlobalMaxBlocks:       -1,
+			MaxBlocksPerHandle:    -1,
+			StartBlocksPerHandle:  1,
+			MinBlocksPerHandle:    4,
+			MaxDownloadsPerObject: -1,
+		}},
+		{"negative_max_inflight_downloads", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			MaxInflightDownloads: -1,
+		}},
+		{"negative_sliding_window_blocks", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			SlidingWindowBlocks:  -1,
+		}},
+		{"unsupported_prefetch_policy", ReadConfig{
+			BlockSizeMb:          16,
+			EnableBufferedRead:   true,
+			GlobalMaxBlocks:      -1,
+			MaxBlocksPerHandle:   -1,
+			StartBlocksPerHandle: 1,
+			MinBlocksPerHandle:   4,
+			PrefetchPolicy:       "random",
+		}},
+		{"negative_efficiency_summary_interval", ReadConfig{
+			BlockSizeMb:               16,
+			EnableBufferedRead:        true,
+			GlobalMaxBlocks:           -1,
+			MaxBlocksPerHandle:        -1,
+			StartBlocksPerHandle:      1,
+			MinBlocksPerHandle:        4,
+			PrefetchPolicy:            PrefetchPolicyAdaptive,
+			EfficiencySummaryInterval: -time.Second,
+		}},
+		{"negative_demand_block_size", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DemandBlockSizeKb:       -1,
+		}},
+		{"demand_block_size_beyond_block_size", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DemandBlockSizeKb:       16*1024 + 1,
+		}},
+		{"negative_download_deadline", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DownloadDeadlineSecs:    -1,
+		}},
+		{"negative_download_max_retries", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DownloadMaxRetries:      -1,
+		}},
+		{"negative_download_reschedules", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DownloadReschedules:     -1,
+		}},
+		{"negative_fan_out_max_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			FanOutMaxBlocks:         -1,
+		}},
+		{"negative_hot_file_resident_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			HotFileResidentBlocks:   -1,
+		}},
+		{"zero_hot_file_min_opens", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			HotFileResidentBlocks:   2,
+			HotFileMinOpens:         0,
+			HotFileWindow:           time.Minute,
+		}},
+		{"zero_hot_file_window", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			HotFileResidentBlocks:   2,
+			HotFileMinOpens:         3,
+		}},
+		{"negative_download_reschedule_backoff", ReadConfig{
+			BlockSizeMb:               16,
+			EnableBufferedRead:        true,
+			GlobalMaxBlocks:           -1,
+			MaxBlocksPerHandle:        -1,
+			StartBlocksPerHandle:      1,
+			MinBlocksPerHandle:        4,
+			PrefetchPolicy:            PrefetchPolicyAdaptive,
+			BlockAlignment:            BlockAlignmentNone,
+			CancelledDownloadPolicy:   CancelledDownloadPolicyDiscard,
+			DownloadRescheduleBackoff: -time.Second,
+		}},
+		{"negative_download_retry_backoff", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DownloadRetryBackoff:    -time.Second,
+		}},
+		{"negative_timeout", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			Timeout:                 -time.Second,
+		}},
+		{"negative_worker_pool_watchdog_timeout", ReadConfig{
+			BlockSizeMb:               16,
+			EnableBufferedRead:        true,
+			GlobalMaxBlocks:           -1,
+			MaxBlocksPerHandle:        -1,
+			StartBlocksPerHandle:      1,
+			MinBlocksPerHandle:        4,
+			PrefetchPolicy:            PrefetchPolicyAdaptive,
+			BlockAlignment:            BlockAlignmentNone,
+			CancelledDownloadPolicy:   CancelledDownloadPolicyDiscard,
+			WorkerPoolWatchdogTimeout: -time.Second,
+		}},
+		{"zero_warm_state_parallelism", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			WarmStateFile:           "/tmp/warm_state.json",
+			WarmStateParallelism:    0,
+		}},
+		{"unsupported_block_alignment", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          "power-of-two",
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+		}},
+		{"unsupported_cancelled_download_policy", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: "keep-all",
+		}},
+		{"negative_pinned_max_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PinnedMaxBlocks:         -1,
+		}},
+		{"pinned_objects_without_pinned_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PinnedObjects:           []string{"model.bin"},
+		}},
+		{"pinned_blocks_not_less_than_global_max_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PinnedMaxBlocks:         10,
+			PinnedObjects:           []string{"model.bin"},
+		}},
+		{"preallocate_blocks_with_infinite_global_max_blocks", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PreallocateBlocks:       true,
+		}},
 	}
 
 	for _, tc := range testCases {
@@ -802,34 +1224,336 @@ func Test_isValidMRDConfig(t *testing.T) {
 	}
 }
 
+func Test_isValidGarbageCollectionConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mode             string
+		maxDeleteBacklog int64
+		period           time.Duration
+		staleness        time.Duration
+		excludePatterns  []string
+		wantErr          bool
+	}{
+		{name: "client", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: false},
+		{name: "lifecycle", mode: GarbageCollectionModeLifecycle, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: false},
+		{name: "unset", mode: "", maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: false},
+		{name: "unsupported", mode: "server", maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: true},
+		{name: "single_name_backlog", mode: GarbageCollectionModeClient, maxDeleteBacklog: 1, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: false},
+		{name: "zero_backlog", mode: GarbageCollectionModeClient, maxDeleteBacklog: 0, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: true},
+		{name: "negative_backlog", mode: GarbageCollectionModeClient, maxDeleteBacklog: -1, period: 10 * time.Minute, staleness: 30 * time.Minute, wantErr: true},
+		{name: "short_period_and_staleness", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: time.Second, staleness: time.Second, wantErr: false},
+		{name: "zero_period", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 0, staleness: 30 * time.Minute, wantErr: true},
+		{name: "negative_period", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: -time.Minute, staleness: 30 * time.Minute, wantErr: true},
+		{name: "zero_staleness", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 0, wantErr: true},
+		{name: "sub_second_staleness", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 500 * time.Millisecond, wantErr: true},
+		{name: "exclude_patterns", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, excludePatterns: []string{".gcsfuse_tmp/scratch-*", ".gcsfuse_tmp/keep"}, wantErr: false},
+		{name: "malformed_exclude_pattern", mode: GarbageCollectionModeClient, maxDeleteBacklog: 100, period: 10 * time.Minute, staleness: 30 * time.Minute, excludePatterns: []string{".gcsfuse_tmp/[scratch"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidGarbageCollectionConfig(&GarbageCollectionConfig{Mode: tc.mode, MaxDeleteBacklog: tc.maxDeleteBacklog, TempObjectPeriod: tc.period, TempObjectStaleness: tc.staleness, ExcludePatterns: tc.excludePatterns})
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidUploadConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  WriteConfig
+		wantErr bool
+	}{
+		{name: "simple", config: WriteConfig{UploadStrategy: UploadStrategySimple}, wantErr: false},
+		{name: "unset", config: WriteConfig{}, wantErr: false},
+		{name: "parallel_composite", config: WriteConfig{UploadStrategy: UploadStrategyParallelComposite, ParallelCompositeComponentSizeMb: 64}, wantErr: false},
+		{name: "parallel_composite_zero_component_size", config: WriteConfig{UploadStrategy: UploadStrategyParallelComposite}, wantErr: true},
+		{name: "unsupported", config: WriteConfig{UploadStrategy: "multipart"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidUploadConfig(&tc.config)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidReadFailureDiagnosticInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		interval time.Duration
+		wantErr  bool
+	}{
+		{name: "disabled", interval: 0, wantErr: false},
+		{name: "hourly", interval: time.Hour, wantErr: false},
+		{name: "negative", interval: -time.Second, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidReadFailureDiagnosticInterval(tc.interval)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidGenerationChangeMode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{name: "estale", mode: GenerationChangeModeEstale, wantErr: false},
+		{name: "reopen", mode: GenerationChangeModeReopen, wantErr: false},
+		{name: "unset", mode: "", wantErr: false},
+		{name: "unsupported", mode: "retry", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidGenerationChangeMode(tc.mode)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidGenerationStrategy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy string
+		wantErr  bool
+	}{
+		{name: "latest_at_open", strategy: GenerationStrategyLatestAtOpen, wantErr: false},
+		{name: "latest_always", strategy: GenerationStrategyLatestAlways, wantErr: false},
+		{name: "snapshot", strategy: GenerationStrategySnapshot, wantErr: false},
+		{name: "unset", strategy: "", wantErr: false},
+		{name: "unsupported", strategy: "oldest", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidGenerationStrategy(tc.strategy)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidRenameDirOverLimitMode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{name: "fail", mode: RenameDirOverLimitModeFail, wantErr: false},
+		{name: "copy_delete", mode: RenameDirOverLimitModeCopyDelete, wantErr: false},
+		{name: "unset", mode: "", wantErr: false},
+		{name: "unsupported", mode: "copy", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidRenameDirOverLimitMode(tc.mode)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidVanishedEntryMode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{name: "skip", mode: VanishedEntryModeSkip, wantErr: false},
+		{name: "error", mode: VanishedEntryModeError, wantErr: false},
+		{name: "unset", mode: "", wantErr: false},
+		{name: "unsupported", mode: "ignore", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidVanishedEntryMode(tc.mode)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_isValidReadSourceOrder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		order   []string
+		wantErr bool
+	}{
+		{name: "cache_first", order: []string{"cache", "pool", "gcs"}, wantErr: false},
+		{name: "pool_first", order: []string{"pool", "cache", "gcs"}, wantErr: false},
+		{name: "unset", order: nil, wantErr: false},
+		{name: "gcs_not_last", order: []string{"cache", "gcs", "pool"}, wantErr: true},
+		{name: "missing_source", order: []string{"cache", "gcs"}, wantErr: true},
+		{name: "repeated_source", order: []string{"cache", "cache", "gcs"}, wantErr: true},
+		{name: "unsupported_source", order: []string{"disk", "pool", "gcs"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := isValidReadSourceOrder(tc.order)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_isValidBufferedReadConfig_ValidScenarios(t *testing.T) {
 	var testCases = []struct {
 		testName string
 		read     ReadConfig
 	}{
 		{"valid_config_1", ReadConfig{
-			BlockSizeMb:          16,
-			EnableBufferedRead:   true,
-			GlobalMaxBlocks:      -1,
-			MaxBlocksPerHandle:   -1,
-			StartBlocksPerHandle: 1,
-			MinBlocksPerHandle:   1,
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         -1,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      1,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
 		}},
 		{"valid_config_2", ReadConfig{
-			BlockSizeMb:          16,
-			EnableBufferedRead:   true,
-			GlobalMaxBlocks:      10,
-			MaxBlocksPerHandle:   -1,
-			StartBlocksPerHandle: 1,
-			MinBlocksPerHandle:   4,
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      -1,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      4,
+			PrefetchPolicy:          PrefetchPolicySequential,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
 		}},
 		{"valid_config_3", ReadConfig{
-			BlockSizeMb:          16,
-			EnableBufferedRead:   true,
-			GlobalMaxBlocks:      10,
-			MaxBlocksPerHandle:   5,
-			StartBlocksPerHandle: 1,
-			MinBlocksPerHandle:   5,
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyFooterFirst,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+		}},
+		{"valid_config_5", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyNone,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+		}},
+		{"valid_config_6", ReadConfig{
+			BlockSizeMb:               16,
+			EnableBufferedRead:        true,
+			GlobalMaxBlocks:           10,
+			MaxBlocksPerHandle:        5,
+			StartBlocksPerHandle:      1,
+			MinBlocksPerHandle:        5,
+			PrefetchPolicy:            PrefetchPolicyAdaptive,
+			BlockAlignment:            BlockAlignmentNone,
+			CancelledDownloadPolicy:   CancelledDownloadPolicyDiscard,
+			EfficiencySummaryInterval: time.Minute,
+		}},
+		{"valid_config_7", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentObjectSize,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+		}},
+		{"valid_config_8", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			DownloadDeadlineSecs:    10,
+			DownloadMaxRetries:      3,
+		}},
+		{"valid_config_9", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PinnedMaxBlocks:         4,
+			PinnedObjects:           []string{"model.bin"},
+		}},
+		{"valid_config_10", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyDiscard,
+			PreallocateBlocks:       true,
+		}},
+		{"valid_config_11", ReadConfig{
+			BlockSizeMb:             16,
+			EnableBufferedRead:      true,
+			GlobalMaxBlocks:         10,
+			MaxBlocksPerHandle:      5,
+			StartBlocksPerHandle:    1,
+			MinBlocksPerHandle:      5,
+			PrefetchPolicy:          PrefetchPolicyAdaptive,
+			BlockAlignment:          BlockAlignmentNone,
+			CancelledDownloadPolicy: CancelledDownloadPolicyKeepPartial,
 		}},
 		{"valid_config_4", ReadConfig{
 			BlockSizeMb:          16,
@@ -933,6 +1657,11 @@ func validConfig(t *testing.T) Config {
 			Workers:    1,
 			BufferSize: 1,
 		},
+		GarbageCollection: GarbageCollectionConfig{
+			MaxDeleteBacklog:    100,
+			TempObjectPeriod:    10 * time.Minute,
+			TempObjectStaleness: 30 * time.Minute,
+		},
 		Mrd: MrdConfig{
 			PoolSize: 4,
 		},
@@ -1038,6 +1767,44 @@ func TestValidateMetrics(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid_otlp_exporter",
+			metricsConfig: MetricsConfig{
+				Exporter:     MetricsExporterOTLP,
+				OtlpEndpoint: "http://localhost:4317",
+				Workers:      10,
+				BufferSize:   100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "otlp_exporter_without_endpoint",
+			metricsConfig: MetricsConfig{
+				Exporter:   MetricsExporterOTLP,
+				Workers:    10,
+				BufferSize: 100,
+			},
+			wantErr: true,
+		},
+		{
+			name: "otlp_exporter_with_endpoint_without_scheme",
+			metricsConfig: MetricsConfig{
+				Exporter:     MetricsExporterOTLP,
+				OtlpEndpoint: "localhost:4317",
+				Workers:      10,
+				BufferSize:   100,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported_exporter",
+			metricsConfig: MetricsConfig{
+				Exporter:   "statsd",
+				Workers:    10,
+				BufferSize: 100,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1233,6 +2000,18 @@ func TestValidateProfile(t *testing.T) {
 			name:    "profile_checkpointing",
 			profile: ProfileAIMLCheckpointing,
 			wantErr: false,
+		}, {
+			name:    "profile_inference",
+			profile: ProfileAIMLInference,
+			wantErr: false,
+		}, {
+			name:    "profile_bigdata_analytics",
+			profile: ProfileBigdataAnalytics,
+			wantErr: false,
+		}, {
+			name:    "profile_metadata_heavy",
+			profile: ProfileMetadataHeavy,
+			wantErr: false,
 		}, {
 			name:    "unsupported_profile",
 			profile: "unsupported-profile",
@@ -1255,3 +2034,78 @@ func TestValidateProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateProfileErrorListsValidProfiles(t *testing.T) {
+	c := validConfig(t)
+	c.Profile = "aiml-trainng"
+
+	err := ValidateConfig(viper.New(), &c)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"aiml-trainng"`)
+	for _, profile := range []string{ProfileAIMLTraining, ProfileAIMLServing, ProfileAIMLInference, ProfileAIMLCheckpointing, ProfileBigdataAnalytics, ProfileMetadataHeavy} {
+		assert.Contains(t, err.Error(), fmt.Sprintf("%q", profile))
+	}
+}
+
+func TestValidateProfileMetadataCacheSizes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		key     string
+		size    any
+		wantErr bool
+	}{
+		{name: "positive_stat_cache_size", key: StatCacheMaxSizeConfigKey, size: int64(64), wantErr: false},
+		{name: "unlimited_stat_cache_size", key: StatCacheMaxSizeConfigKey, size: int64(-1), wantErr: true},
+		{name: "zero_stat_cache_size", key: StatCacheMaxSizeConfigKey, size: int64(0), wantErr: true},
+		{name: "positive_type_cache_size", key: TypeCacheMaxSizeConfigKey, size: int64(16), wantErr: false},
+		{name: "zero_type_cache_size", key: TypeCacheMaxSizeConfigKey, size: int64(0), wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := AllFlagOptimizationRules[tc.key]
+			t.Cleanup(func() { AllFlagOptimizationRules[tc.key] = original })
+			AllFlagOptimizationRules[tc.key] = shared.OptimizationRules{
+				Profiles: []shared.ProfileOptimization{{Name: ProfileAIMLCheckpointing, Value: tc.size}},
+			}
+			c := validConfig(t)
+			c.Profile = ProfileAIMLCheckpointing
+
+			err := ValidateConfig(viper.New(), &c)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMaxHandlesPerFile(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		maxHandles int64
+		wantErr    bool
+	}{
+		{name: "no_limit", maxHandles: 0},
+		{name: "positive", maxHandles: 100},
+		{name: "negative", maxHandles: -1, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c := validConfig(t)
+			c.FileSystem.MaxHandlesPerFile = tc.maxHandles
+
+			err := ValidateConfig(viper.New(), &c)
+
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "max-handles-per-file")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -212,12 +212,14 @@ func TestValidateConfigFile_WriteConfig(t *testing.T) {
 			configFile: "testdata/empty_file.yaml",
 			expectedConfig: &cfg.Config{
 				Write: cfg.WriteConfig{
-					CreateEmptyFile:       false,
-					BlockSizeMb:           32,
-					EnableStreamingWrites: true,
-					GlobalMaxBlocks:       4,
-					MaxBlocksPerFile:      1,
-					EnableRapidAppends:    true,
+					CreateEmptyFile:                  false,
+					BlockSizeMb:                      32,
+					EnableStreamingWrites:            true,
+					GlobalMaxBlocks:                  4,
+					MaxBlocksPerFile:                 1,
+					EnableRapidAppends:               true,
+					ParallelCompositeComponentSizeMb: 64,
+					UploadStrategy:                   "simple",
 				},
 			},
 		},
@@ -226,11 +228,13 @@ func TestValidateConfigFile_WriteConfig(t *testing.T) {
 			configFile: "testdata/valid_config.yaml",
 			expectedConfig: &cfg.Config{
 				Write: cfg.WriteConfig{
-					CreateEmptyFile:       false, // changed due to enabled streaming writes.
-					BlockSizeMb:           10,
-					EnableStreamingWrites: true,
-					GlobalMaxBlocks:       20,
-					MaxBlocksPerFile:      2,
+					CreateEmptyFile:                  false, // changed due to enabled streaming writes.
+					BlockSizeMb:                      10,
+					EnableStreamingWrites:            true,
+					GlobalMaxBlocks:                  20,
+					MaxBlocksPerFile:                 2,
+					ParallelCompositeComponentSizeMb: 64,
+					UploadStrategy:                   "simple",
 				},
 			},
 		},
@@ -258,14 +262,35 @@ func TestValidateConfigFile_ReadConfig(t *testing.T) {
 			configFile: "testdata/empty_file.yaml",
 			expectedConfig: &cfg.Config{
 				Read: cfg.ReadConfig{
-					InactiveStreamTimeout: 10 * time.Second,
-					BlockSizeMb:           16,
-					EnableBufferedRead:    false,
-					GlobalMaxBlocks:       40,
-					MaxBlocksPerHandle:    20,
-					StartBlocksPerHandle:  1,
-					MinBlocksPerHandle:    4,
-					RandomSeekThreshold:   3,
+					InactiveStreamTimeout:     10 * time.Second,
+					BlockAlignment:            "none",
+					BlockSizeMb:               16,
+					CancelledDownloadPolicy:   "discard",
+					CircuitBreakerCooldown:    30 * time.Second,
+					DownloadLimitBytesPerSec:  -1,
+					DownloadRescheduleBackoff: 100 * time.Millisecond,
+					DownloadReschedules:       1,
+					DownloadRetryBackoff:      100 * time.Millisecond,
+					EnableBufferedRead:        false,
+					FailureDiagnosticInterval: time.Hour,
+					FanOutMaxBlocks:           4,
+					GenerationChangeMode:      "estale",
+					GenerationStrategy:        "latest-at-open",
+					GlobalMaxBlocks:           40,
+					HotFileMinOpens:           3,
+					HotFileWindow:             10 * time.Minute,
+					MaxBlocksPerHandle:        20,
+					MaxPrefetchFiles:          -1,
+					PhaseRandomThreshold:      0.75,
+					PhaseSequentialThreshold:  0.75,
+					PrefetchPolicy:            "adaptive",
+					StartBlocksPerHandle:      1,
+					MinBlocksPerHandle:        4,
+					RandomSeekThreshold:       3,
+					ObjectMetadataKeys:        []string{},
+					PinnedObjects:             []string{},
+					SourceOrder:               []string{"cache", "pool", "gcs"},
+					WarmStateParallelism:      4,
 				},
 			},
 		},
@@ -274,14 +299,35 @@ func TestValidateConfigFile_ReadConfig(t *testing.T) {
 			configFile: "testdata/valid_config.yaml",
 			expectedConfig: &cfg.Config{
 				Read: cfg.ReadConfig{
-					InactiveStreamTimeout: 10 * time.Second,
-					BlockSizeMb:           8,
-					EnableBufferedRead:    true,
-					MaxBlocksPerHandle:    20,
-					GlobalMaxBlocks:       20,
-					StartBlocksPerHandle:  4,
-					MinBlocksPerHandle:    2,
-					RandomSeekThreshold:   10,
+					InactiveStreamTimeout:     10 * time.Second,
+					BlockAlignment:            "none",
+					BlockSizeMb:               8,
+					CancelledDownloadPolicy:   "discard",
+					CircuitBreakerCooldown:    30 * time.Second,
+					DownloadLimitBytesPerSec:  -1,
+					DownloadRescheduleBackoff: 100 * time.Millisecond,
+					DownloadReschedules:       1,
+					DownloadRetryBackoff:      100 * time.Millisecond,
+					EnableBufferedRead:        true,
+					FailureDiagnosticInterval: time.Hour,
+					FanOutMaxBlocks:           4,
+					GenerationChangeMode:      "estale",
+					GenerationStrategy:        "latest-at-open",
+					MaxBlocksPerHandle:        20,
+					GlobalMaxBlocks:           20,
+					HotFileMinOpens:           3,
+					HotFileWindow:             10 * time.Minute,
+					MaxPrefetchFiles:          -1,
+					PhaseRandomThreshold:      0.75,
+					PhaseSequentialThreshold:  0.75,
+					PrefetchPolicy:            "adaptive",
+					StartBlocksPerHandle:      4,
+					MinBlocksPerHandle:        2,
+					RandomSeekThreshold:       10,
+					ObjectMetadataKeys:        []string{},
+					PinnedObjects:             []string{},
+					SourceOrder:               []string{"cache", "pool", "gcs"},
+					WarmStateParallelism:      4,
 				},
 			},
 		},
@@ -597,6 +643,7 @@ func TestValidateConfigFile_FileSystemConfigSuccessful(t *testing.T) {
 					KernelListCacheTtlSecs: 0,
 					InactiveMrdCacheSize:   1000,
 					RenameDirLimit:         0,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
 					TempDir:                "",
 					Uid:                    -1,
 					MaxReadAheadKb:         0,
@@ -617,6 +664,7 @@ func TestValidateConfigFile_FileSystemConfigSuccessful(t *testing.T) {
 					KernelListCacheTtlSecs: 0,
 					InactiveMrdCacheSize:   1000,
 					RenameDirLimit:         0,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
 					TempDir:                "",
 					Uid:                    -1,
 					MaxReadAheadKb:         0,
@@ -637,6 +685,7 @@ func TestValidateConfigFile_FileSystemConfigSuccessful(t *testing.T) {
 					KernelListCacheTtlSecs: 300,
 					InactiveMrdCacheSize:   1000,
 					RenameDirLimit:         10,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
 					TempDir:                cfg.ResolvedPath(path.Join(hd, "temp")),
 					Uid:                    8,
 					MaxReadAheadKb:         1024,
@@ -670,14 +719,14 @@ func TestValidateConfigFile_ListConfigSuccessful(t *testing.T) {
 			name:       "empty_config_file",
 			configFile: "testdata/empty_file.yaml",
 			expectedConfig: &cfg.Config{
-				List: cfg.ListConfig{EnableEmptyManagedFolders: false},
+				List: cfg.ListConfig{EnableEmptyManagedFolders: false, VanishedEntryMode: "skip"},
 			},
 		},
 		{
 			name:       "valid_config_file",
 			configFile: "testdata/valid_config.yaml",
 			expectedConfig: &cfg.Config{
-				List: cfg.ListConfig{EnableEmptyManagedFolders: true},
+				List: cfg.ListConfig{EnableEmptyManagedFolders: true, VanishedEntryMode: "skip"},
 			},
 		},
 	}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/spf13/cobra"
+)
+
+// gcCmdName is the name of the command garbage collecting the temporary
+// objects of a bucket without mounting it.
+const gcCmdName = "gc"
+
+// gcOptions are the options of the gc command.
+type gcOptions struct {
+	bucketName string
+	prefix     string
+	instanceID string
+	staleness  time.Duration
+	dryRun     bool
+}
+
+type gcFn func(config *cfg.Config, opts *gcOptions) error
+
+// garbageCollect runs the gc command. It is replaced in tests.
+var garbageCollect gcFn = GarbageCollect
+
+// newGcCmd returns the gc command, which runs gc with the parsed options. The
+// config is populated from the flags and config file shared with the mount
+// command, e.g. to pick the credentials and the endpoint.
+func newGcCmd(config *cfg.Config, gc gcFn) *cobra.Command {
+	var opts gcOptions
+	gcCmd := &cobra.Command{
+		Use:   gcCmdName + " --bucket=bucket [flags]",
+		Short: "Garbage collect the stale temporary objects of a bucket without mounting it",
+		Long: `Deletes once the objects under the prefix of the bucket last updated before
+the staleness threshold, as the periodic garbage collection of a mount does,
+and prints the number of deleted objects. With --instance-id, only the objects
+of the given mount instance are deleted.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.bucketName == "" {
+				return fmt.Errorf("--bucket must be set")
+			}
+			if opts.staleness < 0 {
+				return fmt.Errorf("--staleness must not be negative: %v", opts.staleness)
+			}
+			if opts.instanceID != "" && !strings.Contains(config.Write.TmpObjectNameTemplate, "{instance}") {
+				return fmt.Errorf("--instance-id requires --write-tmp-object-name-template to embed {instance}")
+			}
+			return gc(config, &opts)
+		},
+	}
+	gcCmd.Flags().StringVar(&opts.bucketName, "bucket", "", "The bucket to garbage collect.")
+	gcCmd.Flags().StringVar(&opts.prefix, "prefix", tmpObjectPrefix, "The prefix of the objects to garbage collect.")
+	gcCmd.Flags().StringVar(&opts.instanceID, "instance-id", "", "The mount instance whose objects are deleted, as embedded in their names by --write-tmp-object-name-template. Empty deletes the objects of all instances.")
+	gcCmd.Flags().DurationVar(&opts.staleness, "staleness", gcsx.GarbageCollectionStalenessThreshold, "The age beyond which objects are deleted.")
+	gcCmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the number of objects which would be deleted, without deleting them.")
+	return gcCmd
+}
+
+// GarbageCollect garbage collects the bucket of the options once, connecting
+// to it as a mount would.
+func GarbageCollect(config *cfg.Config, opts *gcOptions) error {
+	logger.UpdateDefaultLogger(config.Logging.Format, opts.bucketName)
+
+	userAgent := getUserAgent(config.AppName, getConfigForUserAgent(config), logger.MountInstanceID(opts.bucketName))
+	storageHandle, err := createStorageHandle(config, userAgent, metrics.NewNoopMetrics(), false)
+	if err != nil {
+		return fmt.Errorf("createStorageHandle: %w", err)
+	}
+
+	ctx := context.Background()
+	bucket, err := storageHandle.BucketHandle(ctx, opts.bucketName, config.GcsConnection.BillingProject)
+	if err != nil {
+		return fmt.Errorf("BucketHandle: %w", err)
+	}
+	namer, err := gcsx.NewTmpObjectNamer(opts.prefix, config.Write.TmpObjectNameTemplate, logger.MountInstanceID(opts.bucketName))
+	if err != nil {
+		return fmt.Errorf("NewTmpObjectNamer: %w", err)
+	}
+	return garbageCollectBucket(ctx, bucket, namer, opts, os.Stdout)
+}
+
+// garbageCollectBucket garbage collects the temporary objects of the bucket
+// named by namer once and writes the number of deleted objects to out.
+func garbageCollectBucket(ctx context.Context, bucket gcs.Bucket, namer *gcsx.TmpObjectNamer, opts *gcOptions, out io.Writer) error {
+	objectsDeleted, err := gcsx.GarbageCollectOnce(ctx, bucket, namer, opts.instanceID, opts.staleness, opts.dryRun)
+	if err != nil {
+		return fmt.Errorf("garbage collection of bucket %q failed after deleting %d objects: %w", bucket.Name(), objectsDeleted, err)
+	}
+
+	if opts.dryRun {
+		fmt.Fprintf(out, "Would delete %d objects.\n", objectsDeleted)
+	} else {
+		fmt.Fprintf(out, "Deleted %d objects.\n", objectsDeleted)
+	}
+	return nil
+}
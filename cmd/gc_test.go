@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGarbageCollect replaces the gc command run for the duration of the test,
+// capturing the config and options it is called with.
+func stubGarbageCollect(t *testing.T) (gotConfig **cfg.Config, gotOpts **gcOptions) {
+	t.Helper()
+	gotConfig = new(*cfg.Config)
+	gotOpts = new(*gcOptions)
+	orig := garbageCollect
+	t.Cleanup(func() { garbageCollect = orig })
+	garbageCollect = func(config *cfg.Config, opts *gcOptions) error {
+		*gotConfig = config
+		*gotOpts = opts
+		return nil
+	}
+	return
+}
+
+func TestGcCmdParsing(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		wantOpts gcOptions
+	}{
+		{
+			name: "defaults",
+			args: []string{"gc", "--bucket=abc"},
+			wantOpts: gcOptions{
+				bucketName: "abc",
+				prefix:     tmpObjectPrefix,
+				staleness:  gcsx.GarbageCollectionStalenessThreshold,
+			},
+		},
+		{
+			name: "all_options",
+			args: []string{"gc", "--bucket=abc", "--prefix=scratch/", "--instance-id=abc-1234", "--write-tmp-object-name-template={instance}/{random}", "--staleness=1h", "--dry-run"},
+			wantOpts: gcOptions{
+				bucketName: "abc",
+				prefix:     "scratch/",
+				instanceID: "abc-1234",
+				staleness:  time.Hour,
+				dryRun:     true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gotOpts := stubGarbageCollect(t)
+			cmd, err := newRootCmd(func(*mountInfo, string, string) error {
+				t.Fatal("The gc command must not mount the bucket.")
+				return nil
+			})
+			require.NoError(t, err)
+			cmd.SetArgs(convertToPosixArgs(tc.args, cmd))
+
+			require.NoError(t, cmd.Execute())
+
+			require.NotNil(t, *gotOpts)
+			assert.Equal(t, tc.wantOpts, **gotOpts)
+		})
+	}
+}
+
+func TestGcCmdHonorsMountFlags(t *testing.T) {
+	gotConfig, _ := stubGarbageCollect(t)
+	cmd, err := newRootCmd(func(*mountInfo, string, string) error { return nil })
+	require.NoError(t, err)
+	cmd.SetArgs(convertToPosixArgs([]string{"gc", "--bucket=abc", "--billing-project=proj", "--anonymous-access"}, cmd))
+
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, *gotConfig)
+	assert.Equal(t, "proj", (*gotConfig).GcsConnection.BillingProject)
+	assert.True(t, (*gotConfig).GcsAuth.AnonymousAccess)
+}
+
+func TestGcCmdInvalidOptions(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "missing_bucket",
+			args: []string{"gc"},
+		},
+		{
+			name: "negative_staleness",
+			args: []string{"gc", "--bucket=abc", "--staleness=-1m"},
+		},
+		{
+			name: "instance_id_without_template",
+			args: []string{"gc", "--bucket=abc", "--instance-id=abc-1234"},
+		},
+		{
+			name: "positional_args",
+			args: []string{"gc", "--bucket=abc", "extra"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gotOpts := stubGarbageCollect(t)
+			cmd, err := newRootCmd(func(*mountInfo, string, string) error { return nil })
+			require.NoError(t, err)
+			cmd.SetArgs(convertToPosixArgs(tc.args, cmd))
+
+			assert.Error(t, cmd.Execute())
+			assert.Nil(t, *gotOpts)
+		})
+	}
+}
+
+func TestGarbageCollectBucket(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dryRun      bool
+		wantOutput  string
+		wantRemains []string
+	}{
+		{
+			name:        "deletes_stale_objects",
+			wantOutput:  "Deleted 1 objects.\n",
+			wantRemains: []string{tmpObjectPrefix + "fresh"},
+		},
+		{
+			name:        "dry_run",
+			dryRun:      true,
+			wantOutput:  "Would delete 1 objects.\n",
+			wantRemains: []string{tmpObjectPrefix + "fresh", tmpObjectPrefix + "stale"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var clock timeutil.SimulatedClock
+			clock.SetTime(time.Now().Add(-time.Hour))
+			bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+			_, err := storageutil.CreateObject(ctx, bucket, tmpObjectPrefix+"stale", []byte("taco"))
+			require.NoError(t, err)
+			clock.SetTime(time.Now())
+			_, err = storageutil.CreateObject(ctx, bucket, tmpObjectPrefix+"fresh", []byte("burrito"))
+			require.NoError(t, err)
+			opts := &gcOptions{bucketName: "bucket", prefix: tmpObjectPrefix, staleness: 30 * time.Minute, dryRun: tc.dryRun}
+			namer, err := gcsx.NewTmpObjectNamer(opts.prefix, "", "")
+			require.NoError(t, err)
+			var out bytes.Buffer
+
+			err = garbageCollectBucket(ctx, bucket, namer, opts, &out)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOutput, out.String())
+			listing, err := bucket.ListObjects(ctx, &gcs.ListObjectsRequest{})
+			require.NoError(t, err)
+			var remains []string
+			for _, o := range listing.MinObjects {
+				remains = append(remains, o.Name)
+			}
+			assert.ElementsMatch(t, tc.wantRemains, remains)
+		})
+	}
+}
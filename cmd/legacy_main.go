@@ -60,6 +60,10 @@ const (
 	DynamicMountFSName             = "gcsfuse"
 	WaitTimeOnSignalReceive        = 30 * time.Second
 	MountTimeThreshold             = 8 * time.Second
+
+	// tmpObjectPrefix is the prefix of the temporary objects written to the
+	// bucket, which garbage collection deletes once stale.
+	tmpObjectPrefix = ".gcsfuse_tmp/"
 )
 
 ////////////////////////////////////////////////////////////////////////
@@ -168,6 +172,7 @@ func createStorageHandle(newConfig *cfg.Config, userAgent string, metricHandle m
 		ExperimentalEnablePirlo:                 newConfig.FileSystem.ExperimentalEnablePirlo,
 		TracingEnabled:                          cfg.IsTracingEnabled(newConfig),
 		EnableHTTPDNSCache:                      newConfig.GcsConnection.EnableHttpDnsCache,
+		RegionAwareReadEndpoint:                 newConfig.GcsConnection.RegionAwareReadEndpoint,
 		LocalSocketAddress:                      newConfig.GcsConnection.ExperimentalLocalSocketAddress,
 		EnableGrpcMetrics:                       newConfig.Metrics.ExperimentalEnableGrpcMetrics,
 		IsGKE:                                   isGKE,
@@ -373,6 +378,9 @@ func logGCSFuseMountInformation(mountInfo *mountInfo) {
 	if mountInfo.configFileFlags != nil {
 		logger.Info("GCSFuse Config", "ConfigFile Flags", mountInfo.configFileFlags)
 	}
+	if mountInfo.detectedMachineType != "" {
+		logger.Infof("Detected machine type %q from the metadata server.", mountInfo.detectedMachineType)
+	}
 	if len(mountInfo.optimizedFlags) > 0 {
 		logger.Info("GCSFuse Config", "Optimized Flags", mountInfo.optimizedFlags)
 	}
@@ -396,6 +404,14 @@ func Mount(mountInfo *mountInfo, bucketName, mountPoint string) (err error) {
 	}
 
 	logger.Infof("Start gcsfuse/%s for app %q using mount point: %s\n", common.GetVersion(), newConfig.AppName, mountPoint)
+	if newConfig.EnableTypeCacheDeprecation {
+		logger.Infof("Metadata cache: stat-cache-max-size-mb %d, type cache deprecated\n", newConfig.MetadataCache.StatCacheMaxSizeMb)
+	} else {
+		logger.Infof("Metadata cache: stat-cache-max-size-mb %d, type-cache-max-size-mb %d\n", newConfig.MetadataCache.StatCacheMaxSizeMb, newConfig.MetadataCache.TypeCacheMaxSizeMb)
+	}
+	if newConfig.Read.GlobalMaxMemoryFraction > 0 {
+		logger.Infof("Buffered read: read-global-max-blocks %d, i.e. %d MiB, for read-global-max-memory-fraction %v\n", newConfig.Read.GlobalMaxBlocks, newConfig.Read.GlobalMaxBlocks*newConfig.Read.BlockSizeMb, newConfig.Read.GlobalMaxMemoryFraction)
+	}
 
 	// Log mount-config and the CLI flags in the log-file.
 	// If there is no log-file, then log these to stdout.
@@ -416,7 +432,9 @@ func Mount(mountInfo *mountInfo, bucketName, mountPoint string) (err error) {
 		logger.Warnf("Deprecated flag stat-cache-ttl and/or type-cache-ttl used! Please switch to config parameter 'metadata-cache: ttl-secs' .")
 	}
 
-	if newConfig.EnableTypeCacheDeprecation && (newConfig.MetadataCache.TypeCacheMaxSizeMb != mount.DefaultTypeCacheSizeMB || newConfig.MetadataCache.EnableNonexistentTypeCache) {
+	// Profiles size the type cache too, which the user didn't ask for.
+	typeCacheSizeSet := newConfig.MetadataCache.TypeCacheMaxSizeMb != mount.DefaultTypeCacheSizeMB && (mountInfo.viperConfig == nil || mountInfo.viperConfig.IsSet(cfg.TypeCacheMaxSizeConfigKey))
+	if newConfig.EnableTypeCacheDeprecation && (typeCacheSizeSet || newConfig.MetadataCache.EnableNonexistentTypeCache) {
 		logger.Warnf("Type cache is deprecated. The flags 'type-cache-max-size-mb' and 'enable-nonexistent-type-cache' will be ignored. Please use 'stat-cache-max-size-mb' and 'metadata-cache-negative-ttl-secs' instead.")
 	}
 
@@ -91,6 +91,14 @@ be interacting with the file system.`)
 		gid = uint32(newConfig.FileSystem.Gid)
 	}
 
+	// Name the temporary objects after the template, embedding the ID of the
+	// mount instance for garbage collection and auditing to parse.
+	tmpObjectNamer, err := gcsx.NewTmpObjectNamer(tmpObjectPrefix, newConfig.Write.TmpObjectNameTemplate, logger.MountInstanceID(fsName(bucketName)))
+	if err != nil {
+		err = fmt.Errorf("NewTmpObjectNamer: %w", err)
+		return
+	}
+
 	bucketCfg := gcsx.BucketConfig{
 		BillingProject:                     newConfig.GcsConnection.BillingProject,
 		OnlyDir:                            newConfig.OnlyDir,
@@ -104,13 +112,34 @@ be interacting with the file system.`)
 		AppendThreshold:                    1 << 21, // 2 MiB, a total guess.
 		ChunkRetryDeadlineSecs:             newConfig.GcsRetries.ChunkRetryDeadlineSecs,
 		ChunkTransferTimeoutSecs:           newConfig.GcsRetries.ChunkTransferTimeoutSecs,
-		TmpObjectPrefix:                    ".gcsfuse_tmp/",
+		TmpObjectPrefix:                    tmpObjectPrefix,
+		GarbageCollectionMode:              newConfig.GarbageCollection.Mode,
+		GarbageCollectionDeleteOpsPerSec:   newConfig.GarbageCollection.DeleteOpsPerSec,
+		GarbageCollectionMaxDeleteBacklog:  int(newConfig.GarbageCollection.MaxDeleteBacklog),
+		GarbageCollectionDeleteParallelism: int(newConfig.GarbageCollection.DeleteParallelism),
+		GarbageCollectionStaleness:         newConfig.GarbageCollection.TempObjectStaleness,
+		GarbageCollectionPeriod:            newConfig.GarbageCollection.TempObjectPeriod,
+		GarbageCollectionDryRun:            newConfig.GarbageCollection.DryRun,
+		GarbageCollectionExcludePatterns:   newConfig.GarbageCollection.ExcludePatterns,
+		GarbageCollectionFinalSweep:        newConfig.GarbageCollection.FinalSweep,
 		DisableListAccessCheck:             newConfig.DisableListAccessCheck,
 		DummyIOCfg:                         newConfig.DummyIo,
 		IsTypeCacheDeprecated:              newConfig.EnableTypeCacheDeprecation,
 		ImplicitDir:                        newConfig.ImplicitDirs,
+		UploadConfig: gcsx.UploadConfig{
+			ParallelComposite: newConfig.Write.UploadStrategy == cfg.UploadStrategyParallelComposite,
+			ComponentSize:     newConfig.Write.ParallelCompositeComponentSizeMb * 1024 * 1024,
+			TmpObjectNamer:    tmpObjectNamer,
+		},
 	}
 	bm := gcsx.NewBucketManager(bucketCfg, storageHandle)
+	defer func() {
+		// The file system failing to mount is never destroyed, so its buckets
+		// are shut down here, without the final garbage collection sweep.
+		if err != nil {
+			bm.ShutDownOnError(err)
+		}
+	}()
 
 	// Create a file system server.
 	serverCfg := &fs.ServerConfig{
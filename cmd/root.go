@@ -45,6 +45,10 @@ type mountInfo struct {
 	// optimizedFlags contains the flags that were optimized
 	// based on either machine-type or profile.
 	optimizedFlags map[string]any
+	// detectedMachineType is the machine type looked up from the metadata
+	// server, as --machine-type was unset. Empty if set by the user, or if the
+	// lookup failed, e.g. off GCE.
+	detectedMachineType string
 	// viperConfig is used to check if a flag was explicitly set by the user.
 	// This is used to determine if optimization rules should be applied.
 	viperConfig *viper.Viper
@@ -89,9 +93,11 @@ func getConfigFileFlags(v *viper.Viper) map[string]any {
 // newRootCmd accepts the mountFn that it executes with the parsed configuration
 func newRootCmd(m mountFn) (*cobra.Command, error) {
 	var (
-		mountInfo   mountInfo
-		cfgFile     string
-		viperConfig = viper.New()
+		mountInfo        mountInfo
+		cfgFile          string
+		printResolvedEnv bool
+		optimizedFlags   map[string]cfg.OptimizationResult
+		viperConfig      = viper.New()
 	)
 	mountInfo.config = &cfg.Config{}
 	rootCmd := &cobra.Command{
@@ -100,8 +106,14 @@ func newRootCmd(m mountFn) (*cobra.Command, error) {
 		Long: `Cloud Storage FUSE is an open source FUSE adapter that lets you mount 
 and access Cloud Storage buckets as local file systems. For a technical overview
 of Cloud Storage FUSE, see https://cloud.google.com/storage/docs/gcs-fuse.`,
-		Version:      common.GetVersion(),
-		Args:         cobra.RangeArgs(2, 3),
+		Version: common.GetVersion(),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// Printing the resolved settings needs neither bucket nor mount point.
+			if printResolvedEnv {
+				return cobra.RangeArgs(1, 3)(cmd, args)
+			}
+			return cobra.RangeArgs(2, 3)(cmd, args)
+		},
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if cfgFile != "" {
@@ -130,7 +142,15 @@ of Cloud Storage FUSE, see https://cloud.google.com/storage/docs/gcs-fuse.`,
 			}
 
 			mountInfo.viperConfig = viperConfig
-			optimizedFlags := mountInfo.config.ApplyOptimizations(viperConfig, nil)
+			// The machine type is otherwise looked up from the metadata server.
+			if printResolvedEnv && !mountInfo.config.DisableAutoconfig && mountInfo.config.MachineType == "" {
+				return fmt.Errorf("--print-resolved-env requires --machine-type")
+			}
+			userMachineType := mountInfo.config.MachineType
+			optimizedFlags = mountInfo.config.ApplyOptimizations(viperConfig, nil)
+			if userMachineType == "" {
+				mountInfo.detectedMachineType = mountInfo.config.MachineType
+			}
 			optimizedFlagNames := slices.Collect(maps.Keys(optimizedFlags))
 			if err := cfg.Rationalize(viperConfig, mountInfo.config, optimizedFlagNames); err != nil {
 				return fmt.Errorf("error rationalizing config: %w", err)
@@ -150,6 +170,12 @@ of Cloud Storage FUSE, see https://cloud.google.com/storage/docs/gcs-fuse.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if printResolvedEnv {
+				for _, line := range cfg.ResolvedEnv(mountInfo.config, optimizedFlags) {
+					fmt.Fprintln(cmd.OutOrStdout(), line)
+				}
+				return nil
+			}
 			bucket, mountPoint, err := populateArgs(args[1:])
 			if err != nil {
 				return fmt.Errorf("error occurred while extracting the bucket and mountPoint: %w", err)
@@ -159,6 +185,8 @@ of Cloud Storage FUSE, see https://cloud.google.com/storage/docs/gcs-fuse.`,
 	}
 	rootCmd.PersistentFlags().StringVar(&cfgFile, cfg.ConfigFileFlagName, "", "The path to the config file where all gcsfuse related config needs to be specified. "+
 		"Refer to 'https://cloud.google.com/storage/docs/gcsfuse-cli#config-file' for possible configurations.")
+	rootCmd.Flags().BoolVar(&printResolvedEnv, "print-resolved-env", false, "Prints the profile, the machine type and the flags optimized for them as GCSFUSE_KEY=value lines, and exits without mounting. "+
+		"Requires --machine-type, not to query the metadata server.")
 
 	// Add all the other flags.
 	if err := cfg.BuildFlagSet(rootCmd.PersistentFlags()); err != nil {
@@ -167,6 +195,11 @@ of Cloud Storage FUSE, see https://cloud.google.com/storage/docs/gcs-fuse.`,
 	if err := cfg.BindFlags(viperConfig, rootCmd.PersistentFlags()); err != nil {
 		return nil, fmt.Errorf("error while binding flags: %w", err)
 	}
+
+	// The gc command shares the flags of the mount command, like the ones
+	// picking the credentials and the endpoint.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(newGcCmd(mountInfo.config, garbageCollect))
 	return rootCmd, nil
 }
 
@@ -217,7 +250,13 @@ var ExecuteMountCmd = func() {
 	if err != nil {
 		log.Fatalf("Error occurred while creating the root command on gcsfuse/%s: %v", common.GetVersion(), err)
 	}
-	rootCmd.SetArgs(convertToPosixArgs(os.Args, rootCmd))
+	args := convertToPosixArgs(os.Args, rootCmd)
+	// The mount command takes the program name as its first argument, while
+	// the gc command is looked up from the first argument.
+	if len(args) > 1 && args[1] == gcCmdName {
+		args = args[1:]
+	}
+	rootCmd.SetArgs(args)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatalf("Error occurred during command execution on gcsfuse/%s: %v", common.GetVersion(), err)
 	}
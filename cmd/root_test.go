@@ -15,11 +15,13 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"os"
 	"path"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -579,6 +581,75 @@ func TestArgsParsing_ReadConfigFlags(t *testing.T) {
 	}
 }
 
+func TestArgsParsing_MachineTypeSetByUserIsNotDetected(t *testing.T) {
+	var mi *mountInfo
+	cmd, err := newRootCmd(func(mountInfo *mountInfo, _, _ string) error {
+		mi = mountInfo
+		return nil
+	})
+	require.Nil(t, err)
+	cmd.SetArgs(convertToPosixArgs([]string{"gcsfuse", "--machine-type=a3-highgpu-8g", "abc", "pqr"}, cmd))
+
+	err = cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Equal(t, "a3-highgpu-8g", mi.config.MachineType)
+	assert.Empty(t, mi.detectedMachineType)
+}
+
+func TestArgsParsing_AIMLInferenceProfile(t *testing.T) {
+	tests := []struct {
+		name                       string
+		args                       []string
+		expectedEnableBufferedRead bool
+		expectedGlobalMaxBlocks    int64
+		expectedMaxBlocksPerHandle int64
+		expectedPrefetchPolicy     string
+		expectedImplicitDirs       bool
+	}{
+		{
+			name:                       "profile_defaults",
+			args:                       []string{"gcsfuse", "--profile=" + cfg.ProfileAIMLInference, "abc", "pqr"},
+			expectedEnableBufferedRead: true,
+			expectedGlobalMaxBlocks:    200,
+			expectedMaxBlocksPerHandle: 40,
+			expectedPrefetchPolicy:     cfg.PrefetchPolicySequential,
+			expectedImplicitDirs:       true,
+		},
+		{
+			name:                       "user_set_flags_override_profile",
+			args:                       []string{"gcsfuse", "--profile=" + cfg.ProfileAIMLInference, "--enable-buffered-read=false", "--read-global-max-blocks=50", "--read-max-blocks-per-handle=10", "--read-prefetch-policy=adaptive", "--implicit-dirs=false", "abc", "pqr"},
+			expectedEnableBufferedRead: false,
+			expectedGlobalMaxBlocks:    50,
+			expectedMaxBlocksPerHandle: 10,
+			expectedPrefetchPolicy:     cfg.PrefetchPolicyAdaptive,
+			expectedImplicitDirs:       false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var c *cfg.Config
+			cmd, err := newRootCmd(func(mountInfo *mountInfo, _, _ string) error {
+				c = mountInfo.config
+				return nil
+			})
+			require.Nil(t, err)
+			cmd.SetArgs(convertToPosixArgs(tc.args, cmd))
+
+			err = cmd.Execute()
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedEnableBufferedRead, c.Read.EnableBufferedRead)
+				assert.Equal(t, tc.expectedGlobalMaxBlocks, c.Read.GlobalMaxBlocks)
+				assert.Equal(t, tc.expectedMaxBlocksPerHandle, c.Read.MaxBlocksPerHandle)
+				assert.Equal(t, tc.expectedPrefetchPolicy, c.Read.PrefetchPolicy)
+				assert.Equal(t, tc.expectedImplicitDirs, c.ImplicitDirs)
+			}
+		})
+	}
+}
+
 func TestArgsParsing_FileCacheFlags(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -961,6 +1032,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 		KernelListCacheTtlSecs:        0,
 		InactiveMrdCacheSize:          1000,
 		RenameDirLimit:                0,
+		RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 		TempDir:                       "",
 		ExperimentalODirect:           false,
 		Uid:                           -1,
@@ -993,6 +1065,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					KernelListCacheTtlSecs:        300,
 					InactiveMrdCacheSize:          1000,
 					RenameDirLimit:                10,
+					RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 					TempDir:                       cfg.ResolvedPath(path.Join(hd, "temp")),
 					ExperimentalODirect:           false,
 					Uid:                           8,
@@ -1015,6 +1088,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					KernelListCacheTtlSecs:        0,
 					InactiveMrdCacheSize:          1000,
 					RenameDirLimit:                0,
+					RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 					TempDir:                       "",
 					ExperimentalODirect:           false,
 					Uid:                           -1,
@@ -1037,6 +1111,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					KernelListCacheTtlSecs:        0,
 					InactiveMrdCacheSize:          1000,
 					RenameDirLimit:                200000,
+					RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 					TempDir:                       "",
 					ExperimentalODirect:           false,
 					Uid:                           -1,
@@ -1061,6 +1136,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					KernelListCacheTtlSecs:        0,
 					InactiveMrdCacheSize:          1000,
 					RenameDirLimit:                0,
+					RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 					TempDir:                       "",
 					ExperimentalODirect:           false,
 					Uid:                           -1,
@@ -1085,6 +1161,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					KernelListCacheTtlSecs:        0,
 					InactiveMrdCacheSize:          1000,
 					RenameDirLimit:                15000,
+					RenameDirOverLimitMode:        cfg.RenameDirOverLimitModeFail,
 					TempDir:                       "",
 					ExperimentalODirect:           false,
 					Uid:                           -1,
@@ -1153,14 +1230,15 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--experimental-o-direct", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  true,
-					Uid:                  -1,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    true,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
 				},
 			},
 		},
@@ -1175,6 +1253,7 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 					Gid:                     -1,
 					IgnoreInterrupts:        true,
 					InactiveMrdCacheSize:    1000,
+					RenameDirOverLimitMode:  cfg.RenameDirOverLimitModeFail,
 					Uid:                     -1,
 					ExperimentalEnablePirlo: true,
 				},
@@ -1185,15 +1264,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--max-read-ahead-kb=1024", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					MaxReadAheadKb:       1024,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					MaxReadAheadKb:         1024,
 				},
 			},
 		},
@@ -1202,14 +1282,15 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					Uid:                  -1,
-					MaxReadAheadKb:       0,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					MaxReadAheadKb:         0,
 				},
 			},
 		},
@@ -1218,15 +1299,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--max-background=512", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					MaxBackground:        512,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					MaxBackground:          512,
 				},
 			},
 		},
@@ -1235,15 +1317,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					MaxBackground:        0,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					MaxBackground:          0,
 				},
 			},
 		},
@@ -1252,15 +1335,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--congestion-threshold=256", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					CongestionThreshold:  256,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					CongestionThreshold:    256,
 				},
 			},
 		},
@@ -1269,15 +1353,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					CongestionThreshold:  0,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					CongestionThreshold:    0,
 				},
 			},
 		},
@@ -1286,15 +1371,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--enable-kernel-reader", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					EnableKernelReader:   true,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					EnableKernelReader:     true,
 				},
 			},
 		},
@@ -1303,15 +1389,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					EnableKernelReader:   false,
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					EnableKernelReader:     false,
 				},
 			},
 		},
@@ -1320,15 +1407,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--kernel-params-file=/tmp/params", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					KernelParamsFile:     "/tmp/params",
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					KernelParamsFile:       "/tmp/params",
 				},
 			},
 		},
@@ -1337,15 +1425,16 @@ func TestArgsParsing_FileSystemFlags(t *testing.T) {
 			args: []string{"gcsfuse", "--config-file", createTempConfigFile(t, "file-system:\n  kernel-params-file: /tmp/config_params"), "abc", "pqr"},
 			expectedConfig: &cfg.Config{
 				FileSystem: cfg.FileSystemConfig{
-					DirMode:              0755,
-					FileMode:             0644,
-					FuseOptions:          []string{},
-					Gid:                  -1,
-					IgnoreInterrupts:     true,
-					InactiveMrdCacheSize: 1000,
-					ExperimentalODirect:  false,
-					Uid:                  -1,
-					KernelParamsFile:     "/tmp/config_params",
+					DirMode:                0755,
+					FileMode:               0644,
+					FuseOptions:            []string{},
+					Gid:                    -1,
+					IgnoreInterrupts:       true,
+					InactiveMrdCacheSize:   1000,
+					ExperimentalODirect:    false,
+					RenameDirOverLimitMode: cfg.RenameDirOverLimitModeFail,
+					Uid:                    -1,
+					KernelParamsFile:       "/tmp/config_params",
 				},
 			},
 		},
@@ -1450,14 +1539,21 @@ func TestArgsParsing_ListFlags(t *testing.T) {
 			name: "normal",
 			args: []string{"gcsfuse", "--enable-empty-managed-folders", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
-				List: cfg.ListConfig{EnableEmptyManagedFolders: true},
+				List: cfg.ListConfig{EnableEmptyManagedFolders: true, VanishedEntryMode: "skip"},
+			},
+		},
+		{
+			name: "vanished_entry_mode",
+			args: []string{"gcsfuse", "--list-vanished-entry-mode=error", "abc", "pqr"},
+			expectedConfig: &cfg.Config{
+				List: cfg.ListConfig{EnableEmptyManagedFolders: false, VanishedEntryMode: "error"},
 			},
 		},
 		{
 			name: "default",
 			args: []string{"gcsfuse", "abc", "pqr"},
 			expectedConfig: &cfg.Config{
-				List: cfg.ListConfig{EnableEmptyManagedFolders: false},
+				List: cfg.ListConfig{EnableEmptyManagedFolders: false, VanishedEntryMode: "skip"},
 			},
 		},
 	}
@@ -1831,6 +1927,17 @@ func TestArgsParsing_MetricsFlags(t *testing.T) {
 				ExperimentalEnableGrpcMetrics: true,
 			},
 		},
+		{
+			name: "otlp_exporter",
+			args: []string{"gcsfuse", "--metrics-exporter=otlp", "--metrics-otlp-endpoint=http://localhost:4317", "abc", "pqr"},
+			expected: &cfg.MetricsConfig{
+				Exporter:                      cfg.MetricsExporterOTLP,
+				OtlpEndpoint:                  "http://localhost:4317",
+				Workers:                       3,
+				BufferSize:                    256,
+				ExperimentalEnableGrpcMetrics: true,
+			},
+		},
 		{
 			name: "enable_grpc_metrics_non_default",
 			args: []string{"gcsfuse", "--experimental-enable-grpc-metrics=false", "abc", "pqr"},
@@ -2884,3 +2991,50 @@ func TestArgParsing_CliFlagsOverridesFlagOptimizations(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintResolvedEnv(t *testing.T) {
+	args := []string{"--profile=" + cfg.ProfileAIMLTraining, "--machine-type=a3-highgpu-8g"}
+	var resolved *mountInfo
+	cmd, err := newRootCmd(func(mi *mountInfo, _, _ string) error {
+		resolved = mi
+		return nil
+	})
+	require.NoError(t, err)
+	cmd.SetArgs(convertToPosixArgs(append(append([]string{"gcsfuse"}, args...), "bucket", "mountpoint"), cmd))
+	require.NoError(t, cmd.Execute())
+	var out bytes.Buffer
+	cmd, err = newRootCmd(func(*mountInfo, string, string) error {
+		t.Error("Unexpected mount")
+		return nil
+	})
+	require.NoError(t, err)
+	cmd.SetOut(&out)
+	cmd.SetArgs(convertToPosixArgs(append([]string{"gcsfuse", "--print-resolved-env"}, args...), cmd))
+
+	err = cmd.Execute()
+
+	require.NoError(t, err)
+	var settings []string
+	for line := range strings.Lines(out.String()) {
+		if !strings.HasPrefix(line, "#") {
+			settings = append(settings, strings.TrimSuffix(line, "\n"))
+		}
+	}
+	assert.Contains(t, settings, "GCSFUSE_PROFILE="+resolved.config.Profile)
+	assert.Contains(t, settings, "GCSFUSE_MACHINE_TYPE="+resolved.config.MachineType)
+	assert.Contains(t, settings, fmt.Sprintf("GCSFUSE_IMPLICIT_DIRS=%t", resolved.config.ImplicitDirs))
+	assert.Contains(t, settings, fmt.Sprintf("GCSFUSE_WRITE_GLOBAL_MAX_BLOCKS=%d", resolved.config.Write.GlobalMaxBlocks))
+	// Flag values, as opposed to the rationalized config.
+	assert.Contains(t, settings, "GCSFUSE_METADATA_CACHE_TTL_SECS=-1")
+	assert.Contains(t, out.String(), fmt.Sprintf("# implicit-dirs: profile %q", cfg.ProfileAIMLTraining))
+}
+
+func TestPrintResolvedEnvRequiresMachineType(t *testing.T) {
+	cmd, err := newRootCmd(func(*mountInfo, string, string) error { return nil })
+	require.NoError(t, err)
+	cmd.SetArgs(convertToPosixArgs([]string{"gcsfuse", "--print-resolved-env", "--profile=" + cfg.ProfileAIMLTraining}, cmd))
+
+	err = cmd.Execute()
+
+	assert.ErrorContains(t, err, "requires --machine-type")
+}
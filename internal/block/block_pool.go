@@ -29,6 +29,9 @@ type GenBlock interface {
 
 	// Deallocate releases the resources held by the block.
 	Deallocate() error
+
+	// Cap returns the capacity of the block.
+	Cap() int64
 }
 
 // GenBlockPool is a generic block pool for managing blocks that implement the GenBlock interface.
@@ -147,6 +150,27 @@ func (bp *GenBlockPool[T]) TryGet() (T, error) {
 	}
 }
 
+// TryGetSized is like TryGet, but returns a newly allocated block of just size
+// bytes if size is smaller than the block size, e.g. for the last block of an
+// object, falling back to a free block if no new block can be allocated. Such
+// blocks count towards the limits like the others, and are deallocated rather
+// than reused once released.
+// Not thread-safe, calling from multiple goroutines may lead to memory leaks
+// because of race conditions.
+func (bp *GenBlockPool[T]) TryGetSized(size int64) (T, error) {
+	if size <= 0 || size >= bp.blockSize || !bp.canAllocateBlock() {
+		return bp.TryGet()
+	}
+	b, err := bp.createBlockFunc(size)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	bp.totalBlocks++
+	return b, nil
+}
+
 // canAllocateBlock checks if a new block can be allocated.
 func (bp *GenBlockPool[T]) canAllocateBlock() bool {
 	// If max blocks limit is reached, then no more blocks can be allocated.
@@ -164,8 +188,21 @@ func (bp *GenBlockPool[T]) canAllocateBlock() bool {
 	return semAcquired
 }
 
-// Release puts the block back into the free blocks channel for reuse.
+// Release puts the block back into the free blocks channel for reuse, unless
+// it is smaller than the block size, in which case it is deallocated so that
+// it is never handed out for a full block.
 func (bp *GenBlockPool[T]) Release(b T) {
+	if b.Cap() < bp.blockSize {
+		if err := b.Deallocate(); err != nil {
+			// If we get here, there is likely memory corruption.
+			panic(fmt.Sprintf("Block pool's undersized block deallocation failed: %v", err))
+		}
+		if bp.totalBlocks > bp.reservedBlocks {
+			bp.globalMaxBlocksSem.Release(1)
+		}
+		bp.totalBlocks--
+		return
+	}
 	select {
 	case bp.freeBlocksCh <- b:
 	default:
@@ -103,6 +103,48 @@ func (t *BlockPoolTest) TestTryGetWhenTotalBlocksIsLessThanThanMaxBlocks() {
 	assert.Equal(t.T(), int64(0), block.Size())
 }
 
+func (t *BlockPoolTest) TestTryGetSizedAllocatesRightSizedBlockDeallocatedOnRelease() {
+	sem := semaphore.NewWeighted(2)
+	bp, err := NewGenBlockPool(1024, 2, 0, sem, createBlock)
+	require.Nil(t.T(), err)
+
+	b, err := bp.TryGetSized(100)
+
+	require.Nil(t.T(), err)
+	assert.Equal(t.T(), int64(100), b.Cap())
+	assert.Equal(t.T(), int64(1), bp.totalBlocks)
+	bp.Release(b)
+	// The block is deallocated rather than reused for a full block, and its
+	// permit is given back.
+	assert.Equal(t.T(), 0, bp.TotalFreeBlocks())
+	assert.Equal(t.T(), int64(0), bp.totalBlocks)
+	assert.True(t.T(), sem.TryAcquire(2))
+}
+
+func (t *BlockPoolTest) TestTryGetSizedFallsBackToFreeBlockAtLimit() {
+	bp, err := NewGenBlockPool(1024, 1, 0, semaphore.NewWeighted(1), createBlock)
+	require.Nil(t.T(), err)
+	full, err := bp.TryGet()
+	require.Nil(t.T(), err)
+	bp.Release(full)
+
+	b, err := bp.TryGetSized(100)
+
+	require.Nil(t.T(), err)
+	assert.Equal(t.T(), int64(1024), b.Cap())
+	assert.Equal(t.T(), int64(1), bp.totalBlocks)
+}
+
+func (t *BlockPoolTest) TestTryGetSizedHandsOutFullBlockForFullSize() {
+	bp, err := NewGenBlockPool(1024, 1, 0, semaphore.NewWeighted(1), createBlock)
+	require.Nil(t.T(), err)
+
+	b, err := bp.TryGetSized(2048)
+
+	require.Nil(t.T(), err)
+	assert.Equal(t.T(), int64(1024), b.Cap())
+}
+
 func (t *BlockPoolTest) TestTryGetToCreateLargeBlock() {
 	// Creating block of size 1TB
 	bp, err := NewGenBlockPool(1024*1024*1024*1024, 10, 0, semaphore.NewWeighted(10), createBlock)
@@ -34,9 +34,10 @@ type BlockStatus struct {
 type BlockState int
 
 const (
-	BlockStateInProgress     BlockState = iota // Download of this block is in progress
-	BlockStateDownloaded                       // Download of this block is complete
-	BlockStateDownloadFailed                   // Download of this block has failed
+	BlockStateInProgress          BlockState = iota // Download of this block is in progress
+	BlockStateDownloaded                            // Download of this block is complete
+	BlockStateDownloadFailed                        // Download of this block has failed
+	BlockStatePartiallyDownloaded                   // Download of this block was cancelled, keeping the bytes downloaded until then
 )
 
 type PrefetchBlock interface {
@@ -69,6 +70,8 @@ type PrefetchBlock interface {
 	// The value indicates the status of the block:
 	// - BlockStatusDownloaded: Download of this block is complete.
 	// - BlockStatusDownloadFailed: Download of this block has failed.
+	// - BlockStatusPartiallyDownloaded: Download of this block was cancelled,
+	//   and the block holds the bytes downloaded until then.
 	NotifyReady(val BlockStatus)
 
 	// IncRef increments the reference count of the block.
@@ -97,6 +100,13 @@ type prefetchMemoryBlock struct {
 
 	// refCount tracks the number of active references to the block.
 	refCount atomic.Int32
+
+	// arena the block was preallocated by, to which it goes back when
+	// deallocated. Nil if the block was allocated on demand.
+	arena *PrefetchBlockArena
+
+	// arenaIndex is the index of the block in its arena.
+	arenaIndex int
 }
 
 func (pmb *prefetchMemoryBlock) Reuse() {
@@ -108,6 +118,15 @@ func (pmb *prefetchMemoryBlock) Reuse() {
 	pmb.refCount.Store(0)
 }
 
+// Deallocate returns the block to its arena if it was preallocated, and
+// unmaps it otherwise.
+func (pmb *prefetchMemoryBlock) Deallocate() error {
+	if pmb.arena != nil {
+		return pmb.arena.release(pmb)
+	}
+	return pmb.memoryBlock.Deallocate()
+}
+
 // createPrefetchBlock creates a new PrefetchBlock.
 func createPrefetchBlock(blockSize int64) (PrefetchBlock, error) {
 	prot, flags := syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE
@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// PrefetchBlockArena holds prefetch blocks allocated up front, with their
+// memory resident, which block pools hand out instead of allocating new
+// blocks. Blocks deallocated by the pools go back to the arena rather than
+// being unmapped, so that reads never wait on block allocation.
+//
+// The blocks are slices of a single mapping, and their descriptors are
+// allocated along with the arena, so that handing a block out or taking it
+// back is a matter of index bookkeeping, without any heap allocation for the
+// garbage collector to track.
+// It is safe for concurrent use by multiple pools.
+type PrefetchBlockArena struct {
+	blockSize int64
+
+	// region is the mapping the blocks are sliced from.
+	region []byte
+
+	// blocks holds the preallocated blocks, the i-th one backed by the i-th
+	// blockSize bytes of region.
+	blocks []prefetchMemoryBlock
+
+	mu sync.Mutex
+
+	// free holds the indexes of the blocks not handed out, the most recently
+	// released last so that its memory is the likeliest to be cache-hot.
+	// GUARDED by (mu)
+	free []int
+
+	// destroyed is true once Destroy has been called, region being unmapped
+	// as soon as all the blocks are back.
+	// GUARDED by (mu)
+	destroyed bool
+
+	// misses counts the blocks allocated because the arena was empty.
+	misses atomic.Int64
+}
+
+// NewPrefetchBlockArena allocates numBlocks prefetch blocks of blockSize bytes
+// and touches all their pages, so that they are resident once it returns.
+func NewPrefetchBlockArena(blockSize, numBlocks int64) (*PrefetchBlockArena, error) {
+	if blockSize <= 0 || numBlocks <= 0 || blockSize > math.MaxInt/numBlocks {
+		return nil, fmt.Errorf("invalid configuration provided for block arena, blocksize: %d, numBlocks: %d", blockSize, numBlocks)
+	}
+
+	prot, flags := syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE
+	region, err := syscall.Mmap(-1, 0, int(blockSize*numBlocks), prot, flags)
+	if err != nil {
+		return nil, fmt.Errorf("NewPrefetchBlockArena: Mmap: %w", err)
+	}
+	pageSize := os.Getpagesize()
+	for i := 0; i < len(region); i += pageSize {
+		region[i] = 0
+	}
+
+	a := &PrefetchBlockArena{
+		blockSize: blockSize,
+		region:    region,
+		blocks:    make([]prefetchMemoryBlock, numBlocks),
+		free:      make([]int, 0, numBlocks),
+	}
+	for i := range a.blocks {
+		start := int64(i) * blockSize
+		b := &a.blocks[i]
+		// Bound the capacity of the block, so that it can't grow into the next
+		// one.
+		b.buffer = region[start:start:(start + blockSize)]
+		b.arena = a
+		b.arenaIndex = i
+		b.Reuse()
+		a.free = append(a.free, i)
+	}
+	return a, nil
+}
+
+// FreeBlocks returns the number of preallocated blocks not handed out.
+// LOCKS_EXCLUDED(a.mu)
+func (a *PrefetchBlockArena) FreeBlocks() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.free)
+}
+
+// Misses returns the number of blocks the pools had to allocate because the
+// arena was empty.
+func (a *PrefetchBlockArena) Misses() int64 {
+	return a.misses.Load()
+}
+
+// Destroy stops handing out the preallocated blocks, and unmaps them once all
+// of them are back, immediately if none is handed out.
+// LOCKS_EXCLUDED(a.mu)
+func (a *PrefetchBlockArena) Destroy() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.destroyed {
+		return nil
+	}
+	a.destroyed = true
+	return a.unmapIfIdleLocked()
+}
+
+// unmapIfIdleLocked unmaps the region if all the blocks are back.
+// LOCKS_REQUIRED(a.mu)
+func (a *PrefetchBlockArena) unmapIfIdleLocked() error {
+	if len(a.free) < len(a.blocks) {
+		return nil
+	}
+	err := syscall.Munmap(a.region)
+	a.region = nil
+	a.free = nil
+	if err != nil {
+		// If we get here, there is likely memory corruption.
+		return fmt.Errorf("PrefetchBlockArena: munmap error: %w", err)
+	}
+	return nil
+}
+
+// createBlock returns a preallocated block, or a newly allocated one if the
+// arena is empty, destroyed or holds blocks of another size.
+// LOCKS_EXCLUDED(a.mu)
+func (a *PrefetchBlockArena) createBlock(blockSize int64) (PrefetchBlock, error) {
+	if blockSize == a.blockSize {
+		a.mu.Lock()
+		if n := len(a.free); n > 0 && !a.destroyed {
+			i := a.free[n-1]
+			a.free = a.free[:n-1]
+			a.mu.Unlock()
+			b := &a.blocks[i]
+			b.Reuse()
+			return b, nil
+		}
+		a.mu.Unlock()
+	}
+	a.misses.Add(1)
+	return createPrefetchBlock(blockSize)
+}
+
+// release puts a preallocated block back into the arena, unmapping the arena
+// if it was destroyed and this was the last block handed out.
+// LOCKS_EXCLUDED(a.mu)
+func (a *PrefetchBlockArena) release(b *prefetchMemoryBlock) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.free) == len(a.blocks) {
+		panic("Block arena's free list is full, this should never happen")
+	}
+	a.free = append(a.free, b.arenaIndex)
+	if a.destroyed {
+		return a.unmapIfIdleLocked()
+	}
+	return nil
+}
+
+// NewPrefetchBlockPoolWithArena creates GenBlockPool for block.PrefetchBlock
+// interface, taking its blocks from the given arena. A nil arena means the
+// blocks are allocated on demand, as with NewPrefetchBlockPool.
+func NewPrefetchBlockPoolWithArena(blockSize int64, maxBlocks int64, reservedBlocks int64, globalMaxBlocksSem *semaphore.Weighted, arena *PrefetchBlockArena) (bp *GenBlockPool[PrefetchBlock], err error) {
+	if arena == nil {
+		return NewPrefetchBlockPool(blockSize, maxBlocks, reservedBlocks, globalMaxBlocksSem)
+	}
+	return NewGenBlockPool(blockSize, maxBlocks, reservedBlocks, globalMaxBlocksSem, arena.createBlock)
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package block
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestNewPrefetchBlockArenaInvalidConfig(t *testing.T) {
+	_, err := NewPrefetchBlockArena(0, 2)
+	assert.Error(t, err)
+
+	_, err = NewPrefetchBlockArena(1024, 0)
+	assert.Error(t, err)
+}
+
+func TestPrefetchBlockPoolWithArena(t *testing.T) {
+	arena, err := NewPrefetchBlockArena(1024, 2)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, arena.Destroy()) }()
+	require.Equal(t, 2, arena.FreeBlocks())
+	bp, err := NewPrefetchBlockPoolWithArena(1024, 3, 1, semaphore.NewWeighted(3), arena)
+	require.NoError(t, err)
+
+	b1, err := bp.Get()
+	require.NoError(t, err)
+	b2, err := bp.Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, arena.FreeBlocks())
+	assert.Zero(t, arena.Misses())
+	assert.Equal(t, int64(1024), b1.Cap())
+	// The arena is empty, so the next block is allocated on demand.
+	b3, err := bp.Get()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), arena.Misses())
+	// Preallocated blocks go back to the arena once the pool is cleared.
+	bp.Release(b1)
+	bp.Release(b2)
+	bp.Release(b3)
+	require.NoError(t, bp.ClearFreeBlockChannel(true))
+	assert.Equal(t, 2, arena.FreeBlocks())
+}
+
+func TestPrefetchBlockArenaBlocksAreReset(t *testing.T) {
+	arena, err := NewPrefetchBlockArena(1024, 1)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, arena.Destroy()) }()
+	bp, err := NewPrefetchBlockPoolWithArena(1024, 1, 1, semaphore.NewWeighted(1), arena)
+	require.NoError(t, err)
+	b, err := bp.Get()
+	require.NoError(t, err)
+	require.NoError(t, b.SetAbsStartOff(1024))
+	_, err = b.Write([]byte("data"))
+	require.NoError(t, err)
+	bp.Release(b)
+	require.NoError(t, bp.ClearFreeBlockChannel(true))
+	bp, err = NewPrefetchBlockPoolWithArena(1024, 1, 1, semaphore.NewWeighted(1), arena)
+	require.NoError(t, err)
+
+	b, err = bp.Get()
+
+	require.NoError(t, err)
+	assert.Zero(t, b.Size())
+	assert.NoError(t, b.SetAbsStartOff(0))
+	assert.Zero(t, arena.Misses())
+}
+
+func TestPrefetchBlockArenaBlocksDontOverlap(t *testing.T) {
+	const blockSize = 1024
+	arena, err := NewPrefetchBlockArena(blockSize, 2)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, arena.Destroy()) }()
+	bp, err := NewPrefetchBlockPoolWithArena(blockSize, 2, 2, semaphore.NewWeighted(2), arena)
+	require.NoError(t, err)
+	b1, err := bp.Get()
+	require.NoError(t, err)
+	b2, err := bp.Get()
+	require.NoError(t, err)
+
+	// Fill the blocks from readers holding more than a block of data, as the
+	// downloads do.
+	n1, err1 := io.CopyN(b1, bytes.NewReader(bytes.Repeat([]byte("a"), 2*blockSize)), blockSize)
+	n2, err2 := io.CopyN(b2, bytes.NewReader(bytes.Repeat([]byte("b"), 2*blockSize)), blockSize)
+
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, int64(blockSize), n1)
+	assert.Equal(t, int64(blockSize), n2)
+	data1, err := b1.ReadAtSlice(0, blockSize)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("a"), blockSize), data1)
+	data2, err := b2.ReadAtSlice(0, blockSize)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("b"), blockSize), data2)
+	// A full block can't grow into its neighbour.
+	_, err = b1.Write([]byte("c"))
+	assert.Error(t, err)
+	bp.Release(b1)
+	bp.Release(b2)
+	require.NoError(t, bp.ClearFreeBlockChannel(true))
+}
+
+func TestPrefetchBlockArenaDestroyWaitsForHandedOutBlocks(t *testing.T) {
+	arena, err := NewPrefetchBlockArena(1024, 2)
+	require.NoError(t, err)
+	bp, err := NewPrefetchBlockPoolWithArena(1024, 2, 2, semaphore.NewWeighted(2), arena)
+	require.NoError(t, err)
+	b, err := bp.Get()
+	require.NoError(t, err)
+
+	require.NoError(t, arena.Destroy())
+
+	// The handed out block is still usable, and the arena no longer hands out
+	// blocks.
+	_, err = b.Write([]byte("data"))
+	assert.NoError(t, err)
+	other, err := bp.Get()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), arena.Misses())
+	bp.Release(b)
+	bp.Release(other)
+	require.NoError(t, bp.ClearFreeBlockChannel(true))
+	assert.Nil(t, arena.region, "The arena must be unmapped once all the blocks are back.")
+}
+
+func BenchmarkPrefetchBlockPoolGetRelease(b *testing.B) {
+	const blockSize = 1 << 20
+	const numBlocks = 16
+	benchmarks := []struct {
+		name     string
+		newArena func() (*PrefetchBlockArena, error)
+	}{
+		{name: "on_demand", newArena: func() (*PrefetchBlockArena, error) { return nil, nil }},
+		{name: "arena", newArena: func() (*PrefetchBlockArena, error) { return NewPrefetchBlockArena(blockSize, numBlocks) }},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			arena, err := bm.newArena()
+			require.NoError(b, err)
+			if arena != nil {
+				defer func() { assert.NoError(b, arena.Destroy()) }()
+			}
+			blocks := make([]PrefetchBlock, numBlocks)
+			b.ReportAllocs()
+
+			for b.Loop() {
+				bp, err := NewPrefetchBlockPoolWithArena(blockSize, numBlocks, numBlocks, semaphore.NewWeighted(numBlocks), arena)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for i := range blocks {
+					if blocks[i], err = bp.Get(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				for _, blk := range blocks {
+					bp.Release(blk)
+				}
+				if err := bp.ClearFreeBlockChannel(true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
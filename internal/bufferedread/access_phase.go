@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+// accessPhaseDetector detects whether a file is in a random or a sequential
+// phase of reads from a rolling window of its recent reads. A phase switches
+// once the fraction of the reads of the other kind in the full window reaches
+// its threshold. Both thresholds being above half, the phase doesn't flip-flop
+// on a window evenly split between both kinds.
+//
+// It is not safe for concurrent use.
+type accessPhaseDetector struct {
+	randomThreshold     float64
+	sequentialThreshold float64
+
+	// window holds whether each of the recent reads was random, next the
+	// oldest read, overwritten by the next one.
+	window []bool
+	next   int
+	// filled is the number of reads in the window, until it is full.
+	filled int
+	// randomCount is the number of random reads in the window.
+	randomCount int
+
+	// random is true in a random phase. Files start in a sequential phase.
+	random bool
+}
+
+func newAccessPhaseDetector(windowSize int64, randomThreshold, sequentialThreshold float64) *accessPhaseDetector {
+	return &accessPhaseDetector{
+		randomThreshold:     randomThreshold,
+		sequentialThreshold: sequentialThreshold,
+		window:              make([]bool, windowSize),
+	}
+}
+
+// record adds a read to the window, and returns true if it switched the
+// phase.
+func (d *accessPhaseDetector) record(random bool) bool {
+	if d.filled == len(d.window) && d.window[d.next] {
+		d.randomCount--
+	}
+	d.window[d.next] = random
+	d.next = (d.next + 1) % len(d.window)
+	d.filled = min(d.filled+1, len(d.window))
+	if random {
+		d.randomCount++
+	}
+	if d.filled < len(d.window) {
+		return false
+	}
+
+	randomFraction := float64(d.randomCount) / float64(len(d.window))
+	switch {
+	case !d.random && randomFraction >= d.randomThreshold:
+		d.random = true
+		return true
+	case d.random && 1-randomFraction >= d.sequentialThreshold:
+		d.random = false
+		return true
+	default:
+		return false
+	}
+}
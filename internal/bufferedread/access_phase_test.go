@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPhaseDetectorSwitchesOnceWindowIsFull(t *testing.T) {
+	d := newAccessPhaseDetector(4, 0.75, 0.75)
+
+	for range 3 {
+		assert.False(t, d.record(true))
+	}
+	assert.True(t, d.record(true))
+	assert.True(t, d.random)
+}
+
+func TestAccessPhaseDetectorHysteresis(t *testing.T) {
+	d := newAccessPhaseDetector(4, 0.75, 0.75)
+	for range 4 {
+		d.record(true)
+	}
+
+	// An evenly split window keeps the random phase.
+	assert.False(t, d.record(false))
+	assert.False(t, d.record(false))
+	assert.True(t, d.random)
+	assert.True(t, d.record(false))
+	assert.False(t, d.random)
+	// As well as the sequential phase.
+	assert.False(t, d.record(true))
+	assert.False(t, d.record(true))
+	assert.False(t, d.random)
+}
+
+func (t *BufferedReaderTest) TestPrefetchPolicySwitchesOnAccessPhaseChange() {
+	const blockCount = 32
+	t.object.Size = uint64(blockCount * testPrefetchBlockSizeBytes)
+	t.config.RandomSeekThreshold = 100
+	t.config.PhaseDetectionWindow = 4
+	t.config.PhaseRandomThreshold = 0.75
+	t.config.PhaseSequentialThreshold = 0.75
+	t.bucket.On("Name").Return("test-bucket").Maybe()
+	// A block may be downloaded once per phase.
+	for range 2 {
+		for i := range int64(blockCount) {
+			t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+				return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+			})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once().Maybe()
+		}
+	}
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+	})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	readBlock := func(blockIndex int64) {
+		offset := blockIndex * testPrefetchBlockSizeBytes
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: offset})
+		require.NoError(t.T(), err, "reading block %d", blockIndex)
+		assertReadResponseContent(t.T(), resp, offset)
+		resp.Callback()
+	}
+	require.Equal(t.T(), cfg.PrefetchPolicyAdaptive, reader.prefetchPolicy.Name())
+
+	for _, blockIndex := range []int64{20, 5, 27, 12} {
+		readBlock(blockIndex)
+	}
+
+	assert.Equal(t.T(), cfg.PrefetchPolicyNone, reader.prefetchPolicy.Name())
+	// The window holds three sequential reads out of four after the third one.
+	readBlock(0)
+	readBlock(1)
+	assert.Equal(t.T(), cfg.PrefetchPolicyNone, reader.prefetchPolicy.Name())
+	readBlock(2)
+	assert.Equal(t.T(), cfg.PrefetchPolicyAdaptive, reader.prefetchPolicy.Name())
+	readBlock(3)
+	assert.Equal(t.T(), cfg.PrefetchPolicyAdaptive, reader.prefetchPolicy.Name())
+}
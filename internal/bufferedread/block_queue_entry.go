@@ -31,12 +31,45 @@ type blockQueueEntry struct {
 	// wasEvicted is true if the block has been removed from the block queue but
 	// still has outstanding references.
 	wasEvicted bool
+
+	// prefetched is true if the block was scheduled ahead of the read needing
+	// it, or borrowed from another reader.
+	prefetched bool
+
+	// read is true once data has been read from the block.
+	read bool
+
+	// sharedKey is the key under which the block is lent to other readers via
+	// the SharedBlockRegistry; nil if the block isn't registered.
+	sharedKey *sharedBlockKey
+
+	// sharedOwner is the reader which downloads the block, if the block was
+	// borrowed from another reader; nil for blocks downloaded by this reader.
+	sharedOwner *BufferedReader
+
+	// ownerEntry is the sharedOwner's queue entry for a borrowed block.
+	ownerEntry *blockQueueEntry
+
+	// pinned is true if the block is owned by the PinnedBlockStore, which
+	// alone releases it.
+	pinned bool
+
+	// demandEnd is the end offset of a demand block, which covers the first
+	// bytes of a read within the block following it in the queue; zero for
+	// blocks spanning a whole object block.
+	demandEnd int64
 }
 
 // cancelAndWait cancels the download context for the entry and waits for the
 // download goroutine to finish. It logs a warning if the download terminates
-// with an error other than context.Canceled.
-func (bqe *blockQueueEntry) cancelAndWait() {
+// with an error other than context.Canceled. Returns true if the download was
+// still in flight, i.e. it has been cancelled rather than completed.
+func (bqe *blockQueueEntry) cancelAndWait() (cancelled bool) {
+	// The download of a borrowed block is owned by another reader, which
+	// alone decides when to cancel it.
+	if bqe.sharedOwner != nil {
+		return false
+	}
 	bqe.cancel()
 	// We wait for the block's worker goroutine to finish. We expect its
 	// status to contain a context.Canceled error because we just called cancel.
@@ -48,4 +81,5 @@ func (bqe *blockQueueEntry) cancelAndWait() {
 		logger.Warnf("cancelAndWait: block starting at %d terminated with an unexpected error: %v",
 			bqe.block.AbsStartOff(), status.Err)
 	}
+	return err == nil && errors.Is(status.Err, context.Canceled)
 }
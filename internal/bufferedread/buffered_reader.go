@@ -23,11 +23,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/common"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/util"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
@@ -41,12 +44,36 @@ import (
 // implement a fallback mechanism, e.g. falling back to another reader.
 var ErrPrefetchBlockNotAvailable = errors.New("block for prefetching not available")
 
+// ErrReadTimeout is returned when a read waits for the downloads of its blocks
+// for longer than the read timeout. It is reported to the application as EIO.
+var ErrReadTimeout = errors.New("read timed out waiting for block downloads")
+
 type BufferedReadConfig struct {
-	MaxPrefetchBlockCnt     int64 // Maximum number of blocks that can be prefetched.
-	PrefetchBlockSizeBytes  int64 // Size of each block to be prefetched.
-	InitialPrefetchBlockCnt int64 // Number of blocks to prefetch initially.
-	MinBlocksPerHandle      int64 // Minimum number of blocks available in block-pool to start buffered-read.
-	RandomSeekThreshold     int64 // Seek count threshold to switch another reader
+	MaxPrefetchBlockCnt       int64         // Maximum number of blocks that can be prefetched.
+	PrefetchBlockSizeBytes    int64         // Size of each block to be prefetched.
+	DemandBlockSizeBytes      int64         // Size of the block a read waits on first when starting at a new offset, 0 meaning the prefetch block size.
+	InitialPrefetchBlockCnt   int64         // Number of blocks to prefetch initially.
+	MinBlocksPerHandle        int64         // Minimum number of blocks available in block-pool to start buffered-read.
+	RandomSeekThreshold       int64         // Seek count threshold to switch another reader
+	PrefetchPolicy            string        // Name of the policy deciding which blocks to prefetch.
+	BlockAlignment            string        // How blocks are aligned to the object size.
+	DownloadDeadline          time.Duration // Deadline of each block download attempt, 0 meaning none.
+	DownloadMaxRetries        int64         // Number of times a failed block download is retried.
+	DownloadRetryBackoff      time.Duration // Wait before the first retry of a failed block download, doubled after each retry.
+	DownloadReschedules       int64         // Number of times a read re-schedules a failed block download.
+	DownloadRescheduleBackoff time.Duration // Wait before re-scheduling a failed block download.
+	FallbackOnDownloadFailure bool          // Whether a read whose block download failed falls back to another reader rather than failing.
+	FanOutMaxBlocks           int64         // Maximum number of blocks of a single read downloaded in parallel, 0 meaning no fan-out.
+	HotFileResidentBlocks     int64         // Minimum number of blocks kept prefetched for hot objects, 0 meaning no floor.
+	SlidingWindowBlocks       int64         // Maximum number of blocks queued at once, slid along as they are consumed, 0 meaning no window.
+	ReadTimeout               time.Duration // Maximum wait of a read for the downloads of its blocks, 0 meaning none.
+	ReadLatestGeneration      bool          // Whether blocks are downloaded from the latest generation of the object rather than the opened one.
+	CancelledDownloadPolicy   string        // Whether the bytes of a download cancelled midway are discarded or kept.
+	VerifyObjectChecksum      bool          // Whether the blocks holding whole objects are validated against the CRC32C of the object.
+	ObjectMetadataKeys        []string      // Custom metadata keys of the object available to the reader.
+	PhaseDetectionWindow      int64         // Number of recent reads over which random and sequential phases are detected, 0 meaning no detection.
+	PhaseRandomThreshold      float64       // Fraction of random reads in the window switching a sequential phase to random.
+	PhaseSequentialThreshold  float64       // Fraction of sequential reads in the window switching a random phase to sequential.
 }
 
 const (
@@ -59,10 +86,23 @@ type BufferedReader struct {
 	bucket gcs.Bucket
 	config *BufferedReadConfig
 
+	// objectMetadata holds the allowlisted keys of the custom metadata of the
+	// object. The object is shared with the read manager, which keeps its size
+	// up to date, so it is left untouched.
+	objectMetadata map[string]string
+
+	// blockSize is the size of the blocks the object is split into. It is the
+	// configured block size, unless blocks are aligned to the object size.
+	blockSize int64
+
 	// nextBlockIndexToPrefetch is the index of the next block to be
 	// prefetched.
 	nextBlockIndexToPrefetch int64
 
+	// demandEnd is the end offset of the latest demand block scheduled, at
+	// which a read continues sequentially once the block is consumed.
+	demandEnd int64
+
 	// randomSeekCount is the number of random seeks performed. This is used to
 	// detect if the read pattern is random and fall back to another reader.
 	randomSeekCount int64
@@ -78,12 +118,31 @@ type BufferedReader struct {
 	// handleID is the file handle id, used for logging.
 	handleID fuseops.HandleID
 
-	readHandle []byte // For zonal bucket.
+	// readHandle is captured from the most recent download and reused by the
+	// subsequent ones to bypass the auth & metadata checks. Only used for zonal
+	// buckets. It is updated from worker goroutines, hence guarded by its own
+	// mutex rather than mu, which is held while awaiting downloads.
+	// GUARDED by (readHandleMu)
+	readHandle   []byte
+	readHandleMu sync.Mutex
+
+	// isZonalBucket is true if the object belongs to a zonal bucket.
+	isZonalBucket bool
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 
-	prefetchMultiplier int64 // Multiplier for number of blocks to prefetch.
+	// prefetchPolicy decides the number of blocks to prefetch in each cycle.
+	prefetchPolicy PrefetchPolicy
+
+	// sequentialPrefetchPolicy is the policy of the object, switched back to
+	// once a random phase detected by phaseDetector ends.
+	sequentialPrefetchPolicy PrefetchPolicy
+
+	// phaseDetector detects the random and sequential phases of the reads, in
+	// which the adaptive policy is switched to no prefetching and back. Nil if
+	// detection is disabled or the policy isn't adaptive.
+	phaseDetector *accessPhaseDetector
 
 	randomReadsThreshold int64 // Number of random reads after which the reader falls back to another reader.
 
@@ -94,6 +153,11 @@ type BufferedReader struct {
 	// GUARDED by (mu)
 	workerPool workerpool.WorkerPool
 
+	// clobberedErr is set once a download finds the generation being read
+	// replaced, failing all the following reads.
+	// GUARDED by (mu)
+	clobberedErr error
+
 	// blockQueue is the core of the prefetching pipeline, holding blocks that are
 	// either downloaded or in the process of being downloaded.
 	// GUARDED by (mu)
@@ -108,6 +172,55 @@ type BufferedReader struct {
 	// GUARDED by (mu)
 	blockPool *block.GenBlockPool[block.PrefetchBlock]
 
+	// retiredBlockPool is the blockPool of a destroyed reader. Blocks lent to
+	// other readers at the time of destruction are released into it, and
+	// deallocated, once their last reference is dropped.
+	// GUARDED by (mu)
+	retiredBlockPool *block.GenBlockPool[block.PrefetchBlock]
+
+	// stats accumulates the counters summarizing buffered read efficiency.
+	stats *Stats
+
+	// objectStats counts the prefetch effectiveness of the reads of this
+	// reader alone.
+	objectStats objectStats
+
+	// openObjects, if non-nil, lists the reader while it is open.
+	openObjects *OpenObjectRegistry
+
+	// checksumManifest, if non-nil, holds the expected checksums against which
+	// downloaded blocks are validated.
+	checksumManifest *ChecksumManifest
+
+	// sharedBlocks lends the blocks of this reader to, and borrows blocks from,
+	// other readers of the same object. Nil disables sharing.
+	sharedBlocks *SharedBlockRegistry
+
+	// pinnedBlocks serves, and keeps copies of, the blocks of pinned objects.
+	// Nil disables pinning.
+	pinnedBlocks *PinnedBlockStore
+
+	// downloadLimiter bounds the concurrent block downloads of the object
+	// across readers. Nil disables the limit.
+	downloadLimiter *ObjectDownloadLimiter
+
+	// downloadThrottle limits the aggregate bandwidth of the block downloads
+	// across readers. Nil disables the limit.
+	downloadThrottle ratelimit.Throttle
+
+	// residentBlocks bounds the blocks of the object in the block pool across
+	// readers. Nil disables the limit.
+	residentBlocks *ObjectResidentBlockLimiter
+
+	// circuitBreaker fails the reads fast after consecutive download failures
+	// across readers. Nil disables it.
+	circuitBreaker *DownloadCircuitBreaker
+
+	// hot is true if the object was classified as hot when the reader was
+	// created, in which case the reader keeps at least HotFileResidentBlocks
+	// blocks prefetched.
+	hot bool
+
 	// A WaitGroup to synchronize the destruction of the reader with any ongoing
 	// FUSE read callback goroutines. This ensures that all callbacks for
 	// in-flight data slices have completed before the reader is fully torn down.
@@ -116,6 +229,21 @@ type BufferedReader struct {
 	// readTypeClassifier tracks the read access pattern (e.g., sequential, random)
 	// to optimize read strategies. It is shared across different reader layers.
 	readTypeClassifier *gcsx.ReadTypeClassifier
+
+	// prefetchFilesSem limits the number of files that can prefetch concurrently
+	// across all BufferedReader instances. A nil semaphore means no limit.
+	prefetchFilesSem *semaphore.Weighted
+
+	// correlationID is the correlation ID of the latest read, tagging the
+	// downloads it schedules. Empty if the read carried none.
+	// GUARDED by (mu)
+	correlationID string
+
+	// hasPrefetchSlot is true if this reader holds a slot in prefetchFilesSem.
+	// Readers without a slot only download the blocks needed to serve the
+	// current read (demand-only) until a slot frees up.
+	// GUARDED by (mu)
+	hasPrefetchSlot bool
 }
 
 // BufferedReaderOptions holds the dependencies for a BufferedReader.
@@ -129,6 +257,46 @@ type BufferedReaderOptions struct {
 	TraceHandle        tracing.TraceHandle
 	ReadTypeClassifier *gcsx.ReadTypeClassifier
 	HandleID           fuseops.HandleID
+	// PrefetchFilesSem limits the number of files prefetching concurrently.
+	// Optional; nil means no limit.
+	PrefetchFilesSem *semaphore.Weighted
+	// BucketType is the type of the bucket, known at mount time.
+	BucketType gcs.BucketType
+	// SharedBlockRegistry deduplicates block downloads across readers of the
+	// same object. Optional; nil means blocks are not shared.
+	SharedBlockRegistry *SharedBlockRegistry
+	// Stats accumulates buffered read efficiency counters across readers.
+	// Optional; if nil, the reader keeps its own counters.
+	Stats *Stats
+	// ChecksumManifest holds the expected checksums of object blocks, against
+	// which downloaded blocks are validated. Optional; nil disables validation.
+	ChecksumManifest *ChecksumManifest
+	// PinnedBlockStore keeps the blocks of pinned objects once downloaded.
+	// Optional; nil means no object is pinned.
+	PinnedBlockStore *PinnedBlockStore
+	// BlockArena holds the blocks preallocated at mount time, which the reader
+	// uses before allocating blocks. Optional; nil means blocks are allocated
+	// on demand.
+	BlockArena *block.PrefetchBlockArena
+	// ObjectDownloadLimiter bounds the concurrent block downloads of any single
+	// object across readers. Optional; nil means no limit.
+	ObjectDownloadLimiter *ObjectDownloadLimiter
+	// DownloadThrottle limits the aggregate bandwidth of the block downloads
+	// across readers. Optional; nil means no limit.
+	DownloadThrottle ratelimit.Throttle
+	// ObjectResidentBlockLimiter bounds the blocks of any single object held in
+	// the block pool across readers. Optional; nil means no limit.
+	ObjectResidentBlockLimiter *ObjectResidentBlockLimiter
+	// DownloadCircuitBreaker fails the reads fast after consecutive download
+	// failures across readers. Optional; nil disables it.
+	DownloadCircuitBreaker *DownloadCircuitBreaker
+	// HotFileTracker classifies the objects opened often as hot, for which
+	// the reader keeps a floor of blocks prefetched. Optional; nil means no
+	// object is hot.
+	HotFileTracker *HotFileTracker
+	// OpenObjectRegistry lists the prefetch effectiveness of the objects open
+	// for buffered reads. Optional; nil means the reader isn't listed.
+	OpenObjectRegistry *OpenObjectRegistry
 }
 
 // NewBufferedReader returns a new bufferedReader instance.
@@ -136,11 +304,17 @@ func NewBufferedReader(opts *BufferedReaderOptions) (*BufferedReader, error) {
 	if opts.Config.PrefetchBlockSizeBytes <= 0 {
 		return nil, fmt.Errorf("NewBufferedReader: PrefetchBlockSizeBytes must be positive, but is %d", opts.Config.PrefetchBlockSizeBytes)
 	}
+	objectMetadata := selectObjectMetadata(opts.Object, opts.Config.ObjectMetadataKeys)
+	prefetchPolicy, err := newObjectPrefetchPolicy(opts.Object, objectMetadata, opts.Config)
+	if err != nil {
+		return nil, fmt.Errorf("NewBufferedReader: %w", err)
+	}
+	blockSize := alignedBlockSize(int64(opts.Object.Size), opts.Config)
 	// To optimize resource usage, reserve only the number of blocks required for
 	// the file, capped by the configured minimum.
-	blocksInFile := (int64(opts.Object.Size) + opts.Config.PrefetchBlockSizeBytes - 1) / opts.Config.PrefetchBlockSizeBytes
+	blocksInFile := (int64(opts.Object.Size) + blockSize - 1) / blockSize
 	numBlocksToReserve := min(blocksInFile, opts.Config.MinBlocksPerHandle)
-	blockpool, err := block.NewPrefetchBlockPool(opts.Config.PrefetchBlockSizeBytes, opts.Config.MaxPrefetchBlockCnt, numBlocksToReserve, opts.GlobalMaxBlocksSem)
+	blockpool, err := block.NewPrefetchBlockPoolWithArena(opts.Config.PrefetchBlockSizeBytes, opts.Config.MaxPrefetchBlockCnt, numBlocksToReserve, opts.GlobalMaxBlocksSem, opts.BlockArena)
 	if err != nil {
 		if errors.Is(err, block.CantAllocateAnyBlockError) {
 			opts.MetricHandle.BufferedReadFallbackTriggerCount(1, "insufficient_memory")
@@ -150,26 +324,92 @@ func NewBufferedReader(opts *BufferedReaderOptions) (*BufferedReader, error) {
 
 	reader := &BufferedReader{
 		object:                   opts.Object,
+		objectMetadata:           objectMetadata,
 		bucket:                   opts.Bucket,
 		config:                   opts.Config,
+		blockSize:                blockSize,
 		nextBlockIndexToPrefetch: 0,
 		randomSeekCount:          0,
-		numPrefetchBlocks:        opts.Config.InitialPrefetchBlockCnt,
+		numPrefetchBlocks:        prefetchPolicy.InitialBlockCount(0, blocksInFile),
 		blockQueue:               common.NewLinkedListQueue[*blockQueueEntry](),
 		blockPool:                blockpool,
 		workerPool:               opts.WorkerPool,
 		metricHandle:             opts.MetricHandle,
 		traceHandle:              opts.TraceHandle,
 		handleID:                 opts.HandleID,
-		prefetchMultiplier:       defaultPrefetchMultiplier,
+		prefetchPolicy:           prefetchPolicy,
+		sequentialPrefetchPolicy: prefetchPolicy,
 		randomReadsThreshold:     opts.Config.RandomSeekThreshold,
 		readTypeClassifier:       opts.ReadTypeClassifier,
+		prefetchFilesSem:         opts.PrefetchFilesSem,
+		isZonalBucket:            opts.BucketType.Zonal,
+		sharedBlocks:             opts.SharedBlockRegistry,
+		stats:                    opts.Stats,
+		checksumManifest:         opts.ChecksumManifest,
+		pinnedBlocks:             opts.PinnedBlockStore,
+		downloadLimiter:          opts.ObjectDownloadLimiter,
+		downloadThrottle:         opts.DownloadThrottle,
+		residentBlocks:           opts.ObjectResidentBlockLimiter,
+		circuitBreaker:           opts.DownloadCircuitBreaker,
+		openObjects:              opts.OpenObjectRegistry,
+	}
+	if reader.stats == nil {
+		reader.stats = NewStats()
+	}
+	if opts.Config.PhaseDetectionWindow > 0 && prefetchPolicy.Name() == cfg.PrefetchPolicyAdaptive {
+		reader.phaseDetector = newAccessPhaseDetector(opts.Config.PhaseDetectionWindow, opts.Config.PhaseRandomThreshold, opts.Config.PhaseSequentialThreshold)
 	}
+	if reader.openObjects != nil {
+		reader.openObjects.register(reader)
+	}
+	reader.hot = opts.HotFileTracker != nil && opts.Config.HotFileResidentBlocks > 0 &&
+		opts.HotFileTracker.RecordOpen(opts.Bucket.Name(), opts.Object.Name)
+
+	reader.hasPrefetchSlot = reader.prefetchFilesSem == nil || reader.prefetchFilesSem.TryAcquire(1)
+	reader.metricHandle.BufferedReadPrefetchModeFiles(1, reader.prefetchMode())
 
 	reader.ctx, reader.cancelFunc = context.WithCancel(context.Background())
 	return reader, nil
 }
 
+// alignedBlockSize returns the size of the blocks an object of the given size
+// is split into. Blocks aligned to the object size are evenly sized, so that
+// the last block isn't a tiny remainder, while staying within the configured
+// block size, which is the capacity of the pool blocks.
+func alignedBlockSize(objectSize int64, config *BufferedReadConfig) int64 {
+	if config.BlockAlignment != cfg.BlockAlignmentObjectSize || objectSize == 0 {
+		return config.PrefetchBlockSizeBytes
+	}
+	blockCount := (objectSize + config.PrefetchBlockSizeBytes - 1) / config.PrefetchBlockSizeBytes
+	return (objectSize + blockCount - 1) / blockCount
+}
+
+// prefetchMode returns the metric attribute describing whether the reader is
+// currently allowed to prefetch.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) prefetchMode() metrics.PrefetchMode {
+	if p.hasPrefetchSlot {
+		return metrics.PrefetchModePrefetchAttr
+	}
+	return metrics.PrefetchModeDemandOnlyAttr
+}
+
+// tryAcquirePrefetchSlot returns true if the reader holds a prefetch slot,
+// attempting to acquire one if it is currently demand-only.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) tryAcquirePrefetchSlot() bool {
+	if p.hasPrefetchSlot {
+		return true
+	}
+	if !p.prefetchFilesSem.TryAcquire(1) {
+		return false
+	}
+	p.hasPrefetchSlot = true
+	p.metricHandle.BufferedReadPrefetchModeFiles(-1, metrics.PrefetchModeDemandOnlyAttr)
+	p.metricHandle.BufferedReadPrefetchModeFiles(1, metrics.PrefetchModePrefetchAttr)
+	return true
+}
+
 func (p *BufferedReader) ReaderName() string {
 	return "buffered_reader"
 }
@@ -194,7 +434,9 @@ func (p *BufferedReader) handleRandomRead(offset int64) error {
 		return gcsx.FallbackToAnotherReader
 	}
 
-	if !p.isRandomSeek(offset) {
+	random := p.isRandomSeek(offset)
+	p.detectAccessPhase(offset, random)
+	if !random {
 		return nil
 	}
 
@@ -202,11 +444,12 @@ func (p *BufferedReader) handleRandomRead(offset int64) error {
 
 	// When a random seek is detected, the prefetched blocks in the queue become
 	// irrelevant. We must clear the queue, cancel any ongoing downloads, and
-	// release the blocks back to the pool.
-	for !p.blockQueue.IsEmpty() {
-		entry := p.blockQueue.Pop()
-		entry.cancelAndWait()
-		p.releaseOrMarkEvicted(entry)
+	// release the blocks back to the pool. The prefetch then restarts around the
+	// new position.
+	backwardSeek := !p.blockQueue.IsEmpty() && offset < p.blockQueue.Peek().block.AbsStartOff()
+	if cancelled := p.discardQueue(); backwardSeek && cancelled > 0 {
+		logger.Tracef("Cancelled %d prefetch downloads of object %q, handle %d, on backward seek to offset %d", cancelled, p.object.Name, p.handleID, offset)
+		p.metricHandle.BufferedReadPrefetchCancelledBySeekCount(cancelled)
 	}
 
 	if p.randomSeekCount > p.randomReadsThreshold {
@@ -224,15 +467,49 @@ func (p *BufferedReader) handleRandomRead(offset int64) error {
 	return nil
 }
 
+// detectAccessPhase records whether the read at offset is random, switching
+// the prefetch policy on a change of phase: no prefetching in a random phase,
+// and the policy of the object in a sequential one.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) detectAccessPhase(offset int64, random bool) {
+	if p.phaseDetector == nil || !p.phaseDetector.record(random) {
+		return
+	}
+	previous := p.prefetchPolicy
+	if p.phaseDetector.random {
+		p.prefetchPolicy = &noPrefetchPolicy{}
+	} else {
+		p.prefetchPolicy = p.sequentialPrefetchPolicy
+	}
+	p.numPrefetchBlocks = p.prefetchPolicy.InitialBlockCount(offset/p.blockSize, p.totalBlockCount())
+	logger.Infof("Switched prefetch policy of object %q, handle %d, from %s to %s at offset %d on a change of access phase.", p.object.Name, p.handleID, previous.Name(), p.prefetchPolicy.Name(), offset)
+}
+
 // isRandomSeek checks if the read for the given offset is random or not.
 // LOCKS_REQUIRED(p.mu)
 func (p *BufferedReader) isRandomSeek(offset int64) bool {
+	if p.prefetchPolicy.IsExpectedSeek(offset/p.blockSize, p.totalBlockCount()) {
+		return false
+	}
+
 	if p.blockQueue.IsEmpty() {
-		return offset != 0
+		// A read continuing right after the last scheduled block is sequential,
+		// e.g. when the policy doesn't prefetch beyond the block being read.
+		return offset != 0 && offset != p.nextBlockIndexToPrefetch*p.blockSize && offset != p.demandEnd
 	}
 
-	start := p.blockQueue.Peek().block.AbsStartOff()
-	end := start + int64(p.blockQueue.Len())*p.config.PrefetchBlockSizeBytes
+	first := p.blockQueue.Peek()
+	start := first.block.AbsStartOff()
+	end := start + int64(p.blockQueue.Len())*p.blockSize
+	if first.demandEnd > 0 {
+		// The blocks following a demand block start with the block it lies
+		// within, unless it covers the rest of it.
+		nextBlockIndex := start / p.blockSize
+		if first.demandEnd >= min((nextBlockIndex+1)*p.blockSize, int64(p.object.Size)) {
+			nextBlockIndex++
+		}
+		end = max(first.demandEnd, (nextBlockIndex+int64(p.blockQueue.Len())-1)*p.blockSize)
+	}
 	if offset < start || offset >= end {
 		return true
 	}
@@ -251,7 +528,7 @@ func (p *BufferedReader) prepareQueueForOffset(offset int64) {
 		entry := p.blockQueue.Peek()
 		block := entry.block
 		blockStart := block.AbsStartOff()
-		blockEnd := blockStart + block.Cap()
+		blockEnd := p.entryEnd(entry)
 
 		if offset < blockStart || offset >= blockEnd {
 			// Offset is either before or beyond this block – discard.
@@ -276,13 +553,21 @@ func (p *BufferedReader) prepareQueueForOffset(offset int64) {
 //  3. If the queue becomes empty (e.g., on a fresh read or a large seek), it
 //     initiates a "fresh start" to prefetch blocks starting from the current
 //     offset.
-//  4. It then enters a loop to fill the destination buffer:
-//     a. It waits for the block at the head of the queue to be downloaded.
-//     b. If the download failed or was cancelled, it returns an appropriate error.
+//  4. If the read spans several blocks, the downloads of the blocks it covers
+//     are scheduled together as urgent, up to FanOutMaxBlocks.
+//  5. It then enters a loop to fill the destination buffer:
+//     a. It waits for the block at the head of the queue to be downloaded,
+//     failing with ErrReadTimeout once the read has waited for longer than
+//     ReadTimeout.
+//     b. If the download failed or was cancelled, it returns an appropriate
+//     error, or with FallbackOnDownloadFailure, a FallbackToAnotherReader
+//     error for the failures another reader may not run into.
 //     c. If successful, it copies data from the downloaded block into the buffer.
 //     d. If a block is fully consumed, it is removed from the queue, and a new
-//     prefetch operation is triggered to keep the pipeline full.
-//  5. The loop continues until the buffer is full, the end of the file is
+//     prefetch operation is triggered to keep the pipeline full. With a
+//     sliding window, the download of the block following the window is
+//     scheduled in its place.
+//  6. The loop continues until the buffer is full, the end of the file is
 //     reached, or an error occurs.
 //
 // LOCKS_EXCLUDED(p.mu)
@@ -290,7 +575,7 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 	reqID := uuid.New()
 	start := time.Now()
 	readOffset := req.Offset
-	blockIdx := readOffset / p.config.PrefetchBlockSizeBytes
+	blockIdx := readOffset / p.blockSize
 	var bytesRead int
 
 	logger.Tracef("%.13v <- ReadAt(%s:/%s, %d, %d, %d, %d)", reqID, p.bucket.Name(), p.object.Name, p.handleID, readOffset, len(req.Buffer), blockIdx)
@@ -307,12 +592,18 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.clobberedErr != nil {
+		return resp, fmt.Errorf("BufferedReader.ReadAt: %w", p.clobberedErr)
+	}
+	p.correlationID = CorrelationIDFromContext(ctx)
+
 	var dataSlices [][]byte
 	var entriesToCallback []*blockQueueEntry
 	defer func() {
 		dur := time.Since(start)
 		p.metricHandle.BufferedReadReadLatency(ctx, dur)
 		p.metricHandle.GcsReadBytesCount(int64(bytesRead))
+		p.stats.bytesRead.Add(int64(bytesRead))
 
 		if err == nil || errors.Is(err, io.EOF) {
 			logger.Tracef("%.13v -> ReadAt(): Ok(%v)", reqID, dur)
@@ -320,48 +611,123 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 			resp.Data = dataSlices
 			resp.Callback = func() { p.callback(entriesToCallback) }
 			resp.Size = bytesRead
-		} else if errors.Is(err, gcsx.FallbackToAnotherReader) {
-			// When falling back, we must immediately release the blocks we've acquired references to.
+		} else {
+			// When falling back, or failing, we must immediately release the blocks
+			// we've acquired references to, as no callback follows.
 			p.releaseInflightBlocks(entriesToCallback)
 			resp = gcsx.ReadResponse{}
 		}
 	}()
 
+	if p.circuitBreaker != nil {
+		if err = p.circuitBreaker.allow(); err != nil {
+			err = fmt.Errorf("BufferedReader.ReadAt: %w", err)
+			return
+		}
+	}
+
 	if err = p.handleRandomRead(readOffset); err != nil {
 		err = fmt.Errorf("BufferedReader.ReadAt: handleRandomRead: %w", err)
 		return
 	}
 
+	// The read is clamped to the end of the object, like the block downloads
+	// are, so that a read spanning into a partially filled last block returns
+	// the valid bytes only, without waiting for any beyond them.
+	readLen := int(min(int64(len(req.Buffer)), int64(p.object.Size)-readOffset))
+	// The read fails once it has waited for the downloads of its blocks for
+	// longer than the read timeout, however long each download may take.
+	waitCtx := ctx
+	if p.config.ReadTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, p.config.ReadTimeout)
+		defer cancel()
+	}
 	prefetchTriggered := false
-	for bytesRead < len(req.Buffer) {
+	fannedOut := false
+	// reschedules is the number of failed block downloads re-scheduled by this
+	// read.
+	var reschedules int64
+	for bytesRead < readLen {
+		// The object may have shrunk since its size was checked, in which case no
+		// block is scheduled beyond its end.
+		if p.pastEOF(readOffset, bytesRead) {
+			err = io.EOF
+			break
+		}
 		p.prepareQueueForOffset(readOffset)
 
 		if p.blockQueue.IsEmpty() {
 			if err = p.freshStart(readOffset); err != nil {
 				logger.Warnf("Fallback to another reader for object %q, handle %d, due to freshStart failure: %v", p.object.Name, p.handleID, err)
-				p.metricHandle.BufferedReadFallbackTriggerCount(1, "insufficient_memory")
+				if !errors.Is(err, workerpool.ErrPoolStopped) {
+					p.metricHandle.BufferedReadFallbackTriggerCount(1, "insufficient_memory")
+				}
 				err = gcsx.FallbackToAnotherReader
 				return
 			}
 			prefetchTriggered = true
 		}
 
+		if !fannedOut {
+			fannedOut = true
+			p.fanOut(readOffset, int64(readLen-bytesRead))
+		}
+
 		entry := p.blockQueue.Peek()
 		blk := entry.block
 
-		status, waitErr := blk.AwaitReady(ctx)
+		status, waitErr := blk.AwaitReady(waitCtx)
 		if waitErr != nil {
+			if ctx.Err() == nil && errors.Is(waitErr, context.DeadlineExceeded) {
+				logger.Warnf("BufferedReader.ReadAt: read of object %q at offset %d, handle %d, timed out after %v waiting for the download of the block at offset %d.", p.object.Name, readOffset, p.handleID, p.config.ReadTimeout, blk.AbsStartOff())
+				err = fmt.Errorf("BufferedReader.ReadAt: %w after %v", ErrReadTimeout, p.config.ReadTimeout)
+				break
+			}
 			err = fmt.Errorf("BufferedReader.ReadAt: AwaitReady: %w", waitErr)
 			break
 		}
 
-		if status.State != block.BlockStateDownloaded {
+		if status.State == block.BlockStatePartiallyDownloaded && readOffset-blk.AbsStartOff() >= blk.Size() {
+			// None of the bytes kept from the cancelled download cover the read.
+			status.State = block.BlockStateDownloadFailed
+		}
+		if status.State != block.BlockStateDownloaded && status.State != block.BlockStatePartiallyDownloaded {
 			p.blockQueue.Pop()
-			p.blockPool.Release(blk)
+			if entry.sharedOwner != nil {
+				// The owner of a borrowed block cancelled or failed its download. Stop
+				// others from borrowing it and download the block afresh.
+				p.sharedBlocks.unregister(entry.ownerEntry)
+				p.releaseOrMarkEvicted(entry)
+				continue
+			}
+			p.releaseOrMarkEvicted(entry)
 			entry.cancel()
 
 			switch status.State {
 			case block.BlockStateDownloadFailed:
+				var clobberedErr *gcsfuse_errors.FileClobberedError
+				var permissionDeniedErr *gcs.PermissionDeniedError
+				retryable := !errors.Is(status.Err, context.Canceled) && !errors.As(status.Err, &permissionDeniedErr)
+				if errors.As(status.Err, &clobberedErr) {
+					p.handleClobbered(clobberedErr)
+				} else if reschedules < p.config.DownloadReschedules && retryable {
+					reschedules++
+					logger.Warnf("BufferedReader.ReadAt: re-scheduling the failed download of object %q at offset %d (%d/%d): %v", p.object.Name, readOffset, reschedules, p.config.DownloadReschedules, status.Err)
+					if p.waitToReschedule(waitCtx) {
+						// The blocks queued behind the failed one are discarded, and the
+						// download starts afresh from the failed block.
+						p.discardQueue()
+						continue
+					}
+				}
+				// The clobbered object fails the read, the generation read being gone.
+				if clobberedErr == nil && retryable && p.config.FallbackOnDownloadFailure && waitCtx.Err() == nil {
+					logger.Warnf("Fallback to another reader for object %q at offset %d, handle %d, due to failed download: %v", p.object.Name, readOffset, p.handleID, status.Err)
+					p.metricHandle.BufferedReadFallbackTriggerCount(1, "download_failed")
+					err = gcsx.FallbackToAnotherReader
+					break
+				}
 				err = fmt.Errorf("BufferedReader.ReadAt: download failed: %w", status.Err)
 			default:
 				err = fmt.Errorf("BufferedReader.ReadAt: unexpected block state: %d", status.State)
@@ -370,7 +736,13 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 		}
 
 		relOff := readOffset - blk.AbsStartOff()
-		bytesToRead := len(req.Buffer) - bytesRead
+		// A block starting beyond the end of an object that shrank while it was
+		// downloaded is left empty.
+		if relOff >= blk.Size() && p.pastEOF(readOffset, bytesRead) {
+			err = io.EOF
+			break
+		}
+		bytesToRead := readLen - bytesRead
 		dataSlice, readErr := blk.ReadAtSlice(relOff, bytesToRead)
 		sliceLen := len(dataSlice)
 		bytesRead += sliceLen
@@ -382,6 +754,16 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 		}
 
 		if sliceLen > 0 {
+			if !entry.read {
+				entry.read = true
+				p.stats.blocksRead.Add(1)
+				p.objectStats.blocksRead.Add(1)
+				p.metricHandle.BufferedReadBlockReadCount(1, entry.prefetched)
+				if entry.prefetched {
+					p.stats.blocksHit.Add(1)
+					p.objectStats.blocksHit.Add(1)
+				}
+			}
 			dataSlices = append(dataSlices, dataSlice)
 			p.inflightCallbackWg.Add(1)
 			blk.IncRef()
@@ -402,12 +784,154 @@ func (p *BufferedReader) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (res
 					logger.Warnf("BufferedReader.ReadAt: while prefetching: %v", pfErr)
 				}
 			}
+			p.slideWindow()
 		}
 	}
 
+	if err == nil || errors.Is(err, io.EOF) {
+		p.keepResidentFloor()
+	}
 	return
 }
 
+// pastEOF returns true if nothing was read yet and the offset is at or beyond
+// the end of the object.
+func (p *BufferedReader) pastEOF(offset int64, bytesRead int) bool {
+	return bytesRead == 0 && offset >= int64(p.object.Size)
+}
+
+// Advise implements gcsx.RangeAdviser. WILLNEED advice prefetches the range
+// ahead of regular prefetches and DONTNEED advice evicts its blocks.
+// LOCKS_EXCLUDED(p.mu)
+func (p *BufferedReader) Advise(advice gcsx.Advice, offset, length int64) error {
+	switch advice {
+	case gcsx.AdviceWillNeed:
+		return p.PrefetchRange(offset, length)
+	case gcsx.AdviceDontNeed:
+		p.EvictRange(offset, length)
+		return nil
+	default:
+		return fmt.Errorf("BufferedReader.Advise: unsupported advice: %d", advice)
+	}
+}
+
+// PrefetchRange schedules the blocks covering [offset, offset+length) as
+// urgent downloads. Blocks already in the queue are kept if the range
+// continues it; otherwise the queue is restarted at offset. Scheduling stops
+// early once the queue or the block pool is full.
+// LOCKS_EXCLUDED(p.mu)
+func (p *BufferedReader) PrefetchRange(offset, length int64) error {
+	if length <= 0 || offset < 0 || offset >= int64(p.object.Size) {
+		return nil
+	}
+	startBlockIndex := offset / p.blockSize
+	endBlockIndex := (min(offset+length, int64(p.object.Size)) + p.blockSize - 1) / p.blockSize
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.blockPool == nil {
+		return nil
+	}
+	if p.blockQueue.IsEmpty() ||
+		startBlockIndex < p.blockQueue.Peek().block.AbsStartOff()/p.blockSize ||
+		startBlockIndex > p.nextBlockIndexToPrefetch {
+		p.discardQueue()
+		p.nextBlockIndexToPrefetch = startBlockIndex
+	}
+
+	for p.nextBlockIndexToPrefetch < endBlockIndex && int64(p.blockQueue.Len()) < p.maxQueuedBlocks() {
+		if err := p.scheduleNextBlock(metrics.DownloadClassWarmupAttr); err != nil {
+			if errors.Is(err, ErrPrefetchBlockNotAvailable) {
+				break
+			}
+			return fmt.Errorf("BufferedReader.PrefetchRange: scheduling block index %d: %w", p.nextBlockIndexToPrefetch, err)
+		}
+	}
+	return nil
+}
+
+// EvictRange drops the queued blocks overlapping [offset, offset+length). As
+// the queue holds consecutive blocks, the blocks following the range are
+// dropped as well and prefetching resumes from the first dropped block.
+// LOCKS_EXCLUDED(p.mu)
+func (p *BufferedReader) EvictRange(offset, length int64) {
+	if length <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var entries []*blockQueueEntry
+	for !p.blockQueue.IsEmpty() {
+		entries = append(entries, p.blockQueue.Pop())
+	}
+	firstEvicted := len(entries)
+	for i, entry := range entries {
+		start := entry.block.AbsStartOff()
+		if start < offset+length && p.entryEnd(entry) > offset {
+			firstEvicted = i
+			break
+		}
+	}
+	for _, entry := range entries[:firstEvicted] {
+		p.blockQueue.Push(entry)
+	}
+	if firstEvicted == len(entries) {
+		return
+	}
+	p.nextBlockIndexToPrefetch = entries[firstEvicted].block.AbsStartOff() / p.blockSize
+	for _, entry := range entries[firstEvicted:] {
+		entry.cancelAndWait()
+		p.releaseOrMarkEvicted(entry)
+	}
+	p.keepResidentFloor()
+}
+
+// handleClobbered cancels the downloads of all queued blocks on the first
+// download finding the generation being read replaced, as the others are
+// bound to fail too. The following reads fail with the given error.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) handleClobbered(err *gcsfuse_errors.FileClobberedError) {
+	cancelled := p.discardQueue()
+	p.clobberedErr = err
+	logger.Infof("Generation %d of object %q was replaced, cancelled %d downloads of handle %d.", p.object.Generation, p.object.Name, cancelled, p.handleID)
+}
+
+// discardQueue cancels the downloads of all queued blocks and releases them.
+// Returns the number of downloads cancelled while in flight.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) discardQueue() (cancelled int64) {
+	for !p.blockQueue.IsEmpty() {
+		entry := p.blockQueue.Pop()
+		if entry.cancelAndWait() {
+			cancelled++
+		}
+		p.releaseOrMarkEvicted(entry)
+	}
+	return cancelled
+}
+
+// waitToReschedule waits for the backoff before re-scheduling a failed block
+// download, releasing p.mu meanwhile so that the download callbacks and the
+// other calls on the reader aren't held up. Returns false if ctx is done first.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) waitToReschedule(ctx context.Context) bool {
+	timer := time.NewTimer(p.config.DownloadRescheduleBackoff)
+	defer timer.Stop()
+
+	p.mu.Unlock()
+	defer p.mu.Lock()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // releaseInflightBlocks immediately invokes the callback for a list of block
 // entries and waits for them to complete. This is used when a read operation
 // must fall back to another reader, ensuring that any blocks referenced during
@@ -433,10 +957,14 @@ func (p *BufferedReader) callback(entries []*blockQueueEntry) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	for _, entry := range entries {
-		if entry.block.DecRef() && entry.wasEvicted {
-			if p.blockPool != nil {
-				p.blockPool.Release(entry.block)
-			}
+		if !entry.block.DecRef() {
+			continue
+		}
+		if entry.sharedOwner != nil {
+			go entry.sharedOwner.releaseSharedBlock(entry.ownerEntry)
+		} else if entry.wasEvicted && p.blockPool != nil {
+			p.blockPool.Release(entry.block)
+			p.blockReleased()
 		}
 	}
 }
@@ -445,14 +973,19 @@ func (p *BufferedReader) callback(entries []*blockQueueEntry) {
 // the nextBlockIndexToPrefetch.
 // LOCKS_REQUIRED(p.mu)
 func (p *BufferedReader) prefetch() error {
+	// Files beyond the prefetch file limit are served demand-only.
+	if !p.tryAcquirePrefetchSlot() {
+		return nil
+	}
+
 	// Determine the number of blocks to prefetch in this cycle, respecting the
-	// MaxPrefetchBlockCnt and the number of blocks remaining in the file.
-	availableSlots := p.config.MaxPrefetchBlockCnt - int64(p.blockQueue.Len())
+	// maximum number of blocks queued and the number of blocks remaining in the
+	// file.
+	availableSlots := p.maxQueuedBlocks() - int64(p.blockQueue.Len())
 	if availableSlots <= 0 {
 		return nil
 	}
-	totalBlockCount := (int64(p.object.Size) + p.config.PrefetchBlockSizeBytes - 1) / p.config.PrefetchBlockSizeBytes
-	remainingBlocksInFile := totalBlockCount - p.nextBlockIndexToPrefetch
+	remainingBlocksInFile := p.totalBlockCount() - p.nextBlockIndexToPrefetch
 	blockCountToPrefetch := min(min(p.numPrefetchBlocks, availableSlots), remainingBlocksInFile)
 	if blockCountToPrefetch <= 0 {
 		return nil
@@ -460,8 +993,8 @@ func (p *BufferedReader) prefetch() error {
 
 	allBlocksScheduledSuccessfully := true
 	for range blockCountToPrefetch {
-		if err := p.scheduleNextBlock(false); err != nil {
-			if errors.Is(err, ErrPrefetchBlockNotAvailable) {
+		if err := p.scheduleNextBlock(metrics.DownloadClassPrefetchAttr); err != nil {
+			if errors.Is(err, ErrPrefetchBlockNotAvailable) || errors.Is(err, workerpool.ErrPoolStopped) {
 				// This is not a critical error for a background prefetch. We just stop
 				// trying to prefetch more in this cycle. The specific reason has
 				// already been logged by scheduleNextBlock.
@@ -472,34 +1005,117 @@ func (p *BufferedReader) prefetch() error {
 		}
 	}
 
-	// Only increase the prefetch window size if we successfully scheduled all the
-	// intended blocks. This is a more conservative approach that prevents the
-	// window from growing aggressively if block pool is consistently under pressure.
+	// Only let the policy grow the prefetch window if we successfully scheduled
+	// all the intended blocks. This is a more conservative approach that prevents
+	// the window from growing aggressively if block pool is consistently under
+	// pressure.
 	if allBlocksScheduledSuccessfully {
-		// Set the size for the next multiplicative prefetch.
-		p.numPrefetchBlocks *= p.prefetchMultiplier
+		p.numPrefetchBlocks = p.prefetchPolicy.NextBlockCount(p.numPrefetchBlocks)
+	}
+	return nil
+}
+
+// fanOut schedules the downloads of the blocks covering a read of the given
+// length at the given offset, so that a read spanning several blocks does not
+// wait for each of them in turn. Blocks already queued are left as they are,
+// and at most FanOutMaxBlocks blocks from the one holding the offset are
+// covered.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) fanOut(offset, length int64) {
+	if p.config.FanOutMaxBlocks <= 0 || length <= 0 {
+		return
+	}
+	startBlockIndex := offset / p.blockSize
+	endBlockIndex := (offset + length + p.blockSize - 1) / p.blockSize
+	endBlockIndex = min(endBlockIndex, startBlockIndex+p.config.FanOutMaxBlocks, p.totalBlockCount())
+	if endBlockIndex-startBlockIndex <= 1 {
+		return
+	}
 
-		// Cap the prefetch window size for the next cycle at the configured
-		// maximum to prevent unbounded growth.
-		if p.numPrefetchBlocks > p.config.MaxPrefetchBlockCnt {
-			p.numPrefetchBlocks = p.config.MaxPrefetchBlockCnt
+	for p.nextBlockIndexToPrefetch < endBlockIndex {
+		// A streaming reader holds no more blocks than its window.
+		if p.config.SlidingWindowBlocks > 0 && int64(p.blockQueue.Len()) >= p.maxQueuedBlocks() {
+			return
+		}
+		if err := p.scheduleNextBlock(metrics.DownloadClassDemandAttr); err != nil {
+			// The blocks not scheduled here are downloaded as the read reaches
+			// them.
+			if !errors.Is(err, ErrPrefetchBlockNotAvailable) && !errors.Is(err, workerpool.ErrPoolStopped) {
+				logger.Warnf("fanOut: scheduling block index %d: %v", p.nextBlockIndexToPrefetch, err)
+			}
+			return
 		}
 	}
-	return nil
+}
+
+// keepResidentFloor tops the queue of a hot object up to HotFileResidentBlocks
+// blocks, so that the blocks consumed by reads or evicted are re-prefetched
+// right away rather than as the prefetch window grows back.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) keepResidentFloor() {
+	if !p.hot || p.blockPool == nil || !p.tryAcquirePrefetchSlot() {
+		return
+	}
+	p.fillQueue(min(p.config.HotFileResidentBlocks, p.maxQueuedBlocks()), "keepResidentFloor")
+}
+
+// slideWindow advances the sliding window of a reader streaming the object,
+// scheduling the downloads of the blocks following the window in place of the
+// blocks consumed, so that the downloads keep SlidingWindowBlocks blocks ahead
+// of the reads without ever holding more.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) slideWindow() {
+	if p.config.SlidingWindowBlocks <= 0 || p.blockPool == nil || !p.tryAcquirePrefetchSlot() {
+		return
+	}
+	p.fillQueue(p.maxQueuedBlocks(), "slideWindow")
+}
+
+// fillQueue prefetches the next blocks until the queue holds target blocks or
+// the end of the object is reached. The caller is used for logging.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) fillQueue(target int64, caller string) {
+	for int64(p.blockQueue.Len()) < target && p.nextBlockIndexToPrefetch < p.totalBlockCount() {
+		if err := p.scheduleNextBlock(metrics.DownloadClassPrefetchAttr); err != nil {
+			if !errors.Is(err, ErrPrefetchBlockNotAvailable) && !errors.Is(err, workerpool.ErrPoolStopped) {
+				logger.Warnf("%s: scheduling block index %d: %v", caller, p.nextBlockIndexToPrefetch, err)
+			}
+			return
+		}
+	}
+}
+
+// maxQueuedBlocks returns the maximum number of blocks held in the queue:
+// MaxPrefetchBlockCnt, capped by the sliding window if enabled.
+func (p *BufferedReader) maxQueuedBlocks() int64 {
+	if w := p.config.SlidingWindowBlocks; w > 0 && (p.config.MaxPrefetchBlockCnt < 0 || w < p.config.MaxPrefetchBlockCnt) {
+		return w
+	}
+	return p.config.MaxPrefetchBlockCnt
+}
+
+// totalBlockCount returns the number of blocks spanning the object.
+func (p *BufferedReader) totalBlockCount() int64 {
+	return (int64(p.object.Size) + p.blockSize - 1) / p.blockSize
 }
 
 // freshStart resets the prefetching state and schedules the initial set of
 // blocks starting from the given offset.
 // LOCKS_REQUIRED(p.mu)
 func (p *BufferedReader) freshStart(currentOffset int64) error {
-	blockIndex := currentOffset / p.config.PrefetchBlockSizeBytes
+	blockIndex := currentOffset / p.blockSize
 	p.nextBlockIndexToPrefetch = blockIndex
 
 	// Determine the number of blocks for the initial prefetch.
-	p.numPrefetchBlocks = min(p.config.InitialPrefetchBlockCnt, p.config.MaxPrefetchBlockCnt)
+	p.numPrefetchBlocks = p.prefetchPolicy.InitialBlockCount(blockIndex, p.totalBlockCount())
 
-	// Schedule the first block as urgent.
-	if err := p.scheduleNextBlock(true); err != nil {
+	// Schedule the first block as urgent, or a smaller demand block ahead of it
+	// for the read to get its first bytes sooner.
+	if p.config.DemandBlockSizeBytes > 0 && p.config.DemandBlockSizeBytes < p.blockSize {
+		if err := p.scheduleDemandBlock(currentOffset); err != nil {
+			return fmt.Errorf("freshStart: scheduling demand block: %w", err)
+		}
+	} else if err := p.scheduleNextBlock(metrics.DownloadClassDemandAttr); err != nil {
 		return fmt.Errorf("freshStart: scheduling first block: %w", err)
 	}
 
@@ -509,64 +1125,282 @@ func (p *BufferedReader) freshStart(currentOffset int64) error {
 		// has already been scheduled. Log the error and continue.
 		logger.Warnf("freshStart: initial prefetch: %v", err)
 	}
+	// A streaming reader fills its window right away rather than as the
+	// prefetch window grows.
+	p.slideWindow()
 	return nil
 }
 
-// scheduleNextBlock schedules the next block for prefetch.
+// scheduleNextBlock schedules the next block for download, of the given
+// class. Downloads of any class but prefetch are urgent.
 // LOCKS_REQUIRED(p.mu)
-func (p *BufferedReader) scheduleNextBlock(urgent bool) error {
-	b, err := p.blockPool.TryGet()
+func (p *BufferedReader) scheduleNextBlock(class metrics.DownloadClass) error {
+	urgent := class != metrics.DownloadClassPrefetchAttr
+	if p.pinnedBlocks != nil {
+		if b := p.pinnedBlocks.acquire(p.sharedBlockKey(p.nextBlockIndexToPrefetch)); b != nil {
+			logger.Tracef("Pinned block: (%s, %d, %t).", p.object.Name, p.nextBlockIndexToPrefetch, urgent)
+			p.blockQueue.Push(&blockQueueEntry{
+				block:      b,
+				cancel:     func() {},
+				prefetched: true,
+				pinned:     true,
+			})
+			p.nextBlockIndexToPrefetch++
+			return nil
+		}
+	}
+	if p.sharedBlocks != nil {
+		if entry := p.sharedBlocks.acquire(p.sharedBlockKey(p.nextBlockIndexToPrefetch), p); entry != nil {
+			logger.Tracef("Sharing block: (%s, %d, %t).", p.object.Name, p.nextBlockIndexToPrefetch, urgent)
+			entry.prefetched = true
+			p.blockQueue.Push(entry)
+			p.nextBlockIndexToPrefetch++
+			return nil
+		}
+	}
+
+	b, err := p.tryGetBlock(p.partialBlockSize(p.nextBlockIndexToPrefetch))
 	if err != nil {
-		// Any error from TryGet (e.g., pool exhausted, mmap failure) means we
-		// can't get a block. For the buffered reader, this is a recoverable
+		// Any error from tryGetBlock (e.g., pool exhausted, mmap failure) means
+		// we can't get a block. For the buffered reader, this is a recoverable
 		// condition that should either trigger a fallback to another reader (for
 		// urgent reads) or be ignored (for background prefetches).
 		logger.Tracef("scheduleNextBlock: could not get block from pool (urgent=%t): %v", urgent, err)
 		return ErrPrefetchBlockNotAvailable
 	}
 
-	if err := p.scheduleBlockWithIndex(b, p.nextBlockIndexToPrefetch, urgent); err != nil {
+	if err := p.scheduleBlockWithIndex(b, p.nextBlockIndexToPrefetch, class); err != nil {
 		p.blockPool.Release(b)
+		p.blockReleased()
 		return fmt.Errorf("scheduleNextBlock: %w", err)
 	}
 	p.nextBlockIndexToPrefetch++
 	return nil
 }
 
-// scheduleBlockWithIndex schedules a block with a specific index.
+// partialBlockSize returns the number of bytes of the object block with the
+// given index if it is the last block of an object whose size isn't a multiple
+// of the block size, and zero otherwise.
 // LOCKS_REQUIRED(p.mu)
-func (p *BufferedReader) scheduleBlockWithIndex(b block.PrefetchBlock, blockIndex int64, urgent bool) error {
-	startOffset := blockIndex * p.config.PrefetchBlockSizeBytes
+func (p *BufferedReader) partialBlockSize(blockIndex int64) int64 {
+	if size := int64(p.object.Size) - blockIndex*p.blockSize; size > 0 && size < p.blockSize {
+		return size
+	}
+	return 0
+}
+
+// tryGetBlock takes a block from the pool, unless the object is at its limit of
+// resident blocks. A non-zero size asks for a block of just that many bytes
+// rather than a full one, which the pool hands out if it can allocate it.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) tryGetBlock(size int64) (block.PrefetchBlock, error) {
+	if p.residentBlocks != nil && !p.residentBlocks.tryAcquire(p.bucket.Name(), p.object.Name) {
+		return nil, fmt.Errorf("object at its limit of %d resident blocks", p.residentBlocks.limit)
+	}
+	b, err := p.blockPool.TryGetSized(size)
+	if err != nil {
+		if p.residentBlocks != nil {
+			p.residentBlocks.release(p.bucket.Name(), p.object.Name)
+		}
+		return nil, err
+	}
+	p.stats.blocksInUse.Add(1)
+	return b, nil
+}
+
+// blockReleased accounts for a block taken with tryGetBlock being released to
+// the pool.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) blockReleased() {
+	p.stats.blocksInUse.Add(-1)
+	if p.residentBlocks != nil {
+		p.residentBlocks.release(p.bucket.Name(), p.object.Name)
+	}
+}
+
+// scheduleBlockWithIndex schedules a block with a specific index, downloaded
+// as the given class. Downloads of any class but prefetch are urgent.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) scheduleBlockWithIndex(b block.PrefetchBlock, blockIndex int64, class metrics.DownloadClass) error {
+	urgent := class != metrics.DownloadClassPrefetchAttr
+	startOffset := blockIndex * p.blockSize
 	if err := b.SetAbsStartOff(startOffset); err != nil {
 		return fmt.Errorf("scheduleBlockWithIndex: setting start offset: %w", err)
 	}
 
-	ctx, cancel := context.WithCancel(p.ctx)
-	task := &downloadTask{
-		ctx:          ctx,
-		object:       p.object,
-		bucket:       p.bucket,
-		block:        b,
-		readHandle:   p.readHandle,
-		metricHandle: p.metricHandle,
+	task := p.newDownloadTask(b, class)
+	if p.pinnedBlocks != nil && p.pinnedBlocks.isPinned(p.object.Name) {
+		task.pinnedBlocks = p.pinnedBlocks
+		task.pinKey = p.sharedBlockKey(blockIndex)
+		// The block is kept in the pinned block store, whatever the read.
+		task.class = metrics.DownloadClassCacheThroughAttr
 	}
 
 	logger.Tracef("Scheduling block: (%s, %d, %t).", p.object.Name, blockIndex, urgent)
-	p.blockQueue.Push(&blockQueueEntry{
-		block:  b,
-		cancel: cancel,
-	})
-	p.workerPool.Schedule(urgent, task)
+	entry, err := p.queueDownload(task, urgent)
+	if err != nil {
+		return fmt.Errorf("scheduleBlockWithIndex: %w", err)
+	}
+	if p.sharedBlocks != nil {
+		p.sharedBlocks.register(p.sharedBlockKey(blockIndex), p, entry)
+	}
+	return nil
+}
+
+// scheduleDemandBlock schedules the urgent download of a demand block of
+// DemandBlockSizeBytes holding the given offset, for the read waiting on it to
+// get its first bytes sooner than from a whole block. Demand blocks are laid
+// out from the start of the object block they lie within, so that they never
+// straddle two object blocks. The object block is left to be prefetched as
+// usual, overlapping the demand block, unless the demand block covers the
+// rest of it. Demand blocks are neither shared nor pinned.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) scheduleDemandBlock(offset int64) error {
+	blockIndex := offset / p.blockSize
+	blockStart := blockIndex * p.blockSize
+	demandSize := p.config.DemandBlockSizeBytes
+	start := blockStart + (offset-blockStart)/demandSize*demandSize
+	blockEnd := min(blockStart+p.blockSize, int64(p.object.Size))
+	end := min(start+demandSize, blockEnd)
+
+	b, err := p.tryGetBlock(0)
+	if err != nil {
+		logger.Tracef("scheduleDemandBlock: could not get block from pool: %v", err)
+		return ErrPrefetchBlockNotAvailable
+	}
+	if err := b.SetAbsStartOff(start); err != nil {
+		p.blockPool.Release(b)
+		p.blockReleased()
+		return fmt.Errorf("scheduleDemandBlock: setting start offset: %w", err)
+	}
+
+	task := p.newDownloadTask(b, metrics.DownloadClassDemandAttr)
+	task.downloadSize = end - start
+
+	logger.Tracef("Scheduling demand block: (%s, %d, [%d, %d)).", p.object.Name, blockIndex, start, end)
+	entry, err := p.queueDownload(task, true)
+	if err != nil {
+		p.blockPool.Release(b)
+		p.blockReleased()
+		return fmt.Errorf("scheduleDemandBlock: %w", err)
+	}
+	entry.demandEnd = end
+	p.demandEnd = end
+	if end == blockEnd {
+		p.nextBlockIndexToPrefetch = blockIndex + 1
+	}
 	return nil
 }
 
+// newDownloadTask returns the task downloading the object block starting at
+// the start offset of b into it, as the given class. The download is cancelled
+// along with the reader.
+func (p *BufferedReader) newDownloadTask(b block.PrefetchBlock, class metrics.DownloadClass) *downloadTask {
+	ctx, cancel := context.WithCancel(p.ctx)
+	task := &downloadTask{
+		ctx:              ctx,
+		cancel:           cancel,
+		object:           p.object,
+		bucket:           p.bucket,
+		block:            b,
+		blockSize:        p.blockSize,
+		deadline:         p.config.DownloadDeadline,
+		maxRetries:       p.config.DownloadMaxRetries,
+		retryBackoff:     p.config.DownloadRetryBackoff,
+		cancelPolicy:     p.config.CancelledDownloadPolicy,
+		checksumManifest: p.checksumManifest,
+		verifyObject:     p.config.VerifyObjectChecksum,
+		metricHandle:     p.metricHandle,
+		traceHandle:      p.traceHandle,
+		correlationID:    p.correlationID,
+		downloadLimiter:  p.downloadLimiter,
+		downloadThrottle: p.downloadThrottle,
+		circuitBreaker:   p.circuitBreaker,
+		stats:            p.stats,
+		class:            class,
+		latestGeneration: p.config.ReadLatestGeneration,
+	}
+	if p.isZonalBucket {
+		task.readHandle = p.getReadHandle()
+		task.readHandleUpdater = p.setReadHandle
+	}
+	return task
+}
+
+// queueDownload schedules the task on the worker pool and queues its block.
+// LOCKS_REQUIRED(p.mu)
+func (p *BufferedReader) queueDownload(task *downloadTask, urgent bool) (*blockQueueEntry, error) {
+	// Scheduled before the block is queued, as the worker pool is stopped on
+	// unmount while late reads may still arrive.
+	if err := p.workerPool.Schedule(urgent, task); err != nil {
+		task.cancel()
+		return nil, err
+	}
+	entry := &blockQueueEntry{
+		block:      task.block,
+		cancel:     task.cancel,
+		prefetched: !urgent,
+	}
+	p.blockQueue.Push(entry)
+	p.stats.blocksScheduled.Add(1)
+	p.objectStats.blocksScheduled.Add(1)
+	return entry, nil
+}
+
+// entryEnd returns the end offset of the range of the object covered by the
+// block of the entry.
+func (p *BufferedReader) entryEnd(entry *blockQueueEntry) int64 {
+	if entry.demandEnd > 0 {
+		return entry.demandEnd
+	}
+	return entry.block.AbsStartOff() + p.blockSize
+}
+
+// sharedBlockKey returns the key of the block with the given index in the
+// SharedBlockRegistry.
+func (p *BufferedReader) sharedBlockKey(blockIndex int64) sharedBlockKey {
+	return sharedBlockKey{
+		bucketName: p.bucket.Name(),
+		objectName: p.object.Name,
+		generation: p.object.Generation,
+		blockIndex: blockIndex,
+	}
+}
+
+// getReadHandle returns the most recently captured read handle.
+// LOCKS_EXCLUDED(p.readHandleMu)
+func (p *BufferedReader) getReadHandle() []byte {
+	p.readHandleMu.Lock()
+	defer p.readHandleMu.Unlock()
+	return p.readHandle
+}
+
+// setReadHandle stores the read handle captured by a download task.
+// LOCKS_EXCLUDED(p.readHandleMu)
+func (p *BufferedReader) setReadHandle(readHandle []byte) {
+	p.readHandleMu.Lock()
+	defer p.readHandleMu.Unlock()
+	p.readHandle = readHandle
+}
+
 // LOCKS_EXCLUDED(p.mu)
 func (p *BufferedReader) Destroy() {
+	if p.openObjects != nil {
+		p.openObjects.unregister(p)
+	}
 	p.mu.Lock()
+	var wasted int64
 	for !p.blockQueue.IsEmpty() {
 		bqe := p.blockQueue.Pop()
 		bqe.cancelAndWait()
-		p.releaseOrMarkEvicted(bqe)
+		if p.releaseOrMarkEvicted(bqe) {
+			wasted++
+		}
+	}
+	p.stats.filesClosed.Add(1)
+	if wasted > 0 {
+		p.stats.blocksWastedOnClose.Add(wasted)
+		p.stats.filesClosedWithWaste.Add(1)
 	}
 	p.mu.Unlock()
 
@@ -604,7 +1438,13 @@ func (p *BufferedReader) Destroy() {
 	if err := p.blockPool.ClearFreeBlockChannel(true); err != nil {
 		logger.Warnf("Destroy: clearing free block channel: %v", err)
 	}
+	p.retiredBlockPool = p.blockPool
 	p.blockPool = nil
+	p.metricHandle.BufferedReadPrefetchModeFiles(-1, p.prefetchMode())
+	if p.hasPrefetchSlot && p.prefetchFilesSem != nil {
+		p.prefetchFilesSem.Release(1)
+	}
+	p.hasPrefetchSlot = false
 	p.mu.Unlock()
 }
 
@@ -613,15 +1453,67 @@ func (p *BufferedReader) Destroy() {
 // it has not been returned to a FUSE read), it is immediately returned to the
 // block pool. Otherwise, the block is marked as evicted, and its final release
 // is deferred until the last reference's callback is executed.
+// Returns true if the block was downloaded by this reader and never read.
 // LOCKS_REQUIRED(p.mu)
-func (p *BufferedReader) releaseOrMarkEvicted(entry *blockQueueEntry) {
+func (p *BufferedReader) releaseOrMarkEvicted(entry *blockQueueEntry) (wasted bool) {
+	// A pinned block stays in the PinnedBlockStore; only the reference taken
+	// on it is dropped.
+	if entry.pinned {
+		entry.block.DecRef()
+		return false
+	}
+	// A borrowed block is returned by dropping the reference taken on it; its
+	// owner releases it once it is evicted and no longer referenced.
+	if entry.sharedOwner != nil {
+		if entry.block.DecRef() {
+			go entry.sharedOwner.releaseSharedBlock(entry.ownerEntry)
+		}
+		return false
+	}
+	// Stop lending the block before checking its references, so that no other
+	// reader can reference it once it is released.
+	if p.sharedBlocks != nil {
+		p.sharedBlocks.unregister(entry)
+	}
+
 	// If the block still has outstanding references, do not release it to the
 	// pool. Instead, mark it as evicted so the callback can release it later,
 	// when the reference count drops to zero.
+	if !entry.read {
+		p.stats.blocksWasted.Add(1)
+		p.objectStats.blocksWasted.Add(1)
+	}
 	if entry.block.RefCount() > 0 {
 		entry.wasEvicted = true
 	} else {
 		p.blockPool.Release(entry.block)
+		p.blockReleased()
+	}
+	return !entry.read
+}
+
+// releaseSharedBlock releases a block lent to other readers once its last
+// reference is dropped, if it has been evicted from this reader's queue. It
+// runs on its own goroutine, as the borrower holds its own lock when dropping
+// the reference.
+// LOCKS_EXCLUDED(p.mu)
+func (p *BufferedReader) releaseSharedBlock(entry *blockQueueEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !entry.wasEvicted || entry.block.RefCount() > 0 {
+		return
+	}
+	entry.wasEvicted = false
+	p.blockReleased()
+	if p.blockPool != nil {
+		p.blockPool.Release(entry.block)
+		return
+	}
+	// The reader has been destroyed, so deallocate the block right away.
+	p.retiredBlockPool.Release(entry.block)
+	if err := p.retiredBlockPool.ClearFreeBlockChannel(false); err != nil {
+		logger.Warnf("releaseSharedBlock: clearing free block channel: %v", err)
 	}
 }
 
@@ -641,9 +1533,10 @@ func (p *BufferedReader) CheckInvariants() {
 		panic(fmt.Sprintf("BufferedReader: PrefetchBlockSizeBytes must be at least 1 MiB, but is %d", p.config.PrefetchBlockSizeBytes))
 	}
 
-	// The number of items in the blockQueue should not exceed MaxPrefetchBlockCnt.
-	if int64(p.blockQueue.Len()) > p.config.MaxPrefetchBlockCnt {
-		panic(fmt.Sprintf("BufferedReader: blockQueue length %d exceeds limit %d", p.blockQueue.Len(), p.config.MaxPrefetchBlockCnt))
+	// The number of items in the blockQueue should not exceed the maximum
+	// number of blocks queued.
+	if int64(p.blockQueue.Len()) > p.maxQueuedBlocks() {
+		panic(fmt.Sprintf("BufferedReader: blockQueue length %d exceeds limit %d", p.blockQueue.Len(), p.maxQueuedBlocks()))
 	}
 
 	// The random seek count should never exceed randomReadsThreshold.
@@ -658,5 +1551,6 @@ func (p *BufferedReader) resetBufferedReaderState() {
 	// Reset the reader state
 	p.randomSeekCount = 0
 	p.nextBlockIndexToPrefetch = 0
-	p.numPrefetchBlocks = p.config.InitialPrefetchBlockCnt
+	p.demandEnd = 0
+	p.numPrefetchBlocks = p.prefetchPolicy.InitialBlockCount(0, p.totalBlockCount())
 }
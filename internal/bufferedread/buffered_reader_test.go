@@ -20,10 +20,13 @@ import (
 	"errors"
 	"io"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
@@ -31,7 +34,11 @@ import (
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/jacobsa/timeutil"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -113,6 +120,20 @@ func assertBufferContent(t *testing.T, buf []byte, absStartOffset int64) {
 	}
 }
 
+// awaitQueuedBlocks waits for the downloads of all blocks queued in the
+// reader to finish, leaving the queue unchanged.
+func awaitQueuedBlocks(t *testing.T, reader *BufferedReader) {
+	t.Helper()
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+	for range reader.blockQueue.Len() {
+		entry := reader.blockQueue.Pop()
+		_, err := entry.block.AwaitReady(context.Background())
+		require.NoError(t, err)
+		reader.blockQueue.Push(entry)
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Tests
 ////////////////////////////////////////////////////////////////////////
@@ -435,11 +456,11 @@ func (t *BufferedReaderTest) TestCheckInvariantsNoPanic() {
 
 func (t *BufferedReaderTest) TestScheduleNextBlock() {
 	testCases := []struct {
-		name   string
-		urgent bool
+		name  string
+		class metrics.DownloadClass
 	}{
-		{name: "non-urgent", urgent: false},
-		{name: "urgent", urgent: true},
+		{name: "non-urgent", class: metrics.DownloadClassPrefetchAttr},
+		{name: "urgent", class: metrics.DownloadClassDemandAttr},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func() {
@@ -459,7 +480,7 @@ func (t *BufferedReaderTest) TestScheduleNextBlock() {
 				mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == uint64(startOffset) }),
 			).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), startOffset), nil).Once()
 
-			err = reader.scheduleNextBlock(tc.urgent)
+			err = reader.scheduleNextBlock(tc.class)
 
 			require.NoError(t.T(), err)
 			bqe := reader.blockQueue.Peek()
@@ -475,6 +496,84 @@ func (t *BufferedReaderTest) TestScheduleNextBlock() {
 	}
 }
 
+func (t *BufferedReaderTest) TestDownloadBytesAccountedByClass() {
+	testCases := []struct {
+		name     string
+		pinned   bool
+		schedule func(reader *BufferedReader) error
+		class    metrics.DownloadClass
+	}{
+		{
+			name: "demand",
+			schedule: func(reader *BufferedReader) error {
+				return reader.scheduleNextBlock(metrics.DownloadClassDemandAttr)
+			},
+			class: metrics.DownloadClassDemandAttr,
+		},
+		{
+			name: "prefetch",
+			schedule: func(reader *BufferedReader) error {
+				return reader.scheduleNextBlock(metrics.DownloadClassPrefetchAttr)
+			},
+			class: metrics.DownloadClassPrefetchAttr,
+		},
+		{
+			name: "warmup",
+			schedule: func(reader *BufferedReader) error {
+				return reader.PrefetchRange(0, testPrefetchBlockSizeBytes)
+			},
+			class: metrics.DownloadClassWarmupAttr,
+		},
+		{
+			name:   "cache_through",
+			pinned: true,
+			schedule: func(reader *BufferedReader) error {
+				return reader.scheduleNextBlock(metrics.DownloadClassDemandAttr)
+			},
+			class: metrics.DownloadClassCacheThroughAttr,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			origProvider := otel.GetMeterProvider()
+			defer otel.SetMeterProvider(origProvider)
+			metricReader := metric.NewManualReader()
+			otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+			mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+			require.NoError(t.T(), err)
+			var store *PinnedBlockStore
+			if tc.pinned {
+				store, err = NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, 1, mh)
+				require.NoError(t.T(), err)
+			}
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:             t.object,
+				Bucket:             t.bucket,
+				Config:             t.config,
+				GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+				WorkerPool:         t.workerPool,
+				MetricHandle:       mh,
+				ReadTypeClassifier: t.readTypeClassifier,
+				PinnedBlockStore:   store,
+			})
+			require.NoError(t.T(), err)
+			defer reader.Destroy()
+			t.bucket.On("Name").Return("test-bucket").Maybe()
+			t.bucket.On("NewReaderWithReadHandle",
+				mock.Anything,
+				mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 }),
+			).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+
+			require.NoError(t.T(), tc.schedule(reader))
+
+			status, err := reader.blockQueue.Peek().block.AwaitReady(t.ctx)
+			require.NoError(t.T(), err)
+			assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+			metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/download_bytes_count", attribute.NewSet(attribute.String("download_class", string(tc.class))), testPrefetchBlockSizeBytes)
+		})
+	}
+}
+
 func (t *BufferedReaderTest) TestScheduleNextBlockSuccessive() {
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
@@ -491,7 +590,7 @@ func (t *BufferedReaderTest) TestScheduleNextBlockSuccessive() {
 		mock.Anything,
 		mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == uint64(startOffset1) }),
 	).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), startOffset1), nil).Once()
-	err = reader.scheduleNextBlock(false)
+	err = reader.scheduleNextBlock(metrics.DownloadClassPrefetchAttr)
 	require.NoError(t.T(), err)
 	bqe1 := reader.blockQueue.Pop()
 	assert.Equal(t.T(), int64(1), reader.nextBlockIndexToPrefetch)
@@ -506,7 +605,7 @@ func (t *BufferedReaderTest) TestScheduleNextBlockSuccessive() {
 		mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == uint64(startOffset2) }),
 	).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), startOffset2), nil).Once()
 
-	err = reader.scheduleNextBlock(false)
+	err = reader.scheduleNextBlock(metrics.DownloadClassPrefetchAttr)
 
 	require.NoError(t.T(), err)
 	bqe2 := reader.blockQueue.Pop()
@@ -524,11 +623,11 @@ func (t *BufferedReaderTest) TestScheduleNextBlockSuccessive() {
 func (t *BufferedReaderTest) TestScheduleBlockWithIndex() {
 	testCases := []struct {
 		name       string
-		urgent     bool
+		class      metrics.DownloadClass
 		blockIndex int64
 	}{
-		{name: "non-urgent", urgent: false, blockIndex: 5},
-		{name: "urgent", urgent: true, blockIndex: 3},
+		{name: "non-urgent", class: metrics.DownloadClassPrefetchAttr, blockIndex: 5},
+		{name: "urgent", class: metrics.DownloadClassDemandAttr, blockIndex: 3},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func() {
@@ -550,7 +649,7 @@ func (t *BufferedReaderTest) TestScheduleBlockWithIndex() {
 			b, err := reader.blockPool.Get()
 			require.NoError(t.T(), err)
 
-			err = reader.scheduleBlockWithIndex(b, tc.blockIndex, tc.urgent)
+			err = reader.scheduleBlockWithIndex(b, tc.blockIndex, tc.class)
 
 			require.NoError(t.T(), err)
 			bqe := reader.blockQueue.Peek()
@@ -658,6 +757,174 @@ func (t *BufferedReaderTest) TestFreshStartWithNonBlockAlignedOffset() {
 	t.bucket.AssertExpectations(t.T())
 }
 
+func (t *BufferedReaderTest) TestFreshStartSchedulesDemandBlockAheadOfBlock() {
+	testCases := []struct {
+		name                   string
+		offset                 int64
+		demandStart            int64
+		demandEnd              int64
+		prefetchedStarts       []int64
+		nextBlockIndexExpected int64
+	}{
+		{
+			name:                   "within_block",
+			offset:                 2500,
+			demandStart:            2304,
+			demandEnd:              2560,
+			prefetchedStarts:       []int64{2048, 3072},
+			nextBlockIndexExpected: 4,
+		},
+		{
+			// The demand block covers the rest of the block, which isn't downloaded
+			// again.
+			name:                   "covering_rest_of_block",
+			offset:                 2900,
+			demandStart:            2816,
+			demandEnd:              3072,
+			prefetchedStarts:       []int64{3072, 4096},
+			nextBlockIndexExpected: 5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			t.bucket = new(storage.TestifyMockBucket)
+			t.config.DemandBlockSizeBytes = 256
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:             t.object,
+				Bucket:             t.bucket,
+				Config:             t.config,
+				GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+				WorkerPool:         t.workerPool,
+				MetricHandle:       t.metricHandle,
+				ReadTypeClassifier: t.readTypeClassifier})
+			require.NoError(t.T(), err)
+			defer reader.Destroy()
+			t.bucket.On("Name").Return("test-bucket").Maybe()
+			t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+				return r.Range.Start == uint64(tc.demandStart) && r.Range.Limit == uint64(tc.demandEnd)
+			})).Return(createFakeReaderWithOffset(t.T(), int(tc.demandEnd-tc.demandStart), tc.demandStart), nil).Once()
+			for _, start := range tc.prefetchedStarts {
+				t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+					return r.Range.Start == uint64(start) && r.Range.Limit == uint64(start+testPrefetchBlockSizeBytes)
+				})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), start), nil).Once()
+			}
+
+			reader.mu.Lock()
+			err = reader.freshStart(tc.offset)
+			reader.mu.Unlock()
+
+			require.NoError(t.T(), err)
+			assert.Equal(t.T(), tc.nextBlockIndexExpected, reader.nextBlockIndexToPrefetch)
+			require.Equal(t.T(), 1+len(tc.prefetchedStarts), reader.blockQueue.Len())
+			demand := reader.blockQueue.Pop()
+			assert.Equal(t.T(), tc.demandStart, demand.block.AbsStartOff())
+			assert.Equal(t.T(), tc.demandEnd, reader.entryEnd(demand))
+			assert.False(t.T(), demand.prefetched)
+			status, err := demand.block.AwaitReady(t.ctx)
+			require.NoError(t.T(), err)
+			assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+			assertBlockContent(t.T(), demand.block, tc.demandStart, int(tc.demandEnd-tc.demandStart))
+			reader.blockPool.Release(demand.block)
+			for _, start := range tc.prefetchedStarts {
+				bqe := reader.blockQueue.Pop()
+				assert.Equal(t.T(), start, bqe.block.AbsStartOff())
+				assert.Equal(t.T(), start+testPrefetchBlockSizeBytes, reader.entryEnd(bqe))
+				assert.True(t.T(), bqe.prefetched)
+				status, err := bqe.block.AwaitReady(t.ctx)
+				require.NoError(t.T(), err)
+				assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+				assertBlockContent(t.T(), bqe.block, start, int(testPrefetchBlockSizeBytes))
+				reader.blockPool.Release(bqe.block)
+			}
+			t.bucket.AssertExpectations(t.T())
+		})
+	}
+}
+
+func (t *BufferedReaderTest) TestReadAtWithDemandBlocks() {
+	const demandBlockSize = 256
+	testCases := []struct {
+		name     string
+		prefetch bool
+		readSize int64
+	}{
+		{
+			// Only the first read waits on a demand block, the following reads
+			// being served by the blocks prefetched alongside it.
+			name:     "prefetching",
+			prefetch: true,
+			readSize: 512,
+		},
+		{
+			// A reader not allowed to prefetch reads in demand blocks.
+			name:     "demand_only",
+			prefetch: false,
+			readSize: demandBlockSize,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			t.bucket = new(storage.TestifyMockBucket)
+			t.config.DemandBlockSizeBytes = demandBlockSize
+			prefetchFilesSem := semaphore.NewWeighted(1)
+			if !tc.prefetch {
+				require.True(t.T(), prefetchFilesSem.TryAcquire(1))
+			}
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:             t.object,
+				Bucket:             t.bucket,
+				Config:             t.config,
+				GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+				WorkerPool:         t.workerPool,
+				MetricHandle:       t.metricHandle,
+				ReadTypeClassifier: t.readTypeClassifier,
+				PrefetchFilesSem:   prefetchFilesSem,
+			})
+			require.NoError(t.T(), err)
+			defer reader.Destroy()
+			t.bucket.On("Name").Return("test-bucket").Maybe()
+			var blockCount int64
+			if tc.prefetch {
+				t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+					return r.Range.Start == 0 && r.Range.Limit == demandBlockSize
+				})).Return(createFakeReaderWithOffset(t.T(), demandBlockSize, 0), nil).Once()
+				for start := int64(0); start < int64(t.object.Size); start += testPrefetchBlockSizeBytes {
+					t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+						return r.Range.Start == uint64(start) && r.Range.Limit == uint64(start+testPrefetchBlockSizeBytes)
+					})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), start), nil).Once()
+				}
+				blockCount = 1 + int64(t.object.Size)/testPrefetchBlockSizeBytes
+			} else {
+				for start := int64(0); start < int64(t.object.Size); start += demandBlockSize {
+					t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+						return r.Range.Start == uint64(start) && r.Range.Limit == uint64(start+demandBlockSize)
+					})).Return(createFakeReaderWithOffset(t.T(), demandBlockSize, start), nil).Once()
+				}
+				blockCount = int64(t.object.Size) / demandBlockSize
+			}
+			buf := make([]byte, tc.readSize)
+
+			for offset := int64(0); offset < int64(t.object.Size); offset += tc.readSize {
+				resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: offset})
+				require.NoError(t.T(), err)
+				require.Equal(t.T(), int(tc.readSize), resp.Size)
+				assertReadResponseContent(t.T(), resp, offset)
+				resp.Callback()
+			}
+
+			// The reads are sequential, whatever the size of the blocks.
+			assert.Equal(t.T(), int64(0), reader.randomSeekCount)
+			assert.Equal(t.T(), blockCount, reader.stats.blocksRead.Load())
+			if tc.prefetch {
+				assert.Equal(t.T(), blockCount-1, reader.stats.blocksHit.Load())
+			} else {
+				assert.Equal(t.T(), int64(0), reader.stats.blocksHit.Load())
+			}
+			t.bucket.AssertExpectations(t.T())
+		})
+	}
+}
+
 func (t *BufferedReaderTest) TestFreshStartWhenInitialCountGreaterThanMax() {
 	t.config.MaxPrefetchBlockCnt = 3
 	t.config.InitialPrefetchBlockCnt = 4
@@ -1004,16 +1271,111 @@ func (t *BufferedReaderTest) TestReadAtOffsetBeyondEOF() {
 		MetricHandle:       t.metricHandle,
 		ReadTypeClassifier: t.readTypeClassifier})
 	require.NoError(t.T(), err)
-	buf := make([]byte, 10)
 	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
 
+	for _, offset := range []int64{int64(t.object.Size), int64(t.object.Size + 1)} {
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+			Buffer: make([]byte, 10),
+			Offset: offset,
+		})
+
+		assert.ErrorIs(t.T(), err, io.EOF)
+		assert.Zero(t.T(), resp.Size)
+	}
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	t.bucket.AssertNotCalled(t.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
+}
+
+func (t *BufferedReaderTest) TestReadAtPartialLastBlock() {
+	// The last block holds 300 valid bytes.
+	const objectSize = 2*testPrefetchBlockSizeBytes + 300
+	testCases := []struct {
+		name     string
+		offset   int64
+		size     int
+		wantSize int
+		wantErr  error
+	}{
+		{name: "ending_within_last_block", offset: 2 * testPrefetchBlockSizeBytes, size: 200, wantSize: 200},
+		{name: "ending_at_last_valid_byte", offset: 2 * testPrefetchBlockSizeBytes, size: 300, wantSize: 300},
+		{name: "ending_beyond_last_valid_byte", offset: 2 * testPrefetchBlockSizeBytes, size: int(testPrefetchBlockSizeBytes), wantSize: 300},
+		{name: "spanning_into_last_block", offset: 1500, size: 2000, wantSize: int(objectSize - 1500)},
+		{name: "starting_at_end", offset: objectSize, size: 10, wantErr: io.EOF},
+		{name: "starting_beyond_end", offset: 3 * testPrefetchBlockSizeBytes, size: 10, wantErr: io.EOF},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			t.object.Size = uint64(objectSize)
+			t.bucket = new(storage.TestifyMockBucket)
+			t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+			// Blocks are downloaded up to the end of the object, and never beyond.
+			for i := range int64(3) {
+				start := i * testPrefetchBlockSizeBytes
+				limit := min(start+testPrefetchBlockSizeBytes, objectSize)
+				t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+					return r.Range.Start == uint64(start) && r.Range.Limit == uint64(limit)
+				})).Return(createFakeReaderWithOffset(t.T(), int(limit-start), start), nil).Maybe()
+			}
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:             t.object,
+				Bucket:             t.bucket,
+				Config:             t.config,
+				GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+				WorkerPool:         t.workerPool,
+				MetricHandle:       t.metricHandle,
+				ReadTypeClassifier: t.readTypeClassifier})
+			require.NoError(t.T(), err)
+			defer reader.Destroy()
+
+			resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+				Buffer: make([]byte, tc.size),
+				Offset: tc.offset,
+			})
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t.T(), err, tc.wantErr)
+				assert.Zero(t.T(), resp.Size)
+				t.bucket.AssertNotCalled(t.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t.T(), err)
+			assert.Equal(t.T(), tc.wantSize, resp.Size)
+			assertReadResponseContent(t.T(), resp, tc.offset)
+			resp.Callback()
+		})
+	}
+}
+
+func (t *BufferedReaderTest) TestBlockScheduledBeyondShrunkObjectIsNotDownloaded() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	// The object shrinks below the start of the block before it is downloaded.
+	t.object.Size = uint64(testPrefetchBlockSizeBytes)
+
+	err = reader.freshStart(testPrefetchBlockSizeBytes)
+
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), 1, reader.blockQueue.Len())
+	b := reader.blockQueue.Peek().block
+	status, err := b.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+	assert.Zero(t.T(), b.Size())
 	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
-		Buffer: buf,
-		Offset: int64(t.object.Size + 1),
+		Buffer: make([]byte, 10),
+		Offset: testPrefetchBlockSizeBytes,
 	})
-
 	assert.ErrorIs(t.T(), err, io.EOF)
 	assert.Zero(t.T(), resp.Size)
+	t.bucket.AssertNotCalled(t.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
 }
 
 func (t *BufferedReaderTest) TestReadAtEmptyBuffer() {
@@ -1141,6 +1503,124 @@ func (t *BufferedReaderTest) TestReadAtForwardSeekDiscardsPreviousBlocks() {
 	t.bucket.AssertExpectations(t.T())
 }
 
+func (t *BufferedReaderTest) TestReadAtBackwardSeekCancelsForwardPrefetch() {
+	origProvider := otel.GetMeterProvider()
+	t.T().Cleanup(func() { otel.SetMeterProvider(origProvider) })
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       mh,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	var cancelCount int
+	// Simulate a sequential read of block 3, with blocks 4 and 5 being
+	// prefetched.
+	addBlockToQueue := func(offset int64, downloaded bool) {
+		b, poolErr := reader.blockPool.Get()
+		require.NoError(t.T(), poolErr)
+		require.NoError(t.T(), b.SetAbsStartOff(offset))
+		if downloaded {
+			_, writeErr := b.Write(make([]byte, testPrefetchBlockSizeBytes))
+			require.NoError(t.T(), writeErr)
+			b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloaded})
+		}
+		reader.blockQueue.Push(&blockQueueEntry{
+			block: b,
+			cancel: func() {
+				cancelCount++
+				if !downloaded {
+					b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: context.Canceled})
+				}
+			},
+		})
+	}
+	addBlockToQueue(3072, true)
+	addBlockToQueue(4096, false)
+	addBlockToQueue(5120, false)
+	reader.nextBlockIndexToPrefetch = 6
+	// The prefetch restarts around the new position.
+	for i := range int64(3) {
+		start := i * testPrefetchBlockSizeBytes
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == uint64(start) })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), start), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 1024),
+		Offset: 0,
+	})
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	assert.Equal(t.T(), 3, cancelCount, "Expected all the queued blocks to be discarded")
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/prefetch_cancelled_by_seek_count", attribute.NewSet(), 2)
+	require.Equal(t.T(), 2, reader.blockQueue.Len(), "Queue should contain the blocks prefetched after block 0")
+	for !reader.blockQueue.IsEmpty() {
+		_, err = reader.blockQueue.Pop().block.AwaitReady(t.ctx)
+		require.NoError(t.T(), err)
+	}
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestReadAtRecordsPrefetchHitsAndMisses() {
+	origProvider := otel.GetMeterProvider()
+	t.T().Cleanup(func() { otel.SetMeterProvider(origProvider) })
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       mh,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	blockCount := int64(t.object.Size) / testPrefetchBlockSizeBytes
+	mockBlockDownload := func(i int64) *mock.Call {
+		return t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	for i := range blockCount {
+		mockBlockDownload(i)
+	}
+	// The backward seek downloads the first block again, the blocks following
+	// it being prefetched in the background.
+	mockBlockDownload(0)
+	for i := int64(1); i < blockCount; i++ {
+		mockBlockDownload(i).Maybe()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	buf := make([]byte, testPrefetchBlockSizeBytes)
+	// All the blocks but the first one are prefetched ahead of the sequential
+	// reads.
+	for offset := int64(0); offset < int64(t.object.Size); offset += testPrefetchBlockSizeBytes {
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: offset})
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, offset)
+		resp.Callback()
+	}
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: 0})
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/block_read_count", attribute.NewSet(attribute.Bool("cache_hit", true)), blockCount-1)
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/block_read_count", attribute.NewSet(attribute.Bool("cache_hit", false)), 2)
+}
+
 func (t *BufferedReaderTest) TestReadAtInitialDownloadFails() {
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
@@ -1179,7 +1659,11 @@ func (t *BufferedReaderTest) TestReadAtInitialDownloadFails() {
 	t.bucket.AssertExpectations(t.T())
 }
 
-func (t *BufferedReaderTest) TestReadAtAwaitReadyCancelled() {
+func (t *BufferedReaderTest) TestReadAtReschedulesFailedDownload() {
+	t.object.Size = uint64(testPrefetchBlockSizeBytes)
+	t.config.DownloadMaxRetries = 1
+	t.config.DownloadReschedules = 1
+	t.config.DownloadRescheduleBackoff = time.Millisecond
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
 		Bucket:             t.bucket,
@@ -1189,25 +1673,26 @@ func (t *BufferedReaderTest) TestReadAtAwaitReadyCancelled() {
 		MetricHandle:       t.metricHandle,
 		ReadTypeClassifier: t.readTypeClassifier})
 	require.NoError(t.T(), err)
-	b, err := reader.blockPool.Get()
-	require.NoError(t.T(), err)
-	err = b.SetAbsStartOff(0)
-	require.NoError(t.T(), err)
-	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
+	// The first download fails both of its attempts, the re-scheduled one
+	// succeeds.
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.AnythingOfType("*gcs.ReadObjectRequest")).Return(nil, errors.New("gcs error")).Twice()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.AnythingOfType("*gcs.ReadObjectRequest")).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
 	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
 
-	// Read with a cancelled context.
-	_, err = reader.ReadAt(ctx, &gcsx.ReadRequest{
-		Buffer: make([]byte, 10),
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, testPrefetchBlockSizeBytes),
 		Offset: 0,
 	})
 
-	assert.ErrorIs(t.T(), err, context.Canceled)
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	t.bucket.AssertExpectations(t.T())
+	resp.Callback()
 }
 
-func (t *BufferedReaderTest) TestReadAtBlockStateDownloadFailed() {
+func (t *BufferedReaderTest) TestReadAtFailsOnceReschedulesAreExhausted() {
+	t.object.Size = uint64(testPrefetchBlockSizeBytes)
+	t.config.DownloadReschedules = 1
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
 		Bucket:             t.bucket,
@@ -1217,30 +1702,21 @@ func (t *BufferedReaderTest) TestReadAtBlockStateDownloadFailed() {
 		MetricHandle:       t.metricHandle,
 		ReadTypeClassifier: t.readTypeClassifier})
 	require.NoError(t.T(), err)
-	b, err := reader.blockPool.Get()
-	require.NoError(t.T(), err)
-	err = b.SetAbsStartOff(0)
-	require.NoError(t.T(), err)
-	downloadError := errors.New("simulated download error")
-	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: downloadError})
-	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
+	downloadError := errors.New("gcs error")
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.AnythingOfType("*gcs.ReadObjectRequest")).Return(nil, downloadError).Twice()
 	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
 
-	// Read from a reader where the next block has failed to download.
 	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
 		Buffer: make([]byte, 10),
 		Offset: 0,
 	})
 
+	assert.ErrorContains(t.T(), err, "download failed")
 	assert.ErrorIs(t.T(), err, downloadError)
-	status, err := b.AwaitReady(t.ctx)
-	require.NoError(t.T(), err)
-	assert.Equal(t.T(), block.BlockStateDownloadFailed, status.State)
-	assert.ErrorIs(t.T(), status.Err, downloadError)
-	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	t.bucket.AssertExpectations(t.T())
 }
 
-func (t *BufferedReaderTest) TestReadAtBlockDownloadCancelled() {
+func (t *BufferedReaderTest) TestReadAtAwaitReadyCancelled() {
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
 		Bucket:             t.bucket,
@@ -1254,25 +1730,21 @@ func (t *BufferedReaderTest) TestReadAtBlockDownloadCancelled() {
 	require.NoError(t.T(), err)
 	err = b.SetAbsStartOff(0)
 	require.NoError(t.T(), err)
-	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: context.Canceled})
 	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
 	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
 
-	// Read from a reader where the next block download was cancelled.
-	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+	// Read with a cancelled context.
+	_, err = reader.ReadAt(ctx, &gcsx.ReadRequest{
 		Buffer: make([]byte, 10),
 		Offset: 0,
 	})
 
 	assert.ErrorIs(t.T(), err, context.Canceled)
-	status, err := b.AwaitReady(t.ctx)
-	require.NoError(t.T(), err)
-	assert.Equal(t.T(), block.BlockStateDownloadFailed, status.State)
-	assert.ErrorIs(t.T(), status.Err, context.Canceled)
-	assert.True(t.T(), reader.blockQueue.IsEmpty())
 }
 
-func (t *BufferedReaderTest) TestReadAtBlockStateUnexpected() {
+func (t *BufferedReaderTest) TestReadAtBlockStateDownloadFailed() {
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
 		Bucket:             t.bucket,
@@ -1286,28 +1758,180 @@ func (t *BufferedReaderTest) TestReadAtBlockStateUnexpected() {
 	require.NoError(t.T(), err)
 	err = b.SetAbsStartOff(0)
 	require.NoError(t.T(), err)
-	b.NotifyReady(block.BlockStatus{State: block.BlockStateInProgress})
+	downloadError := errors.New("simulated download error")
+	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: downloadError})
 	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
 	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
 
-	// Read from a reader where the next block is in an unexpected state.
+	// Read from a reader where the next block has failed to download.
 	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
 		Buffer: make([]byte, 10),
 		Offset: 0,
 	})
 
-	assert.ErrorContains(t.T(), err, "unexpected block state")
+	assert.ErrorIs(t.T(), err, downloadError)
 	status, err := b.AwaitReady(t.ctx)
 	require.NoError(t.T(), err)
-	assert.Equal(t.T(), block.BlockStateInProgress, status.State)
-	assert.Nil(t.T(), status.Err)
+	assert.Equal(t.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorIs(t.T(), status.Err, downloadError)
 	assert.True(t.T(), reader.blockQueue.IsEmpty())
 }
 
-func (t *BufferedReaderTest) TestReadAtFromDownloadedBlock() {
-	reader, err := NewBufferedReader(&BufferedReaderOptions{
-		Object:             t.object,
-		Bucket:             t.bucket,
+func (t *BufferedReaderTest) TestReadAtBlockStateDownloadFailedWithFallback() {
+	clobberedErr := &gcsfuse_errors.FileClobberedError{Err: errors.New("object generation replaced")}
+	testCases := []struct {
+		name        string
+		downloadErr error
+		wantErr     error
+	}{
+		{name: "retryable_failure", downloadErr: errors.New("simulated download error"), wantErr: gcsx.FallbackToAnotherReader},
+		{name: "clobbered", downloadErr: clobberedErr, wantErr: clobberedErr},
+		{name: "cancelled", downloadErr: context.Canceled, wantErr: context.Canceled},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			t.config.FallbackOnDownloadFailure = true
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:             t.object,
+				Bucket:             t.bucket,
+				Config:             t.config,
+				GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+				WorkerPool:         t.workerPool,
+				MetricHandle:       t.metricHandle,
+				ReadTypeClassifier: t.readTypeClassifier})
+			require.NoError(t.T(), err)
+			defer reader.Destroy()
+			b, err := reader.blockPool.Get()
+			require.NoError(t.T(), err)
+			require.NoError(t.T(), b.SetAbsStartOff(0))
+			b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: tc.downloadErr})
+			reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
+			t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+			resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+				Buffer: make([]byte, 10),
+				Offset: 0,
+			})
+
+			assert.ErrorIs(t.T(), err, tc.wantErr)
+			assert.Zero(t.T(), resp.Size)
+			assert.True(t.T(), reader.blockQueue.IsEmpty())
+		})
+	}
+}
+
+func (t *BufferedReaderTest) TestReadAtGenerationReplacedCancelsQueuedDownloads() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	b1, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), b1.SetAbsStartOff(0))
+	clobberedErr := &gcsfuse_errors.FileClobberedError{Err: errors.New("object generation replaced")}
+	b1.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: clobberedErr})
+	reader.blockQueue.Push(&blockQueueEntry{block: b1, cancel: func() {}})
+	b2, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), b2.SetAbsStartOff(t.config.PrefetchBlockSizeBytes))
+	b2Cancelled := false
+	reader.blockQueue.Push(&blockQueueEntry{block: b2, cancel: func() {
+		b2Cancelled = true
+		b2.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: context.Canceled})
+	}})
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 10),
+		Offset: 0,
+	})
+
+	assert.ErrorAs(t.T(), err, &clobberedErr)
+	assert.True(t.T(), b2Cancelled)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	// Following reads fail without scheduling downloads of the replaced generation.
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 10),
+		Offset: t.config.PrefetchBlockSizeBytes,
+	})
+	assert.ErrorAs(t.T(), err, &clobberedErr)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	t.bucket.AssertNotCalled(t.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
+}
+
+func (t *BufferedReaderTest) TestReadAtBlockDownloadCancelled() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	b, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+	err = b.SetAbsStartOff(0)
+	require.NoError(t.T(), err)
+	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: context.Canceled})
+	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	// Read from a reader where the next block download was cancelled.
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 10),
+		Offset: 0,
+	})
+
+	assert.ErrorIs(t.T(), err, context.Canceled)
+	status, err := b.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorIs(t.T(), status.Err, context.Canceled)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+}
+
+func (t *BufferedReaderTest) TestReadAtBlockStateUnexpected() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	b, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+	err = b.SetAbsStartOff(0)
+	require.NoError(t.T(), err)
+	b.NotifyReady(block.BlockStatus{State: block.BlockStateInProgress})
+	reader.blockQueue.Push(&blockQueueEntry{block: b, cancel: func() {}})
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	// Read from a reader where the next block is in an unexpected state.
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 10),
+		Offset: 0,
+	})
+
+	assert.ErrorContains(t.T(), err, "unexpected block state")
+	status, err := b.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), block.BlockStateInProgress, status.State)
+	assert.Nil(t.T(), status.Err)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+}
+
+func (t *BufferedReaderTest) TestReadAtFromDownloadedBlock() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
 		Config:             t.config,
 		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
 		WorkerPool:         t.workerPool,
@@ -1449,6 +2073,58 @@ func (t *BufferedReaderTest) TestReadAtSpanningMultipleBlocks() {
 	t.bucket.AssertExpectations(t.T())
 }
 
+func (t *BufferedReaderTest) TestReadAtSpanningMultipleBlocksFansOutDownloads() {
+	// No prefetching, so that only the fan-out schedules the blocks beyond the
+	// first one.
+	t.config.InitialPrefetchBlockCnt = 0
+	t.config.FanOutMaxBlocks = 3
+	t.object.Size = 3072
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	// The download of block 0 is held until block 2 is requested, which only
+	// happens before block 0 is read if the blocks are downloaded in parallel.
+	block2Requested := make(chan struct{})
+	var downloadedInParallel atomic.Bool
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == uint64(0*testPrefetchBlockSizeBytes)
+	})).Run(func(mock.Arguments) {
+		select {
+		case <-block2Requested:
+			downloadedInParallel.Store(true)
+		case <-time.After(2 * time.Second):
+		}
+	}).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0*testPrefetchBlockSizeBytes), nil).Once()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == uint64(1*testPrefetchBlockSizeBytes)
+	})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 1*testPrefetchBlockSizeBytes), nil).Once()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == uint64(2*testPrefetchBlockSizeBytes)
+	})).Run(func(mock.Arguments) {
+		close(block2Requested)
+	}).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 2*testPrefetchBlockSizeBytes), nil).Once()
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	buf := make([]byte, 3072)
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: buf,
+		Offset: 0,
+	})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 3072, resp.Size)
+	assertReadResponseContent(t.T(), resp, 0)
+	assert.True(t.T(), downloadedInParallel.Load(), "Blocks of a single read should be downloaded in parallel.")
+	assert.Equal(t.T(), int64(3), reader.stats.blocksScheduled.Load())
+	t.bucket.AssertExpectations(t.T())
+}
+
 func (t *BufferedReaderTest) TestReadAtSequentialReadAcrossBlocks() {
 	t.config.InitialPrefetchBlockCnt = 1
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
@@ -1505,6 +2181,43 @@ func (t *BufferedReaderTest) TestReadAtSequentialReadAcrossBlocks() {
 	t.bucket.AssertExpectations(t.T())
 }
 
+func (t *BufferedReaderTest) TestReadAtAdaptsPrefetchWindowToAccessPattern() {
+	const blockCount = 32
+	t.object.Size = uint64(blockCount * testPrefetchBlockSizeBytes)
+	for i := range int64(blockCount) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes) })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Maybe()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe()
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	// The window doubles, up to MaxPrefetchBlockCnt, only once a prefetch cycle
+	// has scheduled all its blocks; reads served from the blocks already queued
+	// leave it as it is.
+	var windows []int64
+	for i := range int64(3) {
+		_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: i * testPrefetchBlockSizeBytes})
+		require.NoError(t.T(), err)
+		windows = append(windows, reader.numPrefetchBlocks)
+	}
+	require.Equal(t.T(), []int64{2 * testInitialPrefetchBlockCnt, 4 * testInitialPrefetchBlockCnt, 4 * testInitialPrefetchBlockCnt}, windows)
+
+	// A jump beyond the prefetched blocks restarts the window from
+	// InitialPrefetchBlockCnt, grown by the initial prefetch cycle only.
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, 100), Offset: 20 * testPrefetchBlockSizeBytes})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 2*testInitialPrefetchBlockCnt, reader.numPrefetchBlocks)
+	assert.Equal(t.T(), int(1+testInitialPrefetchBlockCnt), reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(20)*testPrefetchBlockSizeBytes, reader.blockQueue.Peek().block.AbsStartOff())
+}
+
 func (t *BufferedReaderTest) TestReadAtFallsBackAfterRandomReads() {
 	t.config.InitialPrefetchBlockCnt = 1
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
@@ -1694,11 +2407,43 @@ func (t *BufferedReaderTest) TestReadAtFallbackOnFreshStartFailure() {
 	assert.ErrorIs(t.T(), err, gcsx.FallbackToAnotherReader, "ReadAt should fall back when freshStart fails to get a block")
 }
 
+func (t *BufferedReaderTest) TestReadAtFallbackAfterWorkerPoolStopped() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	t.bucket.On("Name").Return("test-bucket").Maybe()
+	// Unmount in progress.
+	t.workerPool.Stop()
+
+	var resp gcsx.ReadResponse
+	assert.NotPanics(t.T(), func() {
+		resp, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+			Buffer: make([]byte, 10),
+			Offset: 0,
+		})
+	})
+
+	assert.ErrorIs(t.T(), err, gcsx.FallbackToAnotherReader, "ReadAt should fall back to another reader once the worker pool is stopped")
+	assert.Zero(t.T(), resp.Size)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	assert.Equal(t.T(), int64(0), reader.stats.Snapshot().BlocksInUse, "The block of the unscheduled download should be released")
+	t.bucket.AssertNotCalled(t.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
+	reader.Destroy()
+}
+
 func (t *BufferedReaderTest) TestReadAtFallbackOnMmapFailure() {
 	// Configure a huge block size that will likely cause mmap to fail.
 	// This simulates a non-recoverable error during block creation within the
-	// buffered reader, which should cause a fallback.
+	// buffered reader, which should cause a fallback. The object spans several
+	// blocks, for its first one to be allocated in full.
 	t.config.PrefetchBlockSizeBytes = oneTB
+	t.object.Size = uint64(2 * oneTB)
 	reader, err := NewBufferedReader(&BufferedReaderOptions{
 		Object:             t.object,
 		Bucket:             t.bucket,
@@ -2118,3 +2863,720 @@ func (t *BufferedReaderTest) TestEvictedBlockIsReleasedOnlyAfterCallback() {
 	assert.Equal(t.T(), 1, reader.blockPool.TotalFreeBlocks(), "Evicted block should be released after its callback.")
 	resp2.Callback()
 }
+
+func (t *BufferedReaderTest) TestPrefetchFilesLimitMakesNewestReadersDemandOnly() {
+	prefetchFilesSem := semaphore.NewWeighted(1)
+	newReader := func() *BufferedReader {
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: t.readTypeClassifier,
+			PrefetchFilesSem:   prefetchFilesSem,
+		})
+		require.NoError(t.T(), err)
+		return reader
+	}
+	oldest := newReader()
+	newest := newReader()
+
+	assert.True(t.T(), oldest.hasPrefetchSlot)
+	assert.False(t.T(), newest.hasPrefetchSlot)
+	// A demand-only reader must not schedule any read-ahead blocks.
+	newest.mu.Lock()
+	err := newest.prefetch()
+	newest.mu.Unlock()
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 0, newest.blockQueue.Len())
+	assert.Equal(t.T(), int64(0), newest.nextBlockIndexToPrefetch)
+	newest.Destroy()
+	oldest.Destroy()
+}
+
+func (t *BufferedReaderTest) TestDemandOnlyReaderStartsPrefetchingWhenSlotFrees() {
+	prefetchFilesSem := semaphore.NewWeighted(1)
+	opts := &BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		PrefetchFilesSem:   prefetchFilesSem,
+	}
+	oldest, err := NewBufferedReader(opts)
+	require.NoError(t.T(), err)
+	newest, err := NewBufferedReader(opts)
+	require.NoError(t.T(), err)
+	require.False(t.T(), newest.hasPrefetchSlot)
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 1024 })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 1024), nil).Once()
+
+	oldest.Destroy()
+	newest.mu.Lock()
+	err = newest.prefetch()
+	newest.mu.Unlock()
+
+	require.NoError(t.T(), err)
+	assert.True(t.T(), newest.hasPrefetchSlot)
+	assert.Equal(t.T(), 2, newest.blockQueue.Len())
+	newest.Destroy()
+	// The slot held by the newest reader is released on destroy.
+	assert.True(t.T(), prefetchFilesSem.TryAcquire(1))
+}
+
+func (t *BufferedReaderTest) TestReadHandleNotCapturedForNonZonalBucket() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		BucketType:         gcs.BucketType{Hierarchical: true},
+	})
+	require.NoError(t.T(), err)
+	fakeReader := createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0)
+	fakeReader.Handle = []byte("opaque-handle")
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 && r.ReadHandle == nil })).Return(fakeReader, nil).Once()
+	b, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+
+	err = reader.scheduleBlockWithIndex(b, 0, metrics.DownloadClassDemandAttr)
+
+	require.NoError(t.T(), err)
+	status, err := reader.blockQueue.Peek().block.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+	assert.Nil(t.T(), reader.getReadHandle())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestReadHandleCapturedAndReusedForZonalBucket() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		BucketType:         gcs.BucketType{Zonal: true},
+	})
+	require.NoError(t.T(), err)
+	readHandle := []byte("opaque-handle")
+	firstReader := createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0)
+	firstReader.Handle = readHandle
+	secondReader := createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 1024)
+	secondReader.Handle = readHandle
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 && r.ReadHandle == nil })).Return(firstReader, nil).Once()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == 1024 && bytes.Equal(r.ReadHandle, readHandle)
+	})).Return(secondReader, nil).Once()
+	b1, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), reader.scheduleBlockWithIndex(b1, 0, metrics.DownloadClassDemandAttr))
+	_, err = reader.blockQueue.Peek().block.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	b2, err := reader.blockPool.Get()
+	require.NoError(t.T(), err)
+
+	err = reader.scheduleBlockWithIndex(b2, 1, metrics.DownloadClassPrefetchAttr)
+
+	require.NoError(t.T(), err)
+	reader.blockQueue.Pop()
+	status, err := reader.blockQueue.Peek().block.AwaitReady(t.ctx)
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), block.BlockStateDownloaded, status.State)
+	assert.Equal(t.T(), readHandle, reader.getReadHandle())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestNewBufferedReaderWithUnsupportedPrefetchPolicy() {
+	t.config.PrefetchPolicy = "random"
+
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+
+	assert.ErrorContains(t.T(), err, "unsupported prefetch policy")
+	assert.Nil(t.T(), reader, "BufferedReader should be nil on error")
+}
+
+func (t *BufferedReaderTest) TestNewBufferedReaderHonorsAllowlistedObjectMetadata() {
+	t.object.Metadata = map[string]string{PrefetchPolicyMetadataKey: cfg.PrefetchPolicyNone, "owner": "team"}
+	newReader := func(keys []string) *BufferedReader {
+		config := *t.config
+		config.ObjectMetadataKeys = keys
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             &config,
+			GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: t.readTypeClassifier})
+		require.NoError(t.T(), err)
+		t.T().Cleanup(reader.Destroy)
+		return reader
+	}
+
+	allowlisted := newReader([]string{PrefetchPolicyMetadataKey, "missing"})
+	notAllowlisted := newReader(nil)
+
+	assert.Equal(t.T(), map[string]string{PrefetchPolicyMetadataKey: cfg.PrefetchPolicyNone}, allowlisted.objectMetadata)
+	assert.Equal(t.T(), cfg.PrefetchPolicyNone, allowlisted.prefetchPolicy.Name())
+	assert.Zero(t.T(), allowlisted.numPrefetchBlocks)
+	assert.Nil(t.T(), notAllowlisted.objectMetadata)
+	assert.Equal(t.T(), cfg.PrefetchPolicyAdaptive, notAllowlisted.prefetchPolicy.Name())
+	assert.Equal(t.T(), testInitialPrefetchBlockCnt, notAllowlisted.numPrefetchBlocks)
+	assert.Same(t.T(), t.object, allowlisted.object, "The object must be shared with the caller.")
+	assert.Len(t.T(), t.object.Metadata, 2, "The metadata of the object must be left untouched.")
+}
+
+func (t *BufferedReaderTest) TestNewBufferedReaderIgnoresInvalidPrefetchPolicyMetadata() {
+	t.object.Metadata = map[string]string{PrefetchPolicyMetadataKey: "random"}
+	t.config.ObjectMetadataKeys = []string{PrefetchPolicyMetadataKey}
+
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	assert.Equal(t.T(), cfg.PrefetchPolicyAdaptive, reader.prefetchPolicy.Name())
+}
+
+func (t *BufferedReaderTest) TestFirstReadWithPreallocatedBlocksIncursNoPoolGrowth() {
+	arena, err := block.NewPrefetchBlockArena(testPrefetchBlockSizeBytes, testGlobalMaxBlocks)
+	require.NoError(t.T(), err)
+	defer func() { assert.NoError(t.T(), arena.Destroy()) }()
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		BlockArena:         arena,
+	})
+	require.NoError(t.T(), err)
+	for i := range int64(t.object.Size) / testPrefetchBlockSizeBytes {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Maybe()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, testPrefetchBlockSizeBytes),
+		Offset: 0,
+	})
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	assert.Zero(t.T(), arena.Misses(), "The first read must not allocate any block.")
+	assert.Less(t.T(), arena.FreeBlocks(), int(testGlobalMaxBlocks))
+	reader.Destroy()
+	assert.Equal(t.T(), int(testGlobalMaxBlocks), arena.FreeBlocks(), "Blocks must go back to the arena.")
+}
+
+func (t *BufferedReaderTest) TestReadAtWithNoPrefetchPolicyDownloadsOnDemand() {
+	t.config.PrefetchPolicy = cfg.PrefetchPolicyNone
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	// Only the blocks being read are downloaded.
+	for i := range int64(2) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	for i := range int64(2) {
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+			Buffer: make([]byte, testPrefetchBlockSizeBytes),
+			Offset: i * testPrefetchBlockSizeBytes,
+		})
+
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, i*testPrefetchBlockSizeBytes)
+		resp.Callback()
+	}
+	assert.Equal(t.T(), int64(0), reader.randomSeekCount)
+	assert.True(t.T(), reader.blockQueue.IsEmpty())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestReadAtWithFooterFirstPolicyFooterReadIsNotRandom() {
+	t.config.PrefetchPolicy = cfg.PrefetchPolicyFooterFirst
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	footerOffset := int64(t.object.Size) - testPrefetchBlockSizeBytes
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == uint64(footerOffset)
+	})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), footerOffset), nil).Once()
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 100),
+		Offset: footerOffset,
+	})
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, footerOffset)
+	resp.Callback()
+	assert.Equal(t.T(), int64(0), reader.randomSeekCount)
+	assert.Equal(t.T(), 1, reader.blockQueue.Len())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestAdviseWillNeedPrefetchesRangeUrgently() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	// The advised range spans blocks 4 and 5.
+	for i := int64(4); i <= 5; i++ {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	err = reader.Advise(gcsx.AdviceWillNeed, 4*testPrefetchBlockSizeBytes+10, testPrefetchBlockSizeBytes)
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 2, reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(6), reader.nextBlockIndexToPrefetch)
+	// A read of the advised range is served from the prefetched blocks without
+	// counting as a random seek.
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 100),
+		Offset: 4*testPrefetchBlockSizeBytes + 10,
+	})
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 4*testPrefetchBlockSizeBytes+10)
+	resp.Callback()
+	assert.Equal(t.T(), int64(0), reader.randomSeekCount)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestAdviseWillNeedExtendsQueueContinuingRange() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	for i := range int64(5) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	reader.mu.Lock()
+	require.NoError(t.T(), reader.freshStart(0)) // Schedules blocks 0, 1 and 2.
+	headBlock := reader.blockQueue.Peek().block
+	reader.mu.Unlock()
+
+	err = reader.PrefetchRange(2*testPrefetchBlockSizeBytes, 3*testPrefetchBlockSizeBytes)
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 5, reader.blockQueue.Len())
+	assert.Same(t.T(), headBlock, reader.blockQueue.Peek().block, "Queued blocks should be kept.")
+	assert.Equal(t.T(), int64(5), reader.nextBlockIndexToPrefetch)
+	awaitQueuedBlocks(t.T(), reader)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestAdviseDontNeedEvictsRange() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+	// The downloads of the evicted blocks may be cancelled before they start.
+	for i := int64(1); i < 3; i++ {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Maybe()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	reader.mu.Lock()
+	require.NoError(t.T(), reader.freshStart(0)) // Schedules blocks 0, 1 and 2.
+	reader.mu.Unlock()
+	freeBlocksBefore := reader.blockPool.TotalFreeBlocks()
+
+	err = reader.Advise(gcsx.AdviceDontNeed, testPrefetchBlockSizeBytes+10, 10)
+
+	require.NoError(t.T(), err)
+	// Block 1 overlaps the range, and block 2 follows it in the queue.
+	assert.Equal(t.T(), 1, reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(0), reader.blockQueue.Peek().block.AbsStartOff())
+	assert.Equal(t.T(), int64(1), reader.nextBlockIndexToPrefetch)
+	assert.Equal(t.T(), freeBlocksBefore+2, reader.blockPool.TotalFreeBlocks())
+	awaitQueuedBlocks(t.T(), reader)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestAdviseDontNeedOutsideQueueKeepsBlocks() {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	for i := range int64(3) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	reader.mu.Lock()
+	require.NoError(t.T(), reader.freshStart(0)) // Schedules blocks 0, 1 and 2.
+	reader.mu.Unlock()
+
+	reader.EvictRange(5*testPrefetchBlockSizeBytes, testPrefetchBlockSizeBytes)
+
+	assert.Equal(t.T(), 3, reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(3), reader.nextBlockIndexToPrefetch)
+	awaitQueuedBlocks(t.T(), reader)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestEvictRangeRePrefetchesResidentFloorOfHotObject() {
+	t.config.HotFileResidentBlocks = 4
+	tracker := NewHotFileTracker(2, time.Minute, timeutil.RealClock())
+	t.bucket.On("Name").Return("test-bucket")
+	// The object was opened before, so that this open makes it hot.
+	tracker.RecordOpen("test-bucket", t.object.Name)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		HotFileTracker:     tracker})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	require.True(t.T(), reader.hot)
+	for i := range int64(4) {
+		call := t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil)
+		// The evicted blocks 1 and 2 are downloaded again, their first downloads
+		// may be cancelled before they start.
+		if i == 0 || i == 3 {
+			call.Once()
+		}
+	}
+	reader.mu.Lock()
+	require.NoError(t.T(), reader.freshStart(0)) // Schedules blocks 0, 1 and 2.
+	reader.mu.Unlock()
+
+	reader.EvictRange(testPrefetchBlockSizeBytes, testPrefetchBlockSizeBytes)
+
+	// Block 0 is kept, and blocks 1 to 3 are prefetched to keep 4 blocks.
+	assert.Equal(t.T(), 4, reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(4), reader.nextBlockIndexToPrefetch)
+	awaitQueuedBlocks(t.T(), reader)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestEvictRangeDoesNotRePrefetchColdObject() {
+	t.config.HotFileResidentBlocks = 4
+	tracker := NewHotFileTracker(2, time.Minute, timeutil.RealClock())
+	t.bucket.On("Name").Return("test-bucket")
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		HotFileTracker:     tracker})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	require.False(t.T(), reader.hot)
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 })).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+	for i := int64(1); i < 3; i++ {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Maybe()
+	}
+	reader.mu.Lock()
+	require.NoError(t.T(), reader.freshStart(0)) // Schedules blocks 0, 1 and 2.
+	reader.mu.Unlock()
+
+	reader.EvictRange(testPrefetchBlockSizeBytes, testPrefetchBlockSizeBytes)
+
+	assert.Equal(t.T(), 1, reader.blockQueue.Len())
+	assert.Equal(t.T(), int64(1), reader.nextBlockIndexToPrefetch)
+	awaitQueuedBlocks(t.T(), reader)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestReadAtStreamsWithinSlidingWindow() {
+	const windowBlocks = 3
+	const blockCount = 64
+	t.config.SlidingWindowBlocks = windowBlocks
+	t.object.Size = blockCount * uint64(testPrefetchBlockSizeBytes)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	for i := range int64(blockCount) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	buf := make([]byte, testPrefetchBlockSizeBytes/2)
+
+	for offset := int64(0); offset < int64(t.object.Size); offset += int64(len(buf)) {
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: offset})
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, offset)
+		resp.Callback()
+
+		reader.mu.Lock()
+		queued := int64(reader.blockQueue.Len())
+		reader.mu.Unlock()
+		// The consumed blocks are evicted, the last one excepted, and the window
+		// stays full, with the downloads kept ahead of the reads, until it
+		// reaches the end of the object.
+		firstQueued := min((offset+int64(resp.Size))/testPrefetchBlockSizeBytes, blockCount-1)
+		assert.Equal(t.T(), min(windowBlocks, blockCount-firstQueued), queued)
+		assert.LessOrEqual(t.T(), reader.stats.blocksInUse.Load(), int64(windowBlocks))
+	}
+
+	// All blocks but the first one had been downloaded ahead of the reads.
+	assert.Equal(t.T(), int64(blockCount), reader.stats.blocksRead.Load())
+	assert.Equal(t.T(), int64(blockCount-1), reader.stats.blocksHit.Load())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestOpenObjectRegistryListsPrefetchEffectivenessPerObject() {
+	const sequentialBlockCount = 8
+	const randomBlockCount = 16
+	registry := NewOpenObjectRegistry()
+	newReader := func(name string, blockCount int64) *BufferedReader {
+		object := &gcs.MinObject{Name: name, Size: uint64(blockCount * testPrefetchBlockSizeBytes), Generation: 1234567890}
+		for i := range blockCount {
+			t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+				return r.Name == name && r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+			})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Maybe()
+		}
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: gcsx.NewReadTypeClassifier(1, 0),
+			OpenObjectRegistry: registry,
+		})
+		require.NoError(t.T(), err)
+		return reader
+	}
+	read := func(reader *BufferedReader, offset int64) {
+		buf := make([]byte, testPrefetchBlockSizeBytes/2)
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: offset})
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, offset)
+		resp.Callback()
+	}
+	t.bucket.On("Name").Return("test-bucket")
+	sequentialReader := newReader("sequential_object", sequentialBlockCount)
+	randomReader := newReader("random_object", randomBlockCount)
+
+	// The sequential reads are served by the blocks prefetched ahead of them.
+	for offset := int64(0); offset < sequentialBlockCount*testPrefetchBlockSizeBytes; offset += testPrefetchBlockSizeBytes / 2 {
+		read(sequentialReader, offset)
+	}
+	// The seek evicts the blocks prefetched after the first one, never read.
+	read(randomReader, 0)
+	read(randomReader, 10*testPrefetchBlockSizeBytes)
+
+	listing := registry.Listing()
+	require.Len(t.T(), listing, 2)
+	random, sequential := listing[0], listing[1]
+	assert.Equal(t.T(), "random_object", random.ObjectName)
+	assert.Equal(t.T(), "sequential_object", sequential.ObjectName)
+	for _, s := range listing {
+		assert.Equal(t.T(), "test-bucket", s.BucketName)
+		assert.Equal(t.T(), 1, s.Readers)
+		assert.Equal(t.T(), testPrefetchBlockSizeBytes, s.BlockSizeBytes)
+	}
+	assert.Equal(t.T(), int64(sequentialBlockCount), sequential.BlockCount)
+	assert.Equal(t.T(), int64(randomBlockCount), random.BlockCount)
+	// All blocks but the first one were prefetched ahead of the reads, and none
+	// was wasted.
+	assert.Equal(t.T(), int64(sequentialBlockCount), sequential.BlocksRead)
+	assert.Equal(t.T(), int64(sequentialBlockCount-1), sequential.BlocksHit)
+	assert.Zero(t.T(), sequential.WasteRate())
+	// Both blocks read were read on demand, and the prefetched ones wasted.
+	assert.Equal(t.T(), int64(2), random.BlocksRead)
+	assert.Zero(t.T(), random.HitRate())
+	assert.Positive(t.T(), random.BlocksWasted)
+	assert.Greater(t.T(), sequential.HitRate(), random.HitRate())
+	assert.Greater(t.T(), random.WasteRate(), sequential.WasteRate())
+
+	// The readers are no longer listed once destroyed.
+	sequentialReader.Destroy()
+	require.Len(t.T(), registry.Listing(), 1)
+	randomReader.Destroy()
+	assert.Empty(t.T(), registry.Listing())
+}
+
+func (t *BufferedReaderTest) TestReadAtFailsAfterReadTimeoutWhenDownloadsNeverComplete() {
+	const readTimeout = 100 * time.Millisecond
+	t.config.ReadTimeout = readTimeout
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier})
+	require.NoError(t.T(), err)
+	// The downloads hang until the reader is destroyed.
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.AnythingOfType("*gcs.ReadObjectRequest")).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil, context.Canceled)
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	start := time.Now()
+
+	_, err = reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, testPrefetchBlockSizeBytes),
+		Offset: 0,
+	})
+
+	assert.ErrorIs(t.T(), err, ErrReadTimeout)
+	assert.GreaterOrEqual(t.T(), time.Since(start), readTimeout)
+	reader.Destroy()
+}
+
+func TestAlignedBlockSize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		alignment  string
+		objectSize int64
+		want       int64
+	}{
+		{"no_alignment", cfg.BlockAlignmentNone, 3*testPrefetchBlockSizeBytes + 10, testPrefetchBlockSizeBytes},
+		{"aligned_size", cfg.BlockAlignmentObjectSize, 4 * testPrefetchBlockSizeBytes, testPrefetchBlockSizeBytes},
+		{"small_remainder", cfg.BlockAlignmentObjectSize, 3*testPrefetchBlockSizeBytes + 10, 771},
+		{"large_remainder", cfg.BlockAlignmentObjectSize, 4*testPrefetchBlockSizeBytes - 1, testPrefetchBlockSizeBytes},
+		{"smaller_than_block", cfg.BlockAlignmentObjectSize, 10, 10},
+		{"empty_object", cfg.BlockAlignmentObjectSize, 0, testPrefetchBlockSizeBytes},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &BufferedReadConfig{PrefetchBlockSizeBytes: testPrefetchBlockSizeBytes, BlockAlignment: tc.alignment}
+
+			assert.Equal(t, tc.want, alignedBlockSize(tc.objectSize, config))
+		})
+	}
+}
+
+func (t *BufferedReaderTest) TestReadAtWithBlocksAlignedToObjectSize() {
+	t.object.Size = uint64(3*testPrefetchBlockSizeBytes + 10)
+	t.config.BlockAlignment = cfg.BlockAlignmentObjectSize
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+	})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	// Instead of three full blocks and a 10 byte one, the object is split into
+	// four blocks of 771 bytes, the last one holding 769 bytes.
+	const blockSize = 771
+	for i := range uint64(4) {
+		start, limit := i*blockSize, min((i+1)*blockSize, t.object.Size)
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == start && r.Range.Limit == limit
+		})).Return(createFakeReaderWithOffset(t.T(), int(limit-start), int64(start)), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, t.object.Size),
+		Offset: 0,
+	})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), int(t.object.Size), resp.Size)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	t.bucket.AssertExpectations(t.T())
+}
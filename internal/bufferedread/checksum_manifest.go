@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"os"
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ChecksumManifestEntry is the expected CRC32C (Castagnoli) checksum of the
+// [Start, End) byte range of an object.
+type ChecksumManifestEntry struct {
+	Object string `json:"object"`
+	Start  int64  `json:"start"`
+	End    int64  `json:"end"`
+	CRC32C uint32 `json:"crc32c"`
+}
+
+type checksumManifestKey struct {
+	object     string
+	start, end int64
+}
+
+// ChecksumManifest holds externally supplied checksums of object blocks,
+// against which downloaded blocks are validated independently of the GCS
+// object metadata. Blocks are matched by their exact byte range, so the
+// manifest must be generated with the block size used for reading.
+//
+// It is immutable once built, hence safe for concurrent use.
+type ChecksumManifest struct {
+	checksums map[checksumManifestKey]uint32
+}
+
+// NewChecksumManifest builds a manifest from the given entries.
+func NewChecksumManifest(entries []ChecksumManifestEntry) (*ChecksumManifest, error) {
+	m := &ChecksumManifest{checksums: make(map[checksumManifestKey]uint32, len(entries))}
+	for i, e := range entries {
+		if e.Object == "" || e.Start < 0 || e.End <= e.Start {
+			return nil, fmt.Errorf("invalid checksum manifest entry %d: object %q, range [%d, %d)", i, e.Object, e.Start, e.End)
+		}
+		key := checksumManifestKey{e.Object, e.Start, e.End}
+		if crc, ok := m.checksums[key]; ok && crc != e.CRC32C {
+			return nil, fmt.Errorf("conflicting checksums in manifest for object %q, range [%d, %d)", e.Object, e.Start, e.End)
+		}
+		m.checksums[key] = e.CRC32C
+	}
+	return m, nil
+}
+
+// LoadChecksumManifest reads a manifest from a JSON file holding an array of
+// ChecksumManifestEntry.
+func LoadChecksumManifest(path string) (*ChecksumManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading checksum manifest: %w", err)
+	}
+	var entries []ChecksumManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing checksum manifest %s: %w", path, err)
+	}
+	return NewChecksumManifest(entries)
+}
+
+// lookup returns the expected checksum of the [start, end) range of the
+// object, if the manifest has one.
+func (m *ChecksumManifest) lookup(object string, start, end int64) (uint32, bool) {
+	crc, ok := m.checksums[checksumManifestKey{object, start, end}]
+	return crc, ok
+}
+
+// BlockCorruptionError is returned when the checksum of a downloaded block
+// doesn't match the one supplied by the checksum manifest, or the one of the
+// object for a block holding the whole object.
+type BlockCorruptionError struct {
+	ObjectName string
+	Start, End int64
+	Expected   uint32
+	Actual     uint32
+}
+
+func (e *BlockCorruptionError) Error() string {
+	return fmt.Sprintf("block corruption detected for object %q, range [%d, %d): CRC32C mismatch, actual: %d, expected: %d",
+		e.ObjectName, e.Start, e.End, e.Actual, e.Expected)
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadChecksumManifest(t *testing.T) {
+	path := writeManifest(t, `[
+		{"object": "a/b", "start": 0, "end": 500, "crc32c": 1234},
+		{"object": "a/b", "start": 500, "end": 1024, "crc32c": 5678}
+	]`)
+
+	m, err := LoadChecksumManifest(path)
+
+	require.NoError(t, err)
+	crc, ok := m.lookup("a/b", 500, 1024)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(5678), crc)
+	_, ok = m.lookup("a/b", 0, 1024)
+	assert.False(t, ok)
+	_, ok = m.lookup("a/c", 0, 500)
+	assert.False(t, ok)
+}
+
+func TestLoadChecksumManifestErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "malformed_json",
+			content: `{"object": "a/b"`,
+			wantErr: "parsing checksum manifest",
+		},
+		{
+			name:    "empty_range",
+			content: `[{"object": "a/b", "start": 500, "end": 500, "crc32c": 1}]`,
+			wantErr: "invalid checksum manifest entry 0",
+		},
+		{
+			name:    "missing_object",
+			content: `[{"start": 0, "end": 500, "crc32c": 1}]`,
+			wantErr: "invalid checksum manifest entry 0",
+		},
+		{
+			name:    "conflicting_checksums",
+			content: `[{"object": "a/b", "start": 0, "end": 500, "crc32c": 1}, {"object": "a/b", "start": 0, "end": 500, "crc32c": 2}]`,
+			wantErr: "conflicting checksums",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadChecksumManifest(writeManifest(t, tc.content))
+
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoadChecksumManifestMissingFile(t *testing.T) {
+	_, err := LoadChecksumManifest(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/jacobsa/timeutil"
+)
+
+// ErrCircuitOpen is returned by the reads failing fast while the circuit
+// breaker is open. It is reported to the application as EIO.
+var ErrCircuitOpen = errors.New("failing fast after consecutive block download failures")
+
+type circuitState int
+
+const (
+	// circuitClosed lets all the reads through.
+	circuitClosed circuitState = iota
+	// circuitOpen fails the reads fast until the cooldown elapses.
+	circuitOpen
+	// circuitHalfOpen lets a single read through per cooldown, probing whether
+	// downloads succeed again.
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// DownloadCircuitBreaker fails the buffered reads of the mount fast once the
+// block downloads failed failureThreshold times in a row, e.g. during a GCS
+// outage, rather than each read waiting out the retries and timeouts of its
+// downloads. Once the cooldown elapses, a read is let through to probe GCS,
+// and then one per cooldown until a download succeeds, which closes the
+// circuit.
+type DownloadCircuitBreaker struct {
+	failureThreshold int64
+	cooldown         time.Duration
+	clock            timeutil.Clock
+
+	mu sync.Mutex
+
+	// GUARDED by (mu)
+	state circuitState
+
+	// consecutiveFailures is the number of downloads failed since the last
+	// successful one.
+	// GUARDED by (mu)
+	consecutiveFailures int64
+
+	// lastTripped is the time the circuit opened, or the last probe was let
+	// through.
+	// GUARDED by (mu)
+	lastTripped time.Time
+}
+
+// NewDownloadCircuitBreaker returns a DownloadCircuitBreaker opening after
+// failureThreshold consecutive download failures, for the given cooldown.
+func NewDownloadCircuitBreaker(failureThreshold int64, cooldown time.Duration, clock timeutil.Clock) *DownloadCircuitBreaker {
+	return &DownloadCircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		clock:            clock,
+	}
+}
+
+// allow returns ErrCircuitOpen if the read must fail fast, and nil if it may
+// proceed, possibly as a probe.
+// LOCKS_EXCLUDED(b.mu)
+func (b *DownloadCircuitBreaker) allow() error {
+	now := b.clock.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == circuitClosed {
+		return nil
+	}
+	if now.Sub(b.lastTripped) < b.cooldown {
+		return ErrCircuitOpen
+	}
+	if b.state == circuitOpen {
+		logger.Infof("Buffered read circuit breaker half-open after %v, probing GCS.", b.cooldown)
+		b.state = circuitHalfOpen
+	}
+	b.lastTripped = now
+	return nil
+}
+
+// recordSuccess accounts for a successful download, closing the circuit.
+// LOCKS_EXCLUDED(b.mu)
+func (b *DownloadCircuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != circuitClosed {
+		logger.Infof("Buffered read circuit breaker closed, downloads succeed again.")
+	}
+	b.state = circuitClosed
+	b.consecutiveFailures = 0
+}
+
+// recordFailure accounts for a failed download, opening the circuit once
+// failureThreshold downloads failed in a row, or on a failed probe.
+// LOCKS_EXCLUDED(b.mu)
+func (b *DownloadCircuitBreaker) recordFailure() {
+	now := b.clock.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures++
+	switch {
+	case b.state == circuitHalfOpen:
+		b.state = circuitOpen
+		b.lastTripped = now
+	case b.state == circuitClosed && b.consecutiveFailures >= b.failureThreshold:
+		logger.Warnf("Buffered read circuit breaker open after %d consecutive download failures, failing reads fast for %v.", b.consecutiveFailures, b.cooldown)
+		b.state = circuitOpen
+		b.lastTripped = now
+	}
+}
+
+// currentState returns the state of the circuit.
+// LOCKS_EXCLUDED(b.mu)
+func (b *DownloadCircuitBreaker) currentState() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadCircuitBreakerProbesOncePerCooldown(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	breaker := NewDownloadCircuitBreaker(2, time.Minute, &clock)
+	breaker.recordFailure()
+	require.NoError(t, breaker.allow())
+	breaker.recordFailure()
+	require.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+
+	clock.AdvanceTime(time.Minute)
+
+	assert.NoError(t, breaker.allow())
+	assert.Equal(t, circuitHalfOpen, breaker.currentState())
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+	// A failed probe opens the circuit again for a whole cooldown.
+	breaker.recordFailure()
+	assert.Equal(t, circuitOpen, breaker.currentState())
+	clock.AdvanceTime(time.Minute / 2)
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+}
+
+func TestDownloadCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	breaker := NewDownloadCircuitBreaker(2, time.Minute, &clock)
+
+	breaker.recordFailure()
+	breaker.recordSuccess()
+	breaker.recordFailure()
+
+	assert.NoError(t, breaker.allow())
+	assert.Equal(t, circuitClosed, breaker.currentState())
+}
+
+func (t *BufferedReaderTest) TestDownloadCircuitBreakerOpensThenRecovers() {
+	const failureThreshold = 3
+	var clock timeutil.SimulatedClock
+	breaker := NewDownloadCircuitBreaker(failureThreshold, time.Minute, &clock)
+	// A single download per read.
+	t.config.InitialPrefetchBlockCnt = 0
+	t.bucket.On("Name").Return("test-bucket").Maybe()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.Anything).Return(nil, errors.New("service unavailable")).Times(failureThreshold)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:                 t.object,
+		Bucket:                 t.bucket,
+		Config:                 t.config,
+		GlobalMaxBlocksSem:     t.globalMaxBlocksSem,
+		WorkerPool:             t.workerPool,
+		MetricHandle:           t.metricHandle,
+		ReadTypeClassifier:     t.readTypeClassifier,
+		DownloadCircuitBreaker: breaker,
+	})
+	require.NoError(t.T(), err)
+	defer reader.Destroy()
+	readAt := func() (gcsx.ReadResponse, error) {
+		return reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: 0})
+	}
+	for range failureThreshold {
+		_, err := readAt()
+		require.Error(t.T(), err)
+		require.NotErrorIs(t.T(), err, ErrCircuitOpen)
+	}
+
+	// The circuit is open: the read fails without downloading.
+	_, err = readAt()
+
+	assert.ErrorIs(t.T(), err, ErrCircuitOpen)
+	assert.Equal(t.T(), circuitOpen, breaker.currentState())
+	t.bucket.AssertExpectations(t.T())
+
+	// Once the cooldown elapsed, a read probes GCS, which recovered.
+	clock.AdvanceTime(time.Minute)
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start == 0
+	})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+
+	resp, err := readAt()
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	assert.Equal(t.T(), circuitClosed, breaker.currentState())
+}
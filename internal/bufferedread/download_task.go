@@ -18,31 +18,155 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/googlecloudplatform/gcsfuse/v3/tracing"
 )
 
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx tagged with the given correlation
+// ID, e.g. of a training epoch or an inference request. The downloads
+// scheduled by reads with the returned context carry the ID in their trace
+// logs and spans.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID ctx is tagged with, or
+// the empty string if none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+const (
+	// Blocks smaller than mediumBlockSize are small, and those smaller than
+	// largeBlockSize medium, in the download latency metric.
+	mediumBlockSize = 4 * util.MiB
+	largeBlockSize  = 16 * util.MiB
+)
+
+// blockSizeBucket returns the bucket of the given block size with which the
+// download latencies are recorded, so that the latencies of large blocks
+// don't mix with those of small ones.
+func blockSizeBucket(blockSize int64) metrics.BlockSize {
+	switch {
+	case blockSize < mediumBlockSize:
+		return metrics.BlockSizeSmallAttr
+	case blockSize < largeBlockSize:
+		return metrics.BlockSizeMediumAttr
+	default:
+		return metrics.BlockSizeLargeAttr
+	}
+}
+
 type downloadTask struct {
 	workerpool.Task
 	object       *gcs.MinObject
 	bucket       gcs.Bucket
 	metricHandle metrics.MetricHandle
 
+	// traceHandle, if non-nil, traces the download in a span.
+	traceHandle tracing.TraceHandle
+
+	// correlationID, if non-empty, tags the trace logs and span of the
+	// download with the higher-level operation it belongs to.
+	correlationID string
+
+	// class is the class of the download the downloaded bytes are accounted
+	// to, e.g. demand or prefetch.
+	class metrics.DownloadClass
+
+	// stats, if non-nil, accumulates the latency of successful downloads.
+	stats *Stats
+
 	// block is the block to which the data will be downloaded.
 	block block.PrefetchBlock
 
+	// blockSize is the size of the object blocks, from which the index of the
+	// block is derived. If zero, the block capacity is used, so it must be set
+	// for the right-sized last blocks of objects, smaller than the others.
+	blockSize int64
+
+	// downloadSize, if non-zero, is the size of the range downloaded from the
+	// start of the block, smaller than blockSize for the demand blocks lying
+	// within an object block. The block is still identified by the index of the
+	// object block it lies within.
+	downloadSize int64
+
+	// deadline bounds each download attempt. Zero means no deadline.
+	deadline time.Duration
+
+	// maxRetries is the number of times a failed download is retried, resuming
+	// from the last downloaded byte.
+	maxRetries int64
+
+	// retryBackoff is the wait before the first retry, doubled after each
+	// retry. Zero means no wait.
+	retryBackoff time.Duration
+
+	// cancelPolicy is the cancelled download policy, deciding whether the bytes
+	// downloaded until the download is cancelled are discarded or kept for the
+	// reads they cover.
+	cancelPolicy string
+
+	// checksumManifest, if non-nil, holds the expected checksums against which
+	// the downloaded block is validated.
+	checksumManifest *ChecksumManifest
+
+	// verifyObject, if true, validates the downloaded block against the CRC32C
+	// of the object when it holds the whole object.
+	verifyObject bool
+
+	// pinnedBlocks, if non-nil, keeps a copy of the downloaded block under
+	// pinKey.
+	pinnedBlocks *PinnedBlockStore
+	pinKey       sharedBlockKey
+
+	// downloadLimiter, if non-nil, bounds the concurrent downloads of the
+	// object, the download waiting for a slot before starting.
+	downloadLimiter *ObjectDownloadLimiter
+
+	// downloadThrottle, if non-nil, limits the bandwidth of the download, along
+	// with that of all the other downloads sharing it.
+	downloadThrottle ratelimit.Throttle
+
+	// circuitBreaker, if non-nil, is notified of the outcome of the download.
+	circuitBreaker *DownloadCircuitBreaker
+
+	// latestGeneration, if true, downloads the block from the latest generation
+	// of the object rather than the opened one, so that the blocks of a file
+	// replaced mid-read may come from different generations.
+	latestGeneration bool
+
 	// ctx is the context for the download task. It is used to cancel the download.
 	ctx context.Context
 
+	// cancel, if non-nil, cancels ctx, e.g. when the watchdog of the worker
+	// pool finds the download stuck.
+	cancel context.CancelFunc
+
 	// Used for zonal bucket to bypass the auth & metadata checks.
 	readHandle []byte
+
+	// readHandleUpdater, if non-nil, is called with the read handle of the
+	// reader used for this download so that subsequent downloads can reuse it.
+	// It is only set for zonal buckets, as other bucket types don't support read
+	// handles and capturing them would be pure overhead on the read path.
+	readHandleUpdater func(readHandle []byte)
 }
 
 // Execute implements the workerpool.Task interface. It downloads the data from
@@ -50,36 +174,248 @@ type downloadTask struct {
 // After completion, it notifies the block consumer about the status of the
 // download task. The status can be one of the following:
 // - BlockStatusDownloaded: The download was successful.
-// - BlockStatusDownloadFailed: The download failed due to an error.
+// - BlockStatusDownloadFailed: The download failed due to an error, or was
+// cancelled.
+// - BlockStatusPartiallyDownloaded: The download was cancelled midway, and the
+// bytes downloaded until then are kept as per the cancelled download policy.
 func (p *downloadTask) Execute() {
+	blockSize := p.blockSize
+	if blockSize == 0 {
+		blockSize = p.block.Cap()
+	}
+	// The fill of the block is measured against the size of the object blocks,
+	// rather than the capacity of a right-sized last block.
+	objectBlockSize := blockSize
 	startOff := p.block.AbsStartOff()
-	blockId := startOff / p.block.Cap()
-	logger.Tracef("Download: <- block (%s, %v).", p.object.Name, blockId)
+	blockId := startOff / blockSize
+	if p.downloadSize > 0 {
+		blockSize = p.downloadSize
+	}
+	tag := p.logTag()
+	logger.Tracef("Download: <- block (%s, %v)%s.", p.object.Name, blockId, tag)
 	stime := time.Now()
 	var err error
 	var n int64
+	if p.traceHandle != nil {
+		_, span := p.traceHandle.StartSpan(p.ctx, tracing.DownloadPrefetchBlock)
+		defer func() {
+			p.traceHandle.SetDownloadAttributes(span, n, p.correlationID)
+			if err != nil {
+				p.traceHandle.RecordError(span, err)
+			}
+			p.traceHandle.EndSpan(span)
+		}()
+	}
 	defer func() {
 		dur := time.Since(stime)
+		// Accounted before notifying the block, so that the bytes of a ready
+		// block are always accounted.
+		p.metricHandle.GcsDownloadBytesCount(n, metrics.ReadTypeBufferedAttr)
+		p.metricHandle.BufferedReadDownloadBytesCount(n, p.class)
 		if err == nil {
-			logger.Tracef("Download: -> block (%s, %v) Ok(%v).", p.object.Name, blockId, dur)
+			logger.Tracef("Download: -> block (%s, %v)%s Ok(%v).", p.object.Name, blockId, tag, dur)
+			p.metricHandle.BufferedReadDownloadBlockLatency(p.ctx, dur, blockSizeBucket(blockSize))
+			if p.stats != nil {
+				p.stats.downloadsCompleted.Add(1)
+				p.stats.downloadNanos.Add(int64(dur))
+				p.stats.recordBlockFill(p.block.Size(), objectBlockSize)
+			}
+			p.metricHandle.BufferedReadDownloadCount(1, metrics.StatusSucceededAttr)
+			if p.circuitBreaker != nil {
+				p.circuitBreaker.recordSuccess()
+			}
 			p.block.NotifyReady(block.BlockStatus{State: block.BlockStateDownloaded})
 		} else if errors.Is(err, context.Canceled) && p.ctx.Err() == context.Canceled {
-			logger.Tracef("Download: -> block (%s, %v) cancelled: %v.", p.object.Name, blockId, err)
+			if p.keepsPartialBlock() {
+				logger.Tracef("Download: -> block (%s, %v)%s cancelled, keeping %d bytes: %v.", p.object.Name, blockId, tag, p.block.Size(), err)
+				p.block.NotifyReady(block.BlockStatus{State: block.BlockStatePartiallyDownloaded, Err: err})
+				return
+			}
+			logger.Tracef("Download: -> block (%s, %v)%s cancelled: %v.", p.object.Name, blockId, tag, err)
 			p.block.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: err})
 		} else {
-			logger.Errorf("Download: -> block (%s, %v) failed: %v.", p.object.Name, blockId, err)
+			logger.Errorf("Download: -> block (%s, %v)%s failed: %v.", p.object.Name, blockId, tag, err)
+			p.metricHandle.BufferedReadDownloadCount(1, metrics.StatusFailedAttr)
+			if p.circuitBreaker != nil && isOutageError(err) {
+				p.circuitBreaker.recordFailure()
+			}
 			p.block.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: err})
 		}
-		p.metricHandle.GcsDownloadBytesCount(n, metrics.ReadTypeBufferedAttr)
 	}()
 
 	start := uint64(startOff)
-	end := min(start+uint64(p.block.Cap()), p.object.Size)
+	if start >= p.object.Size {
+		// The object shrank since the block was scheduled, leaving it empty.
+		logger.Tracef("Download: block (%s, %v)%s starts beyond object size %d, skipping.", p.object.Name, blockId, tag, p.object.Size)
+		return
+	}
+	if p.downloadLimiter != nil {
+		var release func()
+		if release, err = p.downloadLimiter.acquire(p.ctx, p.bucket.Name(), p.object.Name); err != nil {
+			return
+		}
+		defer release()
+	}
+	// Bounded by the block capacity too, as the object may have grown past a
+	// right-sized last block since it was scheduled.
+	end := min(start+uint64(blockSize), p.object.Size, start+uint64(p.block.Cap()))
+	backoff := p.retryBackoff
+	for attempt := int64(0); ; attempt++ {
+		var copied int64
+		copied, err = p.downloadRange(start+uint64(n), end)
+		n += copied
+		// Clobbered objects and denied requests fail the same way however many
+		// times they are retried.
+		var clobberedErr *gcsfuse_errors.FileClobberedError
+		var permissionDeniedErr *gcs.PermissionDeniedError
+		if err == nil || attempt >= p.maxRetries || p.ctx.Err() != nil || errors.As(err, &clobberedErr) || errors.As(err, &permissionDeniedErr) {
+			break
+		}
+		logger.Warnf("Download: block (%s, %v)%s attempt %d failed, retrying from offset %d in %v: %v", p.object.Name, blockId, tag, attempt+1, start+uint64(n), backoff, err)
+		p.metricHandle.BufferedReadDownloadCount(1, metrics.StatusRetriedAttr)
+		if err = p.waitRetryBackoff(backoff); err != nil {
+			break
+		}
+		backoff *= 2
+	}
+	if err == nil && p.checksumManifest != nil {
+		err = p.validateChecksum(int64(start), int64(end))
+	}
+	if err == nil && p.verifiesObjectChecksum() {
+		err = p.validateObjectChecksum()
+	}
+	if err == nil && p.pinnedBlocks != nil {
+		p.pinnedBlocks.pin(p.pinKey, p.block)
+	}
+}
+
+// waitRetryBackoff waits for the given backoff before a retry, returning the
+// error of the context if the download is cancelled meanwhile.
+func (p *downloadTask) waitRetryBackoff(backoff time.Duration) error {
+	if backoff <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isOutageError returns false for the download failures which don't hint at
+// GCS being unavailable, like clobbered objects, denied requests and corrupt
+// blocks.
+func isOutageError(err error) bool {
+	var clobberedErr *gcsfuse_errors.FileClobberedError
+	var permissionDeniedErr *gcs.PermissionDeniedError
+	var corruptionErr *BlockCorruptionError
+	return !errors.As(err, &clobberedErr) && !errors.As(err, &permissionDeniedErr) && !errors.As(err, &corruptionErr)
+}
+
+// Cancel implements workerpool.CancellableTask, cancelling the download like
+// the reader does, e.g. on a seek.
+func (p *downloadTask) Cancel() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// String describes the download for the diagnostics of the worker pool.
+func (p *downloadTask) String() string {
+	return fmt.Sprintf("download of %s at offset %d%s", p.object.Name, p.block.AbsStartOff(), p.logTag())
+}
+
+// abort notifies the block that the download failed with the given error,
+// the task being dropped without being executed, e.g. as the worker pool it
+// was queued for stopped.
+func (p *downloadTask) abort(err error) {
+	logger.Tracef("Download: block of %s at offset %d%s aborted: %v.", p.object.Name, p.block.AbsStartOff(), p.logTag(), err)
+	p.block.NotifyReady(block.BlockStatus{State: block.BlockStateDownloadFailed, Err: err})
+}
+
+// keepsPartialBlock returns true if the bytes of the cancelled download are
+// kept in the block, as per the cancelled download policy. The bytes of blocks
+// validated against the checksum manifest or the object checksum are never
+// kept, as they can't be validated until the block is complete.
+func (p *downloadTask) keepsPartialBlock() bool {
+	return p.cancelPolicy == cfg.CancelledDownloadPolicyKeepPartial &&
+		p.checksumManifest == nil && !p.verifiesObjectChecksum() && p.block.Size() > 0
+}
+
+// verifiesObjectChecksum returns true if the downloaded block is validated
+// against the CRC32C of the object, i.e. if enabled for a block holding the
+// whole object, of the opened generation, whose checksum is known.
+func (p *downloadTask) verifiesObjectChecksum() bool {
+	objectSize := int64(p.object.Size)
+	return p.verifyObject && p.object.CRC32C != nil && !p.latestGeneration &&
+		p.block.AbsStartOff() == 0 && objectSize <= p.block.Cap() &&
+		(p.downloadSize == 0 || objectSize <= p.downloadSize)
+}
+
+// logTag returns the suffix tagging the logs of the download with its
+// correlation ID, if any.
+func (p *downloadTask) logTag() string {
+	if p.correlationID == "" {
+		return ""
+	}
+	return " [correlation ID: " + p.correlationID + "]"
+}
+
+// validateChecksum checks the downloaded [start, end) range of the object
+// against the checksum manifest. Ranges missing from the manifest aren't
+// validated.
+func (p *downloadTask) validateChecksum(start, end int64) error {
+	expected, ok := p.checksumManifest.lookup(p.object.Name, start, end)
+	if !ok {
+		return nil
+	}
+	data, err := p.block.ReadAtSlice(0, int(p.block.Size()))
+	if err != nil {
+		return fmt.Errorf("DownloadTask.Execute: while checksum-validation: %w", err)
+	}
+	if actual := crc32.Checksum(data, crc32cTable); actual != expected {
+		return &BlockCorruptionError{ObjectName: p.object.Name, Start: start, End: end, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// validateObjectChecksum checks the downloaded block, holding the whole
+// object, against the CRC32C of the object.
+func (p *downloadTask) validateObjectChecksum() error {
+	data, err := p.block.ReadAtSlice(0, int(p.block.Size()))
+	if err != nil {
+		return fmt.Errorf("DownloadTask.Execute: while object-checksum-validation: %w", err)
+	}
+	if actual := crc32.Checksum(data, crc32cTable); actual != *p.object.CRC32C {
+		return &BlockCorruptionError{ObjectName: p.object.Name, Start: 0, End: int64(p.object.Size), Expected: *p.object.CRC32C, Actual: actual}
+	}
+	return nil
+}
+
+// downloadRange downloads the [start, end) range of the object into the block,
+// within the download deadline if any. It returns the number of bytes written
+// to the block.
+func (p *downloadTask) downloadRange(start, end uint64) (n int64, err error) {
+	ctx := p.ctx
+	if p.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(p.ctx, p.deadline)
+		defer cancel()
+	}
+
+	// Generation zero reads the latest generation.
+	generation := p.object.Generation
+	if p.latestGeneration {
+		generation = 0
+	}
 	newReader, err := p.bucket.NewReaderWithReadHandle(
-		p.ctx,
+		ctx,
 		&gcs.ReadObjectRequest{
 			Name:       p.object.Name,
-			Generation: p.object.Generation,
+			Generation: generation,
 			Range: &gcs.ByteRange{
 				Start: start,
 				Limit: end,
@@ -93,14 +429,52 @@ func (p *downloadTask) Execute() {
 			err = &gcsfuse_errors.FileClobberedError{Err: err, ObjectName: p.object.Name}
 			return
 		}
+		var permissionDeniedErr *gcs.PermissionDeniedError
+		if errors.As(err, &permissionDeniedErr) {
+			err = fmt.Errorf("DownloadTask.Execute: reader-creation denied: %w", err)
+			return
+		}
 		err = fmt.Errorf("DownloadTask.Execute: while reader-creations: %w", err)
 		return
 	}
-	defer newReader.Close()
+	// The reader is also closed once ctx is done, so that a copy stuck on a
+	// wedged connection which doesn't observe ctx fails by the deadline too.
+	closeReader := sync.OnceFunc(func() { newReader.Close() })
+	defer closeReader()
+	stopClosing := context.AfterFunc(ctx, closeReader)
+	defer stopClosing()
+
+	if p.readHandleUpdater != nil {
+		p.readHandleUpdater(newReader.ReadHandle())
+	}
 
-	n, err = io.CopyN(p.block, newReader, int64(end-start))
+	var src io.Reader = newReader
+	if p.downloadThrottle != nil {
+		// The wait for the throttle ends once ctx is done.
+		src = ratelimit.ThrottledReader(ctx, newReader, p.downloadThrottle)
+	}
+	want := int64(end - start)
+	n, err = io.CopyN(p.block, src, want)
+	if err != nil && ctx.Err() != nil {
+		if !errors.Is(err, ctx.Err()) {
+			// The error of the reader closed as ctx is done hides the
+			// cancellation or the deadline, which decides whether the download
+			// failed.
+			err = fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+		err = fmt.Errorf("DownloadTask.Execute: while data-copy: %w", err)
+		return
+	}
+	if errors.Is(err, io.EOF) || (err == nil && n != want) {
+		// The reader ended before the end of the range. Rather than trusting
+		// io.CopyN to report it, check the copied byte count, so that a short
+		// block is never handed out as downloaded.
+		err = fmt.Errorf("DownloadTask.Execute: while data-copy: copied %d bytes out of %d: %w", n, want, io.ErrUnexpectedEOF)
+		return
+	}
 	if err != nil {
 		err = fmt.Errorf("DownloadTask.Execute: while data-copy: %w", err)
 		return
 	}
+	return
 }
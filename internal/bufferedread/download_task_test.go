@@ -18,22 +18,38 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"os"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
 	testutil "github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/googlecloudplatform/gcsfuse/v3/tracing"
+	"github.com/jacobsa/timeutil"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -111,6 +127,96 @@ func (dts *DownloadTaskTestSuite) TestExecuteSuccess() {
 	assert.NoError(dts.T(), err)
 }
 
+func (dts *DownloadTaskTestSuite) TestExecuteReadsLatestGeneration() {
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(0)
+	require.Nil(dts.T(), err)
+	task := &downloadTask{
+		ctx:              context.Background(),
+		object:           dts.object,
+		bucket:           dts.mockBucket,
+		block:            downloadBlock,
+		metricHandle:     dts.metricHandle,
+		latestGeneration: true,
+	}
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	rc := &fake.FakeReader{ReadCloser: getReadCloser(testContent)}
+	readObjectRequest := &gcs.ReadObjectRequest{
+		Name:       dts.object.Name,
+		Generation: 0,
+		Range: &gcs.ByteRange{
+			Start: uint64(0),
+			Limit: uint64(testBlockSize),
+		},
+	}
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, readObjectRequest).Return(rc, nil).Times(1)
+
+	task.Execute()
+
+	dts.mockBucket.AssertExpectations(dts.T())
+	ctx, cancelFunc := context.WithDeadline(context.Background(), time.Now().Add(1*time.Second))
+	defer cancelFunc()
+	status, err := downloadBlock.AwaitReady(ctx)
+	assert.NoError(dts.T(), err)
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, status)
+}
+
+// downloadLatencyMetricHandle records the block size buckets of the download
+// latencies.
+type downloadLatencyMetricHandle struct {
+	metrics.MetricHandle
+
+	blockSizes []metrics.BlockSize
+}
+
+func (m *downloadLatencyMetricHandle) BufferedReadDownloadBlockLatency(ctx context.Context, latency time.Duration, blockSize metrics.BlockSize) {
+	m.blockSizes = append(m.blockSizes, blockSize)
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteRecordsLatencyWithBlockSizeFromCapacity() {
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(0)
+	require.Nil(dts.T(), err)
+	metricHandle := &downloadLatencyMetricHandle{MetricHandle: dts.metricHandle}
+	task := &downloadTask{
+		ctx:          context.Background(),
+		object:       dts.object,
+		bucket:       dts.mockBucket,
+		block:        downloadBlock,
+		metricHandle: metricHandle,
+	}
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent)}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStateDownloaded, awaitBlockStatus(dts.T(), downloadBlock).State)
+	// Without a block size, the blocks are sized as per their capacity.
+	assert.Equal(dts.T(), []metrics.BlockSize{blockSizeBucket(downloadBlock.Cap())}, metricHandle.blockSizes)
+	assert.Equal(dts.T(), []metrics.BlockSize{metrics.BlockSizeSmallAttr}, metricHandle.blockSizes)
+}
+
+func TestBlockSizeBucket(t *testing.T) {
+	testCases := []struct {
+		blockSize int64
+		want      metrics.BlockSize
+	}{
+		{blockSize: testBlockSize, want: metrics.BlockSizeSmallAttr},
+		{blockSize: 4*testutil.MiB - 1, want: metrics.BlockSizeSmallAttr},
+		{blockSize: 4 * testutil.MiB, want: metrics.BlockSizeMediumAttr},
+		{blockSize: 16*testutil.MiB - 1, want: metrics.BlockSizeMediumAttr},
+		{blockSize: 16 * testutil.MiB, want: metrics.BlockSizeLargeAttr},
+		{blockSize: 64 * testutil.MiB, want: metrics.BlockSizeLargeAttr},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.blockSize), func(t *testing.T) {
+			assert.Equal(t, tc.want, blockSizeBucket(tc.blockSize))
+		})
+	}
+}
+
 func (dts *DownloadTaskTestSuite) TestExecuteError() {
 	downloadBlock, err := dts.blockPool.Get()
 	require.Nil(dts.T(), err)
@@ -145,6 +251,88 @@ func (dts *DownloadTaskTestSuite) TestExecuteError() {
 	assert.NoError(dts.T(), err)
 }
 
+func (dts *DownloadTaskTestSuite) TestExecuteTagsSpanWithCorrelationID() {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(0)
+	require.Nil(dts.T(), err)
+	task := &downloadTask{
+		ctx:           context.Background(),
+		object:        dts.object,
+		bucket:        dts.mockBucket,
+		block:         downloadBlock,
+		metricHandle:  dts.metricHandle,
+		traceHandle:   tracing.NewOTELTracer(),
+		correlationID: "epoch-3",
+	}
+	rc := &fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(rc, nil).Once()
+
+	task.Execute()
+
+	spans := recorder.Ended()
+	require.Len(dts.T(), spans, 1)
+	assert.Equal(dts.T(), tracing.DownloadPrefetchBlock, spans[0].Name())
+	assert.Contains(dts.T(), spans[0].Attributes(), attribute.String(tracing.CORRELATION_ID, "epoch-3"))
+	assert.Contains(dts.T(), spans[0].Attributes(), attribute.Int64(tracing.BYTES_DOWNLOADED, testBlockSize))
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteTagsLogsWithCorrelationID() {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(0)
+	require.Nil(dts.T(), err)
+	task := &downloadTask{
+		ctx:           context.Background(),
+		object:        dts.object,
+		bucket:        dts.mockBucket,
+		block:         downloadBlock,
+		metricHandle:  dts.metricHandle,
+		correlationID: "request-42",
+	}
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(nil, errors.New("read error")).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, awaitBlockStatus(dts.T(), downloadBlock).State)
+	assert.Contains(dts.T(), buf.String(), "[correlation ID: request-42] failed")
+}
+
+func TestCorrelationIDFromContext(t *testing.T) {
+	assert.Empty(t, CorrelationIDFromContext(context.Background()))
+	assert.Equal(t, "epoch-3", CorrelationIDFromContext(WithCorrelationID(context.Background(), "epoch-3")))
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteBlockBeyondObjectSize() {
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(int64(dts.object.Size))
+	require.Nil(dts.T(), err)
+	task := &downloadTask{
+		ctx:          context.Background(),
+		object:       dts.object,
+		bucket:       dts.mockBucket,
+		block:        downloadBlock,
+		blockSize:    testBlockSize,
+		metricHandle: dts.metricHandle,
+	}
+
+	task.Execute()
+
+	dts.mockBucket.AssertNotCalled(dts.T(), "NewReaderWithReadHandle", mock.Anything, mock.Anything)
+	ctx, cancelFunc := context.WithDeadline(context.Background(), time.Now().Add(1*time.Second))
+	defer cancelFunc()
+	status, err := downloadBlock.AwaitReady(ctx)
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, status)
+	assert.NoError(dts.T(), err)
+	assert.Zero(dts.T(), downloadBlock.Size())
+}
+
 func (dts *DownloadTaskTestSuite) TestExecuteContextDeadlineExceededByServerTreatedAsFailed() {
 	downloadBlock, err := dts.blockPool.Get()
 	require.Nil(dts.T(), err)
@@ -272,6 +460,72 @@ func (dts *DownloadTaskTestSuite) TestExecuteContextCancelledWhileReadingFromRea
 	assert.ErrorIs(dts.T(), status.Err, context.Canceled)
 }
 
+// cancellingReader cancels the download it serves on its first read, as if the
+// block was evicted midway through the copy.
+type cancellingReader struct {
+	cancel context.CancelFunc
+}
+
+func (r *cancellingReader) Read(p []byte) (n int, err error) {
+	r.cancel()
+	return 0, context.Canceled
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteContextCancelledMidCopyFollowsCancelPolicy() {
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	manifest, err := NewChecksumManifest([]ChecksumManifestEntry{{Object: dts.object.Name, Start: 0, End: testBlockSize, CRC32C: crc32.Checksum(testContent, crc32cTable)}})
+	require.NoError(dts.T(), err)
+	testCases := []struct {
+		name             string
+		cancelPolicy     string
+		checksumManifest *ChecksumManifest
+		wantState        block.BlockState
+	}{
+		{
+			name:         "discard",
+			cancelPolicy: cfg.CancelledDownloadPolicyDiscard,
+			wantState:    block.BlockStateDownloadFailed,
+		},
+		{
+			name:         "keep_partial",
+			cancelPolicy: cfg.CancelledDownloadPolicyKeepPartial,
+			wantState:    block.BlockStatePartiallyDownloaded,
+		},
+		{
+			name:             "keep_partial_with_checksum_manifest",
+			cancelPolicy:     cfg.CancelledDownloadPolicyKeepPartial,
+			checksumManifest: manifest,
+			wantState:        block.BlockStateDownloadFailed,
+		},
+	}
+	for _, tc := range testCases {
+		dts.Run(tc.name, func() {
+			dts.SetupTest()
+			task, downloadBlock := dts.newTestDownloadTask(0, 1)
+			var cancel context.CancelFunc
+			task.ctx, cancel = context.WithCancel(context.Background())
+			defer cancel()
+			task.cancelPolicy = tc.cancelPolicy
+			task.checksumManifest = tc.checksumManifest
+			// The download is cancelled after copying 200 bytes, and not retried.
+			rc := &fake.FakeReader{ReadCloser: io.NopCloser(io.MultiReader(bytes.NewReader(testContent[:200]), &cancellingReader{cancel: cancel}))}
+			dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(rc, nil).Once()
+
+			task.Execute()
+
+			status := awaitBlockStatus(dts.T(), downloadBlock)
+			assert.Equal(dts.T(), tc.wantState, status.State)
+			assert.ErrorIs(dts.T(), status.Err, context.Canceled)
+			if tc.wantState == block.BlockStatePartiallyDownloaded {
+				data, err := downloadBlock.ReadAtSlice(0, testBlockSize)
+				assert.ErrorIs(dts.T(), err, io.EOF)
+				assert.Equal(dts.T(), testContent[:200], data)
+			}
+			dts.mockBucket.AssertExpectations(dts.T())
+		})
+	}
+}
+
 func (dts *DownloadTaskTestSuite) TestExecuteClobbered() {
 	downloadBlock, err := dts.blockPool.Get()
 	require.Nil(dts.T(), err)
@@ -308,3 +562,466 @@ func (dts *DownloadTaskTestSuite) TestExecuteClobbered() {
 	var fileClobberedError *gcsfuse_errors.FileClobberedError
 	assert.True(dts.T(), errors.As(status.Err, &fileClobberedError))
 }
+
+func (dts *DownloadTaskTestSuite) TestExecuteInvokesReadHandleUpdater() {
+	downloadBlock, err := dts.blockPool.Get()
+	require.Nil(dts.T(), err)
+	err = downloadBlock.SetAbsStartOff(0)
+	require.Nil(dts.T(), err)
+	var capturedHandle []byte
+	task := &downloadTask{
+		ctx:               context.Background(),
+		object:            dts.object,
+		bucket:            dts.mockBucket,
+		block:             downloadBlock,
+		metricHandle:      dts.metricHandle,
+		readHandleUpdater: func(readHandle []byte) { capturedHandle = readHandle },
+	}
+	rc := &fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize)), Handle: []byte("opaque-handle")}
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, mock.Anything).Return(rc, nil).Times(1)
+
+	task.Execute()
+
+	assert.Equal(dts.T(), []byte("opaque-handle"), capturedHandle)
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+// newTestDownloadTask returns a task downloading the first block of the object.
+func (dts *DownloadTaskTestSuite) newTestDownloadTask(deadline time.Duration, maxRetries int64) (*downloadTask, block.PrefetchBlock) {
+	downloadBlock, err := dts.blockPool.Get()
+	require.NoError(dts.T(), err)
+	require.NoError(dts.T(), downloadBlock.SetAbsStartOff(0))
+	return &downloadTask{
+		ctx:          context.Background(),
+		object:       dts.object,
+		bucket:       dts.mockBucket,
+		block:        downloadBlock,
+		deadline:     deadline,
+		maxRetries:   maxRetries,
+		metricHandle: dts.metricHandle,
+	}, downloadBlock
+}
+
+func matchRangeStart(start uint64) any {
+	return mock.MatchedBy(func(req *gcs.ReadObjectRequest) bool { return req.Range.Start == start })
+}
+
+func awaitBlockStatus(t *testing.T, b block.PrefetchBlock) block.BlockStatus {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	status, err := b.AwaitReady(ctx)
+	require.NoError(t, err)
+	return status
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteRetriesFromLastDownloadedOffset() {
+	task, downloadBlock := dts.newTestDownloadTask(0, 1)
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	// The first attempt fails after copying 200 bytes.
+	failingReader := &fake.FakeReader{ReadCloser: io.NopCloser(io.MultiReader(bytes.NewReader(testContent[:200]), iotest.ErrReader(errors.New("connection reset"))))}
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(failingReader, nil).Once()
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(req *gcs.ReadObjectRequest) bool {
+		return req.Range.Start == 200 && req.Range.Limit == testBlockSize
+	})).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent[200:])}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+	buf := make([]byte, testBlockSize)
+	_, err := downloadBlock.ReadAt(buf, 0)
+	require.NoError(dts.T(), err)
+	assert.Equal(dts.T(), testContent, buf)
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+// flakyBucket fails its first failures reader creations, like an unavailable
+// backend does.
+type flakyBucket struct {
+	gcs.Bucket
+	failures int
+	attempts int
+}
+
+func (b *flakyBucket) NewReaderWithReadHandle(ctx context.Context, req *gcs.ReadObjectRequest) (gcs.StorageReader, error) {
+	b.attempts++
+	if b.attempts <= b.failures {
+		return nil, errors.New("503 service unavailable")
+	}
+	return b.Bucket.NewReaderWithReadHandle(ctx, req)
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteRetriesWithBackoffUntilBucketRecovers() {
+	const failures = 2
+	const retryBackoff = 5 * time.Millisecond
+	origProvider := otel.GetMeterProvider()
+	defer otel.SetMeterProvider(origProvider)
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	ctx := context.Background()
+	mh, err := metrics.NewOTelMetrics(ctx, 1, 100)
+	require.NoError(dts.T(), err)
+	testContent := testutil.GenerateRandomBytes(int(dts.object.Size))
+	bucket := &flakyBucket{Bucket: fake.NewFakeBucket(timeutil.RealClock(), "bucket", gcs.BucketType{}), failures: failures}
+	o, err := storageutil.CreateObject(ctx, bucket, dts.object.Name, testContent)
+	require.NoError(dts.T(), err)
+	task, downloadBlock := dts.newTestDownloadTask(0, failures)
+	task.object = storageutil.ConvertObjToMinObject(o)
+	task.bucket = bucket
+	task.retryBackoff = retryBackoff
+	task.metricHandle = mh
+	start := time.Now()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+	// The backoff doubles after each retry.
+	assert.GreaterOrEqual(dts.T(), time.Since(start), retryBackoff+2*retryBackoff)
+	assert.Equal(dts.T(), failures+1, bucket.attempts)
+	buf := make([]byte, testBlockSize)
+	_, err = downloadBlock.ReadAt(buf, 0)
+	require.NoError(dts.T(), err)
+	assert.Equal(dts.T(), testContent[:testBlockSize], buf)
+	metrics.VerifyCounterMetric(dts.T(), ctx, metricReader, "buffered_read/download_count", attribute.NewSet(attribute.String("status", "retried")), failures)
+	metrics.VerifyCounterMetric(dts.T(), ctx, metricReader, "buffered_read/download_count", attribute.NewSet(attribute.String("status", "succeeded")), 1)
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteFailsOnShortCopy() {
+	task, downloadBlock := dts.newTestDownloadTask(0, 1)
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	// The reader reports no error, but ends before the end of the range on every
+	// attempt.
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent[:200])}, nil).Once()
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(200)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent[200:300])}, nil).Once()
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorIs(dts.T(), status.Err, io.ErrUnexpectedEOF)
+	assert.ErrorContains(dts.T(), status.Err, fmt.Sprintf("copied 100 bytes out of %d", testBlockSize-200))
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteFailsOnceRetriesAreExhausted() {
+	task, downloadBlock := dts.newTestDownloadTask(0, 2)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(nil, errors.New("backend unavailable")).Times(3)
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorContains(dts.T(), status.Err, "backend unavailable")
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteDoesNotRetryClobberedObject() {
+	task, downloadBlock := dts.newTestDownloadTask(0, 2)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(nil, &gcs.NotFoundError{Err: errors.New("not found")}).Once()
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	var clobberedErr *gcsfuse_errors.FileClobberedError
+	assert.ErrorAs(dts.T(), status.Err, &clobberedErr)
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteDoesNotRetryPermissionDenied() {
+	task, downloadBlock := dts.newTestDownloadTask(0, 2)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(nil, &gcs.PermissionDeniedError{Err: errors.New("permission denied")}).Once()
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+	var permissionDeniedErr *gcs.PermissionDeniedError
+	assert.ErrorAs(dts.T(), status.Err, &permissionDeniedErr)
+	assert.ErrorContains(dts.T(), status.Err, "reader-creation denied")
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteDeadlineCancelsStuckAttempt() {
+	task, downloadBlock := dts.newTestDownloadTask(10*time.Millisecond, 1)
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	// The first attempt hangs until its deadline, the retry succeeds.
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil, context.DeadlineExceeded).Once()
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent)}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+// stuckReadCloser blocks reads until closed, like the body of a wedged
+// connection not observing the cancellation of its context.
+type stuckReadCloser struct {
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *stuckReadCloser) Read([]byte) (int, error) {
+	<-r.closed
+	return 0, errors.New("read on closed body")
+}
+
+func (r *stuckReadCloser) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteDeadlineFailsStuckRead() {
+	const deadline = 10 * time.Millisecond
+	task, downloadBlock := dts.newTestDownloadTask(deadline, 0)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: &stuckReadCloser{closed: make(chan struct{})}}, nil).Once()
+	start := time.Now()
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	assert.Less(dts.T(), time.Since(start), time.Second)
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorIs(dts.T(), status.Err, context.DeadlineExceeded)
+	assert.NotErrorIs(dts.T(), status.Err, context.Canceled)
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteHonorsDownloadThrottle() {
+	const taskCount = 4
+	const bytesPerSec = 10000
+	throttle, err := NewDownloadThrottle(bytesPerSec)
+	require.NoError(dts.T(), err)
+	var blocks []block.PrefetchBlock
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	for range taskCount {
+		task, downloadBlock := dts.newTestDownloadTask(0, 0)
+		task.downloadThrottle = throttle
+		blocks = append(blocks, downloadBlock)
+		dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}, nil).Once()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task.Execute()
+		}()
+	}
+	wg.Wait()
+
+	for _, b := range blocks {
+		assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), b))
+	}
+	// All but the initial burst of the throttle are downloaded at the rate.
+	minDuration := time.Duration(float64(taskCount*testBlockSize-int(throttle.Capacity())) / bytesPerSec * float64(time.Second))
+	assert.GreaterOrEqual(dts.T(), time.Since(start), minDuration)
+	dts.mockBucket.AssertExpectations(dts.T())
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteCancelledWhileWaitingForDownloadThrottle() {
+	// Downloading the block would take seconds at that rate.
+	throttle, err := NewDownloadThrottle(100)
+	require.NoError(dts.T(), err)
+	ctx, cancel := context.WithCancel(context.Background())
+	task, downloadBlock := dts.newTestDownloadTask(0, 0)
+	task.ctx = ctx
+	task.downloadThrottle = throttle
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}, nil).Once()
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+
+	task.Execute()
+
+	status := awaitBlockStatus(dts.T(), downloadBlock)
+	assert.Less(dts.T(), time.Since(start), time.Second)
+	assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+	assert.ErrorIs(dts.T(), status.Err, context.Canceled)
+}
+
+func TestNewDownloadThrottleFailsOnTooLowRate(t *testing.T) {
+	_, err := NewDownloadThrottle(10)
+
+	assert.Error(t, err)
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteValidatesBlockAgainstChecksumManifest() {
+	testContent := testutil.GenerateRandomBytes(testBlockSize)
+	corrupted := bytes.Clone(testContent)
+	corrupted[100] ^= 0xff
+	expected := crc32.Checksum(testContent, crc32cTable)
+	manifest, err := NewChecksumManifest([]ChecksumManifestEntry{{Object: dts.object.Name, Start: 0, End: testBlockSize, CRC32C: expected}})
+	require.NoError(dts.T(), err)
+	testCases := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{name: "matching_block", content: testContent},
+		{name: "corrupted_block", content: corrupted, wantErr: true},
+	}
+	for _, tc := range testCases {
+		dts.Run(tc.name, func() {
+			dts.SetupTest()
+			task, downloadBlock := dts.newTestDownloadTask(0, 0)
+			task.checksumManifest = manifest
+			dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(tc.content)}, nil).Once()
+
+			task.Execute()
+
+			status := awaitBlockStatus(dts.T(), downloadBlock)
+			if !tc.wantErr {
+				assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, status)
+				return
+			}
+			assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+			var corruptionErr *BlockCorruptionError
+			require.ErrorAs(dts.T(), status.Err, &corruptionErr)
+			assert.Equal(dts.T(), &BlockCorruptionError{ObjectName: dts.object.Name, Start: 0, End: testBlockSize, Expected: expected, Actual: crc32.Checksum(corrupted, crc32cTable)}, corruptionErr)
+		})
+	}
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteSkipsValidationOfBlockMissingFromChecksumManifest() {
+	manifest, err := NewChecksumManifest([]ChecksumManifestEntry{{Object: "other-object", Start: 0, End: testBlockSize, CRC32C: 1}})
+	require.NoError(dts.T(), err)
+	task, downloadBlock := dts.newTestDownloadTask(0, 0)
+	task.checksumManifest = manifest
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteValidatesBlockAgainstObjectChecksum() {
+	const objectSize = 300
+	testContent := testutil.GenerateRandomBytes(objectSize)
+	corrupted := bytes.Clone(testContent)
+	corrupted[100] ^= 0xff
+	expected := crc32.Checksum(testContent, crc32cTable)
+	testCases := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{name: "matching_block", content: testContent},
+		{name: "corrupted_block", content: corrupted, wantErr: true},
+	}
+	for _, tc := range testCases {
+		dts.Run(tc.name, func() {
+			dts.SetupTest()
+			dts.object.Size = objectSize
+			dts.object.CRC32C = &expected
+			task, downloadBlock := dts.newTestDownloadTask(0, 0)
+			task.verifyObject = true
+			dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(tc.content)}, nil).Once()
+
+			task.Execute()
+
+			status := awaitBlockStatus(dts.T(), downloadBlock)
+			if !tc.wantErr {
+				assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, status)
+				return
+			}
+			assert.Equal(dts.T(), block.BlockStateDownloadFailed, status.State)
+			var corruptionErr *BlockCorruptionError
+			require.ErrorAs(dts.T(), status.Err, &corruptionErr)
+			assert.Equal(dts.T(), &BlockCorruptionError{ObjectName: dts.object.Name, Start: 0, End: objectSize, Expected: expected, Actual: crc32.Checksum(corrupted, crc32cTable)}, corruptionErr)
+		})
+	}
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteSkipsObjectChecksumValidationOfBlockOfLargerObject() {
+	// The checksum of the whole object can't validate one of its blocks.
+	checksum := uint32(1)
+	dts.object.CRC32C = &checksum
+	task, downloadBlock := dts.newTestDownloadTask(0, 0)
+	task.verifyObject = true
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteWithProfileDefaults() {
+	testCases := []struct {
+		name           string
+		profile        string
+		wantDeadline   time.Duration
+		wantMaxRetries int64
+	}{
+		{name: "no_profile", profile: "", wantDeadline: 0, wantMaxRetries: 0},
+		{name: "aiml_serving", profile: cfg.ProfileAIMLServing, wantDeadline: 10 * time.Second, wantMaxRetries: 1},
+		{name: "aiml_checkpointing", profile: cfg.ProfileAIMLCheckpointing, wantDeadline: 10 * time.Minute, wantMaxRetries: 5},
+	}
+	for _, tc := range testCases {
+		dts.Run(tc.name, func() {
+			dts.SetupTest()
+			c := cfg.Config{Profile: tc.profile}
+			c.ApplyOptimizations(viper.New(), nil)
+			deadline := time.Duration(c.Read.DownloadDeadlineSecs) * time.Second
+			require.Equal(dts.T(), tc.wantDeadline, deadline)
+			require.Equal(dts.T(), tc.wantMaxRetries, c.Read.DownloadMaxRetries)
+			// A download failing as many times as the profile retries succeeds, one
+			// more failure fails it.
+			for _, failures := range []int64{c.Read.DownloadMaxRetries, c.Read.DownloadMaxRetries + 1} {
+				dts.mockBucket = new(storage.TestifyMockBucket)
+				task, downloadBlock := dts.newTestDownloadTask(deadline, c.Read.DownloadMaxRetries)
+				if failures > 0 {
+					dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(nil, errors.New("transient error")).Times(int(failures))
+				}
+				dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, matchRangeStart(0)).Return(&fake.FakeReader{ReadCloser: getReadCloser(testutil.GenerateRandomBytes(testBlockSize))}, nil).Maybe()
+
+				task.Execute()
+
+				wantState := block.BlockStateDownloaded
+				if failures > c.Read.DownloadMaxRetries {
+					wantState = block.BlockStateDownloadFailed
+				}
+				assert.Equal(dts.T(), wantState, awaitBlockStatus(dts.T(), downloadBlock).State, "failures: %d", failures)
+				dts.mockBucket.AssertNumberOfCalls(dts.T(), "NewReaderWithReadHandle", int(min(failures+1, c.Read.DownloadMaxRetries+1)))
+			}
+		})
+	}
+}
+
+func (dts *DownloadTaskTestSuite) TestExecuteDownloadsRightSizedLastBlock() {
+	const lastBlockSize = 100
+	dts.object.Size = 2*testBlockSize + lastBlockSize
+	downloadBlock, err := dts.blockPool.TryGetSized(lastBlockSize)
+	require.NoError(dts.T(), err)
+	require.Equal(dts.T(), int64(lastBlockSize), downloadBlock.Cap())
+	require.NoError(dts.T(), downloadBlock.SetAbsStartOff(2*testBlockSize))
+	task := &downloadTask{
+		ctx:          context.Background(),
+		object:       dts.object,
+		bucket:       dts.mockBucket,
+		block:        downloadBlock,
+		blockSize:    testBlockSize,
+		metricHandle: dts.metricHandle,
+	}
+	testContent := testutil.GenerateRandomBytes(lastBlockSize)
+	dts.mockBucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(req *gcs.ReadObjectRequest) bool {
+		return req.Range.Start == 2*testBlockSize && req.Range.Limit == 2*testBlockSize+lastBlockSize
+	})).Return(&fake.FakeReader{ReadCloser: getReadCloser(testContent)}, nil).Once()
+
+	task.Execute()
+
+	assert.Equal(dts.T(), block.BlockStatus{State: block.BlockStateDownloaded}, awaitBlockStatus(dts.T(), downloadBlock))
+	assert.Equal(dts.T(), int64(lastBlockSize), downloadBlock.Size())
+	assert.Equal(dts.T(), int64(2*testBlockSize), downloadBlock.AbsStartOff())
+	buf := make([]byte, lastBlockSize)
+	_, err = downloadBlock.ReadAt(buf, 0)
+	require.NoError(dts.T(), err)
+	assert.Equal(dts.T(), testContent, buf)
+	dts.mockBucket.AssertExpectations(dts.T())
+	// Once released, the block isn't reused for a full block.
+	dts.blockPool.Release(downloadBlock)
+	assert.Equal(dts.T(), 0, dts.blockPool.TotalFreeBlocks())
+	fullBlock, err := dts.blockPool.TryGet()
+	require.NoError(dts.T(), err)
+	assert.Equal(dts.T(), int64(testBlockSize), fullBlock.Cap())
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
+)
+
+// downloadThrottleWindow is the window over which the download bandwidth
+// limit holds within a few percent, bounding the bursts beyond it.
+const downloadThrottleWindow = time.Second
+
+// NewDownloadThrottle returns a throttle limiting the aggregate bandwidth of
+// the block downloads sharing it to bytesPerSec, e.g. so that the prefetches
+// of all the readers of a mount don't saturate the network of the machine.
+// Fails if the rate can't be enforced over downloadThrottleWindow, i.e. if it
+// is below 50 bytes per second.
+func NewDownloadThrottle(bytesPerSec float64) (ratelimit.Throttle, error) {
+	capacity, err := ratelimit.ChooseLimiterCapacity(bytesPerSec, downloadThrottleWindow)
+	if err != nil {
+		return nil, fmt.Errorf("NewDownloadThrottle: %w", err)
+	}
+	return ratelimit.NewThrottle(bytesPerSec, capacity), nil
+}
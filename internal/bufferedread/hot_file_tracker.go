@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jacobsa/timeutil"
+)
+
+// hotFileSweepThreshold is the number of tracked objects beyond which the
+// objects not opened within the window are dropped on the next open.
+const hotFileSweepThreshold = 1024
+
+// HotFileTracker classifies the objects opened for buffered reads at least
+// minOpens times within the window as hot. The readers of hot objects keep a
+// floor of blocks prefetched, see BufferedReadConfig.HotFileResidentBlocks.
+type HotFileTracker struct {
+	minOpens int64
+	window   time.Duration
+	clock    timeutil.Clock
+
+	mu sync.Mutex
+
+	// opens holds the times of the most recent opens of each object, oldest
+	// first, at most minOpens of them.
+	// GUARDED by (mu)
+	opens map[downloadLimitKey][]time.Time
+}
+
+// NewHotFileTracker returns a HotFileTracker classifying the objects opened at
+// least minOpens times within the window as hot.
+func NewHotFileTracker(minOpens int64, window time.Duration, clock timeutil.Clock) *HotFileTracker {
+	return &HotFileTracker{
+		minOpens: max(minOpens, 1),
+		window:   window,
+		clock:    clock,
+		opens:    make(map[downloadLimitKey][]time.Time),
+	}
+}
+
+// RecordOpen records an open of the given object and returns true if the
+// object is hot, counting this open.
+func (t *HotFileTracker) RecordOpen(bucketName, objectName string) bool {
+	key := downloadLimitKey{bucketName: bucketName, objectName: objectName}
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.opens) >= hotFileSweepThreshold {
+		t.sweep(now)
+	}
+	opens := append(t.opens[key], now)
+	if int64(len(opens)) > t.minOpens {
+		opens = opens[1:]
+	}
+	t.opens[key] = opens
+	return int64(len(opens)) == t.minOpens && now.Sub(opens[0]) <= t.window
+}
+
+// sweep drops the objects not opened within the window.
+// LOCKS_REQUIRED(t.mu)
+func (t *HotFileTracker) sweep(now time.Time) {
+	for key, opens := range t.opens {
+		if now.Sub(opens[len(opens)-1]) > t.window {
+			delete(t.opens, key)
+		}
+	}
+}
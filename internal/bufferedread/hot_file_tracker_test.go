@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotFileTrackerClassifiesFrequentlyOpenedObjectsAsHot(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	tracker := NewHotFileTracker(3, time.Minute, &clock)
+
+	assert.False(t, tracker.RecordOpen("bucket", "a"))
+	clock.AdvanceTime(20 * time.Second)
+	assert.False(t, tracker.RecordOpen("bucket", "a"))
+	// Other objects are counted on their own.
+	assert.False(t, tracker.RecordOpen("bucket", "b"))
+	clock.AdvanceTime(20 * time.Second)
+	assert.True(t, tracker.RecordOpen("bucket", "a"))
+	assert.True(t, tracker.RecordOpen("bucket", "a"))
+}
+
+func TestHotFileTrackerOnlyCountsOpensWithinWindow(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	tracker := NewHotFileTracker(2, time.Minute, &clock)
+	assert.False(t, tracker.RecordOpen("bucket", "a"))
+	clock.AdvanceTime(2 * time.Minute)
+
+	assert.False(t, tracker.RecordOpen("bucket", "a"))
+	clock.AdvanceTime(time.Second)
+	assert.True(t, tracker.RecordOpen("bucket", "a"))
+}
+
+func TestHotFileTrackerDropsObjectsNotOpenedWithinWindow(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	tracker := NewHotFileTracker(2, time.Minute, &clock)
+	for i := range hotFileSweepThreshold {
+		tracker.RecordOpen("bucket", fmt.Sprintf("object-%d", i))
+	}
+	clock.AdvanceTime(2 * time.Minute)
+
+	tracker.RecordOpen("bucket", "a")
+
+	assert.Len(t, tracker.opens, 1)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+)
+
+// abortableTask is a task which can be told that it won't be executed, e.g.
+// a download task notifying its block of the failure.
+type abortableTask interface {
+	abort(err error)
+}
+
+// inflightDownloadLimiter is a worker pool bounding the number of tasks
+// scheduled on the underlying pool and not yet completed, i.e. the block
+// downloads in flight across the mount. Unlike the number of workers, which
+// bounds the downloads running, the limit bounds those scheduled, so that the
+// connections and memory of the downloads stay bounded however many files
+// are open. Tasks beyond the limit are queued until a task completes, the
+// urgent ones ahead of the others. The queued tasks are scheduled on the pool
+// by a dispatcher goroutine rather than by the workers completing tasks, as
+// scheduling blocks while the queue of the pool is full, which only the
+// workers can drain.
+type inflightDownloadLimiter struct {
+	pool         workerpool.WorkerPool
+	limit        int64
+	metricHandle metrics.MetricHandle
+
+	mu sync.Mutex
+
+	// inflight is the number of tasks scheduled on the pool and not yet
+	// completed.
+	// GUARDED by (mu)
+	inflight int64
+
+	// urgent and prefetch are the tasks queued, in the order they were
+	// scheduled.
+	// GUARDED by (mu)
+	urgent   []workerpool.Task
+	prefetch []workerpool.Task
+
+	// stopped is set once the limiter is stopped.
+	// GUARDED by (mu)
+	stopped bool
+
+	// released wakes up the dispatcher once a task completes while tasks are
+	// queued.
+	released chan struct{}
+
+	// stopDispatching is closed to stop the dispatcher.
+	stopDispatching chan struct{}
+}
+
+// NewInflightDownloadLimiter returns a worker pool scheduling tasks on the
+// given pool, with at most limit of them in flight at once.
+func NewInflightDownloadLimiter(pool workerpool.WorkerPool, limit int64, metricHandle metrics.MetricHandle) workerpool.WorkerPool {
+	l := &inflightDownloadLimiter{
+		pool:            pool,
+		limit:           limit,
+		metricHandle:    metricHandle,
+		released:        make(chan struct{}, 1),
+		stopDispatching: make(chan struct{}),
+	}
+	go l.dispatch()
+	return l
+}
+
+func (l *inflightDownloadLimiter) Start() {
+	l.pool.Start()
+}
+
+// Stop aborts the queued tasks, which won't be executed, and stops the
+// dispatcher and the underlying pool.
+func (l *inflightDownloadLimiter) Stop() {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return
+	}
+	l.stopped = true
+	queued := append(l.urgent, l.prefetch...)
+	l.urgent, l.prefetch = nil, nil
+	l.mu.Unlock()
+
+	close(l.stopDispatching)
+
+	l.metricHandle.BufferedReadDownloadsQueuedForInflightLimit(-int64(len(queued)))
+	for _, task := range queued {
+		if t, ok := task.(abortableTask); ok {
+			t.abort(workerpool.ErrPoolStopped)
+		}
+	}
+	l.pool.Stop()
+}
+
+func (l *inflightDownloadLimiter) Schedule(urgent bool, task workerpool.Task) error {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return workerpool.ErrPoolStopped
+	}
+	// Tasks are queued behind those waiting for the dispatcher, even with a
+	// slot free.
+	if l.inflight >= l.limit || len(l.urgent)+len(l.prefetch) > 0 {
+		if urgent {
+			l.urgent = append(l.urgent, task)
+		} else {
+			l.prefetch = append(l.prefetch, task)
+		}
+		l.mu.Unlock()
+		l.metricHandle.BufferedReadDownloadsQueuedForInflightLimit(1)
+		return nil
+	}
+	l.inflight++
+	l.mu.Unlock()
+
+	if err := l.pool.Schedule(urgent, &inflightTask{Task: task, limiter: l}); err != nil {
+		l.mu.Lock()
+		l.inflight--
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Stats returns the utilization of the underlying pool, if it reports it,
+// with the tasks queued for the limit counted as queued.
+// LOCKS_EXCLUDED(l.mu)
+func (l *inflightDownloadLimiter) Stats() workerpool.Stats {
+	var stats workerpool.Stats
+	if r, ok := l.pool.(workerpool.StatsReporter); ok {
+		stats = r.Stats()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats.Queued += len(l.urgent) + len(l.prefetch)
+	return stats
+}
+
+// done is called once an in-flight task completes, releasing its slot. It
+// doesn't block, the dispatcher scheduling the next queued task in its place.
+func (l *inflightDownloadLimiter) done() {
+	l.mu.Lock()
+	l.inflight--
+	queued := len(l.urgent)+len(l.prefetch) > 0
+	l.mu.Unlock()
+
+	if queued {
+		// A wake-up already pending covers this slot too.
+		select {
+		case l.released <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// dispatch schedules the queued tasks on the pool as slots are released,
+// until the limiter is stopped.
+func (l *inflightDownloadLimiter) dispatch() {
+	for {
+		select {
+		case <-l.stopDispatching:
+			return
+		case <-l.released:
+		}
+		for l.scheduleNextQueued() {
+		}
+	}
+}
+
+// scheduleNextQueued schedules the next queued task on the pool if a slot is
+// free, returning false if there is no slot free or no task queued.
+func (l *inflightDownloadLimiter) scheduleNextQueued() bool {
+	l.mu.Lock()
+	if l.stopped || l.inflight >= l.limit {
+		l.mu.Unlock()
+		return false
+	}
+	var next workerpool.Task
+	urgent := len(l.urgent) > 0
+	switch {
+	case urgent:
+		next, l.urgent = l.urgent[0], l.urgent[1:]
+	case len(l.prefetch) > 0:
+		next, l.prefetch = l.prefetch[0], l.prefetch[1:]
+	default:
+		l.mu.Unlock()
+		return false
+	}
+	l.inflight++
+	l.mu.Unlock()
+
+	l.metricHandle.BufferedReadDownloadsQueuedForInflightLimit(-1)
+	if err := l.pool.Schedule(urgent, &inflightTask{Task: next, limiter: l}); err != nil {
+		if t, ok := next.(abortableTask); ok {
+			t.abort(err)
+		}
+		l.mu.Lock()
+		l.inflight--
+		l.mu.Unlock()
+	}
+	return true
+}
+
+// inflightTask is a task counted as in flight by its limiter until it
+// completes.
+type inflightTask struct {
+	workerpool.Task
+	limiter *inflightDownloadLimiter
+}
+
+func (t *inflightTask) Execute() {
+	defer t.limiter.done()
+	t.Task.Execute()
+}
+
+// Cancel cancels the task, if cancellable, for the watchdog of the underlying
+// pool.
+func (t *inflightTask) Cancel() {
+	if c, ok := t.Task.(workerpool.CancellableTask); ok {
+		c.Cancel()
+	}
+}
+
+func (t *inflightTask) String() string {
+	return workerpool.DescribeTask(t.Task)
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualWorkerPool holds the tasks scheduled until they are run by the test.
+type manualWorkerPool struct {
+	mu        sync.Mutex
+	scheduled []workerpool.Task
+	stopped   bool
+}
+
+func (p *manualWorkerPool) Start() {}
+
+func (p *manualWorkerPool) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+}
+
+func (p *manualWorkerPool) Schedule(urgent bool, task workerpool.Task) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return workerpool.ErrPoolStopped
+	}
+	p.scheduled = append(p.scheduled, task)
+	return nil
+}
+
+func (p *manualWorkerPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.scheduled)
+}
+
+// next removes the oldest task scheduled, waiting for the dispatcher of the
+// limiter to schedule one if needed.
+func (p *manualWorkerPool) next(t *testing.T) workerpool.Task {
+	t.Helper()
+	require.Eventually(t, func() bool { return p.len() > 0 }, time.Second, time.Millisecond)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	task := p.scheduled[0]
+	p.scheduled = p.scheduled[1:]
+	return task
+}
+
+// runNext executes the oldest task scheduled.
+func (p *manualWorkerPool) runNext(t *testing.T) {
+	t.Helper()
+	p.next(t).Execute()
+}
+
+// fullWorkerPool is a manualWorkerPool with room for a single task, scheduling
+// further tasks blocking until room is made.
+type fullWorkerPool struct {
+	manualWorkerPool
+	full atomic.Bool
+	room chan struct{}
+}
+
+func (p *fullWorkerPool) Schedule(urgent bool, task workerpool.Task) error {
+	if p.full.Swap(true) {
+		<-p.room
+	}
+	return p.manualWorkerPool.Schedule(urgent, task)
+}
+
+type queuedDownloadsMetricHandle struct {
+	metrics.MetricHandle
+
+	queued atomic.Int64
+}
+
+func (m *queuedDownloadsMetricHandle) BufferedReadDownloadsQueuedForInflightLimit(inc int64) {
+	m.queued.Add(inc)
+}
+
+func inflight(l workerpool.WorkerPool) int64 {
+	limiter := l.(*inflightDownloadLimiter)
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.inflight
+}
+
+// namedTask records its name once executed, or the error it is aborted with.
+type namedTask struct {
+	name     string
+	executed *[]string
+	abortErr error
+}
+
+func (t *namedTask) Execute() {
+	*t.executed = append(*t.executed, t.name)
+}
+
+func (t *namedTask) abort(err error) {
+	t.abortErr = err
+}
+
+func TestInflightDownloadLimiterQueuesTasksBeyondLimit(t *testing.T) {
+	pool := &manualWorkerPool{}
+	metricHandle := &queuedDownloadsMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	limiter := NewInflightDownloadLimiter(pool, 2, metricHandle)
+	var executed []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, limiter.Schedule(false, &namedTask{name: name, executed: &executed}))
+	}
+	// Urgent tasks are queued ahead of the others.
+	require.NoError(t, limiter.Schedule(true, &namedTask{name: "urgent", executed: &executed}))
+
+	assert.Equal(t, 2, pool.len())
+	assert.Equal(t, int64(3), metricHandle.queued.Load())
+
+	for range 5 {
+		pool.runNext(t)
+		assert.LessOrEqual(t, pool.len(), 2)
+	}
+
+	assert.Equal(t, []string{"a", "b", "urgent", "c", "d"}, executed)
+	assert.Zero(t, metricHandle.queued.Load())
+	assert.Zero(t, inflight(limiter))
+}
+
+func TestInflightDownloadLimiterCompletesTaskWithPoolFull(t *testing.T) {
+	pool := &fullWorkerPool{room: make(chan struct{})}
+	metricHandle := &queuedDownloadsMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	limiter := NewInflightDownloadLimiter(pool, 1, metricHandle)
+	var executed []string
+	require.NoError(t, limiter.Schedule(false, &namedTask{name: "a", executed: &executed}))
+	require.NoError(t, limiter.Schedule(false, &namedTask{name: "b", executed: &executed}))
+	task := pool.next(t)
+	completed := make(chan struct{})
+
+	// The worker completing the task doesn't wait for the pool to have room
+	// for the queued task.
+	go func() {
+		task.Execute()
+		close(completed)
+	}()
+
+	select {
+	case <-completed:
+	case <-time.After(time.Second):
+		t.Fatal("The task didn't complete while the pool was full.")
+	}
+	close(pool.room)
+	pool.runNext(t)
+	assert.Equal(t, []string{"a", "b"}, executed)
+	assert.Zero(t, metricHandle.queued.Load())
+}
+
+func TestInflightDownloadLimiterStopAbortsQueuedTasks(t *testing.T) {
+	pool := &manualWorkerPool{}
+	metricHandle := &queuedDownloadsMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	limiter := NewInflightDownloadLimiter(pool, 1, metricHandle)
+	var executed []string
+	running := &namedTask{name: "running", executed: &executed}
+	queued := &namedTask{name: "queued", executed: &executed}
+	require.NoError(t, limiter.Schedule(false, running))
+	require.NoError(t, limiter.Schedule(true, queued))
+
+	limiter.Stop()
+
+	assert.ErrorIs(t, queued.abortErr, workerpool.ErrPoolStopped)
+	assert.NoError(t, running.abortErr)
+	assert.Zero(t, metricHandle.queued.Load())
+	assert.ErrorIs(t, limiter.Schedule(true, &namedTask{name: "late", executed: &executed}), workerpool.ErrPoolStopped)
+}
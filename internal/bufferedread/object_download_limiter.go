@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"golang.org/x/sync/semaphore"
+)
+
+// downloadLimitKey identifies an object, across its generations.
+type downloadLimitKey struct {
+	bucketName string
+	objectName string
+}
+
+// objectDownloadSlots holds the download slots of an object, along with the
+// number of downloads holding or waiting for one.
+type objectDownloadSlots struct {
+	sem  *semaphore.Weighted
+	refs int64
+}
+
+// ObjectDownloadLimiter bounds the number of block downloads of any single
+// object in flight at once, across all the readers of the object, so that a
+// hot object read from many file handles can't monopolize the download
+// workers or hammer GCS. Downloads beyond the limit wait for a slot.
+type ObjectDownloadLimiter struct {
+	limit        int64
+	metricHandle metrics.MetricHandle
+
+	mu sync.Mutex
+
+	// slots holds the download slots of the objects being downloaded. Entries
+	// are removed once no download holds or waits for a slot.
+	// GUARDED by (mu)
+	slots map[downloadLimitKey]*objectDownloadSlots
+}
+
+// NewObjectDownloadLimiter returns an ObjectDownloadLimiter allowing at most
+// limit concurrent block downloads per object.
+func NewObjectDownloadLimiter(limit int64, metricHandle metrics.MetricHandle) *ObjectDownloadLimiter {
+	return &ObjectDownloadLimiter{
+		limit:        limit,
+		metricHandle: metricHandle,
+		slots:        make(map[downloadLimitKey]*objectDownloadSlots),
+	}
+}
+
+// acquire waits for a download slot of the given object, or for ctx to be
+// done. On success, the returned function releases the slot.
+func (l *ObjectDownloadLimiter) acquire(ctx context.Context, bucketName, objectName string) (func(), error) {
+	key := downloadLimitKey{bucketName: bucketName, objectName: objectName}
+	l.mu.Lock()
+	slots, ok := l.slots[key]
+	if !ok {
+		slots = &objectDownloadSlots{sem: semaphore.NewWeighted(l.limit)}
+		l.slots[key] = slots
+	}
+	slots.refs++
+	l.mu.Unlock()
+
+	if !slots.sem.TryAcquire(1) {
+		l.metricHandle.BufferedReadDownloadsWaitingForObjectLimit(1)
+		err := slots.sem.Acquire(ctx, 1)
+		l.metricHandle.BufferedReadDownloadsWaitingForObjectLimit(-1)
+		if err != nil {
+			l.unref(key, slots)
+			return nil, err
+		}
+	}
+	return func() {
+		slots.sem.Release(1)
+		l.unref(key, slots)
+	}, nil
+}
+
+// unref drops a reference to the download slots of an object, removing them
+// once unreferenced.
+func (l *ObjectDownloadLimiter) unref(key downloadLimitKey, slots *objectDownloadSlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots.refs--
+	if slots.refs == 0 {
+		delete(l.slots, key)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+type waitingDownloadsMetricHandle struct {
+	metrics.MetricHandle
+
+	mu         sync.Mutex
+	waiting    int64
+	maxWaiting int64
+}
+
+func (m *waitingDownloadsMetricHandle) BufferedReadDownloadsWaitingForObjectLimit(inc int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.waiting += inc
+	m.maxWaiting = max(m.maxWaiting, m.waiting)
+}
+
+func TestObjectDownloadLimiterReleasesUnusedSlots(t *testing.T) {
+	limiter := NewObjectDownloadLimiter(1, metrics.NewNoopMetrics())
+	release, err := limiter.acquire(context.Background(), "bucket", "a")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = limiter.acquire(ctx, "bucket", "a")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	// Other objects have slots of their own.
+	releaseOther, err := limiter.acquire(context.Background(), "bucket", "b")
+	require.NoError(t, err)
+	releaseOther()
+	release()
+	assert.Empty(t, limiter.slots)
+}
+
+func (t *BufferedReaderTest) TestObjectDownloadLimiterBoundsConcurrentDownloadsOfObject() {
+	const blockCount = 4
+	const readerCount = 4
+	const limit = 2
+	t.object.Size = uint64(blockCount * testPrefetchBlockSizeBytes)
+	t.bucket.On("Name").Return("test-bucket")
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	// Every reader downloads every block.
+	for range readerCount {
+		for i := range int64(blockCount) {
+			t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+				return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+			})).Run(func(mock.Arguments) {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+		}
+	}
+	limiterMetrics := &waitingDownloadsMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	limiter := NewObjectDownloadLimiter(limit, limiterMetrics)
+	globalMaxBlocksSem := semaphore.NewWeighted(readerCount * blockCount)
+	var wg sync.WaitGroup
+
+	for range readerCount {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reader, err := NewBufferedReader(&BufferedReaderOptions{
+				Object:                t.object,
+				Bucket:                t.bucket,
+				Config:                t.config,
+				GlobalMaxBlocksSem:    globalMaxBlocksSem,
+				WorkerPool:            t.workerPool,
+				MetricHandle:          t.metricHandle,
+				ReadTypeClassifier:    gcsx.NewReadTypeClassifier(1, 0),
+				ObjectDownloadLimiter: limiter,
+			})
+			if !assert.NoError(t.T(), err) {
+				return
+			}
+			defer reader.Destroy()
+
+			resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+
+			if assert.NoError(t.T(), err) {
+				assertReadResponseContent(t.T(), resp, 0)
+				resp.Callback()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t.T(), maxInFlight, limit)
+	assert.Positive(t.T(), limiterMetrics.maxWaiting)
+	assert.Zero(t.T(), limiterMetrics.waiting)
+	assert.Empty(t.T(), limiter.slots)
+	t.bucket.AssertExpectations(t.T())
+}
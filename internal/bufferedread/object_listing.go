@@ -0,0 +1,185 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+)
+
+// objectStats counts the prefetch effectiveness of the reads of a single
+// reader, for the listing of the open objects.
+type objectStats struct {
+	// blocksRead is the number of blocks read from at least once, out of which
+	// blocksHit had been prefetched ahead of the read needing them.
+	blocksRead atomic.Int64
+	blocksHit  atomic.Int64
+
+	// blocksScheduled is the number of blocks scheduled for download, out of
+	// which blocksWasted were evicted without ever being read.
+	blocksScheduled atomic.Int64
+	blocksWasted    atomic.Int64
+}
+
+// openObjectKey identifies an object across buckets.
+type openObjectKey struct {
+	bucketName string
+	objectName string
+}
+
+// openObject describes the object read by a registered reader, as it was
+// when the reader was created.
+type openObject struct {
+	key            openObjectKey
+	blockSizeBytes int64
+	blockCount     int64
+}
+
+// OpenObjectRegistry tracks the open buffered readers, to list the prefetch
+// effectiveness of each object being read, e.g. to find out which objects the
+// current tuning serves poorly.
+type OpenObjectRegistry struct {
+	mu sync.Mutex
+
+	// GUARDED by (mu)
+	readers map[*BufferedReader]openObject
+}
+
+// NewOpenObjectRegistry returns a registry with no open reader.
+func NewOpenObjectRegistry() *OpenObjectRegistry {
+	return &OpenObjectRegistry{readers: make(map[*BufferedReader]openObject)}
+}
+
+// register adds a reader to the open readers.
+// LOCKS_EXCLUDED(r.mu)
+func (r *OpenObjectRegistry) register(p *BufferedReader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.readers[p] = openObject{
+		key:            openObjectKey{bucketName: p.bucket.Name(), objectName: p.object.Name},
+		blockSizeBytes: p.blockSize,
+		blockCount:     p.totalBlockCount(),
+	}
+}
+
+// unregister removes a destroyed reader from the open readers.
+// LOCKS_EXCLUDED(r.mu)
+func (r *OpenObjectRegistry) unregister(p *BufferedReader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.readers, p)
+}
+
+// ObjectPrefetchStats is the prefetch effectiveness of the open readers of an
+// object.
+type ObjectPrefetchStats struct {
+	BucketName string
+	ObjectName string
+	// Readers is the number of open readers of the object, whose counters are
+	// summed up.
+	Readers int
+	// BlockSizeBytes is the size of the blocks the object is split into, and
+	// BlockCount the number of blocks spanning the object when opened.
+	BlockSizeBytes  int64
+	BlockCount      int64
+	BlocksRead      int64
+	BlocksHit       int64
+	BlocksScheduled int64
+	BlocksWasted    int64
+}
+
+// HitRate returns the percentage of the blocks read which had been prefetched
+// ahead of the read needing them.
+func (s ObjectPrefetchStats) HitRate() float64 {
+	return percentage(s.BlocksHit, s.BlocksRead)
+}
+
+// WasteRate returns the percentage of the blocks scheduled which were evicted
+// without ever being read.
+func (s ObjectPrefetchStats) WasteRate() float64 {
+	return percentage(s.BlocksWasted, s.BlocksScheduled)
+}
+
+// Listing returns the prefetch effectiveness of each open object, sorted by
+// bucket and object name. It only loads counters, so that it is cheap enough
+// to be computed on demand, and never blocks the reads.
+// LOCKS_EXCLUDED(r.mu)
+func (r *OpenObjectRegistry) Listing() []ObjectPrefetchStats {
+	r.mu.Lock()
+	byObject := make(map[openObjectKey]*ObjectPrefetchStats, len(r.readers))
+	for p, o := range r.readers {
+		s, ok := byObject[o.key]
+		if !ok {
+			s = &ObjectPrefetchStats{
+				BucketName:     o.key.bucketName,
+				ObjectName:     o.key.objectName,
+				BlockSizeBytes: o.blockSizeBytes,
+				BlockCount:     o.blockCount,
+			}
+			byObject[o.key] = s
+		}
+		s.Readers++
+		s.BlocksRead += p.objectStats.blocksRead.Load()
+		s.BlocksHit += p.objectStats.blocksHit.Load()
+		s.BlocksScheduled += p.objectStats.blocksScheduled.Load()
+		s.BlocksWasted += p.objectStats.blocksWasted.Load()
+	}
+	r.mu.Unlock()
+
+	listing := make([]ObjectPrefetchStats, 0, len(byObject))
+	for _, s := range byObject {
+		listing = append(listing, *s)
+	}
+	slices.SortFunc(listing, func(a, b ObjectPrefetchStats) int {
+		return cmp.Or(cmp.Compare(a.BucketName, b.BucketName), cmp.Compare(a.ObjectName, b.ObjectName))
+	})
+	return listing
+}
+
+// WriteObjectPrefetchListing writes the listing as a table, one object per
+// line.
+func WriteObjectPrefetchListing(w io.Writer, listing []ObjectPrefetchStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BUCKET\tOBJECT\tREADERS\tBLOCK SIZE\tBLOCKS\tHIT RATE\tWASTE RATE")
+	for _, s := range listing {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%.1f%% (%d/%d)\t%.1f%% (%d/%d)\n",
+			s.BucketName, s.ObjectName, s.Readers, s.BlockSizeBytes, s.BlockCount,
+			s.HitRate(), s.BlocksHit, s.BlocksRead,
+			s.WasteRate(), s.BlocksWasted, s.BlocksScheduled)
+	}
+	return tw.Flush()
+}
+
+// ServeHTTP serves the listing of the open objects as a table. The listing is
+// read-only.
+func (r *OpenObjectRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := WriteObjectPrefetchListing(w, r.Listing()); err != nil {
+		logger.Warnf("Failed to serve the listing of the buffered read objects: %v", err)
+	}
+}
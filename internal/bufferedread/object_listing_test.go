@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteObjectPrefetchListing(t *testing.T) {
+	listing := []ObjectPrefetchStats{
+		{BucketName: "bucket", ObjectName: "random", Readers: 2, BlockSizeBytes: 1024, BlockCount: 16, BlocksRead: 4, BlocksScheduled: 12, BlocksWasted: 8},
+		{BucketName: "bucket", ObjectName: "sequential", Readers: 1, BlockSizeBytes: 1024, BlockCount: 8, BlocksRead: 8, BlocksHit: 7, BlocksScheduled: 8},
+	}
+	var b strings.Builder
+
+	require.NoError(t, WriteObjectPrefetchListing(&b, listing))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"BUCKET", "OBJECT", "READERS", "BLOCK", "SIZE", "BLOCKS", "HIT", "RATE", "WASTE", "RATE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"bucket", "random", "2", "1024", "16", "0.0%", "(0/4)", "66.7%", "(8/12)"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"bucket", "sequential", "1", "1024", "8", "87.5%", "(7/8)", "0.0%", "(0/8)"}, strings.Fields(lines[2]))
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+)
+
+// PrefetchPolicyMetadataKey is the custom metadata key of an object selecting
+// the prefetch policy of its buffered reads, overriding the configured one.
+// It is only honored if allowlisted in the object metadata keys.
+const PrefetchPolicyMetadataKey = "gcsfuse-prefetch-policy"
+
+// selectObjectMetadata returns the given keys of the custom metadata of the
+// object, so that buffered reads never depend on keys which aren't
+// allowlisted. It returns nil if none of the keys is set.
+func selectObjectMetadata(object *gcs.MinObject, keys []string) map[string]string {
+	var metadata map[string]string
+	for _, k := range keys {
+		v, ok := object.Metadata[k]
+		if !ok {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string, len(keys))
+		}
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// newObjectPrefetchPolicy returns the PrefetchPolicy of the given object,
+// which is the one named by its PrefetchPolicyMetadataKey metadata if set and
+// valid, and the configured one otherwise.
+func newObjectPrefetchPolicy(object *gcs.MinObject, metadata map[string]string, config *BufferedReadConfig) (PrefetchPolicy, error) {
+	name, ok := metadata[PrefetchPolicyMetadataKey]
+	if !ok {
+		return NewPrefetchPolicy(config)
+	}
+	objectConfig := *config
+	objectConfig.PrefetchPolicy = name
+	policy, err := NewPrefetchPolicy(&objectConfig)
+	if err != nil {
+		logger.Warnf("Ignoring the %s metadata of object %q: %v", PrefetchPolicyMetadataKey, object.Name, err)
+		return NewPrefetchPolicy(config)
+	}
+	return policy, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+)
+
+// ObjectResidentBlockLimiter bounds the number of blocks of any single object
+// held in the block pool at once, across all the readers of the object, so
+// that a huge object read sequentially can't fill up the pool and starve the
+// other objects read concurrently. An object at the limit gets no more blocks
+// until the ones already read are released, its readers evicting their older
+// blocks as they advance.
+type ObjectResidentBlockLimiter struct {
+	limit        int64
+	metricHandle metrics.MetricHandle
+
+	mu sync.Mutex
+
+	// resident holds the number of blocks of each object in the pool. Entries
+	// are removed once the object has no block left.
+	// GUARDED by (mu)
+	resident map[openObjectKey]int64
+}
+
+// NewObjectResidentBlockLimiter returns an ObjectResidentBlockLimiter allowing
+// at most limit blocks per object.
+func NewObjectResidentBlockLimiter(limit int64, metricHandle metrics.MetricHandle) *ObjectResidentBlockLimiter {
+	return &ObjectResidentBlockLimiter{
+		limit:        limit,
+		metricHandle: metricHandle,
+		resident:     make(map[openObjectKey]int64),
+	}
+}
+
+// tryAcquire accounts for a block of the given object taken from the pool,
+// and returns true, unless the object is at the limit.
+// LOCKS_EXCLUDED(l.mu)
+func (l *ObjectResidentBlockLimiter) tryAcquire(bucketName, objectName string) bool {
+	key := openObjectKey{bucketName: bucketName, objectName: objectName}
+	l.mu.Lock()
+	resident := l.resident[key]
+	if resident >= l.limit {
+		l.mu.Unlock()
+		return false
+	}
+	resident++
+	l.resident[key] = resident
+	l.mu.Unlock()
+
+	l.metricHandle.BufferedReadObjectResidentBlocks(context.Background(), resident)
+	return true
+}
+
+// release accounts for a block of the given object returned to the pool.
+// LOCKS_EXCLUDED(l.mu)
+func (l *ObjectResidentBlockLimiter) release(bucketName, objectName string) {
+	key := openObjectKey{bucketName: bucketName, objectName: objectName}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resident[key]--
+	if l.resident[key] <= 0 {
+		delete(l.resident, key)
+	}
+}
+
+// residentBlocks returns the number of blocks of the given object in the
+// pool.
+// LOCKS_EXCLUDED(l.mu)
+func (l *ObjectResidentBlockLimiter) residentBlocks(bucketName, objectName string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.resident[openObjectKey{bucketName: bucketName, objectName: objectName}]
+}
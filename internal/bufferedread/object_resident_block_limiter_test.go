@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type residentBlocksMetricHandle struct {
+	metrics.MetricHandle
+
+	mu          sync.Mutex
+	maxResident int64
+}
+
+func (m *residentBlocksMetricHandle) BufferedReadObjectResidentBlocks(_ context.Context, value int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxResident = max(m.maxResident, value)
+}
+
+func TestObjectResidentBlockLimiterIsPerObject(t *testing.T) {
+	limiter := NewObjectResidentBlockLimiter(1, metrics.NewNoopMetrics())
+	require.True(t, limiter.tryAcquire("bucket", "a"))
+
+	assert.False(t, limiter.tryAcquire("bucket", "a"))
+	// Other objects have a limit of their own.
+	require.True(t, limiter.tryAcquire("bucket", "b"))
+	limiter.release("bucket", "b")
+	limiter.release("bucket", "a")
+	assert.True(t, limiter.tryAcquire("bucket", "a"))
+	limiter.release("bucket", "a")
+	assert.Empty(t, limiter.resident)
+}
+
+func (t *BufferedReaderTest) TestObjectResidentBlockLimiterBoundsBlocksOfHugeObject() {
+	const blockCount = 16
+	const limit = 3
+	t.object.Size = uint64(blockCount * testPrefetchBlockSizeBytes)
+	t.bucket.On("Name").Return("test-bucket")
+	for i := range int64(blockCount) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	limiterMetrics := &residentBlocksMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	limiter := NewObjectResidentBlockLimiter(limit, limiterMetrics)
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:                     t.object,
+		Bucket:                     t.bucket,
+		Config:                     t.config,
+		GlobalMaxBlocksSem:         t.globalMaxBlocksSem,
+		WorkerPool:                 t.workerPool,
+		MetricHandle:               t.metricHandle,
+		ReadTypeClassifier:         t.readTypeClassifier,
+		ObjectResidentBlockLimiter: limiter,
+	})
+	require.NoError(t.T(), err)
+
+	for i := range int64(blockCount) {
+		offset := i * testPrefetchBlockSizeBytes
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: offset})
+
+		require.NoError(t.T(), err, "reading block %d", i)
+		assertReadResponseContent(t.T(), resp, offset)
+		assert.LessOrEqual(t.T(), limiter.residentBlocks("test-bucket", t.object.Name), int64(limit))
+		resp.Callback()
+	}
+	reader.Destroy()
+
+	assert.Equal(t.T(), int64(limit), limiterMetrics.maxResident)
+	assert.Empty(t.T(), limiter.resident)
+	t.bucket.AssertExpectations(t.T())
+}
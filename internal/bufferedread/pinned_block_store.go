@@ -0,0 +1,187 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"golang.org/x/sync/semaphore"
+)
+
+// pinnedObjectKey identifies an object, across its generations.
+type pinnedObjectKey struct {
+	bucketName string
+	objectName string
+}
+
+// PinnedBlockStore keeps a copy of every downloaded block of the configured
+// objects, so that they are never evicted and are served without downloading
+// them again. The copies live in a pool of their own, reserved out of the
+// global block budget, so that pinned blocks neither compete with nor are
+// reclaimed by the blocks of regular reads.
+//
+// Only the latest generation of an object seen is pinned. The blocks of older
+// generations are released to the pool once no reader references them.
+type PinnedBlockStore struct {
+	objects      map[string]struct{}
+	metricHandle metrics.MetricHandle
+
+	mu sync.Mutex
+
+	// GUARDED by (mu)
+	pool *block.GenBlockPool[block.PrefetchBlock]
+
+	// GUARDED by (mu)
+	blocks map[sharedBlockKey]block.PrefetchBlock
+
+	// generations holds the generation whose blocks are pinned, per object.
+	// GUARDED by (mu)
+	generations map[pinnedObjectKey]int64
+
+	// retired holds the blocks of superseded generations still referenced by
+	// readers.
+	// GUARDED by (mu)
+	retired []block.PrefetchBlock
+}
+
+// NewPinnedBlockStore returns a PinnedBlockStore pinning the blocks of the
+// given objects, using at most maxBlocks blocks of blockSize bytes.
+func NewPinnedBlockStore(objects []string, blockSize, maxBlocks int64, metricHandle metrics.MetricHandle) (*PinnedBlockStore, error) {
+	pool, err := block.NewPrefetchBlockPool(blockSize, maxBlocks, 0, semaphore.NewWeighted(maxBlocks))
+	if err != nil {
+		return nil, fmt.Errorf("NewPinnedBlockStore: creating block-pool: %w", err)
+	}
+	s := &PinnedBlockStore{
+		objects:      make(map[string]struct{}, len(objects)),
+		metricHandle: metricHandle,
+		pool:         pool,
+		blocks:       make(map[sharedBlockKey]block.PrefetchBlock),
+		generations:  make(map[pinnedObjectKey]int64),
+	}
+	for _, o := range objects {
+		s.objects[o] = struct{}{}
+	}
+	return s, nil
+}
+
+// isPinned returns true if the blocks of the named object are to be pinned.
+func (s *PinnedBlockStore) isPinned(objectName string) bool {
+	_, ok := s.objects[objectName]
+	return ok
+}
+
+// acquire returns the block pinned under key, referenced on behalf of the
+// caller until it calls DecRef, or nil if there is none.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) acquire(key sharedBlockKey) block.PrefetchBlock {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.blocks[key]
+	if !ok {
+		return nil
+	}
+	b.IncRef()
+	return b
+}
+
+// pin keeps a copy of the downloaded block src under key. The block is not
+// pinned if it already is, if it belongs to an older generation than the one
+// pinned, or if the pinned blocks budget is exhausted.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) pin(key sharedBlockKey, src block.PrefetchBlock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blocks[key]; ok {
+		return
+	}
+	objectKey := pinnedObjectKey{bucketName: key.bucketName, objectName: key.objectName}
+	if generation, ok := s.generations[objectKey]; ok && generation > key.generation {
+		return
+	} else if ok && generation < key.generation {
+		s.retireGeneration(objectKey, generation)
+	}
+	s.generations[objectKey] = key.generation
+	s.sweepRetired()
+
+	// Right-sized, so that the pinned last blocks of objects don't hold full
+	// blocks for long.
+	b, err := s.pool.TryGetSized(src.Size())
+	if err != nil {
+		logger.Tracef("PinnedBlockStore: not pinning block (%s, %d): %v", key.objectName, key.blockIndex, err)
+		return
+	}
+	if err := s.copyBlock(b, src); err != nil {
+		logger.Warnf("PinnedBlockStore: pinning block (%s, %d): %v", key.objectName, key.blockIndex, err)
+		s.pool.Release(b)
+		return
+	}
+	s.blocks[key] = b
+	s.metricHandle.BufferedReadPinnedBytes(b.Size())
+}
+
+// copyBlock copies the data of src to the free block b, and marks b as
+// downloaded.
+func (s *PinnedBlockStore) copyBlock(b, src block.PrefetchBlock) error {
+	if err := b.SetAbsStartOff(src.AbsStartOff()); err != nil {
+		return fmt.Errorf("setting start offset: %w", err)
+	}
+	data, err := src.ReadAtSlice(0, int(src.Size()))
+	if err != nil {
+		return fmt.Errorf("reading source block: %w", err)
+	}
+	if _, err := b.Write(data); err != nil {
+		return fmt.Errorf("writing block: %w", err)
+	}
+	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloaded})
+	// The first wait on a block records its status. Do it here, once, so that
+	// the readers sharing the block only ever observe the recorded status.
+	if _, err := b.AwaitReady(context.Background()); err != nil {
+		return fmt.Errorf("awaiting block: %w", err)
+	}
+	return nil
+}
+
+// retireGeneration unpins the blocks of the given generation of an object.
+// LOCKS_REQUIRED(s.mu)
+func (s *PinnedBlockStore) retireGeneration(objectKey pinnedObjectKey, generation int64) {
+	for key, b := range s.blocks {
+		if key.bucketName == objectKey.bucketName && key.objectName == objectKey.objectName && key.generation == generation {
+			delete(s.blocks, key)
+			s.retired = append(s.retired, b)
+		}
+	}
+}
+
+// sweepRetired releases the retired blocks no longer referenced to the pool.
+// LOCKS_REQUIRED(s.mu)
+func (s *PinnedBlockStore) sweepRetired() {
+	stillReferenced := s.retired[:0]
+	for _, b := range s.retired {
+		if b.RefCount() > 0 {
+			stillReferenced = append(stillReferenced, b)
+			continue
+		}
+		s.metricHandle.BufferedReadPinnedBytes(-b.Size())
+		s.pool.Release(b)
+	}
+	s.retired = stillReferenced
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"io"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"golang.org/x/sync/semaphore"
+)
+
+// downloadedBlock returns a block of the given pool holding the data of the
+// object block starting at offset.
+func downloadedBlock(t *testing.T, pool *block.GenBlockPool[block.PrefetchBlock], offset int64) block.PrefetchBlock {
+	t.Helper()
+	b, err := pool.Get()
+	require.NoError(t, err)
+	require.NoError(t, b.SetAbsStartOff(offset))
+	_, err = io.Copy(b, createFakeReaderWithOffset(t, int(testPrefetchBlockSizeBytes), offset))
+	require.NoError(t, err)
+	b.NotifyReady(block.BlockStatus{State: block.BlockStateDownloaded})
+	return b
+}
+
+func TestPinnedBlockStore(t *testing.T) {
+	store, err := NewPinnedBlockStore([]string{"object"}, testPrefetchBlockSizeBytes, 2, metrics.NewNoopMetrics())
+	require.NoError(t, err)
+	pool, err := block.NewPrefetchBlockPool(testPrefetchBlockSizeBytes, 8, 0, semaphore.NewWeighted(8))
+	require.NoError(t, err)
+	key := func(generation, blockIndex int64) sharedBlockKey {
+		return sharedBlockKey{bucketName: "bucket", objectName: "object", generation: generation, blockIndex: blockIndex}
+	}
+
+	assert.True(t, store.isPinned("object"))
+	assert.False(t, store.isPinned("other"))
+	assert.Nil(t, store.acquire(key(1, 0)))
+	store.pin(key(1, 0), downloadedBlock(t, pool, 0))
+	store.pin(key(1, 1), downloadedBlock(t, pool, testPrefetchBlockSizeBytes))
+	store.pin(key(1, 2), downloadedBlock(t, pool, 2*testPrefetchBlockSizeBytes))
+	pinned := store.acquire(key(1, 1))
+	require.NotNil(t, pinned)
+	assertBlockContent(t, pinned, testPrefetchBlockSizeBytes, int(testPrefetchBlockSizeBytes))
+	status, err := pinned.AwaitReady(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, block.BlockStateDownloaded, status.State)
+	assert.Nil(t, store.acquire(key(1, 2)), "Blocks beyond the pinned budget must not be pinned.")
+	// A newer generation replaces the pinned blocks, which are released once no
+	// longer referenced.
+	store.pin(key(2, 0), downloadedBlock(t, pool, 0))
+	assert.Nil(t, store.acquire(key(1, 0)))
+	assert.NotNil(t, store.acquire(key(2, 0)))
+	store.pin(key(1, 0), downloadedBlock(t, pool, 0))
+	assert.Nil(t, store.acquire(key(1, 0)), "Blocks of an older generation must not be pinned.")
+	pinned.DecRef()
+	store.pin(key(2, 1), downloadedBlock(t, pool, testPrefetchBlockSizeBytes))
+	assert.NotNil(t, store.acquire(key(2, 1)))
+}
+
+func (t *BufferedReaderTest) TestPinnedObjectBlocksSurviveExhaustedPool() {
+	t.object.Size = uint64(2 * testPrefetchBlockSizeBytes)
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	store, err := NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, 2, mh)
+	require.NoError(t.T(), err)
+	newReader := func(globalMaxBlocksSem *semaphore.Weighted) *BufferedReader {
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       mh,
+			ReadTypeClassifier: gcsx.NewReadTypeClassifier(1, 0),
+			PinnedBlockStore:   store,
+		})
+		require.NoError(t.T(), err)
+		return reader
+	}
+	// Every block is downloaded exactly once, by the first reader.
+	for i := range int64(2) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket")
+	reader1 := newReader(t.globalMaxBlocksSem)
+	resp, err := reader1.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	reader1.Destroy()
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/pinned_bytes", attribute.NewSet(), 2*testPrefetchBlockSizeBytes)
+	// No block can be allocated for the second reader, whose reads are served by
+	// the pinned blocks.
+	t.config.MinBlocksPerHandle = 0
+	reader2 := newReader(semaphore.NewWeighted(0))
+
+	resp, err = reader2.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	t.bucket.AssertExpectations(t.T())
+	resp.Callback()
+	reader2.Destroy()
+	for i := range int64(2) {
+		b := store.acquire(reader2.sharedBlockKey(i))
+		require.NotNil(t.T(), b, "Pinned blocks must survive the readers.")
+		assert.Equal(t.T(), int32(1), b.RefCount())
+		assertBlockContent(t.T(), b, i*testPrefetchBlockSizeBytes, int(testPrefetchBlockSizeBytes))
+		b.DecRef()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"fmt"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+)
+
+// PrefetchPolicy decides how many blocks the BufferedReader schedules ahead of
+// the block serving the current read. The BufferedReader consults it whenever
+// it (re)starts reading at a new offset and after every prefetch cycle.
+type PrefetchPolicy interface {
+	// Name returns the name of the policy as accepted by read-prefetch-policy.
+	Name() string
+
+	// InitialBlockCount returns the number of blocks to prefetch, in addition
+	// to the urgently scheduled block, when reading starts at startBlockIndex
+	// of a file with totalBlockCount blocks.
+	InitialBlockCount(startBlockIndex, totalBlockCount int64) int64
+
+	// NextBlockCount returns the number of blocks to prefetch in the next
+	// cycle, given the count of the cycle which was fully scheduled.
+	NextBlockCount(currentBlockCount int64) int64
+
+	// IsExpectedSeek returns true if a seek to blockIndex of a file with
+	// totalBlockCount blocks is part of the access pattern the policy is
+	// designed for and hence shouldn't count as a random seek.
+	IsExpectedSeek(blockIndex, totalBlockCount int64) bool
+}
+
+// NewPrefetchPolicy returns the PrefetchPolicy for the given config. An empty
+// policy name selects the adaptive policy.
+func NewPrefetchPolicy(config *BufferedReadConfig) (PrefetchPolicy, error) {
+	adaptive := &adaptivePrefetchPolicy{
+		initialBlockCount: config.InitialPrefetchBlockCnt,
+		maxBlockCount:     config.MaxPrefetchBlockCnt,
+		multiplier:        defaultPrefetchMultiplier,
+	}
+	switch config.PrefetchPolicy {
+	case "", cfg.PrefetchPolicyAdaptive:
+		return adaptive, nil
+	case cfg.PrefetchPolicySequential:
+		return &sequentialPrefetchPolicy{maxBlockCount: config.MaxPrefetchBlockCnt}, nil
+	case cfg.PrefetchPolicyFooterFirst:
+		return &footerFirstPrefetchPolicy{adaptivePrefetchPolicy: adaptive}, nil
+	case cfg.PrefetchPolicyNone:
+		return &noPrefetchPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported prefetch policy: %q", config.PrefetchPolicy)
+	}
+}
+
+// adaptivePrefetchPolicy starts with a small prefetch window and grows it
+// multiplicatively after every fully scheduled cycle, up to the maximum.
+type adaptivePrefetchPolicy struct {
+	initialBlockCount int64
+	maxBlockCount     int64
+	multiplier        int64
+}
+
+func (a *adaptivePrefetchPolicy) Name() string {
+	return cfg.PrefetchPolicyAdaptive
+}
+
+func (a *adaptivePrefetchPolicy) InitialBlockCount(_, _ int64) int64 {
+	return min(a.initialBlockCount, a.maxBlockCount)
+}
+
+func (a *adaptivePrefetchPolicy) NextBlockCount(currentBlockCount int64) int64 {
+	return min(currentBlockCount*a.multiplier, a.maxBlockCount)
+}
+
+func (a *adaptivePrefetchPolicy) IsExpectedSeek(_, _ int64) bool {
+	return false
+}
+
+// sequentialPrefetchPolicy assumes the file is read front to back and keeps
+// the prefetch window at its maximum from the very first read.
+type sequentialPrefetchPolicy struct {
+	maxBlockCount int64
+}
+
+func (s *sequentialPrefetchPolicy) Name() string {
+	return cfg.PrefetchPolicySequential
+}
+
+func (s *sequentialPrefetchPolicy) InitialBlockCount(_, _ int64) int64 {
+	return s.maxBlockCount
+}
+
+func (s *sequentialPrefetchPolicy) NextBlockCount(_ int64) int64 {
+	return s.maxBlockCount
+}
+
+func (s *sequentialPrefetchPolicy) IsExpectedSeek(_, _ int64) bool {
+	return false
+}
+
+// footerFirstPrefetchPolicy targets columnar formats (e.g. parquet, ORC) whose
+// readers fetch the footer before seeking to the data they need. A seek into
+// the last block is expected and prefetches nothing, since there is nothing
+// beyond the footer; reads elsewhere grow the window adaptively.
+type footerFirstPrefetchPolicy struct {
+	*adaptivePrefetchPolicy
+}
+
+func (f *footerFirstPrefetchPolicy) Name() string {
+	return cfg.PrefetchPolicyFooterFirst
+}
+
+func (f *footerFirstPrefetchPolicy) InitialBlockCount(startBlockIndex, totalBlockCount int64) int64 {
+	if f.IsExpectedSeek(startBlockIndex, totalBlockCount) {
+		return 0
+	}
+	return f.adaptivePrefetchPolicy.InitialBlockCount(startBlockIndex, totalBlockCount)
+}
+
+func (f *footerFirstPrefetchPolicy) IsExpectedSeek(blockIndex, totalBlockCount int64) bool {
+	return blockIndex == totalBlockCount-1
+}
+
+// noPrefetchPolicy disables read-ahead; only the blocks required to serve the
+// current read are downloaded.
+type noPrefetchPolicy struct{}
+
+func (n *noPrefetchPolicy) Name() string {
+	return cfg.PrefetchPolicyNone
+}
+
+func (n *noPrefetchPolicy) InitialBlockCount(_, _ int64) int64 {
+	return 0
+}
+
+func (n *noPrefetchPolicy) NextBlockCount(_ int64) int64 {
+	return 0
+}
+
+func (n *noPrefetchPolicy) IsExpectedSeek(_, _ int64) bool {
+	return false
+}
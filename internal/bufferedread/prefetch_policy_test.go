@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	policyTestInitialBlockCnt int64 = 2
+	policyTestMaxBlockCnt     int64 = 10
+	policyTestTotalBlockCnt   int64 = 100
+)
+
+// prefetchWindows replays an access pattern that starts at startBlockIndex and
+// runs numCycles fully scheduled prefetch cycles, returning the window chosen
+// by the policy for each of them.
+func prefetchWindows(policy PrefetchPolicy, startBlockIndex int64, numCycles int) []int64 {
+	windows := []int64{policy.InitialBlockCount(startBlockIndex, policyTestTotalBlockCnt)}
+	for range numCycles {
+		windows = append(windows, policy.NextBlockCount(windows[len(windows)-1]))
+	}
+	return windows
+}
+
+func TestNewPrefetchPolicy(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policyName string
+		wantName   string
+	}{
+		{name: "empty_defaults_to_adaptive", policyName: "", wantName: cfg.PrefetchPolicyAdaptive},
+		{name: "adaptive", policyName: cfg.PrefetchPolicyAdaptive, wantName: cfg.PrefetchPolicyAdaptive},
+		{name: "sequential", policyName: cfg.PrefetchPolicySequential, wantName: cfg.PrefetchPolicySequential},
+		{name: "footer_first", policyName: cfg.PrefetchPolicyFooterFirst, wantName: cfg.PrefetchPolicyFooterFirst},
+		{name: "none", policyName: cfg.PrefetchPolicyNone, wantName: cfg.PrefetchPolicyNone},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPrefetchPolicy(&BufferedReadConfig{PrefetchPolicy: tc.policyName})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, policy.Name())
+		})
+	}
+}
+
+func TestNewPrefetchPolicyUnsupported(t *testing.T) {
+	policy, err := NewPrefetchPolicy(&BufferedReadConfig{PrefetchPolicy: "random"})
+
+	assert.ErrorContains(t, err, "unsupported prefetch policy")
+	assert.Nil(t, policy)
+}
+
+func TestPrefetchPolicyScheduling(t *testing.T) {
+	testCases := []struct {
+		name            string
+		policyName      string
+		startBlockIndex int64
+		wantWindows     []int64
+	}{
+		{
+			name:            "adaptive_sequential_read_from_start",
+			policyName:      cfg.PrefetchPolicyAdaptive,
+			startBlockIndex: 0,
+			wantWindows:     []int64{2, 4, 8, 10, 10},
+		},
+		{
+			name:            "adaptive_read_from_middle",
+			policyName:      cfg.PrefetchPolicyAdaptive,
+			startBlockIndex: 50,
+			wantWindows:     []int64{2, 4, 8, 10, 10},
+		},
+		{
+			name:            "sequential_read_from_start",
+			policyName:      cfg.PrefetchPolicySequential,
+			startBlockIndex: 0,
+			wantWindows:     []int64{10, 10, 10, 10, 10},
+		},
+		{
+			name:            "footer_first_footer_read",
+			policyName:      cfg.PrefetchPolicyFooterFirst,
+			startBlockIndex: policyTestTotalBlockCnt - 1,
+			wantWindows:     []int64{0, 0, 0, 0, 0},
+		},
+		{
+			name:            "footer_first_column_chunk_read",
+			policyName:      cfg.PrefetchPolicyFooterFirst,
+			startBlockIndex: 10,
+			wantWindows:     []int64{2, 4, 8, 10, 10},
+		},
+		{
+			name:            "none_sequential_read_from_start",
+			policyName:      cfg.PrefetchPolicyNone,
+			startBlockIndex: 0,
+			wantWindows:     []int64{0, 0, 0, 0, 0},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPrefetchPolicy(&BufferedReadConfig{
+				InitialPrefetchBlockCnt: policyTestInitialBlockCnt,
+				MaxPrefetchBlockCnt:     policyTestMaxBlockCnt,
+				PrefetchPolicy:          tc.policyName,
+			})
+			require.NoError(t, err)
+
+			windows := prefetchWindows(policy, tc.startBlockIndex, len(tc.wantWindows)-1)
+
+			assert.Equal(t, tc.wantWindows, windows)
+		})
+	}
+}
+
+func TestPrefetchPolicyIsExpectedSeek(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policyName string
+		blockIndex int64
+		want       bool
+	}{
+		{name: "adaptive_footer", policyName: cfg.PrefetchPolicyAdaptive, blockIndex: policyTestTotalBlockCnt - 1, want: false},
+		{name: "sequential_footer", policyName: cfg.PrefetchPolicySequential, blockIndex: policyTestTotalBlockCnt - 1, want: false},
+		{name: "footer_first_footer", policyName: cfg.PrefetchPolicyFooterFirst, blockIndex: policyTestTotalBlockCnt - 1, want: true},
+		{name: "footer_first_middle", policyName: cfg.PrefetchPolicyFooterFirst, blockIndex: 50, want: false},
+		{name: "none_footer", policyName: cfg.PrefetchPolicyNone, blockIndex: policyTestTotalBlockCnt - 1, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPrefetchPolicy(&BufferedReadConfig{
+				InitialPrefetchBlockCnt: policyTestInitialBlockCnt,
+				MaxPrefetchBlockCnt:     policyTestMaxBlockCnt,
+				PrefetchPolicy:          tc.policyName,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, policy.IsExpectedSeek(tc.blockIndex, policyTestTotalBlockCnt))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"sync"
+)
+
+// sharedBlockKey identifies a block of a specific object generation.
+type sharedBlockKey struct {
+	bucketName string
+	objectName string
+	generation int64
+	blockIndex int64
+}
+
+// sharedBlock is a block downloaded (or being downloaded) by its owner reader
+// and available to other readers of the same object generation.
+type sharedBlock struct {
+	owner *BufferedReader
+	entry *blockQueueEntry
+}
+
+// SharedBlockRegistry deduplicates block downloads across concurrent
+// BufferedReaders of the same object. A reader registers every block it
+// schedules; other readers needing the same block borrow it by taking a
+// reference on it instead of downloading it again.
+//
+// Only the owner releases a block to its pool. It unregisters the block before
+// evicting it, so a block evicted while still borrowed is released once the
+// last borrower drops its reference.
+type SharedBlockRegistry struct {
+	mu sync.Mutex
+
+	// GUARDED by (mu)
+	blocks map[sharedBlockKey]*sharedBlock
+}
+
+// NewSharedBlockRegistry returns an empty SharedBlockRegistry.
+func NewSharedBlockRegistry() *SharedBlockRegistry {
+	return &SharedBlockRegistry{
+		blocks: make(map[sharedBlockKey]*sharedBlock),
+	}
+}
+
+// register makes the block of the given entry available to other readers
+// under key. It returns false if another block is already registered for key.
+// LOCKS_EXCLUDED(r.mu)
+func (r *SharedBlockRegistry) register(key sharedBlockKey, owner *BufferedReader, entry *blockQueueEntry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.blocks[key]; ok {
+		return false
+	}
+	entry.sharedKey = &key
+	r.blocks[key] = &sharedBlock{owner: owner, entry: entry}
+	return true
+}
+
+// unregister stops lending the block of the given entry, if it was registered.
+// After it returns, no reader can take a new reference on the block.
+// LOCKS_EXCLUDED(r.mu)
+func (r *SharedBlockRegistry) unregister(entry *blockQueueEntry) {
+	if entry.sharedKey == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sb, ok := r.blocks[*entry.sharedKey]; ok && sb.entry == entry {
+		delete(r.blocks, *entry.sharedKey)
+	}
+}
+
+// acquire returns a queue entry for borrower referencing the block registered
+// under key, or nil if there is none. The block is referenced on behalf of the
+// borrower until the entry is released.
+// LOCKS_EXCLUDED(r.mu)
+func (r *SharedBlockRegistry) acquire(key sharedBlockKey, borrower *BufferedReader) *blockQueueEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sb, ok := r.blocks[key]
+	if !ok || sb.owner == borrower {
+		return nil
+	}
+	sb.entry.block.IncRef()
+	return &blockQueueEntry{
+		block: sb.entry.block,
+		// The download belongs to the owner, the borrower must not cancel it.
+		cancel:      func() {},
+		sharedOwner: sb.owner,
+		ownerEntry:  sb.entry,
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestSharedBlockRegistry(t *testing.T) {
+	registry := NewSharedBlockRegistry()
+	owner, borrower := &BufferedReader{}, &BufferedReader{}
+	pool, err := block.NewPrefetchBlockPool(testPrefetchBlockSizeBytes, 1, 0, semaphore.NewWeighted(1))
+	require.NoError(t, err)
+	blk, err := pool.Get()
+	require.NoError(t, err)
+	entry := &blockQueueEntry{block: blk, cancel: func() {}}
+	key := sharedBlockKey{bucketName: "bucket", objectName: "object", generation: 1, blockIndex: 3}
+
+	require.True(t, registry.register(key, owner, entry))
+	assert.False(t, registry.register(key, borrower, &blockQueueEntry{block: blk}), "A registered key must not be overwritten.")
+	assert.Nil(t, registry.acquire(key, owner), "The owner must not borrow its own block.")
+	assert.Nil(t, registry.acquire(sharedBlockKey{bucketName: "bucket", objectName: "object", generation: 2, blockIndex: 3}, borrower), "Blocks of another generation must not be shared.")
+	borrowed := registry.acquire(key, borrower)
+	require.NotNil(t, borrowed)
+	assert.Same(t, owner, borrowed.sharedOwner)
+	assert.Same(t, entry, borrowed.ownerEntry)
+	assert.Equal(t, int32(1), blk.RefCount())
+	registry.unregister(&blockQueueEntry{block: blk, sharedKey: &key})
+	assert.NotNil(t, registry.acquire(key, borrower), "Only the registered entry can unregister the key.")
+	registry.unregister(entry)
+	assert.Nil(t, registry.acquire(key, borrower))
+}
+
+func (t *BufferedReaderTest) newSharingReader(registry *SharedBlockRegistry) *BufferedReader {
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:              t.object,
+		Bucket:              t.bucket,
+		Config:              t.config,
+		GlobalMaxBlocksSem:  t.globalMaxBlocksSem,
+		WorkerPool:          t.workerPool,
+		MetricHandle:        t.metricHandle,
+		ReadTypeClassifier:  gcsx.NewReadTypeClassifier(1, 0),
+		SharedBlockRegistry: registry,
+	})
+	require.NoError(t.T(), err)
+	return reader
+}
+
+func (t *BufferedReaderTest) TestConcurrentReadersOfOverlappingRangesShareBlocks() {
+	t.object.Size = uint64(3 * testPrefetchBlockSizeBytes)
+	registry := NewSharedBlockRegistry()
+	reader1 := t.newSharingReader(registry)
+	reader2 := t.newSharingReader(registry)
+	// Every block is downloaded exactly once, by the first reader.
+	for i := range int64(3) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	t.bucket.On("Name").Return("test-bucket")
+	// The first read schedules blocks 0, 1 and 2.
+	resp0, err := reader1.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, 100), Offset: 0})
+	require.NoError(t.T(), err)
+	offset1, offset2 := int64(100), testPrefetchBlockSizeBytes+100
+	var resp1, resp2 gcsx.ReadResponse
+	var err1, err2 error
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		resp1, err1 = reader1.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: offset1})
+	}()
+	go func() {
+		defer wg.Done()
+		resp2, err2 = reader2.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, testPrefetchBlockSizeBytes), Offset: offset2})
+	}()
+	wg.Wait()
+
+	require.NoError(t.T(), err1)
+	require.NoError(t.T(), err2)
+	assertReadResponseContent(t.T(), resp1, offset1)
+	assertReadResponseContent(t.T(), resp2, offset2)
+	t.bucket.AssertExpectations(t.T())
+	reader2.mu.Lock()
+	borrowed := reader2.blockQueue.Peek()
+	reader2.mu.Unlock()
+	assert.Same(t.T(), reader1, borrowed.sharedOwner)
+	assert.Equal(t.T(), 2*testPrefetchBlockSizeBytes, borrowed.block.AbsStartOff())
+	registry.mu.Lock()
+	assert.Same(t.T(), borrowed.ownerEntry.block, registry.blocks[reader1.sharedBlockKey(2)].entry.block)
+	registry.mu.Unlock()
+	resp0.Callback()
+	resp1.Callback()
+	resp2.Callback()
+	// Destroying the owner first must keep the borrowed block alive until the
+	// borrower is done with it.
+	reader1.Destroy()
+	assertBlockContent(t.T(), borrowed.block, 2*testPrefetchBlockSizeBytes, int(testPrefetchBlockSizeBytes))
+	reader2.Destroy()
+	assert.Eventually(t.T(), func() bool {
+		return t.globalMaxBlocksSem.TryAcquire(testGlobalMaxBlocks)
+	}, time.Second, 10*time.Millisecond, "All blocks must be released once both readers are destroyed.")
+}
+
+func (t *BufferedReaderTest) TestBorrowerDownloadsBlockWhenOwnerCancelsDownload() {
+	t.config.PrefetchPolicy = cfg.PrefetchPolicyNone
+	registry := NewSharedBlockRegistry()
+	reader1 := t.newSharingReader(registry)
+	reader2 := t.newSharingReader(registry)
+	defer reader2.Destroy()
+	downloadStarted := make(chan struct{})
+	// The owner's download of block 0 blocks until it is cancelled, after which
+	// the borrower downloads the block itself.
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 })).
+		Run(func(args mock.Arguments) {
+			close(downloadStarted)
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, context.Canceled).Once()
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool { return r.Range.Start == 0 })).
+		Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+	t.bucket.On("Name").Return("test-bucket")
+	reader1.mu.Lock()
+	require.NoError(t.T(), reader1.freshStart(0))
+	ownerBlock := reader1.blockQueue.Peek().block
+	reader1.mu.Unlock()
+	<-downloadStarted
+	done := make(chan struct{})
+	var resp gcsx.ReadResponse
+	var err error
+	go func() {
+		defer close(done)
+		resp, err = reader2.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, 100), Offset: 0})
+	}()
+	require.Eventually(t.T(), func() bool { return ownerBlock.RefCount() == 1 }, time.Second, time.Millisecond, "The borrower should reference the owner's block.")
+
+	reader1.Destroy()
+	<-done
+
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	resp.Callback()
+	t.bucket.AssertExpectations(t.T())
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
+)
+
+// blockFillBuckets is the number of buckets of the block fill histogram, each
+// covering an equal share of the block capacity.
+const blockFillBuckets = 4
+
+const (
+	// OversizedBlockCheckInterval is the interval at which the fill of the
+	// downloaded blocks is checked for a block size too large for the workload.
+	OversizedBlockCheckInterval = 5 * time.Minute
+
+	// oversizedBlockMinSamples is the number of blocks downloaded below which
+	// the fill of the blocks isn't representative of the workload.
+	oversizedBlockMinSamples = 20
+
+	// oversizedBlockThresholdPercent is the share of the blocks downloaded
+	// which, when filled less than a quarter, indicates a block size too large
+	// for the workload.
+	oversizedBlockThresholdPercent = 75
+
+	// OverPrefetchCheckInterval is the interval at which the blocks wasted by
+	// the prefetch are checked for a read-ahead distance too large for the
+	// workload.
+	OverPrefetchCheckInterval = 5 * time.Minute
+
+	// overPrefetchMinFiles is the number of files closed below which the waste
+	// of the prefetch isn't representative of the workload.
+	overPrefetchMinFiles = 20
+
+	// overPrefetchFilesThresholdPercent is the share of the files closed which,
+	// when closed with prefetched blocks never read, indicates over-prefetch
+	// across the workload rather than in a few files.
+	overPrefetchFilesThresholdPercent = 50
+
+	// overPrefetchWasteThresholdPercent is the share of the blocks scheduled
+	// which, when never read, indicates a read-ahead distance too large for
+	// the workload.
+	overPrefetchWasteThresholdPercent = 25
+)
+
+// Stats accumulates buffered read counters across all the readers sharing it,
+// for a periodic summary of buffered read efficiency in the logs.
+type Stats struct {
+	bytesRead atomic.Int64
+
+	// blocksRead is the number of blocks read from at least once, out of which
+	// blocksHit had been prefetched ahead of the read needing them.
+	blocksRead atomic.Int64
+	blocksHit  atomic.Int64
+
+	// blocksScheduled is the number of blocks scheduled for download, out of
+	// which blocksWasted were evicted without ever being read. Of these,
+	// blocksWastedOnClose were still queued when their file was closed, the
+	// others being evicted while the file was read, e.g. on a seek.
+	blocksScheduled     atomic.Int64
+	blocksWasted        atomic.Int64
+	blocksWastedOnClose atomic.Int64
+
+	// filesClosed is the number of readers destroyed, out of which
+	// filesClosedWithWaste still had blocks never read in their queue.
+	filesClosed          atomic.Int64
+	filesClosedWithWaste atomic.Int64
+
+	// downloadNanos is the total time taken by the downloadsCompleted
+	// successful block downloads.
+	downloadsCompleted atomic.Int64
+	downloadNanos      atomic.Int64
+
+	// blocksInUse is the number of blocks currently taken from block pools.
+	blocksInUse atomic.Int64
+
+	// blockFill is the histogram of the fill of the downloaded blocks, relative
+	// to their capacity: bucket i counts the blocks filled to less than
+	// (i+1)/blockFillBuckets of their capacity.
+	blockFill [blockFillBuckets]atomic.Int64
+}
+
+// StatsSnapshot is a point-in-time copy of the Stats counters.
+type StatsSnapshot struct {
+	BytesRead       int64
+	BlocksRead      int64
+	BlocksHit       int64
+	BlocksScheduled int64
+	BlocksWasted    int64
+	// BlocksWastedOnClose is the part of BlocksWasted still queued when their
+	// file was closed.
+	BlocksWastedOnClose  int64
+	FilesClosed          int64
+	FilesClosedWithWaste int64
+	DownloadsCompleted   int64
+	DownloadTime         time.Duration
+	BlocksInUse          int64
+	BlockFill            [blockFillBuckets]int64
+}
+
+// NewStats returns Stats with all counters at zero.
+func NewStats() *Stats {
+	return &Stats{}
+}
+
+// Snapshot returns the current value of the counters.
+func (s *Stats) Snapshot() StatsSnapshot {
+	snapshot := StatsSnapshot{
+		BytesRead:            s.bytesRead.Load(),
+		BlocksRead:           s.blocksRead.Load(),
+		BlocksHit:            s.blocksHit.Load(),
+		BlocksScheduled:      s.blocksScheduled.Load(),
+		BlocksWasted:         s.blocksWasted.Load(),
+		BlocksWastedOnClose:  s.blocksWastedOnClose.Load(),
+		FilesClosed:          s.filesClosed.Load(),
+		FilesClosedWithWaste: s.filesClosedWithWaste.Load(),
+		DownloadsCompleted:   s.downloadsCompleted.Load(),
+		DownloadTime:         time.Duration(s.downloadNanos.Load()),
+		BlocksInUse:          s.blocksInUse.Load(),
+	}
+	for i := range s.blockFill {
+		snapshot.BlockFill[i] = s.blockFill[i].Load()
+	}
+	return snapshot
+}
+
+// recordBlockFill adds a downloaded block of the given size, out of the given
+// block size, to the block fill histogram.
+func (s *Stats) recordBlockFill(size, blockSize int64) {
+	if blockSize <= 0 {
+		return
+	}
+	bucket := min(size*blockFillBuckets/blockSize, blockFillBuckets-1)
+	s.blockFill[max(bucket, 0)].Add(1)
+}
+
+// sub returns the counter deltas from prev to s. BlocksInUse is a gauge and is
+// kept as is.
+func (s StatsSnapshot) sub(prev StatsSnapshot) StatsSnapshot {
+	delta := StatsSnapshot{
+		BytesRead:            s.BytesRead - prev.BytesRead,
+		BlocksRead:           s.BlocksRead - prev.BlocksRead,
+		BlocksHit:            s.BlocksHit - prev.BlocksHit,
+		BlocksScheduled:      s.BlocksScheduled - prev.BlocksScheduled,
+		BlocksWasted:         s.BlocksWasted - prev.BlocksWasted,
+		BlocksWastedOnClose:  s.BlocksWastedOnClose - prev.BlocksWastedOnClose,
+		FilesClosed:          s.FilesClosed - prev.FilesClosed,
+		FilesClosedWithWaste: s.FilesClosedWithWaste - prev.FilesClosedWithWaste,
+		DownloadsCompleted:   s.DownloadsCompleted - prev.DownloadsCompleted,
+		DownloadTime:         s.DownloadTime - prev.DownloadTime,
+		BlocksInUse:          s.BlocksInUse,
+	}
+	for i := range s.BlockFill {
+		delta.BlockFill[i] = s.BlockFill[i] - prev.BlockFill[i]
+	}
+	return delta
+}
+
+// percentage returns part as a percentage of whole, or 0 if whole is 0.
+func percentage(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(whole)
+}
+
+// formatStatsSummary formats the deltas of a summary window. globalMaxBlocks
+// is the size of the global block budget, -1 meaning unlimited.
+func formatStatsSummary(delta StatsSnapshot, window time.Duration, globalMaxBlocks int64) string {
+	var avgBlockLatency time.Duration
+	if delta.DownloadsCompleted > 0 {
+		avgBlockLatency = delta.DownloadTime / time.Duration(delta.DownloadsCompleted)
+	}
+	poolUtilization := fmt.Sprintf("%d blocks (unlimited)", delta.BlocksInUse)
+	if globalMaxBlocks >= 0 {
+		poolUtilization = fmt.Sprintf("%.1f%% (%d/%d blocks)", percentage(delta.BlocksInUse, globalMaxBlocks), delta.BlocksInUse, globalMaxBlocks)
+	}
+	return fmt.Sprintf("Buffered read summary for the last %v: bytes read: %d, prefetch hit rate: %.1f%% (%d/%d blocks), waste rate: %.1f%% (%d/%d blocks), avg block latency: %v, pool utilization: %s",
+		window,
+		delta.BytesRead,
+		percentage(delta.BlocksHit, delta.BlocksRead), delta.BlocksHit, delta.BlocksRead,
+		percentage(delta.BlocksWasted, delta.BlocksScheduled), delta.BlocksWasted, delta.BlocksScheduled,
+		avgBlockLatency,
+		poolUtilization)
+}
+
+// LogStatsSummaryPeriodically logs a summary of the buffered read efficiency
+// over the last interval, every interval, until ctx is cancelled.
+func LogStatsSummaryPeriodically(ctx context.Context, stats *Stats, interval time.Duration, globalMaxBlocks int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := stats.Snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cur := stats.Snapshot()
+			logger.Info(formatStatsSummary(cur.sub(prev), interval, globalMaxBlocks))
+			prev = cur
+		}
+	}
+}
+
+// oversizedBlockWarning returns a warning if most of the blocks downloaded
+// were filled less than a quarter, i.e. the objects read are much
+// smaller than the block size, wasting most of the memory of the blocks. It
+// returns an empty string otherwise.
+func oversizedBlockWarning(delta StatsSnapshot, blockSizeBytes int64) string {
+	var downloaded int64
+	for _, n := range delta.BlockFill {
+		downloaded += n
+	}
+	if downloaded < oversizedBlockMinSamples || percentage(delta.BlockFill[0], downloaded) < oversizedBlockThresholdPercent {
+		return ""
+	}
+	return fmt.Sprintf("Buffered read: %d out of %d downloaded blocks were less than a quarter full, the objects read are much smaller than the %d MiB block size. Consider a smaller read-block-size-mb to save memory.",
+		delta.BlockFill[0], downloaded, blockSizeBytes/util.MiB)
+}
+
+// WarnOnOversizedBlocks checks the fill of the blocks downloaded since it
+// started every interval, and logs a warning the first time the block size is
+// found to be much larger than the objects read, until ctx is cancelled.
+func WarnOnOversizedBlocks(ctx context.Context, stats *Stats, interval time.Duration, blockSizeBytes int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := stats.Snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if warning := oversizedBlockWarning(stats.Snapshot().sub(start), blockSizeBytes); warning != "" {
+				logger.Warnf("%s", warning)
+				return
+			}
+		}
+	}
+}
+
+// overPrefetchWarning returns a tuning recommendation if most of the files
+// closed still had prefetched blocks never read, and a large share of the
+// blocks scheduled were never read, i.e. the prefetch systematically reads
+// further ahead than the files are read. It returns an empty string otherwise.
+func overPrefetchWarning(delta StatsSnapshot) string {
+	if delta.FilesClosed < overPrefetchMinFiles ||
+		percentage(delta.FilesClosedWithWaste, delta.FilesClosed) < overPrefetchFilesThresholdPercent ||
+		percentage(delta.BlocksWasted, delta.BlocksScheduled) < overPrefetchWasteThresholdPercent {
+		return ""
+	}
+	return fmt.Sprintf("Buffered read: %d out of %d files were closed with prefetched blocks never read, and %d out of %d blocks scheduled were never read (%d still queued on close, %d evicted while reading). Consider a lower read-max-blocks-per-handle or read-start-blocks-per-handle to reduce the read-ahead distance.",
+		delta.FilesClosedWithWaste, delta.FilesClosed,
+		delta.BlocksWasted, delta.BlocksScheduled,
+		delta.BlocksWastedOnClose, delta.BlocksWasted-delta.BlocksWastedOnClose)
+}
+
+// WarnOnOverPrefetch checks the blocks wasted by the prefetch since it started
+// every interval, and logs a tuning recommendation the first time the prefetch
+// is found to systematically overshoot the reads, until ctx is cancelled.
+func WarnOnOverPrefetch(ctx context.Context, stats *Stats, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := stats.Snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if warning := overPrefetchWarning(stats.Snapshot().sub(start)); warning != "" {
+				logger.Warnf("%s", warning)
+				return
+			}
+		}
+	}
+}
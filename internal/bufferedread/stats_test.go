@@ -0,0 +1,321 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, for capturing logs
+// written from other goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFormatStatsSummary(t *testing.T) {
+	delta := StatsSnapshot{
+		BytesRead:          4096,
+		BlocksRead:         4,
+		BlocksHit:          3,
+		BlocksScheduled:    10,
+		BlocksWasted:       1,
+		DownloadsCompleted: 4,
+		DownloadTime:       400 * time.Millisecond,
+		BlocksInUse:        5,
+	}
+
+	summary := formatStatsSummary(delta, time.Minute, 20)
+
+	assert.Equal(t, "Buffered read summary for the last 1m0s: bytes read: 4096, prefetch hit rate: 75.0% (3/4 blocks), waste rate: 10.0% (1/10 blocks), avg block latency: 100ms, pool utilization: 25.0% (5/20 blocks)", summary)
+}
+
+func TestFormatStatsSummaryIdleWindowWithUnlimitedBlocks(t *testing.T) {
+	summary := formatStatsSummary(StatsSnapshot{BlocksInUse: 3}, time.Second, -1)
+
+	assert.Equal(t, "Buffered read summary for the last 1s: bytes read: 0, prefetch hit rate: 0.0% (0/0 blocks), waste rate: 0.0% (0/0 blocks), avg block latency: 0s, pool utilization: 3 blocks (unlimited)", summary)
+}
+
+func TestLogStatsSummaryPeriodicallyLogsDeltaOverInterval(t *testing.T) {
+	var buf syncBuffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	stats := NewStats()
+	// Counted before the summary starts, hence never part of a window.
+	stats.bytesRead.Add(100)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		LogStatsSummaryPeriodically(ctx, stats, 10*time.Millisecond, 40)
+	}()
+	// The first summary shows the starting snapshot has been taken.
+	require.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "Buffered read summary")
+	}, time.Second, 5*time.Millisecond)
+
+	stats.bytesRead.Add(2048)
+
+	var line string
+	require.Eventually(t, func() bool {
+		for _, l := range strings.Split(buf.String(), "\n") {
+			if strings.Contains(l, "bytes read: 2048,") {
+				line = l
+				return true
+			}
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+	assert.Contains(t, line, "INFO")
+	for _, field := range []string{"prefetch hit rate:", "waste rate:", "avg block latency:", "pool utilization:"} {
+		assert.Contains(t, line, field)
+	}
+	assert.NotContains(t, buf.String(), "bytes read: 2148")
+}
+
+func (t *BufferedReaderTest) TestReadAtRecordsStats() {
+	stats := NewStats()
+	reader, err := NewBufferedReader(&BufferedReaderOptions{
+		Object:             t.object,
+		Bucket:             t.bucket,
+		Config:             t.config,
+		GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+		WorkerPool:         t.workerPool,
+		MetricHandle:       t.metricHandle,
+		ReadTypeClassifier: t.readTypeClassifier,
+		Stats:              stats,
+	})
+	require.NoError(t.T(), err)
+	// Blocks 0, 1 and 2 are scheduled by the fresh start. Block 2 is never read
+	// and its download may be cancelled by Destroy.
+	for i := range int64(3) {
+		call := t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil)
+		if i < 2 {
+			call.Once()
+		} else {
+			call.Maybe()
+		}
+	}
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	// Block 0 is downloaded on demand, block 1 was prefetched.
+	resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{
+		Buffer: make([]byte, 2*testPrefetchBlockSizeBytes),
+		Offset: 0,
+	})
+	require.NoError(t.T(), err)
+	resp.Callback()
+	reader.Destroy()
+
+	snapshot := stats.Snapshot()
+	assert.Equal(t.T(), 2*testPrefetchBlockSizeBytes, snapshot.BytesRead)
+	assert.Equal(t.T(), int64(2), snapshot.BlocksRead)
+	assert.Equal(t.T(), int64(1), snapshot.BlocksHit)
+	assert.Equal(t.T(), int64(3), snapshot.BlocksScheduled)
+	assert.Equal(t.T(), int64(1), snapshot.BlocksWasted, "Block 2 was never read.")
+	assert.Equal(t.T(), int64(0), snapshot.BlocksInUse)
+	t.bucket.AssertExpectations(t.T())
+}
+
+func TestOversizedBlockWarning(t *testing.T) {
+	testCases := []struct {
+		name        string
+		blockFill   [blockFillBuckets]int64
+		wantWarning bool
+	}{
+		{"too_few_blocks", [blockFillBuckets]int64{oversizedBlockMinSamples - 1, 0, 0, 0}, false},
+		{"mostly_full_blocks", [blockFillBuckets]int64{10, 0, 5, 85}, false},
+		{"mostly_small_blocks", [blockFillBuckets]int64{80, 10, 5, 5}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warning := oversizedBlockWarning(StatsSnapshot{BlockFill: tc.blockFill}, 16*util.MiB)
+
+			if tc.wantWarning {
+				assert.Contains(t, warning, "80 out of 100 downloaded blocks were less than a quarter full")
+				assert.Contains(t, warning, "16 MiB block size")
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
+
+func TestRecordBlockFill(t *testing.T) {
+	stats := NewStats()
+
+	stats.recordBlockFill(0, 1024)
+	stats.recordBlockFill(255, 1024)
+	stats.recordBlockFill(256, 1024)
+	stats.recordBlockFill(1023, 1024)
+	stats.recordBlockFill(1024, 1024)
+
+	assert.Equal(t, [blockFillBuckets]int64{2, 1, 0, 2}, stats.Snapshot().BlockFill)
+}
+
+func (t *BufferedReaderTest) TestSmallObjectReadsWarnOfOversizedBlocks() {
+	var buf syncBuffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	t.object.Size = 100
+	stats := NewStats()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WarnOnOversizedBlocks(ctx, stats, 10*time.Millisecond, testPrefetchBlockSizeBytes)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+
+	for range oversizedBlockMinSamples {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.Anything).Return(createFakeReaderWithOffset(t.T(), int(t.object.Size), 0), nil).Once()
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: gcsx.NewReadTypeClassifier(1, 0),
+			Stats:              stats,
+		})
+		require.NoError(t.T(), err)
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, 0)
+		resp.Callback()
+		reader.Destroy()
+	}
+
+	assert.Eventually(t.T(), func() bool {
+		return strings.Contains(buf.String(), "were less than a quarter full")
+	}, time.Second, 5*time.Millisecond)
+	assert.Contains(t.T(), buf.String(), "WARNING")
+}
+
+func TestOverPrefetchWarning(t *testing.T) {
+	testCases := []struct {
+		name        string
+		delta       StatsSnapshot
+		wantWarning bool
+	}{
+		{"too_few_files", StatsSnapshot{FilesClosed: overPrefetchMinFiles - 1, FilesClosedWithWaste: overPrefetchMinFiles - 1, BlocksScheduled: 100, BlocksWasted: 90}, false},
+		{"few_files_with_waste", StatsSnapshot{FilesClosed: 40, FilesClosedWithWaste: 10, BlocksScheduled: 100, BlocksWasted: 90}, false},
+		{"low_waste_rate", StatsSnapshot{FilesClosed: 40, FilesClosedWithWaste: 40, BlocksScheduled: 100, BlocksWasted: 10}, false},
+		{"over_prefetch", StatsSnapshot{FilesClosed: 40, FilesClosedWithWaste: 30, BlocksScheduled: 100, BlocksWasted: 60, BlocksWastedOnClose: 45}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warning := overPrefetchWarning(tc.delta)
+
+			if tc.wantWarning {
+				assert.Contains(t, warning, "30 out of 40 files were closed with prefetched blocks never read")
+				assert.Contains(t, warning, "60 out of 100 blocks scheduled were never read (45 still queued on close, 15 evicted while reading)")
+				assert.Contains(t, warning, "reduce the read-ahead distance")
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
+
+func (t *BufferedReaderTest) TestPartialFileReadsWarnOfOverPrefetch() {
+	var buf syncBuffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	t.object.Size = uint64(3 * testPrefetchBlockSizeBytes)
+	stats := NewStats()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		WarnOnOverPrefetch(ctx, stats, 10*time.Millisecond)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	t.bucket.On("Name").Return("test-bucket").Maybe() // Bucket name used for logging.
+	// The prefetched blocks are never read, and their downloads may be
+	// cancelled on close.
+	t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+		return r.Range.Start != 0
+	})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), testPrefetchBlockSizeBytes), nil).Maybe()
+
+	// Each file is closed after reading the beginning of its first block only.
+	for range overPrefetchMinFiles {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == 0
+		})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), 0), nil).Once()
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: t.globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: gcsx.NewReadTypeClassifier(1, 0),
+			Stats:              stats,
+		})
+		require.NoError(t.T(), err)
+		resp, err := reader.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, 10), Offset: 0})
+		require.NoError(t.T(), err)
+		assertReadResponseContent(t.T(), resp, 0)
+		resp.Callback()
+		reader.Destroy()
+	}
+
+	snapshot := stats.Snapshot()
+	assert.Equal(t.T(), int64(overPrefetchMinFiles), snapshot.FilesClosedWithWaste)
+	assert.Equal(t.T(), snapshot.BlocksWasted, snapshot.BlocksWastedOnClose)
+	assert.Eventually(t.T(), func() bool {
+		return strings.Contains(buf.String(), "Consider a lower read-max-blocks-per-handle")
+	}, time.Second, 5*time.Millisecond)
+	assert.Contains(t.T(), buf.String(), "WARNING")
+}
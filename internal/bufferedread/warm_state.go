@@ -0,0 +1,251 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"golang.org/x/sync/errgroup"
+)
+
+// WarmStateEntry is the [Start, End) byte range of a generation of an object
+// whose blocks are pinned, recorded so that they can be downloaded again
+// after a remount.
+type WarmStateEntry struct {
+	Bucket     string `json:"bucket"`
+	Object     string `json:"object"`
+	Generation int64  `json:"generation"`
+	Start      int64  `json:"start"`
+	End        int64  `json:"end"`
+}
+
+// SaveWarmState writes the entries to a JSON file, replacing it atomically.
+func SaveWarmState(path string, entries []WarmStateEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encoding warm state: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("writing warm state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("writing warm state: %w", err)
+	}
+	return nil
+}
+
+// LoadWarmState reads the entries written by SaveWarmState.
+func LoadWarmState(path string) ([]WarmStateEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading warm state: %w", err)
+	}
+	var entries []WarmStateEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing warm state %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// WarmState returns the byte ranges of the pinned blocks, merging the
+// contiguous blocks of an object generation into a single entry.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) WarmState() []WarmStateEntry {
+	s.mu.Lock()
+	blocks := make([]WarmStateEntry, 0, len(s.blocks))
+	for key, b := range s.blocks {
+		blocks = append(blocks, WarmStateEntry{
+			Bucket:     key.bucketName,
+			Object:     key.objectName,
+			Generation: key.generation,
+			Start:      b.AbsStartOff(),
+			End:        b.AbsStartOff() + b.Size(),
+		})
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(blocks, func(a, b WarmStateEntry) int {
+		return cmp.Or(cmp.Compare(a.Bucket, b.Bucket), cmp.Compare(a.Object, b.Object), cmp.Compare(a.Generation, b.Generation), cmp.Compare(a.Start, b.Start))
+	})
+	var entries []WarmStateEntry
+	for _, b := range blocks {
+		if n := len(entries); n > 0 {
+			last := &entries[n-1]
+			if last.Bucket == b.Bucket && last.Object == b.Object && last.Generation == b.Generation && last.End == b.Start {
+				last.End = b.End
+				continue
+			}
+		}
+		entries = append(entries, b)
+	}
+	return entries
+}
+
+// Rewarm downloads and pins the blocks covering the entries of the given
+// bucket, up to parallelism blocks at a time, so that the blocks pinned before
+// a remount are served from memory again. Entries of objects no longer pinned,
+// or whose generation was replaced, are skipped. It stops once the pinned
+// blocks budget is exhausted or ctx is cancelled, and returns the number of
+// blocks pinned.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) Rewarm(ctx context.Context, bucket gcs.Bucket, entries []WarmStateEntry, config *BufferedReadConfig, parallelism int64) int {
+	var objectNames []string
+	objectEntries := make(map[string][]WarmStateEntry)
+	for _, e := range entries {
+		if e.Bucket != bucket.Name() || !s.isPinned(e.Object) {
+			continue
+		}
+		if _, ok := objectEntries[e.Object]; !ok {
+			objectNames = append(objectNames, e.Object)
+		}
+		objectEntries[e.Object] = append(objectEntries[e.Object], e)
+	}
+	s.metricHandle.BufferedReadWarmupObjects(int64(len(objectNames)), metrics.WarmupStateTotalAttr)
+
+	var pinnedCount atomic.Int64
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(int(parallelism))
+	for _, name := range objectNames {
+		if groupCtx.Err() != nil {
+			break
+		}
+		object, keys := s.rewarmKeys(groupCtx, bucket, objectEntries[name], config)
+		if len(keys) == 0 {
+			s.metricHandle.BufferedReadWarmupObjects(1, metrics.WarmupStateDoneAttr)
+			continue
+		}
+		blockSize := alignedBlockSize(int64(object.Size), config)
+		var remaining atomic.Int64
+		remaining.Store(int64(len(keys)))
+		for _, key := range keys {
+			group.Go(func() error {
+				ok, err := s.rewarmBlock(groupCtx, bucket, object, key, blockSize, config)
+				if err != nil {
+					return err
+				}
+				if ok {
+					pinnedCount.Add(1)
+				}
+				if remaining.Add(-1) == 0 {
+					s.metricHandle.BufferedReadWarmupObjects(1, metrics.WarmupStateDoneAttr)
+				}
+				return nil
+			})
+		}
+	}
+	if err := group.Wait(); err != nil && ctx.Err() == nil {
+		logger.Warnf("Rewarm: %v", err)
+	}
+	return int(pinnedCount.Load())
+}
+
+// rewarmKeys returns the latest generation of the object of the entries,
+// along with the keys of the blocks covering them. No key is returned if the
+// object can't be stat'ed or its generation was replaced.
+func (s *PinnedBlockStore) rewarmKeys(ctx context.Context, bucket gcs.Bucket, entries []WarmStateEntry, config *BufferedReadConfig) (*gcs.MinObject, []sharedBlockKey) {
+	name := entries[0].Object
+	object, _, err := bucket.StatObject(ctx, &gcs.StatObjectRequest{Name: name})
+	if err != nil {
+		logger.Warnf("Rewarm: skipping object %q: %v", name, err)
+		return nil, nil
+	}
+	blockSize := alignedBlockSize(int64(object.Size), config)
+	var keys []sharedBlockKey
+	for _, e := range entries {
+		if object.Generation != e.Generation {
+			logger.Infof("Rewarm: skipping object %q, whose generation %d was replaced by %d.", name, e.Generation, object.Generation)
+			continue
+		}
+		for blockIndex := e.Start / blockSize; blockIndex*blockSize < min(e.End, int64(object.Size)); blockIndex++ {
+			keys = append(keys, sharedBlockKey{bucketName: e.Bucket, objectName: name, generation: e.Generation, blockIndex: blockIndex})
+		}
+	}
+	return object, keys
+}
+
+// rewarmBlock downloads the block under key straight into a block of the
+// store, and pins it. Returns false if the block is already pinned, and an
+// error if no block could be downloaded.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) rewarmBlock(ctx context.Context, bucket gcs.Bucket, object *gcs.MinObject, key sharedBlockKey, blockSize int64, config *BufferedReadConfig) (bool, error) {
+	s.mu.Lock()
+	if _, ok := s.blocks[key]; ok {
+		s.mu.Unlock()
+		return false, nil
+	}
+	b, err := s.pool.TryGetSized(int64(object.Size) - key.blockIndex*blockSize)
+	s.mu.Unlock()
+	if err != nil {
+		return false, fmt.Errorf("no block to pin (%s, %d): %w", key.objectName, key.blockIndex, err)
+	}
+	if err := b.SetAbsStartOff(key.blockIndex * blockSize); err != nil {
+		s.releaseBlock(b)
+		return false, fmt.Errorf("pinning block (%s, %d): %w", key.objectName, key.blockIndex, err)
+	}
+
+	task := &downloadTask{
+		ctx:          ctx,
+		object:       object,
+		bucket:       bucket,
+		block:        b,
+		blockSize:    blockSize,
+		deadline:     config.DownloadDeadline,
+		maxRetries:   config.DownloadMaxRetries,
+		retryBackoff: config.DownloadRetryBackoff,
+		verifyObject: config.VerifyObjectChecksum,
+		metricHandle: s.metricHandle,
+		class:        metrics.DownloadClassWarmupAttr,
+	}
+	task.Execute()
+	// The first wait on a block records its status, which the readers sharing
+	// the block then observe.
+	if status, err := b.AwaitReady(ctx); err != nil || status.State != block.BlockStateDownloaded {
+		s.releaseBlock(b)
+		return false, fmt.Errorf("downloading block (%s, %d): %v %v", key.objectName, key.blockIndex, err, status.Err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objectKey := pinnedObjectKey{bucketName: key.bucketName, objectName: key.objectName}
+	_, pinnedAlready := s.blocks[key]
+	if generation, ok := s.generations[objectKey]; pinnedAlready || (ok && generation != key.generation) {
+		// Pinned meanwhile by a read, possibly of a newer generation.
+		s.pool.Release(b)
+		return false, nil
+	}
+	s.generations[objectKey] = key.generation
+	s.blocks[key] = b
+	s.metricHandle.BufferedReadPinnedBytes(b.Size())
+	return true, nil
+}
+
+// releaseBlock returns a block not pinned to the pool.
+// LOCKS_EXCLUDED(s.mu)
+func (s *PinnedBlockStore) releaseBlock(b block.PrefetchBlock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pool.Release(b)
+}
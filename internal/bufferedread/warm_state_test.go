@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufferedread
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestWarmStateMergesContiguousBlocks(t *testing.T) {
+	store, err := NewPinnedBlockStore([]string{"a", "b"}, testPrefetchBlockSizeBytes, 4, metrics.NewNoopMetrics())
+	require.NoError(t, err)
+	pool, err := block.NewPrefetchBlockPool(testPrefetchBlockSizeBytes, 8, 0, semaphore.NewWeighted(8))
+	require.NoError(t, err)
+	key := func(objectName string, blockIndex int64) sharedBlockKey {
+		return sharedBlockKey{bucketName: "bucket", objectName: objectName, generation: 1, blockIndex: blockIndex}
+	}
+	store.pin(key("a", 0), downloadedBlock(t, pool, 0))
+	store.pin(key("a", 1), downloadedBlock(t, pool, testPrefetchBlockSizeBytes))
+	store.pin(key("a", 3), downloadedBlock(t, pool, 3*testPrefetchBlockSizeBytes))
+	store.pin(key("b", 0), downloadedBlock(t, pool, 0))
+
+	entries := store.WarmState()
+
+	assert.Equal(t, []WarmStateEntry{
+		{Bucket: "bucket", Object: "a", Generation: 1, Start: 0, End: 2 * testPrefetchBlockSizeBytes},
+		{Bucket: "bucket", Object: "a", Generation: 1, Start: 3 * testPrefetchBlockSizeBytes, End: 4 * testPrefetchBlockSizeBytes},
+		{Bucket: "bucket", Object: "b", Generation: 1, Start: 0, End: testPrefetchBlockSizeBytes},
+	}, entries)
+}
+
+func TestLoadWarmStateMissingFile(t *testing.T) {
+	_, err := LoadWarmState(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.ErrorContains(t, err, "reading warm state")
+}
+
+func (t *BufferedReaderTest) TestRewarmRestoresPinnedBlocksAfterRemount() {
+	t.object.Size = uint64(2 * testPrefetchBlockSizeBytes)
+	t.bucket.On("Name").Return("test-bucket")
+	newReader := func(store *PinnedBlockStore, globalMaxBlocksSem *semaphore.Weighted) *BufferedReader {
+		reader, err := NewBufferedReader(&BufferedReaderOptions{
+			Object:             t.object,
+			Bucket:             t.bucket,
+			Config:             t.config,
+			GlobalMaxBlocksSem: globalMaxBlocksSem,
+			WorkerPool:         t.workerPool,
+			MetricHandle:       t.metricHandle,
+			ReadTypeClassifier: gcsx.NewReadTypeClassifier(1, 0),
+			PinnedBlockStore:   store,
+		})
+		require.NoError(t.T(), err)
+		return reader
+	}
+	// Every block is downloaded once by the read before the unmount, and once
+	// more by the rewarm after the remount.
+	for range 2 {
+		for i := range int64(2) {
+			t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+				return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+			})).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+		}
+	}
+	t.bucket.On("StatObject", mock.Anything, mock.Anything).Return(t.object, &gcs.ExtendedObjectAttributes{}, nil).Once()
+	store1, err := NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, 2, t.metricHandle)
+	require.NoError(t.T(), err)
+	reader1 := newReader(store1, t.globalMaxBlocksSem)
+	resp, err := reader1.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+	require.NoError(t.T(), err)
+	resp.Callback()
+	reader1.Destroy()
+	path := filepath.Join(t.T().TempDir(), "warm_state.json")
+	require.NoError(t.T(), SaveWarmState(path, store1.WarmState()))
+	entries, err := LoadWarmState(path)
+	require.NoError(t.T(), err)
+	store2, err := NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, 2, t.metricHandle)
+	require.NoError(t.T(), err)
+
+	pinned := store2.Rewarm(t.ctx, t.bucket, entries, t.config, 1)
+
+	assert.Equal(t.T(), 2, pinned)
+	assert.Equal(t.T(), store1.WarmState(), store2.WarmState())
+	// The rewarmed blocks serve the reads, although no block can be allocated.
+	t.config.MinBlocksPerHandle = 0
+	reader2 := newReader(store2, semaphore.NewWeighted(0))
+	resp, err = reader2.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, t.object.Size), Offset: 0})
+	require.NoError(t.T(), err)
+	assertReadResponseContent(t.T(), resp, 0)
+	t.bucket.AssertExpectations(t.T())
+	resp.Callback()
+	reader2.Destroy()
+}
+
+func (t *BufferedReaderTest) TestRewarmSkipsReplacedGeneration() {
+	t.bucket.On("Name").Return("test-bucket")
+	t.bucket.On("StatObject", mock.Anything, mock.Anything).Return(t.object, &gcs.ExtendedObjectAttributes{}, nil).Once()
+	store, err := NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, 2, t.metricHandle)
+	require.NoError(t.T(), err)
+	entries := []WarmStateEntry{
+		{Bucket: "test-bucket", Object: t.object.Name, Generation: t.object.Generation - 1, Start: 0, End: testPrefetchBlockSizeBytes},
+		{Bucket: "test-bucket", Object: "unpinned", Generation: 1, Start: 0, End: testPrefetchBlockSizeBytes},
+		{Bucket: "other-bucket", Object: t.object.Name, Generation: t.object.Generation, Start: 0, End: testPrefetchBlockSizeBytes},
+	}
+
+	pinned := store.Rewarm(t.ctx, t.bucket, entries, t.config, 1)
+
+	assert.Equal(t.T(), 0, pinned)
+	assert.Empty(t.T(), store.WarmState())
+	t.bucket.AssertExpectations(t.T())
+}
+
+func (t *BufferedReaderTest) TestRewarmBoundsConcurrentDownloads() {
+	const blockCount = 6
+	const parallelism = 2
+	t.object.Size = uint64(blockCount * testPrefetchBlockSizeBytes)
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	t.bucket.On("Name").Return("test-bucket")
+	t.bucket.On("StatObject", mock.Anything, mock.Anything).Return(t.object, &gcs.ExtendedObjectAttributes{}, nil).Once()
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	for i := range int64(blockCount) {
+		t.bucket.On("NewReaderWithReadHandle", mock.Anything, mock.MatchedBy(func(r *gcs.ReadObjectRequest) bool {
+			return r.Range.Start == uint64(i*testPrefetchBlockSizeBytes)
+		})).Run(func(mock.Arguments) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}).Return(createFakeReaderWithOffset(t.T(), int(testPrefetchBlockSizeBytes), i*testPrefetchBlockSizeBytes), nil).Once()
+	}
+	store, err := NewPinnedBlockStore([]string{t.object.Name}, testPrefetchBlockSizeBytes, blockCount, mh)
+	require.NoError(t.T(), err)
+	entries := []WarmStateEntry{
+		{Bucket: "test-bucket", Object: t.object.Name, Generation: t.object.Generation, Start: 0, End: int64(t.object.Size)},
+	}
+
+	pinned := store.Rewarm(t.ctx, t.bucket, entries, t.config, parallelism)
+
+	assert.Equal(t.T(), blockCount, pinned)
+	assert.LessOrEqual(t.T(), maxInFlight, parallelism)
+	t.bucket.AssertExpectations(t.T())
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/warmup_objects", attribute.NewSet(attribute.String("warmup_state", "total")), 1)
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/warmup_objects", attribute.NewSet(attribute.String("warmup_state", "done")), 1)
+}
@@ -308,6 +308,33 @@ func (chr *CacheHandler) InvalidateCache(objectName string, bucketName string) e
 	return nil
 }
 
+// IsFullyCached returns true if the whole content of the given generation of
+// the object is present in the cache, so that any range of it can be read
+// from the cache file without downloading anything.
+func (chr *CacheHandler) IsFullyCached(object *gcs.MinObject, bucket gcs.Bucket) bool {
+	fileInfoKey := data.FileInfoKey{
+		BucketName: bucket.Name(),
+		ObjectName: object.Name,
+	}
+	fileInfoKeyName, err := fileInfoKey.Key()
+	if err != nil {
+		return false
+	}
+
+	fileInfo := chr.fileInfoCache.LookUpWithoutChangingOrder(fileInfoKeyName)
+	if fileInfo == nil {
+		return false
+	}
+	fileInfoData, ok := fileInfo.(data.FileInfo)
+	if !ok || fileInfoData.ObjectGeneration != object.Generation {
+		return false
+	}
+	if fileInfoData.SparseMode {
+		return fileInfoData.DownloadedChunks != nil && fileInfoData.DownloadedChunks.ContainsRange(0, object.Size)
+	}
+	return fileInfoData.Offset >= object.Size
+}
+
 // Destroy destroys the job manager (i.e. invalidate all the jobs).
 // Note: This method is expected to be called at the time of unmounting and
 // because file info cache is in-memory, it is not required to destroy it.
@@ -1037,6 +1037,24 @@ func Test_InvalidateCache_GetCacheHandle_Concurrent(t *testing.T) {
 	}
 }
 
+func Test_IsFullyCached(t *testing.T) {
+	cacheDir := path.Join(os.Getenv("HOME"), "CacheHandlerTest/dir")
+	chTestArgs := initializeCacheHandlerTestArgs(t, &cfg.FileCacheConfig{EnableCrc: true}, cacheDir)
+	// The test entry has nothing downloaded yet.
+	require.False(t, chTestArgs.cacheHandler.IsFullyCached(chTestArgs.object, chTestArgs.bucket))
+	fileInfoKey := data.FileInfoKey{BucketName: chTestArgs.bucket.Name(), ObjectName: chTestArgs.object.Name}
+	fileInfo := data.NewFileInfo(fileInfoKey, chTestArgs.object.Generation, chTestArgs.object.Size, chTestArgs.object.Size, false, nil, 0)
+	_, err := chTestArgs.cache.Insert(chTestArgs.fileInfoKeyName, fileInfo)
+	require.NoError(t, err)
+
+	assert.True(t, chTestArgs.cacheHandler.IsFullyCached(chTestArgs.object, chTestArgs.bucket))
+	newerObject := *chTestArgs.object
+	newerObject.Generation++
+	assert.False(t, chTestArgs.cacheHandler.IsFullyCached(&newerObject, chTestArgs.bucket))
+	otherObject := createObject(t, chTestArgs.bucket, "object_1", []byte("content of object_1"))
+	assert.False(t, chTestArgs.cacheHandler.IsFullyCached(otherObject, chTestArgs.bucket))
+}
+
 func Test_Destroy(t *testing.T) {
 	tbl := []struct {
 		name                     string
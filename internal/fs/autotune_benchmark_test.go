@@ -0,0 +1,295 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// An autotuner of the buffered reads, sweeping the combinations of block size
+// and prefetch count over a workload and suggesting the flags of the one that
+// performed best. The workload is either synthetic, or a trace of the reads of
+// a real one replayed against the benchmark bucket. Run it with:
+//
+//	go test ./internal/fs -run '^$' -bench BenchmarkAutotune -benchtime 5x \
+//	  -autotune.trace=reads.jsonl \
+//	  -autotune.flags='--enable-dummy-io --dummy-io-reader-latency=20ms --dummy-io-per-mb-latency=5ms'
+//
+// The candidates are resolved like the profile benchmarks, on top of the
+// profile and flags given, and compared with them as given.
+
+package fs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	autotuneWorkload  = flag.String("autotune.workload", "sequential", "The synthetic workload to autotune the buffered reads for: sequential or random. Ignored with -autotune.trace.")
+	autotuneTrace     = flag.String("autotune.trace", "", "The file of the reads to autotune the buffered reads for, one JSON object per line with the object name, offset and size of a read.")
+	autotuneObjective = flag.String("autotune.objective", "throughput", "What the autotuned buffered reads maximize: throughput, or latency to minimize the p99 read latency.")
+	autotuneProfile   = flag.String("autotune.profile", "", "The profile of the mount autotuned.")
+	autotuneFlags     = flag.String("autotune.flags", "", "The space-separated flags of the mount autotuned, e.g. to simulate the GCS latency with dummy I/O.")
+)
+
+// The block sizes and prefetch counts swept by the autotuner.
+var (
+	autotuneBlockSizesMb   = []int{4, 8, 16, 32}
+	autotunePrefetchCounts = []int{4, 8, 16, 32}
+)
+
+const autotuneRandomReadCount = 256
+
+// traceRead is a read of a workload trace.
+type traceRead struct {
+	Object string `json:"object"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+}
+
+// loadAutotuneTrace reads the trace of the given file, skipping the blank
+// lines.
+func loadAutotuneTrace(name string) ([]traceRead, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reads []traceRead
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var r traceRead
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if r.Object == "" || r.Offset < 0 || r.Size <= 0 {
+			return nil, fmt.Errorf("line %d: invalid read %+v", line, r)
+		}
+		reads = append(reads, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(reads) == 0 {
+		return nil, fmt.Errorf("no reads in %q", name)
+	}
+	return reads, nil
+}
+
+// syntheticAutotuneTrace returns the trace of the given synthetic workload,
+// over the same object as the profile benchmarks.
+func syntheticAutotuneTrace(workload string) ([]traceRead, error) {
+	var reads []traceRead
+	switch workload {
+	case "sequential":
+		for offset := int64(0); offset < benchmarkLargeObjectSize; offset += benchmarkLargeReadSize {
+			reads = append(reads, traceRead{Object: "large", Offset: offset, Size: benchmarkLargeReadSize})
+		}
+	case "random":
+		// A fixed seed, for all the candidates to read the same offsets.
+		rnd := rand.New(rand.NewPCG(1, 2))
+		for range autotuneRandomReadCount {
+			offset := rnd.Int64N(benchmarkLargeObjectSize - benchmarkSmallReadSize)
+			reads = append(reads, traceRead{Object: "large", Offset: offset, Size: benchmarkSmallReadSize})
+		}
+	default:
+		return nil, fmt.Errorf("unknown workload %q; should be sequential or random", workload)
+	}
+	return reads, nil
+}
+
+// traceObjects returns the objects read by the trace, each as large as its
+// furthest read, along with the placeholder objects of their directories.
+func traceObjects(reads []traceRead) map[string][]byte {
+	sizes := make(map[string]int64)
+	for _, r := range reads {
+		sizes[r.Object] = max(sizes[r.Object], r.Offset+r.Size)
+	}
+	objects := make(map[string][]byte, len(sizes))
+	for name, size := range sizes {
+		objects[name] = make([]byte, size)
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			objects[dir+"/"] = nil
+		}
+	}
+	return objects
+}
+
+// lookUpTraceObject looks up the file of the given object, through the
+// directories of its name.
+func lookUpTraceObject(b *testing.B, fs *fileSystem, object string) fuseops.InodeID {
+	b.Helper()
+	inode := fuseops.InodeID(fuseops.RootInodeID)
+	for _, name := range strings.Split(object, "/") {
+		inode = lookUpBenchmarkFile(b, fs, inode, name)
+	}
+	return inode
+}
+
+// autotuneCandidate is a combination of buffered read settings, the zero one
+// standing for the mount as given.
+type autotuneCandidate struct {
+	blockSizeMb   int
+	prefetchCount int
+}
+
+func (c autotuneCandidate) name() string {
+	if c == (autotuneCandidate{}) {
+		return "baseline"
+	}
+	return fmt.Sprintf("block-%dMiB/prefetch-%d", c.blockSizeMb, c.prefetchCount)
+}
+
+// flags returns the flags setting the candidate.
+func (c autotuneCandidate) flags() []string {
+	if c == (autotuneCandidate{}) {
+		return nil
+	}
+	return []string{
+		"--enable-buffered-read",
+		fmt.Sprintf("--read-block-size-mb=%d", c.blockSizeMb),
+		fmt.Sprintf("--read-max-blocks-per-handle=%d", c.prefetchCount),
+	}
+}
+
+// autotuneResult is the performance of a candidate over the workload.
+type autotuneResult struct {
+	bytesPerSec float64
+	p99         time.Duration
+}
+
+// better reports whether r is better than other for the given objective.
+func (r autotuneResult) better(other autotuneResult, objective string) bool {
+	if objective == "latency" {
+		return r.p99 < other.p99
+	}
+	return r.bytesPerSec > other.bytesPerSec
+}
+
+// replayTrace replays the reads of the trace in order, all of them per
+// iteration, with a handle per object opened for the whole benchmark.
+func replayTrace(b *testing.B, fs *fileSystem, reads []traceRead) autotuneResult {
+	ctx := context.Background()
+	type openFile struct {
+		inode  fuseops.InodeID
+		handle fuseops.HandleID
+	}
+	files := make(map[string]openFile)
+	var maxSize, traceBytes int64
+	for _, r := range reads {
+		maxSize = max(maxSize, r.Size)
+		traceBytes += r.Size
+		if _, ok := files[r.Object]; ok {
+			continue
+		}
+		inode := lookUpTraceObject(b, fs, r.Object)
+		openOp := &fuseops.OpenFileOp{Inode: inode}
+		require.NoError(b, fs.OpenFile(ctx, openOp))
+		b.Cleanup(func() { _ = fs.ReleaseFileHandle(ctx, &fuseops.ReleaseFileHandleOp{Handle: openOp.Handle}) })
+		files[r.Object] = openFile{inode: inode, handle: openOp.Handle}
+	}
+	dst := make([]byte, maxSize)
+	var latencies latencyRecorder
+	b.SetBytes(traceBytes)
+	b.ResetTimer()
+
+	iterations := 0
+	for b.Loop() {
+		iterations++
+		for _, r := range reads {
+			f := files[r.Object]
+			latencies.time(b, func() error { return readBenchmarkFile(fs, f.inode, f.handle, r.Offset, dst[:r.Size]) })
+		}
+	}
+
+	b.StopTimer()
+	latencies.report(b)
+	reportPrefetchEfficiency(b, fs)
+	slices.Sort(latencies.latencies)
+	return autotuneResult{
+		bytesPerSec: float64(traceBytes) * float64(iterations) / b.Elapsed().Seconds(),
+		p99:         latencies.latencies[(len(latencies.latencies)-1)*99/100],
+	}
+}
+
+// improvement returns the improvement of the best result over the baseline, in
+// percent, for the given objective.
+func improvement(best, baseline autotuneResult, objective string) float64 {
+	if objective == "latency" {
+		return 100 * (baseline.p99.Seconds() - best.p99.Seconds()) / baseline.p99.Seconds()
+	}
+	return 100 * (best.bytesPerSec - baseline.bytesPerSec) / baseline.bytesPerSec
+}
+
+func BenchmarkAutotune(b *testing.B) {
+	if *autotuneObjective != "throughput" && *autotuneObjective != "latency" {
+		b.Fatalf("unknown objective %q; should be throughput or latency", *autotuneObjective)
+	}
+	var reads []traceRead
+	var err error
+	if *autotuneTrace != "" {
+		reads, err = loadAutotuneTrace(*autotuneTrace)
+	} else {
+		reads, err = syntheticAutotuneTrace(*autotuneWorkload)
+	}
+	require.NoError(b, err)
+	objects := traceObjects(reads)
+	mountFlags := strings.Fields(*autotuneFlags)
+
+	candidates := []autotuneCandidate{{}}
+	for _, blockSizeMb := range autotuneBlockSizesMb {
+		for _, prefetchCount := range autotunePrefetchCounts {
+			candidates = append(candidates, autotuneCandidate{blockSizeMb: blockSizeMb, prefetchCount: prefetchCount})
+		}
+	}
+	results := make(map[autotuneCandidate]autotuneResult)
+	for _, c := range candidates {
+		b.Run(c.name(), func(b *testing.B) {
+			fs := newBenchmarkFileSystem(b, *autotuneProfile, objects, append(slices.Clone(mountFlags), c.flags()...)...)
+			results[c] = replayTrace(b, fs, reads)
+		})
+	}
+
+	baseline, ok := results[autotuneCandidate{}]
+	if !ok {
+		return
+	}
+	best, bestResult := autotuneCandidate{}, baseline
+	for _, c := range candidates {
+		if r, ok := results[c]; ok && r.better(bestResult, *autotuneObjective) {
+			best, bestResult = c, r
+		}
+	}
+	if best == (autotuneCandidate{}) {
+		b.Logf("No swept combination beats the mount as given for %s.", *autotuneObjective)
+		return
+	}
+	b.Logf("Suggested flags for %s: %s", *autotuneObjective, strings.Join(best.flags(), " "))
+	b.Logf("Throughput %.1f MiB/s (baseline %.1f MiB/s), p99 latency %v (baseline %v): %.1f%% better %s.",
+		bestResult.bytesPerSec/(1<<20), baseline.bytesPerSec/(1<<20), bestResult.p99, baseline.p99,
+		improvement(bestResult, baseline, *autotuneObjective), *autotuneObjective)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+)
+
+// fadviseXattrName is the extended attribute through which applications pass
+// posix_fadvise(2) advice for a file, as the kernel doesn't forward fadvise
+// calls to FUSE. The value has the form "<willneed|dontneed> <offset> <length>",
+// e.g. "willneed 0 1048576".
+const fadviseXattrName = "user.gcsfuse.fadvise"
+
+// parseFadviseXattr parses the value of the fadvise extended attribute.
+func parseFadviseXattr(value []byte) (advice gcsx.Advice, offset, length int64, err error) {
+	fields := strings.Fields(string(value))
+	if len(fields) != 3 {
+		err = fmt.Errorf("expected \"<advice> <offset> <length>\", got %q", value)
+		return
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "willneed":
+		advice = gcsx.AdviceWillNeed
+	case "dontneed":
+		advice = gcsx.AdviceDontNeed
+	default:
+		err = fmt.Errorf("unsupported advice %q", fields[0])
+		return
+	}
+
+	if offset, err = strconv.ParseInt(fields[1], 10, 64); err != nil || offset < 0 {
+		err = fmt.Errorf("invalid offset %q", fields[1])
+		return
+	}
+	if length, err = strconv.ParseInt(fields[2], 10, 64); err != nil || length <= 0 {
+		err = fmt.Errorf("invalid length %q", fields[2])
+		return
+	}
+	return
+}
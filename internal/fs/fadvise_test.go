@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFadviseXattr(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		wantAdvice gcsx.Advice
+		wantOffset int64
+		wantLength int64
+	}{
+		{name: "willneed", value: "willneed 0 1048576", wantAdvice: gcsx.AdviceWillNeed, wantOffset: 0, wantLength: 1048576},
+		{name: "dontneed", value: "dontneed 4096 8192", wantAdvice: gcsx.AdviceDontNeed, wantOffset: 4096, wantLength: 8192},
+		{name: "upper_case_with_trailing_newline", value: "WILLNEED 10 20\n", wantAdvice: gcsx.AdviceWillNeed, wantOffset: 10, wantLength: 20},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			advice, offset, length, err := parseFadviseXattr([]byte(tc.value))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAdvice, advice)
+			assert.Equal(t, tc.wantOffset, offset)
+			assert.Equal(t, tc.wantLength, length)
+		})
+	}
+}
+
+func TestParseFadviseXattrInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "missing_length", value: "willneed 0"},
+		{name: "unsupported_advice", value: "sequential 0 10"},
+		{name: "negative_offset", value: "willneed -1 10"},
+		{name: "zero_length", value: "dontneed 0 0"},
+		{name: "non_numeric_length", value: "dontneed 0 ten"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := parseFadviseXattr([]byte(tc.value))
+
+			assert.Error(t, err)
+		})
+	}
+}
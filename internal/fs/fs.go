@@ -42,6 +42,8 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/bufferedread"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/file"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/file/downloader"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/lru"
@@ -52,6 +54,8 @@ import (
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/locker"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/monitor"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/jacobsa/fuse"
@@ -202,6 +206,7 @@ func NewFileSystem(ctx context.Context, serverCfg *ServerConfig) (fuseutil.FileS
 		dirTypeCacheTTL:            serverCfg.DirTypeCacheTTL,
 		kernelListCacheTTL:         cfg.ListCacheTTLSecsToDuration(serverCfg.NewConfig.FileSystem.KernelListCacheTtlSecs),
 		renameDirLimit:             serverCfg.RenameDirLimit,
+		renameDirOverLimitMode:     serverCfg.NewConfig.FileSystem.RenameDirOverLimitMode,
 		sequentialReadSizeMb:       serverCfg.SequentialReadSizeMb,
 		uid:                        serverCfg.Uid,
 		gid:                        serverCfg.Gid,
@@ -239,10 +244,87 @@ func NewFileSystem(ctx context.Context, serverCfg *ServerConfig) (fuseutil.FileS
 
 	if serverCfg.NewConfig.Read.EnableBufferedRead {
 		var err error
-		fs.bufferedReadWorkerPool, err = workerpool.NewStaticWorkerPoolForCurrentCPU(serverCfg.NewConfig.Read.GlobalMaxBlocks)
+		fs.bufferedReadWorkerPool, err = workerpool.NewStaticWorkerPoolForCurrentCPU(serverCfg.NewConfig.Read.GlobalMaxBlocks, workerpool.WatchdogConfig{
+			StuckTimeout:     serverCfg.NewConfig.Read.WorkerPoolWatchdogTimeout,
+			CancelStuckTasks: serverCfg.NewConfig.Read.WorkerPoolWatchdogCancel,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create worker pool for buffered read: %w", err)
 		}
+		if limit := serverCfg.NewConfig.Read.MaxInflightDownloads; limit > 0 {
+			fs.bufferedReadWorkerPool = bufferedread.NewInflightDownloadLimiter(fs.bufferedReadWorkerPool, limit, fs.metricHandle)
+		}
+		if serverCfg.NewConfig.Read.MaxPrefetchFiles >= 0 {
+			fs.prefetchFilesSem = semaphore.NewWeighted(serverCfg.NewConfig.Read.MaxPrefetchFiles)
+		}
+		fs.sharedBlockRegistry = bufferedread.NewSharedBlockRegistry()
+		if limit := serverCfg.NewConfig.Read.MaxDownloadsPerObject; limit > 0 {
+			fs.objectDownloadLimiter = bufferedread.NewObjectDownloadLimiter(limit, fs.metricHandle)
+		}
+		if limit := serverCfg.NewConfig.Read.DownloadLimitBytesPerSec; limit > 0 {
+			fs.bufferedReadDownloadThrottle, err = bufferedread.NewDownloadThrottle(limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create buffered read download throttle: %w", err)
+			}
+		}
+		if limit := serverCfg.NewConfig.Read.MaxBlocksPerObject; limit > 0 {
+			fs.objectResidentLimiter = bufferedread.NewObjectResidentBlockLimiter(limit, fs.metricHandle)
+		}
+		if readConfig := serverCfg.NewConfig.Read; readConfig.CircuitBreakerFailureThreshold > 0 {
+			fs.downloadCircuitBreaker = bufferedread.NewDownloadCircuitBreaker(readConfig.CircuitBreakerFailureThreshold, readConfig.CircuitBreakerCooldown, timeutil.RealClock())
+		}
+		if readConfig := serverCfg.NewConfig.Read; readConfig.HotFileResidentBlocks > 0 {
+			fs.hotFileTracker = bufferedread.NewHotFileTracker(readConfig.HotFileMinOpens, readConfig.HotFileWindow, timeutil.RealClock())
+		}
+		if manifestPath := string(serverCfg.NewConfig.Read.BlockChecksumManifest); manifestPath != "" {
+			fs.blockChecksumManifest, err = bufferedread.LoadChecksumManifest(manifestPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load block checksum manifest: %w", err)
+			}
+		}
+		if pinned := serverCfg.NewConfig.Read.PinnedObjects; len(pinned) > 0 {
+			// Pinned blocks are reserved out of the global budget, so that regular
+			// reads can't exhaust it.
+			pinnedMaxBlocks := serverCfg.NewConfig.Read.PinnedMaxBlocks
+			fs.pinnedBlockStore, err = bufferedread.NewPinnedBlockStore(pinned, serverCfg.NewConfig.Read.BlockSizeMb*util.MiB, pinnedMaxBlocks, fs.metricHandle)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create pinned block store: %w", err)
+			}
+			fs.globalMaxReadBlocksSem = semaphore.NewWeighted(serverCfg.NewConfig.Read.GlobalMaxBlocks - pinnedMaxBlocks)
+			fs.warmStateFile = string(serverCfg.NewConfig.Read.WarmStateFile)
+		}
+		if serverCfg.NewConfig.Read.PreallocateBlocks {
+			blockSize := serverCfg.NewConfig.Read.BlockSizeMb * util.MiB
+			// Pinned blocks come out of a pool of their own, so only the blocks left
+			// to regular reads are preallocated.
+			numBlocks := serverCfg.NewConfig.Read.GlobalMaxBlocks
+			if fs.pinnedBlockStore != nil {
+				numBlocks -= serverCfg.NewConfig.Read.PinnedMaxBlocks
+			}
+			fs.readBlockArena, err = block.NewPrefetchBlockArena(blockSize, numBlocks)
+			if err != nil {
+				logger.Warnf("Failed to preallocate %d buffered read blocks, allocating them on demand: %v", numBlocks, err)
+			} else {
+				logger.Infof("Preallocated %d buffered read blocks of %d MiB.", numBlocks, serverCfg.NewConfig.Read.BlockSizeMb)
+				fs.metricHandle.BufferedReadPreallocatedBytes(numBlocks * blockSize)
+			}
+		}
+		fs.bufferedReadStats = bufferedread.NewStats()
+		fs.openObjectRegistry = bufferedread.NewOpenObjectRegistry()
+		monitor.RegisterDebugHandler("buffered-read/objects", fs.openObjectRegistry)
+		if interval := serverCfg.NewConfig.Read.EfficiencySummaryInterval; interval > 0 {
+			var summaryCtx context.Context
+			summaryCtx, fs.stopBufferedReadSummary = context.WithCancel(context.Background())
+			go bufferedread.LogStatsSummaryPeriodically(summaryCtx, fs.bufferedReadStats, interval, serverCfg.NewConfig.Read.GlobalMaxBlocks)
+		}
+		var checkCtx context.Context
+		checkCtx, fs.stopBufferedReadChecks = context.WithCancel(context.Background())
+		go bufferedread.WarnOnOversizedBlocks(checkCtx, fs.bufferedReadStats, bufferedread.OversizedBlockCheckInterval, serverCfg.NewConfig.Read.BlockSizeMb*util.MiB)
+		go bufferedread.WarnOnOverPrefetch(checkCtx, fs.bufferedReadStats, bufferedread.OverPrefetchCheckInterval)
+	}
+
+	if interval := serverCfg.NewConfig.Read.FailureDiagnosticInterval; interval > 0 {
+		fs.readFailureDiagnostic = newReadFailureDiagnostic(interval, timeutil.RealClock())
 	}
 
 	// Set up root bucket
@@ -268,6 +350,9 @@ func NewFileSystem(ctx context.Context, serverCfg *ServerConfig) (fuseutil.FileS
 		bucketType := syncerBucket.BucketType()
 		if serverCfg.ViperConfig != nil {
 			bucketTypeEnum := cfg.GetBucketType(bucketType.Hierarchical, bucketType.Zonal, bucketType.Pirlo)
+			for _, w := range cfg.ProfileBucketTypeWarnings(serverCfg.ViperConfig, serverCfg.NewConfig, bucketTypeEnum) {
+				logger.Warnf("GCSFuse Config: %s", w)
+			}
 			optimizedFlags := serverCfg.NewConfig.ApplyOptimizations(serverCfg.ViperConfig, &cfg.OptimizationInput{
 				BucketType: bucketTypeEnum,
 			})
@@ -292,6 +377,9 @@ func NewFileSystem(ctx context.Context, serverCfg *ServerConfig) (fuseutil.FileS
 			kernelParams.ApplyGKE(string(serverCfg.NewConfig.FileSystem.KernelParamsFile))
 		}
 		root = makeRootForBucket(fs, syncerBucket)
+		if fs.warmStateFile != "" {
+			fs.rewarmPinnedBlocks(syncerBucket, &serverCfg.NewConfig.Read)
+		}
 	}
 	root.Lock()
 	root.IncrementLookupCount()
@@ -305,6 +393,33 @@ func NewFileSystem(ctx context.Context, serverCfg *ServerConfig) (fuseutil.FileS
 	return fs, nil
 }
 
+// rewarmPinnedBlocks pins again, in the background, the blocks of the bucket
+// listed in the warm state file saved by the previous mount. Dynamic mounts
+// set up their buckets lazily, so only the blocks of single bucket mounts are
+// rewarmed.
+func (fs *fileSystem) rewarmPinnedBlocks(bucket gcs.Bucket, readCfg *cfg.ReadConfig) {
+	entries, err := bufferedread.LoadWarmState(fs.warmStateFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("Failed to load buffered read warm state, skipping rewarm: %v", err)
+		}
+		return
+	}
+	config := &bufferedread.BufferedReadConfig{
+		PrefetchBlockSizeBytes: readCfg.BlockSizeMb * util.MiB,
+		BlockAlignment:         readCfg.BlockAlignment,
+		DownloadDeadline:       time.Duration(readCfg.DownloadDeadlineSecs) * time.Second,
+		DownloadMaxRetries:     readCfg.DownloadMaxRetries,
+		DownloadRetryBackoff:   readCfg.DownloadRetryBackoff,
+	}
+	var ctx context.Context
+	ctx, fs.stopRewarm = context.WithCancel(context.Background())
+	go func() {
+		pinned := fs.pinnedBlockStore.Rewarm(ctx, bucket, entries, config, readCfg.WarmStateParallelism)
+		logger.Infof("Rewarm: pinned %d blocks of the %d warm state ranges.", pinned, len(entries))
+	}()
+}
+
 // createFileCacheHandler either returns a regular file cache handler with an in-memory LRU cache, or
 // a shared chunk cache manager that allows multiple gcsfuse instances to share the same cache directory
 // on disk, based on the configuration.
@@ -511,6 +626,10 @@ type fileSystem struct {
 	renameDirLimit       int64
 	sequentialReadSizeMb int32
 
+	// renameDirOverLimitMode specifies how renaming a directory with more
+	// descendants than renameDirLimit is handled, see cfg.RenameDirOverLimitMode*.
+	renameDirOverLimitMode string
+
 	// The user and group owning everything in the file system.
 	uid uint32
 	gid uint32
@@ -670,6 +789,77 @@ type fileSystem struct {
 	// This helps control the overall memory usage for buffered reads.
 	globalMaxReadBlocksSem *semaphore.Weighted
 
+	// prefetchFilesSem limits the number of files that can prefetch concurrently
+	// via buffered reads. Files beyond the limit are served demand-only. Nil
+	// means no limit.
+	prefetchFilesSem *semaphore.Weighted
+
+	// sharedBlockRegistry deduplicates buffered read block downloads across
+	// file handles of the same object.
+	sharedBlockRegistry *bufferedread.SharedBlockRegistry
+
+	// bufferedReadStats accumulates buffered read efficiency counters across
+	// file handles, summarized periodically in the logs if configured.
+	bufferedReadStats *bufferedread.Stats
+
+	// blockChecksumManifest holds the expected checksums against which buffered
+	// read blocks are validated. Nil if no manifest is configured.
+	blockChecksumManifest *bufferedread.ChecksumManifest
+
+	// pinnedBlockStore keeps the buffered read blocks of the objects configured
+	// to be pinned. Nil if no object is pinned.
+	pinnedBlockStore *bufferedread.PinnedBlockStore
+
+	// readBlockArena holds the buffered read blocks preallocated at mount time.
+	// Nil if preallocation is disabled or failed.
+	readBlockArena *block.PrefetchBlockArena
+
+	// objectDownloadLimiter bounds the concurrent buffered read block downloads
+	// of any single object. Nil if no limit is configured.
+	objectDownloadLimiter *bufferedread.ObjectDownloadLimiter
+
+	// bufferedReadDownloadThrottle limits the aggregate bandwidth of the
+	// buffered read block downloads. Nil if no limit is configured.
+	bufferedReadDownloadThrottle ratelimit.Throttle
+
+	// objectResidentLimiter bounds the buffered read blocks of any single
+	// object held in the block pool. Nil if no limit is configured.
+	objectResidentLimiter *bufferedread.ObjectResidentBlockLimiter
+
+	// downloadCircuitBreaker fails the buffered reads fast after consecutive
+	// download failures. Nil if disabled.
+	downloadCircuitBreaker *bufferedread.DownloadCircuitBreaker
+
+	// hotFileTracker classifies the objects opened often for buffered reads as
+	// hot. Nil if no floor of blocks is kept prefetched for hot objects.
+	hotFileTracker *bufferedread.HotFileTracker
+
+	// openObjectRegistry lists the prefetch effectiveness of the objects open
+	// for buffered reads. Nil if buffered read is disabled.
+	openObjectRegistry *bufferedread.OpenObjectRegistry
+
+	// stopBufferedReadSummary stops the periodic buffered read efficiency
+	// summary. Nil if the summary is disabled.
+	stopBufferedReadSummary context.CancelFunc
+
+	// stopBufferedReadChecks stops the periodic checks of the buffered read
+	// block size and read-ahead distance against the reads. Nil if buffered
+	// read is disabled.
+	stopBufferedReadChecks context.CancelFunc
+
+	// warmStateFile is the path where the byte ranges of the pinned blocks are
+	// saved on unmount, to be pinned again on the next mount. Empty if no
+	// object is pinned or no warm state file is configured.
+	warmStateFile string
+
+	// stopRewarm stops pinning again the blocks of the previous mount. Nil if
+	// there was nothing to rewarm.
+	stopRewarm context.CancelFunc
+
+	// readFailureDiagnostic rate-limits the diagnostic bundle logged on read
+	// failures. Nil if the diagnostic is disabled.
+	readFailureDiagnostic *readFailureDiagnostic
+
 	// Limits the max number of metadata prefetch background workers across file system when
 	// metadata prefetching is enabled.
 	globalMetadataPrefetchSem *semaphore.Weighted
@@ -1695,7 +1885,19 @@ func (fs *fileSystem) invalidateChildFileCacheIfExist(parentInode inode.DirInode
 	return nil
 }
 
+// errVanishedEntry is returned for the listed entries which vanished before
+// being looked up, like objects replaced by a newer generation than the listed
+// one or local files removed meanwhile.
+var errVanishedEntry = errors.New("entry vanished after being listed")
+
+// skipsVanishedEntries returns true if the listings leave out the entries
+// which vanished before being looked up, rather than failing.
+func (fs *fileSystem) skipsVanishedEntries() bool {
+	return fs.newConfig.List.VanishedEntryMode != cfg.VanishedEntryModeError
+}
+
 // coreToDirentPlus creates a fuseutil.DirentPlus entry from an inode core.
+// Returns errVanishedEntry if the core is older than the inode of the entry.
 // LOCKS_EXCLUDED(fs.mu)
 func (fs *fileSystem) coreToDirentPlus(ctx context.Context, fullName inode.Name, core inode.Core, parInodeCtx context.Context) (entryPlus *fuseutil.DirentPlus, err error) {
 	// Look up or create the inode for the core.
@@ -1704,7 +1906,7 @@ func (fs *fileSystem) coreToDirentPlus(ctx context.Context, fullName inode.Name,
 		return nil, fmt.Errorf("coreToDirentPlus: lookUpOrCreateInodeIfNotStale: %w", err)
 	}
 	if child == nil {
-		return nil, fmt.Errorf("coreToDirentPlus: stale record for %s", path.Base(fullName.LocalName()))
+		return nil, fmt.Errorf("coreToDirentPlus: stale record for %s: %w", path.Base(fullName.LocalName()), errVanishedEntry)
 	}
 	defer child.Unlock()
 
@@ -1775,6 +1977,8 @@ func (fs *fileSystem) localFileEntriesPlus(parent inode.Name) (localEntriesPlus
 
 // lookupAndFetchAttributesForLocalFileEntriesPlus performs a lookup for each local file entry,
 // fetches its attributes, and updates the corresponding DirentPlus.Entry field.
+// The entries of the local files removed since being listed are dropped, unless
+// the vanished entries fail the listing.
 // LOCKS_EXCLUDED(fs.mu)
 func (fs *fileSystem) lookupAndFetchAttributesForLocalFileEntriesPlus(parentName inode.DirInode, localFileEntriesPlus map[string]fuseutil.DirentPlus) (err error) {
 	for localEntryName, localEntryPlus := range localFileEntriesPlus {
@@ -1785,7 +1989,12 @@ func (fs *fileSystem) lookupAndFetchAttributesForLocalFileEntriesPlus(parentName
 		}
 		if child == nil {
 			// This indicates a potential race condition where the file was removed after being listed.
-			return fmt.Errorf("lookupAndFetchAttributesForLocalFileEntriesPlus: local file %q disappeared", localEntryName)
+			if fs.skipsVanishedEntries() {
+				logger.Tracef("ReadDirPlus: skipping local file %q removed after being listed.", localEntryName)
+				delete(localFileEntriesPlus, localEntryName)
+				continue
+			}
+			return fmt.Errorf("lookupAndFetchAttributesForLocalFileEntriesPlus: local file %q disappeared: %w", localEntryName, errVanishedEntry)
 		}
 		// Fetch attributes from the child inode.
 		attrs, err := child.Attributes(context.Background(), false)
@@ -1863,6 +2072,14 @@ func (fs *fileSystem) invalidateCachedEntry(childID fuseops.InodeID) error {
 ////////////////////////////////////////////////////////////////////////
 
 func (fs *fileSystem) Destroy() {
+	if fs.stopRewarm != nil {
+		fs.stopRewarm()
+	}
+	if fs.warmStateFile != "" {
+		if err := bufferedread.SaveWarmState(fs.warmStateFile, fs.pinnedBlockStore.WarmState()); err != nil {
+			logger.Warnf("Failed to save buffered read warm state: %v", err)
+		}
+	}
 	fs.bucketManager.ShutDown()
 	if fs.fileCacheHandler != nil {
 		_ = fs.fileCacheHandler.Destroy()
@@ -1870,6 +2087,15 @@ func (fs *fileSystem) Destroy() {
 	if fs.bufferedReadWorkerPool != nil {
 		fs.bufferedReadWorkerPool.Stop()
 	}
+	if fs.readBlockArena != nil {
+		_ = fs.readBlockArena.Destroy()
+	}
+	if fs.stopBufferedReadSummary != nil {
+		fs.stopBufferedReadSummary()
+	}
+	if fs.stopBufferedReadChecks != nil {
+		fs.stopBufferedReadChecks()
+	}
 }
 
 func (fs *fileSystem) StatFS(
@@ -2268,6 +2494,18 @@ func (fs *fileSystem) CreateFile(
 		fs.newConfig,
 		fs.bufferedReadWorkerPool,
 		fs.globalMaxReadBlocksSem,
+		fs.prefetchFilesSem,
+		fs.sharedBlockRegistry,
+		fs.bufferedReadStats,
+		fs.blockChecksumManifest,
+		fs.pinnedBlockStore,
+		fs.readBlockArena,
+		fs.objectDownloadLimiter,
+		fs.bufferedReadDownloadThrottle,
+		fs.objectResidentLimiter,
+		fs.downloadCircuitBreaker,
+		fs.hotFileTracker,
+		fs.openObjectRegistry,
 		op.Handle,
 	)
 
@@ -2732,8 +2970,14 @@ func (fs *fileSystem) renameHierarchicalDir(ctx context.Context, oldParent inode
 	return
 }
 
+// renameDirProgressInterval is the number of objects between two progress logs
+// of a directory rename exceeding the rename dir limit.
+const renameDirProgressInterval = 1000
+
 // Rename an old directory to a new directory in a non-hierarchical bucket. If the new directory already
 // exists and is non-empty, return ENOTEMPTY.
+// If the old directory has more descendants than the rename dir limit, the
+// rename fails with EMFILE unless the copy-delete over limit mode is set.
 //
 // LOCKS_EXCLUDED(fs.mu)
 // LOCKS_EXCLUDED(oldParent)
@@ -2766,8 +3010,18 @@ func (fs *fileSystem) renameNonHierarchicalDir(
 	if err != nil {
 		return fmt.Errorf("read descendants of the old directory %q: %w", oldName, err)
 	}
-	if len(descendants) > int(fs.renameDirLimit) {
-		return fmt.Errorf("too many objects to be renamed: %w", syscall.EMFILE)
+	overLimit := len(descendants) > int(fs.renameDirLimit)
+	if overLimit {
+		if fs.renameDirOverLimitMode != cfg.RenameDirOverLimitModeCopyDelete {
+			return fmt.Errorf("too many objects to be renamed: %w", syscall.EMFILE)
+		}
+		// Best effort: rename the descendants one by one. A failure midway
+		// leaves them split between the two directories.
+		descendants, err = oldDir.ReadDescendants(ctx, math.MaxInt32)
+		if err != nil {
+			return fmt.Errorf("read descendants of the old directory %q: %w", oldName, err)
+		}
+		logger.Warnf("Renaming %q to %q: %d objects exceed the rename dir limit %d, renaming them one by one", oldName, newName, len(descendants), fs.renameDirLimit)
 	}
 
 	// Create the backing object of the new directory.
@@ -2795,6 +3049,7 @@ func (fs *fileSystem) renameNonHierarchicalDir(
 	}
 
 	// Move all the files from the old directory to the new directory, keeping both directories locked.
+	renamed := 0
 	for _, descendant := range descendants {
 		nameDiff := strings.TrimPrefix(descendant.FullName.GcsObjectName(), oldDir.Name().GcsObjectName())
 		if nameDiff == descendant.FullName.GcsObjectName() {
@@ -2827,6 +3082,11 @@ func (fs *fileSystem) renameNonHierarchicalDir(
 		if err = fs.invalidateChildFileCacheIfExist(oldDir, o.Name); err != nil {
 			return fmt.Errorf("unlink: while invalidating cache for delete file: %w", err)
 		}
+
+		renamed++
+		if overLimit && (renamed%renameDirProgressInterval == 0 || renamed == len(descendants)) {
+			logger.Infof("Renaming %q to %q: renamed %d/%d objects", oldName, newName, renamed, len(descendants))
+		}
 	}
 
 	fs.releaseInodes(&pendingInodes)
@@ -2926,7 +3186,7 @@ func (fs *fileSystem) OpenDir(
 	handleID := fs.nextHandleID
 	fs.nextHandleID++
 
-	fs.handles[handleID] = handle.NewDirHandle(in, fs.implicitDirs)
+	fs.handles[handleID] = handle.NewDirHandle(in, fs.implicitDirs, fs.newConfig.FileSystem.ExperimentalEnableStreamingReaddir)
 	op.Handle = handleID
 
 	fs.mu.Unlock()
@@ -2998,6 +3258,10 @@ func (fs *fileSystem) ReadDirPlus(ctx context.Context, op *fuseops.ReadDirPlusOp
 	var entriesPlus []fuseutil.DirentPlus
 	for fullName, core := range cores {
 		entry, err := fs.coreToDirentPlus(ctx, fullName, *core, in.Context())
+		if errors.Is(err, errVanishedEntry) && fs.skipsVanishedEntries() {
+			logger.Tracef("ReadDirPlus: skipping %q vanished after being listed.", fullName.GcsObjectName())
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -3051,6 +3315,13 @@ func (fs *fileSystem) OpenFile(
 	in.Lock()
 	defer in.Unlock()
 
+	// Reject opens beyond the per-file handle limit, before allocating any
+	// reader resources.
+	if maxHandles := fs.newConfig.FileSystem.MaxHandlesPerFile; maxHandles > 0 && int64(in.OpenFileHandleCount()) >= maxHandles {
+		fs.metricHandle.FsOpenRejectedCount(1)
+		return fmt.Errorf("OpenFile: %q already has the maximum of %d open handles: %w", in.Name().GcsObjectName(), maxHandles, syscall.EMFILE)
+	}
+
 	// Get the fs lock again.
 	fs.mu.Lock()
 	defer fs.mu.Unlock()
@@ -3072,6 +3343,18 @@ func (fs *fileSystem) OpenFile(
 		fs.newConfig,
 		fs.bufferedReadWorkerPool,
 		fs.globalMaxReadBlocksSem,
+		fs.prefetchFilesSem,
+		fs.sharedBlockRegistry,
+		fs.bufferedReadStats,
+		fs.blockChecksumManifest,
+		fs.pinnedBlockStore,
+		fs.readBlockArena,
+		fs.objectDownloadLimiter,
+		fs.bufferedReadDownloadThrottle,
+		fs.objectResidentLimiter,
+		fs.downloadCircuitBreaker,
+		fs.hotFileTracker,
+		fs.openObjectRegistry,
 		op.Handle,
 	)
 
@@ -3118,7 +3401,7 @@ func (fs *fileSystem) ReadFile(
 		}
 	}
 	// Serve the read.
-
+	readSize := int64(len(op.Dst))
 	if fs.newConfig.FileSystem.EnableKernelReader {
 		var resp gcsx.ReadResponse
 		req := &gcsx.ReadRequest{
@@ -3159,6 +3442,9 @@ func (fs *fileSystem) ReadFile(
 	if err == io.EOF {
 		err = nil
 	}
+	if err != nil {
+		fs.maybeLogReadFailureDiagnostic(fh, op.Offset, readSize, err)
+	}
 
 	return
 }
@@ -3292,10 +3578,79 @@ func (fs *fileSystem) ReleaseFileHandle(
 	return
 }
 
+// GetXattr only supports the retention extended attribute of files. Any other
+// attribute is reported missing.
 func (fs *fileSystem) GetXattr(
 	ctx context.Context,
 	op *fuseops.GetXattrOp) (err error) {
-	return syscall.ENOSYS
+	switch op.Name {
+	case retentionXattrName:
+		return fs.getRetentionXattr(ctx, op)
+	default:
+		return syscall.ENODATA
+	}
+}
+
+// SetXattr only supports the fadvise extended attribute, which applies the
+// advice to all open handles of the file, the invalidate attribute of files
+// and the garbage collection skip list attribute of the mount root.
+func (fs *fileSystem) SetXattr(
+	ctx context.Context,
+	op *fuseops.SetXattrOp) error {
+	switch {
+	case op.Name == fadviseXattrName:
+		return fs.setFadviseXattr(op)
+	case op.Name == invalidateXattrName:
+		return fs.setInvalidateXattr(op)
+	case op.Name == gcSkipListXattrName && op.Inode == fuseops.RootInodeID:
+		return fs.setGcSkipListXattr(op)
+	default:
+		return syscall.ENOTSUP
+	}
+}
+
+func (fs *fileSystem) setFadviseXattr(op *fuseops.SetXattrOp) error {
+	advice, offset, length, err := parseFadviseXattr(op.Value)
+	if err != nil {
+		logger.Warnf("SetXattr: parsing %s: %v", fadviseXattrName, err)
+		return syscall.EINVAL
+	}
+
+	fs.mu.Lock()
+	var fileHandles []*handle.FileHandle
+	for _, h := range fs.handles {
+		if fh, ok := h.(*handle.FileHandle); ok && fh.Inode().ID() == op.Inode {
+			fileHandles = append(fileHandles, fh)
+		}
+	}
+	fs.mu.Unlock()
+
+	for _, fh := range fileHandles {
+		if err := fh.Advise(advice, offset, length); err != nil {
+			logger.Warnf("SetXattr: applying advice to inode %d: %v", op.Inode, err)
+		}
+	}
+	return nil
+}
+
+func (fs *fileSystem) setGcSkipListXattr(op *fuseops.SetXattrOp) error {
+	cmd, err := parseGcSkipListXattr(op.Value)
+	if err != nil {
+		logger.Warnf("SetXattr: parsing %s: %v", gcSkipListXattrName, err)
+		return syscall.EINVAL
+	}
+	skipList := fs.bucketManager.GarbageCollectionSkipList()
+	if skipList == nil {
+		return syscall.ENOTSUP
+	}
+
+	switch cmd {
+	case gcSkipListList:
+		logger.Info(formatGcSkipList(skipList.Entries()))
+	case gcSkipListClear:
+		logger.Infof("Cleared %d objects from the garbage collection skip list.", skipList.Clear())
+	}
+	return nil
 }
 
 func (fs *fileSystem) ListXattr(
@@ -372,8 +372,18 @@ type fakeBucketManager struct {
 	tmpObjectPrefix          string
 }
 
+func (bm *fakeBucketManager) GarbageCollectionSkipList() *gcsx.GarbageCollectionSkipList {
+	return nil
+}
+
+func (bm *fakeBucketManager) GarbageCollectionState() *gcsx.GarbageCollectionState {
+	return nil
+}
+
 func (bm *fakeBucketManager) ShutDown() {}
 
+func (bm *fakeBucketManager) ShutDownOnError(err error) {}
+
 func (bm *fakeBucketManager) SetUpBucket(
 	ctx context.Context,
 	name string, isMultibucketMount bool, _ metrics.MetricHandle) (sb gcsx.SyncerBucket, err error) {
@@ -384,6 +394,7 @@ func (bm *fakeBucketManager) SetUpBucket(
 			bm.chunkRetryDeadlineSecs,
 			bm.chunkTransferTimeoutSecs,
 			bm.tmpObjectPrefix,
+			gcsx.UploadConfig{},
 			gcsx.NewContentTypeBucket(bucket),
 		)
 		return
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+)
+
+// gcSkipListXattrName is the extended attribute of the mount root through
+// which operators control the garbage collection skip list, i.e. the temporary
+// objects skipped by garbage collection after failing to delete them. Setting
+// it to "list" logs the skipped objects, and setting it to "clear" forgets them
+// so that the next garbage collection runs reattempt their deletion, e.g.
+//
+//	setfattr -n user.gcsfuse.gc_skip_list -v clear <mount-point>
+const gcSkipListXattrName = "user.gcsfuse.gc_skip_list"
+
+// gcSkipListCommand is the action requested through the skip list attribute.
+type gcSkipListCommand int
+
+const (
+	gcSkipListList gcSkipListCommand = iota
+	gcSkipListClear
+)
+
+// parseGcSkipListXattr parses the value of the skip list extended attribute.
+func parseGcSkipListXattr(value []byte) (gcSkipListCommand, error) {
+	switch strings.ToLower(strings.TrimSpace(string(value))) {
+	case "list":
+		return gcSkipListList, nil
+	case "clear":
+		return gcSkipListClear, nil
+	default:
+		return 0, fmt.Errorf("expected \"list\" or \"clear\", got %q", value)
+	}
+}
+
+// formatGcSkipList formats the entries of the skip list, one per line.
+func formatGcSkipList(entries []gcsx.GarbageCollectionSkipEntry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d objects skipped by garbage collection", len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "\n%s/%s: %d failed deletions, skipped until %s, last error: %s",
+			e.BucketName, e.ObjectName, e.Failures, e.SkipUntil.Format(time.RFC3339), e.LastError)
+	}
+	return sb.String()
+}
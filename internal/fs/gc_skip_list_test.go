@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGcSkipListXattr(t *testing.T) {
+	testCases := []struct {
+		value   string
+		wantCmd gcSkipListCommand
+	}{
+		{value: "list", wantCmd: gcSkipListList},
+		{value: "clear", wantCmd: gcSkipListClear},
+		{value: "CLEAR\n", wantCmd: gcSkipListClear},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			cmd, err := parseGcSkipListXattr([]byte(tc.value))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCmd, cmd)
+		})
+	}
+}
+
+func TestParseGcSkipListXattrInvalid(t *testing.T) {
+	for _, value := range []string{"", "reset", "clear all"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseGcSkipListXattr([]byte(value))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatGcSkipList(t *testing.T) {
+	entries := []gcsx.GarbageCollectionSkipEntry{{
+		BucketName: "bucket",
+		ObjectName: ".gcsfuse_tmp/a",
+		Failures:   2,
+		LastError:  "object is under retention",
+		SkipUntil:  time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC),
+	}}
+
+	assert.Equal(t, "1 objects skipped by garbage collection\nbucket/.gcsfuse_tmp/a: 2 failed deletions, skipped until 2026-01-01T01:00:00Z, last error: object is under retention", formatGcSkipList(entries))
+}
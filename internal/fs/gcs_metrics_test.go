@@ -61,13 +61,24 @@ func (bm *fakeBucketManagerWithMetrics) SetUpBucket(
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		gcsx.NewContentTypeBucket(monitor.NewMonitoringBucket(bucket, mh)),
 	)
 	return sb, err
 }
 
+func (bm *fakeBucketManagerWithMetrics) GarbageCollectionSkipList() *gcsx.GarbageCollectionSkipList {
+	return nil
+}
+
+func (bm *fakeBucketManagerWithMetrics) GarbageCollectionState() *gcsx.GarbageCollectionState {
+	return nil
+}
+
 func (bm *fakeBucketManagerWithMetrics) ShutDown() {}
 
+func (bm *fakeBucketManagerWithMetrics) ShutDownOnError(err error) {}
+
 func createTestFileSystemWithMonitoredBucket(ctx context.Context, t *testing.T, params *serverConfigParams) (gcs.Bucket, fuseutil.FileSystem, metrics.MetricHandle, *metric.ManualReader) {
 	t.Helper()
 	origProvider := otel.GetMeterProvider()
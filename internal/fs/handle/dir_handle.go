@@ -61,6 +61,10 @@ type DirHandle struct {
 	in           inode.DirInode
 	implicitDirs bool
 
+	// If set, ReadDir streams the listing one GCS page at a time rather than
+	// buffering all the entries of the directory.
+	streamingReadDir bool
+
 	/////////////////////////
 	// Mutable state
 	/////////////////////////
@@ -94,16 +98,53 @@ type DirHandle struct {
 	//
 	// GUARDED_BY(Mu)
 	entriesPlusValid bool
+
+	// The state of a streamed listing, if streamingReadDir is set. In that case,
+	// entries only holds the window of entries ready to be returned, starting at
+	// offset windowStart, and entriesValid tells whether a listing is underway.
+	//
+	// GUARDED_BY(Mu)
+	stream listingStream
+}
+
+// listingStream is the state of a directory listing streamed to the kernel one
+// GCS page at a time.
+type listingStream struct {
+	// The offset of the first entry of the window.
+	windowStart fuseops.DirOffset
+
+	// The continuation token of the next page, and whether the last page has
+	// been read.
+	tok  string
+	done bool
+
+	// The largest GCS name, relative to the directory, listed so far. Directory
+	// names end with a slash.
+	maxListedName string
+
+	// Files held back until the listing goes past the name of the directory they
+	// may conflict with, i.e. their name followed by a slash.
+	heldFiles map[string]fuseutil.Dirent
+
+	// The local file entries at the start of the listing, returned once the GCS
+	// listing is done unless already listed.
+	localEntries map[string]fuseutil.Dirent
+
+	// The names of the directories listed so far among the local file entries,
+	// whose local file entries conflict with them.
+	localDirNames map[string]bool
 }
 
 // NewDirHandle creates a directory handle that obtains listings from the supplied inode.
 func NewDirHandle(
 	in inode.DirInode,
-	implicitDirs bool) (dh *DirHandle) {
+	implicitDirs bool,
+	streamingReadDir bool) (dh *DirHandle) {
 	// Set up the basic struct.
 	dh = &DirHandle{
-		in:           in,
-		implicitDirs: implicitDirs,
+		in:               in,
+		implicitDirs:     implicitDirs,
+		streamingReadDir: streamingReadDir,
 	}
 
 	// Set up invariant checking.
@@ -382,6 +423,10 @@ func (dh *DirHandle) ReadDir(
 	ctx context.Context,
 	op *fuseops.ReadDirOp,
 	localFileEntries map[string]fuseutil.Dirent) (err error) {
+	if dh.streamingReadDir {
+		return dh.readDirStreaming(ctx, op, localFileEntries)
+	}
+
 	// If the request is for offset zero, we assume that either this is the first
 	// call or rewinddir has been called. Reset state.
 	if op.Offset == 0 {
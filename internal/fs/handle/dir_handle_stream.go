@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/inode"
+	"github.com/jacobsa/fuse"
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/jacobsa/fuse/fuseutil"
+	"golang.org/x/net/context"
+)
+
+// listedName returns the name under which GCS lists the entry, relative to the
+// directory. GCS lists names in ascending order.
+func listedName(e fuseutil.Dirent) string {
+	if e.Type == fuseutil.DT_Directory {
+		return e.Name + "/"
+	}
+	return e.Name
+}
+
+// startStream starts a new streamed listing of the directory.
+//
+// LOCKS_REQUIRED(dh.Mu)
+func (dh *DirHandle) startStream(localFileEntries map[string]fuseutil.Dirent) {
+	dh.entries = nil
+	dh.entriesValid = true
+	dh.stream = listingStream{
+		heldFiles:     make(map[string]fuseutil.Dirent),
+		localEntries:  maps.Clone(localFileEntries),
+		localDirNames: make(map[string]bool),
+	}
+}
+
+// windowEnd returns the offset following the last entry of the window.
+//
+// LOCKS_REQUIRED(dh.Mu)
+func (dh *DirHandle) windowEnd() fuseops.DirOffset {
+	return dh.stream.windowStart + fuseops.DirOffset(len(dh.entries))
+}
+
+// nextStreamPage replaces the window with the entries made ready by the next
+// page of the listing.
+//
+// LOCKS_REQUIRED(dh.Mu)
+// LOCKS_EXCLUDED(dh.in)
+func (dh *DirHandle) nextStreamPage(ctx context.Context) error {
+	dh.in.Lock()
+	batch, _, tok, err := dh.in.ReadEntries(ctx, dh.stream.tok)
+	dh.in.Unlock()
+	if err != nil {
+		return fmt.Errorf("ReadEntries: %w", err)
+	}
+	dh.stream.tok = tok
+	dh.stream.done = tok == ""
+
+	ready := dh.stream.resolve(batch)
+	dh.stream.windowStart = dh.windowEnd()
+	for i := range ready {
+		// See readAllEntries for the bogus inode ID.
+		ready[i].Inode = fuseops.RootInodeID + 1
+		ready[i].Offset = dh.stream.windowStart + fuseops.DirOffset(i) + 1
+	}
+	dh.entries = ready
+	return nil
+}
+
+// resolve returns the entries of a page of the listing that are ready to be
+// returned, resolving the name conflicts between files and directories like
+// fixConflictingNames does.
+//
+// As a file sorts before the directory of the same name, with possibly many
+// pages in between, files are held back until the listing goes past the name
+// of the directory they may conflict with. Once the listing is done, the
+// remaining held files and the local file entries are returned too.
+func (s *listingStream) resolve(batch []fuseutil.Dirent) (ready []fuseutil.Dirent) {
+	slices.SortFunc(batch, func(a, b fuseutil.Dirent) int {
+		return cmp.Compare(listedName(a), listedName(b))
+	})
+
+	for _, e := range batch {
+		s.maxListedName = max(s.maxListedName, listedName(e))
+		if e.Type != fuseutil.DT_Directory {
+			// A file both listed and local has been uploaded but not yet removed
+			// from the local file entries: return it only once.
+			delete(s.localEntries, e.Name)
+			s.heldFiles[e.Name] = e
+			continue
+		}
+
+		if f, ok := s.heldFiles[e.Name]; ok {
+			f.Name += inode.ConflictingFileNameSuffix
+			ready = append(ready, f)
+			delete(s.heldFiles, e.Name)
+		}
+		if _, ok := s.localEntries[e.Name]; ok {
+			s.localDirNames[e.Name] = true
+		}
+		ready = append(ready, e)
+	}
+
+	// Release the held files whose directory would have been listed by now.
+	var released []fuseutil.Dirent
+	for name, f := range s.heldFiles {
+		if s.done || name+"/" < s.maxListedName {
+			released = append(released, f)
+			delete(s.heldFiles, name)
+		}
+	}
+	if s.done {
+		for name, f := range s.localEntries {
+			if s.localDirNames[name] {
+				f.Name += inode.ConflictingFileNameSuffix
+			}
+			released = append(released, f)
+		}
+		s.localEntries = nil
+	}
+	slices.SortFunc(released, func(a, b fuseutil.Dirent) int { return cmp.Compare(a.Name, b.Name) })
+	return append(ready, released...)
+}
+
+// readDirStreaming serves ReadDir from a listing streamed one GCS page at a
+// time, only keeping the entries of the current page in memory. Offsets are
+// consecutive across pages, so the kernel continues the listing where the
+// previous response stopped. Seeking before the current page relists the
+// directory from the start.
+//
+// LOCKS_REQUIRED(dh.Mu)
+// LOCKS_EXCLUDED(dh.in)
+func (dh *DirHandle) readDirStreaming(
+	ctx context.Context,
+	op *fuseops.ReadDirOp,
+	localFileEntries map[string]fuseutil.Dirent) error {
+	// As for buffered listings, offset zero means either the first call or
+	// rewinddir.
+	if op.Offset == 0 || !dh.entriesValid || op.Offset < dh.stream.windowStart {
+		dh.startStream(localFileEntries)
+	}
+
+	for op.Offset >= dh.windowEnd() && !dh.stream.done {
+		if err := dh.nextStreamPage(ctx); err != nil {
+			// Start over on the next call rather than resuming a broken listing.
+			dh.entries = nil
+			dh.entriesValid = false
+			return err
+		}
+	}
+
+	// A seek past the end of the listing is invalid according to posix.
+	if op.Offset > dh.windowEnd() {
+		return fuse.EINVAL
+	}
+
+	for _, e := range dh.entries[op.Offset-dh.stream.windowStart:] {
+		n := fuseutil.WriteDirent(op.Dst[op.BytesRead:], e)
+		if n == 0 {
+			break
+		}
+		op.BytesRead += n
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ package handle
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"path"
 	"testing"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
+	"github.com/jacobsa/fuse"
 	"github.com/jacobsa/fuse/fuseops"
 	"github.com/jacobsa/fuse/fuseutil"
 	. "github.com/jacobsa/ogletest"
@@ -62,7 +65,7 @@ func (t *DirHandleTest) SetUp(ti *TestInfo) {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}))
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}))
 	t.clock.SetTime(time.Date(2022, 8, 15, 22, 56, 0, 0, time.Local))
 	t.resetDirHandle()
 }
@@ -73,6 +76,10 @@ func (t *DirHandleTest) TearDown() {}
 // Helpers
 // //////////////////////////////////////////////////////////////////////
 func (t *DirHandleTest) resetDirHandle() {
+	t.dh = t.newDirHandle(false)
+}
+
+func (t *DirHandleTest) newDirHandle(streamingReadDir bool) *DirHandle {
 	cfg := &cfg2.Config{
 		List:                         cfg2.ListConfig{EnableEmptyManagedFolders: true},
 		MetadataCache:                cfg2.MetadataCacheConfig{TypeCacheMaxSizeMb: 0},
@@ -98,9 +105,10 @@ func (t *DirHandleTest) resetDirHandle() {
 		semaphore.NewWeighted(10),
 		cfg)
 
-	t.dh = NewDirHandle(
+	return NewDirHandle(
 		dirInode,
 		true,
+		streamingReadDir,
 	)
 }
 
@@ -434,3 +442,108 @@ func (t *DirHandleTest) ReadDirPlusSameNameLocalFileAndGCSDirectory() {
 	t.validateEntryPlus(t.dh.entriesPlus[1], testDirentName+inode.ConflictingFileNameSuffix, fuseutil.DT_File, 2001)
 	AssertEq(t.dh.entriesPlus[1].Dirent.Offset, t.dh.entriesPlus[0].Dirent.Offset+1)
 }
+
+// parseDirents decodes the fuse_dirent structures written by
+// fuseutil.WriteDirent.
+func parseDirents(buf []byte) (entries []fuseutil.Dirent) {
+	const direntSize = 8 + 8 + 4 + 4
+	for len(buf) >= direntSize {
+		nameLen := int(binary.NativeEndian.Uint32(buf[16:]))
+		entries = append(entries, fuseutil.Dirent{
+			Inode:  fuseops.InodeID(binary.NativeEndian.Uint64(buf[0:])),
+			Offset: fuseops.DirOffset(binary.NativeEndian.Uint64(buf[8:])),
+			Type:   fuseutil.DirentType(binary.NativeEndian.Uint32(buf[20:])),
+			Name:   string(buf[direntSize : direntSize+nameLen]),
+		})
+		buf = buf[(direntSize+nameLen+7)/8*8:]
+	}
+	return
+}
+
+// readDirStreamingToEnd lists the directory the way the kernel does, each call
+// continuing at the offset of the last returned entry. It returns the listed
+// entries and the largest number of entries held in memory by the handle.
+func (t *DirHandleTest) readDirStreamingToEnd(localFileEntries map[string]fuseutil.Dirent) (entries []fuseutil.Dirent, maxHeld int) {
+	var offset fuseops.DirOffset
+	for {
+		op := &fuseops.ReadDirOp{Offset: offset, Dst: make([]byte, 4096)}
+		AssertEq(nil, t.dh.ReadDir(t.ctx, op, localFileEntries))
+		maxHeld = max(maxHeld, len(t.dh.entries)+len(t.dh.stream.heldFiles))
+		batch := parseDirents(op.Dst[:op.BytesRead])
+		if len(batch) == 0 {
+			return
+		}
+		entries = append(entries, batch...)
+		offset = batch[len(batch)-1].Offset
+	}
+}
+
+func (t *DirHandleTest) ReadDirStreamingListsLargeDirectoryPageByPage() {
+	t.dh = t.newDirHandle(true)
+	// The file "big" and the directory "big/" are listed on different pages, as
+	// the fillers "big.N" sort between them.
+	const fillers = inode.MaxResultsForListObjectsCall + 2000
+	contents := map[string][]byte{
+		"testDir/big":       nil,
+		"testDir/big/":      nil,
+		"testDir/zzz/":      nil,
+		"testDir/localDir/": nil,
+	}
+	for i := range fillers {
+		contents[fmt.Sprintf("testDir/big.%05d", i)] = nil
+	}
+	AssertEq(nil, storageutil.CreateObjects(t.ctx, t.bucket, contents))
+	localFileEntries := map[string]fuseutil.Dirent{
+		"local":    {Inode: 10, Name: "local", Type: fuseutil.DT_File},
+		"localDir": {Inode: 20, Name: "localDir", Type: fuseutil.DT_File},
+	}
+
+	entries, maxHeld := t.readDirStreamingToEnd(localFileEntries)
+
+	// Every entry is listed once, with consecutive offsets.
+	AssertEq(fillers+6, len(entries))
+	types := make(map[string]fuseutil.DirentType)
+	for i, e := range entries {
+		AssertEq(fuseops.DirOffset(i+1), e.Offset)
+		_, dup := types[e.Name]
+		AssertFalse(dup, "duplicate entry %q", e.Name)
+		types[e.Name] = e.Type
+	}
+	ExpectEq(fuseutil.DT_File, types["big"+inode.ConflictingFileNameSuffix])
+	ExpectEq(fuseutil.DT_Directory, types["big"])
+	ExpectEq(fuseutil.DT_Directory, types["zzz"])
+	ExpectEq(fuseutil.DT_File, types["local"])
+	ExpectEq(fuseutil.DT_Directory, types["localDir"])
+	ExpectEq(fuseutil.DT_File, types["localDir"+inode.ConflictingFileNameSuffix])
+	ExpectEq(fuseutil.DT_File, types["big.00000"])
+	// At most one page, plus the held back file "big", is in memory at once.
+	ExpectTrue(maxHeld <= inode.MaxResultsForListObjectsCall+1, "%d entries held", maxHeld)
+}
+
+func (t *DirHandleTest) ReadDirStreamingRelistsOnRewind() {
+	t.dh = t.newDirHandle(true)
+	AssertEq(nil, storageutil.CreateObjects(t.ctx, t.bucket, map[string][]byte{
+		"testDir/a": nil,
+		"testDir/b": nil,
+	}))
+	first, _ := t.readDirStreamingToEnd(nil)
+	_, err := storageutil.CreateObject(t.ctx, t.bucket, "testDir/c", nil)
+	AssertEq(nil, err)
+
+	second, _ := t.readDirStreamingToEnd(nil)
+
+	AssertEq(2, len(first))
+	AssertEq(3, len(second))
+	t.validateEntry(second[2], "c", fuseutil.DT_File)
+}
+
+func (t *DirHandleTest) ReadDirStreamingSeekPastEndIsInvalid() {
+	t.dh = t.newDirHandle(true)
+	_, err := storageutil.CreateObject(t.ctx, t.bucket, "testDir/a", nil)
+	AssertEq(nil, err)
+	AssertEq(nil, t.dh.ReadDir(t.ctx, &fuseops.ReadDirOp{Dst: make([]byte, 4096)}, nil))
+
+	err = t.dh.ReadDir(t.ctx, &fuseops.ReadDirOp{Offset: 5, Dst: make([]byte, 4096)}, nil)
+
+	ExpectEq(fuse.EINVAL, err)
+}
@@ -20,11 +20,16 @@ import (
 	"io"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/bufferedread"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/file"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/inode"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx/read_manager"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/util"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workloadinsight"
@@ -57,6 +62,13 @@ type FileHandle struct {
 	// GUARDED_BY(mu)
 	readManager gcsx.ReadManager
 
+	// reopenedGeneration is the generation of the object the readManager was
+	// reopened at, after finding the generation of the inode replaced. Zero if
+	// never reopened.
+	//
+	// GUARDED_BY(mu)
+	reopenedGeneration int64
+
 	// MrdKernelReader is a reader that uses an MRD instance to read data from a GCS
 	// object. This reader is kernel-optimized & reads whatever is requested as is.
 	mrdKernelReader *gcsx.MrdKernelReader
@@ -85,6 +97,54 @@ type FileHandle struct {
 	// that can be allocated for buffered read across all files in the file system.
 	globalMaxReadBlocksSem *semaphore.Weighted
 
+	// prefetchFilesSem limits the number of files that can prefetch concurrently
+	// via buffered reads. Nil means no limit.
+	prefetchFilesSem *semaphore.Weighted
+
+	// sharedBlockRegistry deduplicates buffered read block downloads across
+	// file handles of the same object. Nil means blocks are not shared.
+	sharedBlockRegistry *bufferedread.SharedBlockRegistry
+
+	// bufferedReadStats accumulates buffered read efficiency counters across
+	// file handles.
+	bufferedReadStats *bufferedread.Stats
+
+	// blockChecksumManifest holds the expected checksums against which buffered
+	// read blocks are validated. Nil disables validation.
+	blockChecksumManifest *bufferedread.ChecksumManifest
+
+	// pinnedBlockStore keeps the buffered read blocks of pinned objects. Nil if
+	// no object is pinned.
+	pinnedBlockStore *bufferedread.PinnedBlockStore
+
+	// readBlockArena holds the buffered read blocks preallocated at mount time.
+	// Nil if blocks are allocated on demand.
+	readBlockArena *block.PrefetchBlockArena
+
+	// objectDownloadLimiter bounds the concurrent buffered read block downloads
+	// of any single object. Nil means no limit.
+	objectDownloadLimiter *bufferedread.ObjectDownloadLimiter
+
+	// downloadThrottle limits the aggregate bandwidth of the buffered read
+	// block downloads. Nil means no limit.
+	downloadThrottle ratelimit.Throttle
+
+	// objectResidentLimiter bounds the buffered read blocks of any single
+	// object held in the block pool. Nil means no limit.
+	objectResidentLimiter *bufferedread.ObjectResidentBlockLimiter
+
+	// downloadCircuitBreaker fails the buffered reads fast after consecutive
+	// download failures. Nil disables it.
+	downloadCircuitBreaker *bufferedread.DownloadCircuitBreaker
+
+	// hotFileTracker classifies the objects opened often for buffered reads as
+	// hot. Nil means no object is hot.
+	hotFileTracker *bufferedread.HotFileTracker
+
+	// openObjectRegistry lists the prefetch effectiveness of the objects open
+	// for buffered reads. Nil means they aren't listed.
+	openObjectRegistry *bufferedread.OpenObjectRegistry
+
 	// HandleID is an opaque 64-bit number used to create this File Handle, used for logging.
 	handleID fuseops.HandleID
 }
@@ -101,6 +161,18 @@ func NewFileHandle(
 	c *cfg.Config,
 	bufferedReadWorkerPool workerpool.WorkerPool,
 	globalMaxReadBlocksSem *semaphore.Weighted,
+	prefetchFilesSem *semaphore.Weighted,
+	sharedBlockRegistry *bufferedread.SharedBlockRegistry,
+	bufferedReadStats *bufferedread.Stats,
+	blockChecksumManifest *bufferedread.ChecksumManifest,
+	pinnedBlockStore *bufferedread.PinnedBlockStore,
+	readBlockArena *block.PrefetchBlockArena,
+	objectDownloadLimiter *bufferedread.ObjectDownloadLimiter,
+	downloadThrottle ratelimit.Throttle,
+	objectResidentLimiter *bufferedread.ObjectResidentBlockLimiter,
+	downloadCircuitBreaker *bufferedread.DownloadCircuitBreaker,
+	hotFileTracker *bufferedread.HotFileTracker,
+	openObjectRegistry *bufferedread.OpenObjectRegistry,
 	handleID fuseops.HandleID,
 ) (fh *FileHandle) {
 	fh = &FileHandle{
@@ -114,6 +186,18 @@ func NewFileHandle(
 		config:                  c,
 		bufferedReadWorkerPool:  bufferedReadWorkerPool,
 		globalMaxReadBlocksSem:  globalMaxReadBlocksSem,
+		prefetchFilesSem:        prefetchFilesSem,
+		sharedBlockRegistry:     sharedBlockRegistry,
+		bufferedReadStats:       bufferedReadStats,
+		blockChecksumManifest:   blockChecksumManifest,
+		pinnedBlockStore:        pinnedBlockStore,
+		readBlockArena:          readBlockArena,
+		objectDownloadLimiter:   objectDownloadLimiter,
+		downloadThrottle:        downloadThrottle,
+		objectResidentLimiter:   objectResidentLimiter,
+		downloadCircuitBreaker:  downloadCircuitBreaker,
+		hotFileTracker:          hotFileTracker,
+		openObjectRegistry:      openObjectRegistry,
 		handleID:                handleID,
 	}
 
@@ -229,29 +313,7 @@ func (fh *FileHandle) ReadWithReadManager(ctx context.Context, req *gcsx.ReadReq
 
 		fh.destroyReadManager()
 		// Create a new read manager for the current inode state.
-		fh.readManager = read_manager.NewReadManager(minObj, bucket, &read_manager.ReadManagerConfig{
-			SequentialReadSizeMB:    sequentialReadSizeMb,
-			FileCacheHandler:        fh.fileCacheHandler,
-			SharedChunkCacheManager: fh.SharedChunkCacheManager,
-			CacheFileForRangeRead:   fh.cacheFileForRangeRead,
-			MetricHandle:            fh.metricHandle,
-			TraceHandle:             fh.traceHandle,
-			MrdWrapper:              mrdWrapper,
-			Config:                  fh.config,
-			GlobalMaxBlocksSem:      fh.globalMaxReadBlocksSem,
-			WorkerPool:              fh.bufferedReadWorkerPool,
-			HandleID:                fh.handleID,
-			InitialOffset:           req.Offset,
-		})
-
-		// Override the read-manager with visual-read-manager (a wrapper over read_manager with visualizer) if configured.
-		if fh.config.WorkloadInsight.Visualize {
-			if renderer, err := workloadinsight.NewRenderer(); err == nil {
-				fh.readManager = read_manager.NewVisualReadManager(fh.readManager, renderer, fh.config.WorkloadInsight)
-			} else {
-				logger.Warnf("Failed to construct workload insight visualizer: %v", err)
-			}
-		}
+		fh.readManager = fh.newReadManager(minObj, bucket, mrdWrapper, sequentialReadSizeMb, req.Offset)
 
 		// Release RWLock and take RLock on file handle again. Inode lock is not needed now.
 		fh.mu.Unlock()
@@ -265,6 +327,12 @@ func (fh *FileHandle) ReadWithReadManager(ctx context.Context, req *gcsx.ReadReq
 	// Use the readManager to read data.
 	var readResponse gcsx.ReadResponse
 	readResponse, err := fh.readManager.ReadAt(ctx, req)
+	var clobberedErr *gcsfuse_errors.FileClobberedError
+	// Snapshot reads never move on to another generation.
+	if errors.As(err, &clobberedErr) && fh.config.Read.GenerationChangeMode == cfg.GenerationChangeModeReopen &&
+		fh.config.Read.GenerationStrategy != cfg.GenerationStrategySnapshot {
+		readResponse, err = fh.reopenAndReadAt(ctx, req, sequentialReadSizeMb, err)
+	}
 	switch {
 	case errors.Is(err, io.EOF):
 		if err != io.EOF {
@@ -279,6 +347,33 @@ func (fh *FileHandle) ReadWithReadManager(ctx context.Context, req *gcsx.ReadReq
 	return readResponse, nil
 }
 
+// Advise applies access pattern advice to the handle's read manager. Advice
+// given before the first read of the handle is ignored, as the read manager
+// is only created then.
+// LOCKS_EXCLUDED(fh.mu)
+func (fh *FileHandle) Advise(advice gcsx.Advice, offset, length int64) error {
+	fh.mu.RLock()
+	defer fh.mu.RUnlock()
+
+	adviser, ok := fh.readManager.(gcsx.RangeAdviser)
+	if !ok {
+		return nil
+	}
+	return adviser.Advise(advice, offset, length)
+}
+
+// Invalidate destroys the readers of the handle, dropping their buffered
+// blocks and cancelling their in-flight downloads, so that the next read
+// creates new ones fetching the object again.
+// LOCKS_EXCLUDED(fh.mu)
+func (fh *FileHandle) Invalidate() {
+	fh.mu.Lock()
+	defer fh.mu.Unlock()
+
+	fh.destroyReadManager()
+	fh.destroyReader()
+}
+
 // ReadWithMrdKernelReader reads data at the given offset using the mrd kernel reader.
 //
 // LOCKS_REQUIRED(fh.inode.mu)
@@ -384,6 +479,78 @@ func (fh *FileHandle) checkInvariants() {
 	}
 }
 
+// newReadManager returns a read manager reading the given object.
+func (fh *FileHandle) newReadManager(minObj *gcs.MinObject, bucket *gcsx.SyncerBucket, mrdWrapper *gcsx.MultiRangeDownloaderWrapper, sequentialReadSizeMb int32, initialOffset int64) gcsx.ReadManager {
+	var rm gcsx.ReadManager = read_manager.NewReadManager(minObj, bucket, &read_manager.ReadManagerConfig{
+		SequentialReadSizeMB:    sequentialReadSizeMb,
+		FileCacheHandler:        fh.fileCacheHandler,
+		SharedChunkCacheManager: fh.SharedChunkCacheManager,
+		CacheFileForRangeRead:   fh.cacheFileForRangeRead,
+		MetricHandle:            fh.metricHandle,
+		TraceHandle:             fh.traceHandle,
+		MrdWrapper:              mrdWrapper,
+		Config:                  fh.config,
+		GlobalMaxBlocksSem:      fh.globalMaxReadBlocksSem,
+		PrefetchFilesSem:        fh.prefetchFilesSem,
+		SharedBlockRegistry:     fh.sharedBlockRegistry,
+		BufferedReadStats:       fh.bufferedReadStats,
+		BlockChecksumManifest:   fh.blockChecksumManifest,
+		PinnedBlockStore:        fh.pinnedBlockStore,
+		ReadBlockArena:          fh.readBlockArena,
+		ObjectDownloadLimiter:   fh.objectDownloadLimiter,
+		DownloadThrottle:        fh.downloadThrottle,
+		ObjectResidentLimiter:   fh.objectResidentLimiter,
+		DownloadCircuitBreaker:  fh.downloadCircuitBreaker,
+		HotFileTracker:          fh.hotFileTracker,
+		OpenObjectRegistry:      fh.openObjectRegistry,
+		BucketType:              bucket.BucketType(),
+		WorkerPool:              fh.bufferedReadWorkerPool,
+		HandleID:                fh.handleID,
+		InitialOffset:           initialOffset,
+	})
+
+	// Override the read-manager with visual-read-manager (a wrapper over read_manager with visualizer) if configured.
+	if fh.config.WorkloadInsight.Visualize {
+		if renderer, err := workloadinsight.NewRenderer(); err == nil {
+			rm = read_manager.NewVisualReadManager(rm, renderer, fh.config.WorkloadInsight)
+		} else {
+			logger.Warnf("Failed to construct workload insight visualizer: %v", err)
+		}
+	}
+	return rm
+}
+
+// reopenAndReadAt replaces the read manager, whose object generation was
+// found replaced, with one reading the latest generation of the object from
+// the current position, and retries the read with it. It returns
+// clobberedErr if there is no newer generation to read, e.g. if the object
+// was deleted.
+//
+// LOCKS_REQUIRED(fh.mu.RLock)
+func (fh *FileHandle) reopenAndReadAt(ctx context.Context, req *gcsx.ReadRequest, sequentialReadSizeMb int32, clobberedErr error) (gcsx.ReadResponse, error) {
+	oldObj := fh.readManager.Object()
+	bucket := fh.inode.Bucket()
+	latest, _, err := bucket.StatObject(ctx, &gcs.StatObjectRequest{Name: oldObj.Name, ForceFetchFromGcs: true})
+	if err != nil || latest.Generation == oldObj.Generation {
+		logger.Warnf("Can't reopen object %q replaced while reading generation %d: %v", oldObj.Name, oldObj.Generation, err)
+		return gcsx.ReadResponse{}, clobberedErr
+	}
+
+	fh.mu.RUnlock()
+	fh.mu.Lock()
+	// Another read may have reopened the object meanwhile.
+	if fh.readManager.Object().Generation < latest.Generation {
+		logger.Infof("Reopening object %q replaced while reading generation %d, at generation %d.", oldObj.Name, oldObj.Generation, latest.Generation)
+		fh.destroyReadManager()
+		fh.readManager = fh.newReadManager(latest, bucket, nil, sequentialReadSizeMb, req.Offset)
+		fh.reopenedGeneration = latest.Generation
+	}
+	fh.mu.Unlock()
+	fh.mu.RLock()
+
+	return fh.readManager.ReadAt(ctx, req)
+}
+
 // destroyReadManager is a helper function to safely destroy the readManager & set it to nil.
 // LOCKS_REQUIRED(fh.mu)
 // LOCKS_REQUIRED(fh.inode.mu)
@@ -407,7 +574,9 @@ func (fh *FileHandle) isValidReadManager() bool {
 		fh.readManager.Object().Size = fh.inode.SourceGeneration().Size
 		return true
 	}
-	return false
+	// A readManager reopened at a newer generation than the inode's stays valid.
+	return fh.readManager != nil && fh.reopenedGeneration > fh.inode.SourceGeneration().Object &&
+		fh.readManager.Object().Generation == fh.reopenedGeneration
 }
 
 // destroyReader is a helper function to safely destroy the reader and set it to nil.
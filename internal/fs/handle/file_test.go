@@ -23,12 +23,14 @@ import (
 	"io"
 	"math/rand"
 	"os"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/contentcache"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/inode"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx/read_manager"
@@ -76,7 +78,7 @@ func (t *fileTest) SetupTest() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}),
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}),
 	)
 }
 
@@ -178,7 +180,7 @@ func (t *fileTest) Test_IsValidReadManager_NilReadManager() {
 	const objectName = "test_obj"
 	const objectContent = "some data"
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, []byte(objectContent), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	defer fh.inode.Unlock()
 	fh.readManager = nil
@@ -194,7 +196,7 @@ func (t *fileTest) Test_IsValidReadManager_GenerationValidation() {
 	const objectName = "test_obj"
 	const objectContent = "some data"
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, []byte(objectContent), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	defer fh.inode.Unlock()
 
@@ -234,7 +236,7 @@ func (t *fileTest) Test_IsValidReader_NilReader() {
 	const objectName = "test_obj"
 	const objectContent = "some data"
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, []byte(objectContent), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	defer fh.inode.Unlock()
 	fh.reader = nil
@@ -250,7 +252,7 @@ func (t *fileTest) Test_IsValidReader_GenerationValidation() {
 	const objectName = "test_obj"
 	const objectContent = "some data"
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, []byte(objectContent), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	defer fh.inode.Unlock()
 
@@ -289,7 +291,7 @@ func (t *fileTest) Test_Read_Success() {
 	expectedData := []byte("hello from reader")
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, "test_obj_reader", expectedData, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	buf := make([]byte, len(expectedData))
 	fh.inode.Lock()
 
@@ -305,7 +307,7 @@ func (t *fileTest) Test_ReadWithReadManager_Success() {
 	expectedData := []byte("hello from readManager")
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, "test_obj_readManager", expectedData, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	buf := make([]byte, len(expectedData))
 	fh.inode.Lock()
 
@@ -334,7 +336,7 @@ func (t *fileTest) Test_ReadWithReadManager_Concurrent() {
 
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, "concurrent_read_obj", objectContent, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	var wg sync.WaitGroup
 	wg.Add(numReaders)
@@ -393,7 +395,7 @@ func (t *fileTest) Test_Read_Concurrent() {
 
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, "concurrent_read_obj", objectContent, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	var wg sync.WaitGroup
 	wg.Add(numReaders)
@@ -455,7 +457,7 @@ func (t *fileTest) Test_ReadWithReadManager_ErrorScenarios() {
 			t.SetupTest()
 			parent := createDirInode(&t.bucket, &t.clock)
 			testInode := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, object.Name, []byte("data"), false)
-			fh := NewFileHandle(testInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+			fh := NewFileHandle(testInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 			fh.inode.Lock()
 			mockRM := new(read_manager.MockReadManager)
 			mockRM.On("ReadAt", t.ctx, mock.AnythingOfType("*gcsx.ReadRequest")).Return(gcsx.ReadResponse{}, tc.returnErr)
@@ -495,7 +497,7 @@ func (t *fileTest) Test_Read_ErrorScenarios() {
 			t.SetupTest()
 			parent := createDirInode(&t.bucket, &t.clock)
 			testInode := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, object.Name, []byte("data"), false)
-			fh := NewFileHandle(testInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+			fh := NewFileHandle(testInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 			fh.inode.Lock()
 			mockReader := new(gcsx.MockRandomReader)
 			mockReader.On("ReadAt", t.ctx, dst, int64(0)).Return(gcsx.ObjectData{}, tc.returnErr)
@@ -520,7 +522,7 @@ func (t *fileTest) Test_ReadWithReadManager_FallbackToInode() {
 	object := gcs.MinObject{Name: "test_obj"}
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, object.Name, objectData, true)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	mockRM := new(read_manager.MockReadManager)
 	fh.readManager = mockRM
@@ -544,7 +546,7 @@ func (t *fileTest) Test_Read_FallbackToInode() {
 	object := gcs.MinObject{Name: "test_obj"}
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, object.Name, objectData, true)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	mockR := new(gcsx.MockRandomReader)
 	fh.reader = mockR
@@ -565,7 +567,7 @@ func (t *fileTest) Test_ReadWithReadManager_ReadManagerInvalidatedByGenerationCh
 
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, objectName, content1, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	// First read, to create a readManager.
 	fh.inode.Lock()
@@ -611,7 +613,7 @@ func (t *fileTest) Test_Read_ReaderInvalidatedByGenerationChange() {
 
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, &cfg.Config{}, parent, objectName, content1, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	// First read, to create a reader.
 	fh.inode.Lock()
@@ -657,12 +659,12 @@ func (t *fileTest) Test_ReadWithMrdKernelReader_Success() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", mockBucket,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, mockBucket,
 	)
 	parent := createDirInode(&mockSyncerBucket, &t.clock)
 	in := createFileInode(t.T(), &mockSyncerBucket, &t.clock, &cfg.Config{}, parent, objectName, expectedData, false)
 	// Create File Handle.
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	require.NotNil(t.T(), fh.mrdKernelReader)
 	// Mock the downloader that mrdKernelReader will use.
 	fakeMRD := fake.NewFakeMultiRangeDownloader(in.Source(), expectedData)
@@ -691,7 +693,7 @@ func (t *fileTest) Test_ReadWithMrdKernelReader_NotAuthoritative() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "zonal_bucket", gcs.BucketType{Zonal: true}),
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "zonal_bucket", gcs.BucketType{Zonal: true}),
 	)
 	originalData := []byte("some data") // 9 bytes
 	parent := createDirInode(&zonalBucket, &t.clock)
@@ -704,7 +706,7 @@ func (t *fileTest) Test_ReadWithMrdKernelReader_NotAuthoritative() {
 	// After write, content should be "dirtydata".
 	expectedReadData := "dirtydata"
 	// Create file handle.
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	require.NotNil(t.T(), fh.mrdKernelReader)
 	// Create read request and take inode lock.
 	buf := make([]byte, len(expectedReadData))
@@ -730,12 +732,12 @@ func (t *fileTest) Test_ReadWithMrdKernelReader_NilReader() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "non_zonal_bucket", gcs.BucketType{Zonal: true}),
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "non_zonal_bucket", gcs.BucketType{Zonal: true}),
 	)
 	parent := createDirInode(&nonZonalBucket, &t.clock)
 	in := createFileInode(t.T(), &nonZonalBucket, &t.clock, &cfg.Config{}, parent, "test_obj", []byte("data"), false)
 	// Create file handle.
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: false}}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: false}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	require.Nil(t.T(), fh.mrdKernelReader)
 	// Create read request and take inode lock.
 	req := &gcsx.ReadRequest{
@@ -765,12 +767,12 @@ func (t *fileTest) Test_ReadWithMrdKernelReader_ReadAtError() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", mockBucket,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, mockBucket,
 	)
 	parent := createDirInode(&mockSyncerBucket, &t.clock)
 	in := createFileInode(t.T(), &mockSyncerBucket, &t.clock, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, parent, objectName, expectedData, false)
 	// Create file handle.
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, &cfg.Config{FileSystem: cfg.FileSystemConfig{EnableKernelReader: true}}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	require.NotNil(t.T(), fh.mrdKernelReader)
 	// Mock the downloader to return an error.
 	expectedErr := errors.New("mrd read error")
@@ -828,7 +830,7 @@ func (t *fileTest) TestOpenMode() {
 		parent := createDirInode(&t.bucket, &t.clock)
 		config := &cfg.Config{Write: cfg.WriteConfig{EnableStreamingWrites: false}}
 		in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj", nil, false)
-		fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), tc.openMode, &cfg.Config{}, nil, nil, 0)
+		fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), tc.openMode, &cfg.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 		openMode := fh.OpenMode()
 
@@ -847,7 +849,7 @@ func (t *fileTest) TestFileHandle_Destroy_WithReaderAndReadManager() {
 	mockReader.On("Destroy").Once()
 	mockReadManager.On("Destroy").Once()
 	// Construct file handle with mocks
-	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.reader = mockReader
 	fh.readManager = mockReadManager
 
@@ -863,7 +865,7 @@ func (t *fileTest) TestFileHandle_Destroy_WithNilReaderAndReadManager() {
 	config := &cfg.Config{}
 	fileInode := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "destroy_test_nil_obj", nil, false)
 	// Construct file handle with nils
-	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.reader = nil
 	fh.readManager = nil
 
@@ -883,7 +885,7 @@ func (t *fileTest) TestFileHandle_CheckInvariants_WithNonNilReaderAndManager() {
 	// Expectations
 	mockReader.On("CheckInvariants").Once()
 	mockRM.On("CheckInvariants").Once()
-	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(fileInode, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.reader = mockReader
 	fh.readManager = mockRM
 
@@ -900,7 +902,7 @@ func (t *fileTest) TestFileHandle_CheckInvariants_WithNilReaderAndManager() {
 	config := &cfg.Config{}
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_check_invariants_nil", nil, false)
 
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	// Should not panic even if both are nil
 	assert.NotPanics(t.T(), func() {
@@ -912,7 +914,7 @@ func (t *fileTest) Test_LockHandleAndRelockInode_Lock_NoDeadlockWithContention()
 	parent := createDirInode(&t.bucket, &t.clock)
 	config := &cfg.Config{}
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj_deadlock", []byte("content"), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	var wg sync.WaitGroup
 	const numContenders = 10
 	wg.Add(2 * numContenders)
@@ -958,7 +960,7 @@ func (t *fileTest) Test_LockHandleAndRelockInode_RLock_NoDeadlockWithContention(
 	parent := createDirInode(&t.bucket, &t.clock)
 	config := &cfg.Config{}
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj_deadlock", []byte("content"), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	var wg sync.WaitGroup
 	const numContenders = 10
 	wg.Add(2 * numContenders)
@@ -1004,7 +1006,7 @@ func (t *fileTest) Test_LockHandleAndRelockInode_Mixed_NoDeadlockWithContention(
 	parent := createDirInode(&t.bucket, &t.clock)
 	config := &cfg.Config{}
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj_deadlock", []byte("content"), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	var wg sync.WaitGroup
 	const numRContenders = 10
 	const numWContenders = 10
@@ -1050,7 +1052,7 @@ func (t *fileTest) Test_UnlockHandleAndInode() {
 	parent := createDirInode(&t.bucket, &t.clock)
 	config := &cfg.Config{}
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj_deadlock", []byte("content"), false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 
 	var wg sync.WaitGroup
 	const numContenders = 10
@@ -1112,13 +1114,13 @@ func (t *fileTest) Test_ReadWithReadManager_FullReadSuccessWithBufferedRead() {
 			StartBlocksPerHandle: 2,
 		},
 	}
-	workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20)
+	workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20, workerpool.WatchdogConfig{})
 	require.NoError(t.T(), err)
 	defer workerPool.Stop()
 	globalSemaphore := semaphore.NewWeighted(20) // Sufficient blocks for the test
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "read_obj", expectedData, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, globalSemaphore, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, globalSemaphore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	fh.inode.Lock()
 	buf := make([]byte, fileSize)
 
@@ -1133,6 +1135,194 @@ func (t *fileTest) Test_ReadWithReadManager_FullReadSuccessWithBufferedRead() {
 	assert.Equal(t.T(), expectedData, util.ConvertReadResponseToBytes(resp.Data, resp.Size))
 }
 
+func (t *fileTest) Test_ReadWithReadManager_GenerationReplacedWhileReading() {
+	const fileSize = 1 * 1024 * 1024 // 1 MiB
+	testCases := []struct {
+		name                 string
+		generationChangeMode string
+		expectReopen         bool
+	}{
+		{
+			name:                 "estale",
+			generationChangeMode: cfg.GenerationChangeModeEstale,
+			expectReopen:         false,
+		},
+		{
+			name:                 "reopen",
+			generationChangeMode: cfg.GenerationChangeModeReopen,
+			expectReopen:         true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func() {
+			objectName := fmt.Sprintf("clobbered_obj_%d", i)
+			content1 := util.GenerateRandomBytes(fileSize)
+			content2 := util.GenerateRandomBytes(fileSize)
+			config := &cfg.Config{
+				Read: cfg.ReadConfig{
+					EnableBufferedRead:   true,
+					MaxBlocksPerHandle:   10,
+					BlockSizeMb:          1,
+					StartBlocksPerHandle: 2,
+					GenerationChangeMode: tc.generationChangeMode,
+				},
+			}
+			workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20, workerpool.WatchdogConfig{})
+			require.NoError(t.T(), err)
+			defer workerPool.Stop()
+			parent := createDirInode(&t.bucket, &t.clock)
+			in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, content1, false)
+			fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, semaphore.NewWeighted(20), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
+			// Replace the object behind the back of the inode.
+			_, err = t.bucket.CreateObject(t.ctx, &gcs.CreateObjectRequest{
+				Name:     in.Name().GcsObjectName(),
+				Contents: io.NopCloser(bytes.NewReader(content2)),
+			})
+			require.NoError(t.T(), err)
+			buf := make([]byte, fileSize)
+
+			fh.inode.Lock()
+			resp, err := fh.ReadWithReadManager(t.ctx, &gcsx.ReadRequest{
+				Buffer: buf,
+				Offset: 0,
+			}, 200)
+
+			if tc.expectReopen {
+				require.NoError(t.T(), err)
+				assert.Equal(t.T(), content2, util.ConvertReadResponseToBytes(resp.Data, resp.Size))
+				assert.Equal(t.T(), fh.readManager.Object().Generation, fh.reopenedGeneration)
+				// The reopened readManager is kept for the following reads.
+				reopenedReadManager := fh.readManager
+				fh.inode.Lock()
+				_, err = fh.ReadWithReadManager(t.ctx, &gcsx.ReadRequest{
+					Buffer: buf,
+					Offset: 0,
+				}, 200)
+				require.NoError(t.T(), err)
+				assert.Equal(t.T(), reopenedReadManager, fh.readManager)
+			} else {
+				var clobberedErr *gcsfuse_errors.FileClobberedError
+				assert.ErrorAs(t.T(), err, &clobberedErr)
+				assert.Zero(t.T(), fh.reopenedGeneration)
+			}
+			fh.Destroy()
+		})
+	}
+}
+
+func (t *fileTest) Test_ReadWithReadManager_GenerationStrategyWithObjectReplacedMidRead() {
+	const blockSize = 1024 * 1024 // 1 MiB
+	const fileSize = 2 * blockSize
+	const firstReadSize = blockSize / 2
+	content1 := util.GenerateRandomBytes(fileSize)
+	content2 := util.GenerateRandomBytes(fileSize)
+	testCases := []struct {
+		name                 string
+		generationStrategy   string
+		generationChangeMode string
+		// wantContent is the content of the rest of the file read after the
+		// replacement, nil meaning the read fails.
+		wantContent []byte
+	}{
+		{
+			name:                 "latest_at_open",
+			generationStrategy:   cfg.GenerationStrategyLatestAtOpen,
+			generationChangeMode: cfg.GenerationChangeModeEstale,
+		},
+		{
+			name:                 "latest_at_open_reopen",
+			generationStrategy:   cfg.GenerationStrategyLatestAtOpen,
+			generationChangeMode: cfg.GenerationChangeModeReopen,
+			wantContent:          content2[firstReadSize:],
+		},
+		{
+			// The block downloaded before the replacement is read as is, the
+			// following one from the new generation.
+			name:                 "latest_always",
+			generationStrategy:   cfg.GenerationStrategyLatestAlways,
+			generationChangeMode: cfg.GenerationChangeModeEstale,
+			wantContent:          append(slices.Clone(content1[firstReadSize:blockSize]), content2[blockSize:]...),
+		},
+		{
+			name:                 "snapshot",
+			generationStrategy:   cfg.GenerationStrategySnapshot,
+			generationChangeMode: cfg.GenerationChangeModeReopen,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func() {
+			// A fresh bucket, for the object to be created with the generation
+			// the inode assumes.
+			t.SetupTest()
+			objectName := fmt.Sprintf("replaced_mid_read_obj_%d", i)
+			config := &cfg.Config{
+				Read: cfg.ReadConfig{
+					EnableBufferedRead:   true,
+					MaxBlocksPerHandle:   10,
+					BlockSizeMb:          1,
+					StartBlocksPerHandle: 2,
+					// A single block is downloaded at a time, so that the second
+					// block is only downloaded once the object is replaced.
+					SlidingWindowBlocks:  1,
+					GenerationChangeMode: tc.generationChangeMode,
+					GenerationStrategy:   tc.generationStrategy,
+				},
+			}
+			workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20, workerpool.WatchdogConfig{})
+			require.NoError(t.T(), err)
+			defer workerPool.Stop()
+			parent := createDirInode(&t.bucket, &t.clock)
+			in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, objectName, content1, false)
+			fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, semaphore.NewWeighted(20), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
+			defer fh.Destroy()
+			buf := make([]byte, firstReadSize)
+			fh.inode.Lock()
+			resp, err := fh.ReadWithReadManager(t.ctx, &gcsx.ReadRequest{
+				Buffer: buf,
+				Offset: 0,
+			}, 200)
+			require.NoError(t.T(), err)
+			require.Equal(t.T(), content1[:firstReadSize], util.ConvertReadResponseToBytes(resp.Data, resp.Size))
+			// Release the blocks returned, not to hold up destroying the reader.
+			resp.Callback()
+			// Replace the object behind the back of the inode.
+			_, err = t.bucket.CreateObject(t.ctx, &gcs.CreateObjectRequest{
+				Name:     in.Name().GcsObjectName(),
+				Contents: io.NopCloser(bytes.NewReader(content2)),
+			})
+			require.NoError(t.T(), err)
+			buf = make([]byte, fileSize-firstReadSize)
+
+			fh.inode.Lock()
+			resp, err = fh.ReadWithReadManager(t.ctx, &gcsx.ReadRequest{
+				Buffer: buf,
+				Offset: firstReadSize,
+			}, 200)
+
+			if tc.wantContent != nil {
+				require.NoError(t.T(), err)
+				got := util.ConvertReadResponseToBytes(resp.Data, resp.Size)
+				if resp.Callback != nil {
+					resp.Callback()
+				}
+				if len(resp.Data) == 0 {
+					// The reader of a reopened generation may read into the buffer,
+					// rather than return the blocks, and read short.
+					got = buf[:resp.Size]
+				}
+				require.NotEmpty(t.T(), got)
+				assert.Equal(t.T(), tc.wantContent[:len(got)], got)
+			} else {
+				var clobberedErr *gcsfuse_errors.FileClobberedError
+				assert.ErrorAs(t.T(), err, &clobberedErr)
+				assert.Zero(t.T(), fh.reopenedGeneration)
+			}
+		})
+	}
+}
+
 func (t *fileTest) Test_ShouldSkipSizeChecks() {
 	const objectSize = 100
 	unfinalizedObject := &gcs.MinObject{Name: "unfinalized", Size: objectSize}
@@ -1232,12 +1422,12 @@ func (t *fileTest) Test_ShouldSkipSizeChecks() {
 					/*appendThreshold=*/ 1,
 					/*chunkRetryDeadlineSecs=*/ 120,
 					/*chunkTransferTimeoutSecs=*/ 10,
-					".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "some_bucket", bt.bucketType),
+					".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "some_bucket", bt.bucketType),
 				)
 				parent := createDirInode(&t.bucket, &t.clock)
 				config := &cfg.Config{}
 				in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, tc.object.Name, nil, false)
-				fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), tc.openMode, config, nil, nil, 0)
+				fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), tc.openMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 				if tc.useNilReadManager {
 					fh.readManager = nil
 					req := &gcsx.ReadRequest{Offset: tc.offset, Buffer: make([]byte, tc.bufferSize)}
@@ -1273,14 +1463,14 @@ func (t *fileTest) Test_ReadWithReadManager_ConcurrentReadsWithBufferedReader()
 			RandomSeekThreshold:  3,
 		},
 	}
-	workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20)
+	workerPool, err := workerpool.NewStaticWorkerPoolForCurrentCPU(20, workerpool.WatchdogConfig{})
 	require.NoError(t.T(), err)
 	defer workerPool.Stop()
 	globalSemaphore := semaphore.NewWeighted(20)
 	// Create mock inode and file handle.
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "read_obj", expectedData, false)
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, globalSemaphore, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, workerPool, globalSemaphore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	// Use a WaitGroup to synchronize goroutines.
 	var wg sync.WaitGroup
 	wg.Add(numGoroutines)
@@ -1336,7 +1526,7 @@ func (t *fileTest) Test_ReadWithReadManager_WorkloadInsightVisual() {
 	parent := createDirInode(&t.bucket, &t.clock)
 	in := createFileInode(t.T(), &t.bucket, &t.clock, config, parent, "test_obj_visual", content, false)
 	in.Lock()
-	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, 0)
+	fh := NewFileHandle(in, nil, nil, false, metrics.NewNoopMetrics(), tracing.NewNoopTracer(), readMode, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 	in.Unlock()
 
 	// Perform multiple reads and destroy the file-handle.
@@ -69,6 +69,7 @@ func (t *BaseDirTest) SetUp(ti *TestInfo) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		fake.NewFakeBucket(&t.clock, "bucketA", gcs.BucketType{}),
 	)
 	t.bm.buckets["bucketB"] = gcsx.NewSyncerBucket(
@@ -76,6 +77,7 @@ func (t *BaseDirTest) SetUp(ti *TestInfo) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		fake.NewFakeBucket(&t.clock, "bucketB", gcs.BucketType{}),
 	)
 
@@ -110,8 +112,18 @@ func (bm *fakeBucketManager) SetUpBucket(
 	return
 }
 
+func (bm *fakeBucketManager) GarbageCollectionSkipList() *gcsx.GarbageCollectionSkipList {
+	return nil
+}
+
+func (bm *fakeBucketManager) GarbageCollectionState() *gcsx.GarbageCollectionState {
+	return nil
+}
+
 func (bm *fakeBucketManager) ShutDown() {}
 
+func (bm *fakeBucketManager) ShutDownOnError(err error) {}
+
 func (bm *fakeBucketManager) SetUpTimes() int {
 	return bm.setupTimes
 }
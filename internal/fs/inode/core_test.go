@@ -52,7 +52,7 @@ func (t *CoreTest) SetUp(ti *TestInfo) {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}))
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(&t.clock, "some_bucket", gcs.BucketType{}))
 	t.clock.SetTime(time.Date(2012, 8, 15, 22, 56, 0, 0, time.Local))
 }
 
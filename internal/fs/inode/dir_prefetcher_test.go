@@ -55,7 +55,7 @@ func (t *DirPrefetchTest) setup(enablePrefetch bool, ttl time.Duration) (d *dirI
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", t.fake,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, t.fake,
 	)
 
 	t.config = &cfg.Config{
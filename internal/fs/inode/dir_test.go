@@ -82,6 +82,7 @@ func (t *DirTest) SetupTest() {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		bucket)
 	// Create the inode. No implicit dirs by default.
 	t.resetInode(false, false)
@@ -2234,7 +2235,7 @@ func (t *DirTest) TestLookUpChild_TypeCacheDeprecated_CacheMiss() {
 		/*appendThreshold=*/ 1,
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
-		".gcsfuse_tmp/", mockBucket,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, mockBucket,
 	)
 	oldBucket := t.bucket
 	t.bucket = syncerBucket
@@ -2284,7 +2285,7 @@ func (t *DirTest) TestLookUpChild_TypeCacheDeprecated_CacheHit() {
 		/*appendThreshold=*/ 1,
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
-		".gcsfuse_tmp/", mockBucket,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, mockBucket,
 	)
 	oldBucket := t.bucket
 	t.bucket = syncerBucket
@@ -124,6 +124,9 @@ type FileInode struct {
 	// writeHandleCount tracks the count of open fileHandles in write mode.
 	writeHandleCount int32
 
+	// readHandleCount tracks the count of open fileHandles in read-only mode.
+	readHandleCount int32
+
 	// Limits the max number of blocks that can be created across file system when
 	// streaming writes are enabled.
 	globalMaxWriteBlocksSem *semaphore.Weighted
@@ -480,9 +483,18 @@ func (f *FileInode) DecrementLookupCount(n uint64) (destroy bool) {
 	return
 }
 
+// OpenFileHandleCount returns the number of open fileHandles of the file.
+//
+// LOCKS_REQUIRED(f.mu)
+func (f *FileInode) OpenFileHandleCount() int32 {
+	return f.readHandleCount + f.writeHandleCount
+}
+
 // LOCKS_REQUIRED(f.mu)
 func (f *FileInode) RegisterFileHandle(readOnly bool) {
-	if !readOnly {
+	if readOnly {
+		f.readHandleCount++
+	} else {
 		f.writeHandleCount++
 	}
 }
@@ -490,6 +502,7 @@ func (f *FileInode) RegisterFileHandle(readOnly bool) {
 // LOCKS_REQUIRED(f.mu)
 func (f *FileInode) DeRegisterFileHandle(readOnly bool) {
 	if readOnly {
+		f.readHandleCount--
 		return
 	}
 
@@ -84,6 +84,7 @@ func (t *FileMockBucketTest) createLockedInode(fileName string, fileType string)
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket)
 
 	isLocal := false
@@ -139,6 +140,7 @@ func (t *FileMockBucketTest) createGCSBackedFileInode(backingObj *gcs.MinObject)
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket)
 
 	f := NewFileInode(
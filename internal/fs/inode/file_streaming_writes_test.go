@@ -119,6 +119,7 @@ func (t *FileStreamingWritesCommon) createInode(fileType string) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket)
 
 	isLocal := false
@@ -148,6 +148,7 @@ func (t *FileTest) createInodeWithLocalParam(fileName string, local bool) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket)
 
 	if local {
@@ -78,6 +78,7 @@ func (t *hnsDirTest) setupTestSuite(hierarchical bool) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.mockBucket)
 	t.resetDirInode(false, false, true)
 }
@@ -45,7 +45,7 @@ func (t *RecursiveCancellationTest) SetupTest() {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", t.fake,
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, t.fake,
 	)
 	t.config = &cfg.Config{
 		MetadataCache: cfg.MetadataCacheConfig{
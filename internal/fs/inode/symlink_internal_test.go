@@ -75,6 +75,7 @@ func (t *SymlinkInternalTest) createSymlinkInode(name string, target string, leg
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket,
 	)
 
@@ -167,6 +168,7 @@ func (t *SymlinkInternalTest) TestNewSymlinkInode_Legacy() {
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket,
 	)
 
@@ -198,6 +200,7 @@ func (t *SymlinkInternalTest) TestNewSymlinkInode_Standard() {
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket,
 	)
 
@@ -229,6 +232,7 @@ func (t *SymlinkInternalTest) TestNewSymlinkInode_Standard_ReadError() {
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket,
 	)
 
@@ -254,6 +258,7 @@ func (t *SymlinkInternalTest) TestNewSymlinkInode_InvalidMetadata() {
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
 		".gcsfuse_tmp/",
+		gcsx.UploadConfig{},
 		t.bucket,
 	)
 
@@ -51,7 +51,7 @@ func (t *SymlinkTest) SetUp(ti *TestInfo) {
 		/*appendThreshold=*/ 1,
 		/*chunkRetryDeadlineSecs=*/ 120,
 		/*chunkTransferTimeoutSecs=*/ 10,
-		".gcsfuse_tmp/", fake.NewFakeBucket(timeutil.RealClock(), "some-bucket", gcs.BucketType{}))
+		".gcsfuse_tmp/", gcsx.UploadConfig{}, fake.NewFakeBucket(timeutil.RealClock(), "some-bucket", gcs.BucketType{}))
 	t.bucket = &bucket
 }
 
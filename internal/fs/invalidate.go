@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/handle"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/inode"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/jacobsa/fuse/fuseops"
+)
+
+// invalidateXattrName is the extended attribute through which applications
+// tell gcsfuse that the object of a file changed out of band. Setting it to
+// any value drops the cached content of the object, i.e. the buffered read
+// blocks of its open handles and its file cache entry, and cancels their
+// in-flight downloads, so that the next read fetches the object again, e.g.
+//
+//	setfattr -n user.gcsfuse.invalidate -v 1 <file>
+//
+// It complements the invalidation of the stat cache, which the attribute
+// doesn't touch.
+const invalidateXattrName = "user.gcsfuse.invalidate"
+
+func (fs *fileSystem) setInvalidateXattr(op *fuseops.SetXattrOp) error {
+	fs.mu.Lock()
+	in, ok := fs.inodes[op.Inode].(*inode.FileInode)
+	fs.mu.Unlock()
+	if !ok {
+		return syscall.ENOTSUP
+	}
+
+	if err := fs.invalidateObject(in); err != nil {
+		logger.Warnf("SetXattr: %s: %v", invalidateXattrName, err)
+		return syscall.EIO
+	}
+	return nil
+}
+
+// invalidateObject drops the cached content of the object backing the given
+// inode, cancelling its in-flight downloads.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *fileSystem) invalidateObject(in *inode.FileInode) error {
+	fs.mu.Lock()
+	var fileHandles []*handle.FileHandle
+	for _, h := range fs.handles {
+		if fh, ok := h.(*handle.FileHandle); ok && fh.Inode() == in {
+			fileHandles = append(fileHandles, fh)
+		}
+	}
+	fs.mu.Unlock()
+
+	for _, fh := range fileHandles {
+		fh.Invalidate()
+	}
+
+	objectName := in.Name().GcsObjectName()
+	if fs.fileCacheHandler != nil {
+		if err := fs.fileCacheHandler.InvalidateCache(objectName, in.Bucket().Name()); err != nil {
+			return fmt.Errorf("invalidating file cache of %q: %w", objectName, err)
+		}
+	}
+	logger.Infof("Invalidated the cached content of %q.", objectName)
+	return nil
+}
@@ -54,6 +54,7 @@ type serverConfigParams struct {
 	enableParallelDownloadsBlocking bool
 	enableStreamingWrites           bool
 	writeGlobalMaxBlocks            int
+	maxHandlesPerFile               int64
 }
 
 func defaultServerConfigParams() *serverConfigParams {
@@ -103,6 +104,7 @@ func createTestFileSystemWithMetrics(ctx context.Context, t *testing.T, params *
 			EnableNewReader: params.enableNewReader,
 			FileSystem: cfg.FileSystemConfig{
 				EnableKernelReader: params.enableKernelReader,
+				MaxHandlesPerFile:  params.maxHandlesPerFile,
 			},
 		},
 		MetricHandle: mh,
@@ -819,7 +821,7 @@ func TestGetXattr_Metrics(t *testing.T) {
 	err = server.GetXattr(ctx, op)
 	waitForMetricsProcessing()
 
-	// The operation is not implemented, so we expect an error.
+	// The attribute is missing, so we expect an error.
 	assert.NotNil(t, err)
 	attrs := attribute.NewSet(attribute.String("fs_op", "Others"))
 	metrics.VerifyCounterMetric(t, ctx, reader, "fs/ops_count", attrs, 1)
@@ -1108,6 +1110,42 @@ func TestOpenFile_Metrics(t *testing.T) {
 	metrics.VerifyHistogramMetric(t, ctx, reader, "fs/ops_latency", attrs, 1)
 }
 
+func TestOpenFile_MaxHandlesPerFile(t *testing.T) {
+	ctx := context.Background()
+	params := defaultServerConfigParams()
+	params.maxHandlesPerFile = 3
+	bucket, server, mh, reader := createTestFileSystemWithMetrics(ctx, t, params, false)
+	server = wrappers.WithMonitoring(server, mh)
+	createWithContents(ctx, t, bucket, "test", "test")
+	createWithContents(ctx, t, bucket, "other", "other")
+	lookUp := func(name string) fuseops.InodeID {
+		op := &fuseops.LookUpInodeOp{Parent: fuseops.RootInodeID, Name: name}
+		require.NoError(t, server.LookUpInode(ctx, op))
+		return op.Entry.Child
+	}
+	inodeID := lookUp("test")
+	var handles []fuseops.HandleID
+	for range params.maxHandlesPerFile {
+		op := &fuseops.OpenFileOp{Inode: inodeID}
+		require.NoError(t, server.OpenFile(ctx, op))
+		handles = append(handles, op.Handle)
+	}
+
+	// Opens beyond the cap are rejected, many times over.
+	for range 100 {
+		err := server.OpenFile(ctx, &fuseops.OpenFileOp{Inode: inodeID})
+		require.ErrorIs(t, err, syscall.EMFILE)
+	}
+	// The cap is per file.
+	assert.NoError(t, server.OpenFile(ctx, &fuseops.OpenFileOp{Inode: lookUp("other")}))
+	// Closing a handle frees a slot.
+	require.NoError(t, server.ReleaseFileHandle(ctx, &fuseops.ReleaseFileHandleOp{Handle: handles[0]}))
+	assert.NoError(t, server.OpenFile(ctx, &fuseops.OpenFileOp{Inode: inodeID}))
+	assert.ErrorIs(t, server.OpenFile(ctx, &fuseops.OpenFileOp{Inode: inodeID}), syscall.EMFILE)
+	waitForMetricsProcessing()
+	metrics.VerifyCounterMetric(t, ctx, reader, "fs/open_rejected_count", attribute.NewSet(), 101)
+}
+
 func TestReleaseDirHandle_Metrics(t *testing.T) {
 	ctx := context.Background()
 	_, server, mh, reader := createTestFileSystemWithMetrics(ctx, t, defaultServerConfigParams(), false)
@@ -0,0 +1,325 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Benchmarks comparing the optimization profiles on synthetic workloads served
+// by a fake bucket, so that they measure GCSFuse itself rather than the
+// network. Each profile is resolved the way gcsfuse resolves it at mount time,
+// and the file system is driven through its FUSE operations. Run them with:
+//
+//	go test ./internal/fs -run '^$' -bench BenchmarkProfiles -benchtime 50x
+//
+// Besides the throughput, the benchmarks report the p50 and p99 latencies of
+// the operations, and for buffered reads the percentage of the blocks read
+// that were prefetched and of the downloaded blocks that were never read.
+
+package fs
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/googlecloudplatform/gcsfuse/v3/tracing"
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/jacobsa/timeutil"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	benchmarkBucketName = "benchmark-bucket"
+
+	// A machine type without optimization rules, so that the profiles alone
+	// drive the resolved config, and the metadata server isn't queried.
+	benchmarkMachineType = "n2-standard-8"
+
+	benchmarkLargeObjectSize = 64 << 20
+	benchmarkLargeReadSize   = 1 << 20
+	benchmarkSmallReadSize   = 4 << 10
+	benchmarkStatFileCount   = 1000
+)
+
+// benchmarkProfiles are the profiles compared, the empty one standing for the
+// defaults.
+var benchmarkProfiles = []string{
+	"",
+	cfg.ProfileAIMLTraining,
+	cfg.ProfileAIMLServing,
+	cfg.ProfileAIMLInference,
+	cfg.ProfileAIMLCheckpointing,
+	cfg.ProfileBigdataAnalytics,
+	cfg.ProfileMetadataHeavy,
+}
+
+type benchmarkBucketManager struct {
+	bucket gcs.Bucket
+}
+
+func (bm *benchmarkBucketManager) SetUpBucket(
+	ctx context.Context,
+	name string, isMultibucketMount bool, _ metrics.MetricHandle) (gcsx.SyncerBucket, error) {
+	if name != bm.bucket.Name() {
+		return gcsx.SyncerBucket{}, fmt.Errorf("bucket %q does not exist", name)
+	}
+	return gcsx.NewSyncerBucket(0, 0, 10, ".gcsfuse_tmp/", gcsx.UploadConfig{}, gcsx.NewContentTypeBucket(bm.bucket)), nil
+}
+
+func (bm *benchmarkBucketManager) GarbageCollectionSkipList() *gcsx.GarbageCollectionSkipList {
+	return nil
+}
+
+func (bm *benchmarkBucketManager) GarbageCollectionState() *gcsx.GarbageCollectionState {
+	return nil
+}
+
+func (bm *benchmarkBucketManager) ShutDown() {}
+
+func (bm *benchmarkBucketManager) ShutDownOnError(err error) {}
+
+// resolveProfileConfig returns the config of a mount with the given profile and
+// flags, and otherwise default flags, resolved like the gcsfuse command does.
+func resolveProfileConfig(b *testing.B, profile string, flags ...string) *cfg.Config {
+	b.Helper()
+	flagSet := pflag.NewFlagSet("gcsfuse", pflag.ContinueOnError)
+	require.NoError(b, cfg.BuildFlagSet(flagSet))
+	v := viper.New()
+	require.NoError(b, cfg.BindFlags(v, flagSet))
+	args := append([]string{"--machine-type=" + benchmarkMachineType, "--profile=" + profile}, flags...)
+	require.NoError(b, flagSet.Parse(args))
+
+	c := &cfg.Config{}
+	require.NoError(b, v.Unmarshal(c, viper.DecodeHook(cfg.DecodeHook()), func(decoderConfig *mapstructure.DecoderConfig) {
+		decoderConfig.TagName = "yaml"
+	}))
+	require.NoError(b, cfg.ValidateConfig(v, c))
+	optimizedFlags := c.ApplyOptimizations(v, nil)
+	require.NoError(b, cfg.Rationalize(v, c, slices.Collect(maps.Keys(optimizedFlags))))
+	return c
+}
+
+// newBenchmarkFileSystem returns a file system resolved for the given profile
+// and flags over a bucket holding the given objects. With dummy I/O enabled,
+// the bucket serves the reads with the simulated latencies, like at mount time.
+func newBenchmarkFileSystem(b *testing.B, profile string, objects map[string][]byte, flags ...string) *fileSystem {
+	b.Helper()
+	ctx := context.Background()
+	var bucket gcs.Bucket = fake.NewFakeBucket(timeutil.RealClock(), benchmarkBucketName, gcs.BucketType{})
+	require.NoError(b, storageutil.CreateObjects(ctx, bucket, objects))
+
+	c := resolveProfileConfig(b, profile, flags...)
+	if c.DummyIo.Enable {
+		bucket = storage.NewDummyIOBucket(bucket, storage.DummyIOBucketParams{
+			ReaderLatency: c.DummyIo.ReaderLatency,
+			PerMBLatency:  c.DummyIo.PerMbLatency,
+		})
+	}
+	serverCfg := &ServerConfig{
+		CacheClock:                 timeutil.RealClock(),
+		BucketManager:              &benchmarkBucketManager{bucket: bucket},
+		BucketName:                 benchmarkBucketName,
+		ImplicitDirectories:        c.ImplicitDirs,
+		InodeAttributeCacheTTL:     time.Duration(c.MetadataCache.TtlSecs) * time.Second,
+		DirTypeCacheTTL:            time.Duration(c.MetadataCache.TtlSecs) * time.Second,
+		Uid:                        uint32(os.Getuid()),
+		Gid:                        uint32(os.Getgid()),
+		FilePerms:                  os.FileMode(c.FileSystem.FileMode),
+		DirPerms:                   os.FileMode(c.FileSystem.DirMode),
+		RenameDirLimit:             c.FileSystem.RenameDirLimit,
+		SequentialReadSizeMb:       int32(c.GcsConnection.SequentialReadSizeMb),
+		EnableNonexistentTypeCache: c.MetadataCache.EnableNonexistentTypeCache,
+		NewConfig:                  c,
+		MetricHandle:               metrics.NewNoopMetrics(),
+		TraceHandle:                tracing.NewNoopTracer(),
+	}
+	server, err := NewFileSystem(ctx, serverCfg)
+	require.NoError(b, err)
+	b.Cleanup(server.Destroy)
+	return server.(*fileSystem)
+}
+
+// latencyRecorder records the latencies of the benchmarked operations.
+type latencyRecorder struct {
+	latencies []time.Duration
+}
+
+func (r *latencyRecorder) time(b *testing.B, op func() error) {
+	start := time.Now()
+	err := op()
+	r.latencies = append(r.latencies, time.Since(start))
+	require.NoError(b, err)
+}
+
+// report reports the p50 and p99 latencies.
+func (r *latencyRecorder) report(b *testing.B) {
+	if len(r.latencies) == 0 {
+		return
+	}
+	slices.Sort(r.latencies)
+	percentile := func(p int) float64 {
+		return float64(r.latencies[(len(r.latencies)-1)*p/100].Microseconds())
+	}
+	b.ReportMetric(percentile(50), "p50-us")
+	b.ReportMetric(percentile(99), "p99-us")
+}
+
+// reportPrefetchEfficiency reports the efficiency of the buffered reads, if
+// the profile enables them.
+func reportPrefetchEfficiency(b *testing.B, fs *fileSystem) {
+	if fs.bufferedReadStats == nil {
+		return
+	}
+	s := fs.bufferedReadStats.Snapshot()
+	if s.BlocksRead > 0 {
+		b.ReportMetric(100*float64(s.BlocksHit)/float64(s.BlocksRead), "prefetch-hit-%")
+	}
+	if s.BlocksScheduled > 0 {
+		b.ReportMetric(100*float64(s.BlocksWasted)/float64(s.BlocksScheduled), "prefetch-wasted-%")
+	}
+}
+
+func lookUpBenchmarkFile(b *testing.B, fs *fileSystem, parent fuseops.InodeID, name string) fuseops.InodeID {
+	b.Helper()
+	op := &fuseops.LookUpInodeOp{Parent: parent, Name: name}
+	require.NoError(b, fs.LookUpInode(context.Background(), op))
+	return op.Entry.Child
+}
+
+// readBenchmarkFile reads size bytes at offset through the given handle.
+func readBenchmarkFile(fs *fileSystem, inode fuseops.InodeID, handle fuseops.HandleID, offset int64, dst []byte) error {
+	op := &fuseops.ReadFileOp{Inode: inode, Handle: handle, Offset: offset, Dst: dst}
+	err := fs.ReadFile(context.Background(), op)
+	if op.Callback != nil {
+		op.Callback()
+	}
+	return err
+}
+
+// benchmarkSequentialRead reads a large object from start to end, with one
+// handle per iteration.
+func benchmarkSequentialRead(b *testing.B, profile string) {
+	fs := newBenchmarkFileSystem(b, profile, map[string][]byte{
+		"large": make([]byte, benchmarkLargeObjectSize),
+	})
+	ctx := context.Background()
+	inode := lookUpBenchmarkFile(b, fs, fuseops.RootInodeID, "large")
+	dst := make([]byte, benchmarkLargeReadSize)
+	var latencies latencyRecorder
+	b.SetBytes(benchmarkLargeObjectSize)
+	b.ResetTimer()
+
+	for b.Loop() {
+		openOp := &fuseops.OpenFileOp{Inode: inode}
+		require.NoError(b, fs.OpenFile(ctx, openOp))
+		for offset := int64(0); offset < benchmarkLargeObjectSize; offset += benchmarkLargeReadSize {
+			latencies.time(b, func() error { return readBenchmarkFile(fs, inode, openOp.Handle, offset, dst) })
+		}
+		require.NoError(b, fs.ReleaseFileHandle(ctx, &fuseops.ReleaseFileHandleOp{Handle: openOp.Handle}))
+	}
+
+	b.StopTimer()
+	latencies.report(b)
+	reportPrefetchEfficiency(b, fs)
+}
+
+// benchmarkRandomRead reads small ranges at random offsets of a large object,
+// one per iteration.
+func benchmarkRandomRead(b *testing.B, profile string) {
+	fs := newBenchmarkFileSystem(b, profile, map[string][]byte{
+		"large": make([]byte, benchmarkLargeObjectSize),
+	})
+	ctx := context.Background()
+	inode := lookUpBenchmarkFile(b, fs, fuseops.RootInodeID, "large")
+	openOp := &fuseops.OpenFileOp{Inode: inode}
+	require.NoError(b, fs.OpenFile(ctx, openOp))
+	b.Cleanup(func() { _ = fs.ReleaseFileHandle(ctx, &fuseops.ReleaseFileHandleOp{Handle: openOp.Handle}) })
+	// A fixed seed, for all the profiles to read the same offsets.
+	rnd := rand.New(rand.NewPCG(1, 2))
+	dst := make([]byte, benchmarkSmallReadSize)
+	var latencies latencyRecorder
+	b.SetBytes(benchmarkSmallReadSize)
+	b.ResetTimer()
+
+	for b.Loop() {
+		offset := rnd.Int64N(benchmarkLargeObjectSize - benchmarkSmallReadSize)
+		latencies.time(b, func() error { return readBenchmarkFile(fs, inode, openOp.Handle, offset, dst) })
+	}
+
+	b.StopTimer()
+	latencies.report(b)
+	reportPrefetchEfficiency(b, fs)
+}
+
+// benchmarkStat looks up and stats one of many files of a directory per
+// iteration, cycling through them.
+func benchmarkStat(b *testing.B, profile string) {
+	objects := map[string][]byte{"dir/": nil}
+	for i := range benchmarkStatFileCount {
+		objects[fmt.Sprintf("dir/file_%04d", i)] = []byte("content")
+	}
+	fs := newBenchmarkFileSystem(b, profile, objects)
+	ctx := context.Background()
+	dir := lookUpBenchmarkFile(b, fs, fuseops.RootInodeID, "dir")
+	var latencies latencyRecorder
+	b.ResetTimer()
+
+	i := 0
+	for b.Loop() {
+		name := fmt.Sprintf("file_%04d", i%benchmarkStatFileCount)
+		i++
+		latencies.time(b, func() error {
+			lookUpOp := &fuseops.LookUpInodeOp{Parent: dir, Name: name}
+			if err := fs.LookUpInode(ctx, lookUpOp); err != nil {
+				return err
+			}
+			return fs.GetInodeAttributes(ctx, &fuseops.GetInodeAttributesOp{Inode: lookUpOp.Entry.Child})
+		})
+	}
+
+	b.StopTimer()
+	latencies.report(b)
+}
+
+func BenchmarkProfiles(b *testing.B) {
+	workloads := []struct {
+		name string
+		run  func(*testing.B, string)
+	}{
+		{name: "SequentialRead", run: benchmarkSequentialRead},
+		{name: "RandomRead", run: benchmarkRandomRead},
+		{name: "Stat", run: benchmarkStat},
+	}
+	for _, profile := range benchmarkProfiles {
+		profileName := profile
+		if profileName == "" {
+			profileName = "default"
+		}
+		for _, w := range workloads {
+			b.Run(profileName+"/"+w.name, func(b *testing.B) { w.run(b, profile) })
+		}
+	}
+}
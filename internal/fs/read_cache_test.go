@@ -457,6 +457,38 @@ func (t *FileCacheTest) DeletingObjectShouldInvalidateTheCorrespondingCache() {
 	AssertTrue(os.IsNotExist(err))
 }
 
+func (t *FileCacheTest) InvalidateXattrShouldMakeNextReadDownloadAgain() {
+	objectContent := generateRandomString(util.MiB)
+	objects := map[string]string{DefaultObjectName: objectContent}
+	err := t.createObjects(objects)
+	AssertEq(nil, err)
+	filePath := path.Join(mntDir, DefaultObjectName)
+	file, err := os.OpenFile(filePath, os.O_RDWR|syscall.O_DIRECT, util.DefaultFilePerm)
+	defer closeFile(file)
+	AssertEq(nil, err)
+	buf := make([]byte, len(objectContent))
+	_, err = file.Read(buf)
+	AssertEq(nil, err)
+	objectPath := util.GetObjectPath(bucket.Name(), DefaultObjectName)
+	downloadPath := util.GetDownloadPath(FileCacheDir, objectPath)
+	_, err = os.Stat(downloadPath)
+	AssertEq(nil, err)
+
+	// Invalidate the cached content of the object.
+	err = syscall.Setxattr(filePath, "user.gcsfuse.invalidate", []byte("1"), 0)
+	AssertEq(nil, err)
+
+	_, err = os.Stat(downloadPath)
+	AssertTrue(os.IsNotExist(err))
+	// The next read downloads the object into the cache again.
+	_, err = file.ReadAt(buf, 0)
+	AssertEq(nil, err)
+	ExpectEq(objectContent, string(buf))
+	cachedContent, err := os.ReadFile(downloadPath)
+	AssertEq(nil, err)
+	ExpectEq(objectContent, string(cachedContent))
+}
+
 func (t *FileCacheTest) RenamingObjectShouldInvalidateTheCorrespondingCache() {
 	objectContent := generateRandomString(util.MiB)
 	objects := map[string]string{DefaultObjectName: objectContent}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/handle"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
+	"github.com/jacobsa/timeutil"
+)
+
+// tcpStatsPath is where the kernel exposes the TCP counters of the host.
+const tcpStatsPath = "/proc/net/snmp"
+
+// tcpStatsKeys are the TCP counters part of the read failure diagnostic.
+var tcpStatsKeys = []string{"CurrEstab", "ActiveOpens", "AttemptFails", "EstabResets", "RetransSegs", "InErrs", "OutRsts"}
+
+// readFailureDiagnostic logs a diagnostic bundle on the first read failure of
+// the mount, and then at most once per interval, so that support gets a
+// snapshot of the state of the mount without the user reproducing the failure
+// with extra flags, nor the log being flooded by a burst of failures.
+type readFailureDiagnostic struct {
+	interval time.Duration
+	clock    timeutil.Clock
+
+	mu sync.Mutex
+
+	// lastLogged is the time the last bundle was logged, zero if none was.
+	// GUARDED by (mu)
+	lastLogged time.Time
+}
+
+func newReadFailureDiagnostic(interval time.Duration, clock timeutil.Clock) *readFailureDiagnostic {
+	return &readFailureDiagnostic{interval: interval, clock: clock}
+}
+
+// due returns true, recording a bundle as logged, if no bundle was logged
+// within the interval.
+// LOCKS_EXCLUDED(d.mu)
+func (d *readFailureDiagnostic) due() bool {
+	now := d.clock.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.lastLogged.IsZero() && now.Sub(d.lastLogged) < d.interval {
+		return false
+	}
+	d.lastLogged = now
+	return true
+}
+
+// readFailureBundle is the state of the mount at a read failure.
+type readFailureBundle struct {
+	profile     string
+	machineType string
+	bucketName  string
+	bucketType  gcs.BucketType
+	objectName  string
+	offset      int64
+	size        int64
+	err         error
+
+	// bufferedRead is true if buffered read is enabled, in which case the
+	// utilization of the block pool and of the worker pool is set.
+	bufferedRead    bool
+	blocksInUse     int64
+	globalMaxBlocks int64
+	workerPool      workerpool.Stats
+
+	// tcpStats holds the TCP counters of the host, and tcpStatsErr why they
+	// couldn't be read.
+	tcpStats    map[string]int64
+	tcpStatsErr error
+}
+
+// bucketTypeName returns a name for the features of a bucket.
+func bucketTypeName(bt gcs.BucketType) string {
+	switch {
+	case bt.Zonal:
+		return "zonal"
+	case bt.Pirlo:
+		return "pirlo"
+	case bt.Hierarchical:
+		return "hierarchical"
+	default:
+		return "flat"
+	}
+}
+
+func (b *readFailureBundle) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Read failure diagnostic, logged at most once per interval:")
+	fmt.Fprintf(&sb, "\n  failing read: %s/%s at offset %d, %d bytes: %v", b.bucketName, b.objectName, b.offset, b.size, b.err)
+	fmt.Fprintf(&sb, "\n  profile: %q, machine-type: %q, bucket type: %s", b.profile, b.machineType, bucketTypeName(b.bucketType))
+	if b.bufferedRead {
+		fmt.Fprintf(&sb, "\n  block pool: %d blocks in use out of %d", b.blocksInUse, b.globalMaxBlocks)
+		fmt.Fprintf(&sb, "\n  worker pool: %d workers, %d tasks in flight, %d tasks queued", b.workerPool.Workers, b.workerPool.Running, b.workerPool.Queued)
+	} else {
+		fmt.Fprintf(&sb, "\n  buffered read disabled")
+	}
+	if b.tcpStatsErr != nil {
+		fmt.Fprintf(&sb, "\n  tcp stats unavailable: %v", b.tcpStatsErr)
+	} else {
+		fmt.Fprintf(&sb, "\n  tcp stats:")
+		for _, key := range tcpStatsKeys {
+			if v, ok := b.tcpStats[key]; ok {
+				fmt.Fprintf(&sb, " %s=%d", key, v)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// readTCPStats returns the TCP counters of the host from a file in the format
+// of /proc/net/snmp: a line of counter names followed by a line of their
+// values, both prefixed with "Tcp:".
+func readTCPStats(path string) (map[string]int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 && fields[0] == "Tcp:" {
+			lines = append(lines, fields[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) != 2 || len(lines[0]) != len(lines[1]) {
+		return nil, fmt.Errorf("malformed TCP stats in %s", path)
+	}
+
+	stats := make(map[string]int64, len(lines[0]))
+	for i, key := range lines[0] {
+		v, err := strconv.ParseInt(lines[1][i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed TCP stat %s in %s: %w", key, path, err)
+		}
+		stats[key] = v
+	}
+	return stats, nil
+}
+
+// maybeLogReadFailureDiagnostic logs the diagnostic bundle of a failed read of
+// size bytes at offset of fh, unless disabled or a bundle was logged within
+// the interval. Interrupted reads aren't failures of the mount.
+func (fs *fileSystem) maybeLogReadFailureDiagnostic(fh *handle.FileHandle, offset, size int64, err error) {
+	if fs.readFailureDiagnostic == nil || errors.Is(err, context.Canceled) || !fs.readFailureDiagnostic.due() {
+		return
+	}
+
+	b := &readFailureBundle{
+		profile:     fs.newConfig.Profile,
+		machineType: fs.newConfig.MachineType,
+		bucketName:  fh.Inode().Bucket().Name(),
+		bucketType:  fh.Inode().Bucket().BucketType(),
+		objectName:  fh.Inode().Name().GcsObjectName(),
+		offset:      offset,
+		size:        size,
+		err:         err,
+	}
+	if fs.bufferedReadStats != nil {
+		b.bufferedRead = true
+		b.blocksInUse = fs.bufferedReadStats.Snapshot().BlocksInUse
+		b.globalMaxBlocks = fs.newConfig.Read.GlobalMaxBlocks
+	}
+	if r, ok := fs.bufferedReadWorkerPool.(workerpool.StatsReporter); ok {
+		b.workerPool = r.Stats()
+	}
+	b.tcpStats, b.tcpStatsErr = readTCPStats(tcpStatsPath)
+	logger.Error(b.String())
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const readFailureDiagnosticMsg = "Read failure diagnostic"
+
+// //////////////////////////////////////////////////////////////////////
+// Boilerplate
+// //////////////////////////////////////////////////////////////////////
+
+// lockedBuffer is a buffer safe to log into from the file system goroutines.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+type readFailureDiagnosticTest struct {
+	fsTest
+	suite.Suite
+}
+
+func (t *readFailureDiagnosticTest) SetupSuite() {
+	t.serverCfg.NewConfig = &cfg.Config{
+		Profile:     cfg.ProfileAIMLServing,
+		MachineType: "a3-highgpu-8g",
+		Read: cfg.ReadConfig{
+			FailureDiagnosticInterval: time.Hour,
+		},
+	}
+	t.fsTest.SetUpTestSuite()
+}
+
+func (t *readFailureDiagnosticTest) TearDownTest() {
+	t.fsTest.TearDown()
+}
+
+func (t *readFailureDiagnosticTest) TearDownSuite() {
+	t.fsTest.TearDownTestSuite()
+}
+
+// //////////////////////////////////////////////////////////////////////
+// Tests
+// //////////////////////////////////////////////////////////////////////
+
+func (t *readFailureDiagnosticTest) TestDiagnosticLoggedOnceWithinInterval() {
+	var buf lockedBuffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	t.f1 = createGCSObject(t.T(), "taco")
+	// Replace the underlying object with a new generation, failing the reads.
+	clobberFile(t.T(), "foobar")
+
+	for range 2 {
+		_, err := t.f1.ReadAt(make([]byte, 4), 0)
+		operations.ValidateESTALEError(t.T(), err)
+	}
+
+	logs := buf.String()
+	require.Equal(t.T(), 1, strings.Count(logs, readFailureDiagnosticMsg), "logs: %s", logs)
+	assert.Contains(t.T(), logs, fileName+" at offset 0, 4 bytes")
+	assert.Contains(t.T(), logs, `profile: \"aiml-serving\", machine-type: \"a3-highgpu-8g\", bucket type: flat`)
+	assert.Contains(t.T(), logs, "buffered read disabled")
+}
+
+func TestReadFailureDiagnostic(t *testing.T) {
+	suite.Run(t, new(readFailureDiagnosticTest))
+}
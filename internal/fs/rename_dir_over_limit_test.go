@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tests renaming a directory with more descendants than the rename dir limit
+// in a bucket without hierarchical namespace.
+package fs_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const renameDirOverLimitTestLimit = 2
+
+// renameDirOverLimitTestObjects has more descendants of "foo" than
+// renameDirOverLimitTestLimit.
+var renameDirOverLimitTestObjects = map[string]string{
+	"foo/":              "",
+	"foo/file1.txt":     "taco",
+	"foo/file2.txt":     "burrito",
+	"foo/dir/":          "",
+	"foo/dir/file3.txt": "enchilada",
+}
+
+func setUpRenameDirOverLimitTest(t *fsTest, mode string) {
+	t.serverCfg.RenameDirLimit = renameDirOverLimitTestLimit
+	t.serverCfg.ImplicitDirectories = true
+	t.serverCfg.NewConfig = &cfg.Config{
+		FileSystem: cfg.FileSystemConfig{
+			RenameDirOverLimitMode: mode,
+		},
+	}
+	t.SetUpTestSuite()
+}
+
+////////////////////////////////////////////////////////////////////////
+// RenameDirOverLimitFailTest
+////////////////////////////////////////////////////////////////////////
+
+type RenameDirOverLimitFailTest struct {
+	suite.Suite
+	fsTest
+}
+
+func TestRenameDirOverLimitFailTestSuite(t *testing.T) {
+	suite.Run(t, new(RenameDirOverLimitFailTest))
+}
+
+func (t *RenameDirOverLimitFailTest) SetupSuite() {
+	setUpRenameDirOverLimitTest(&t.fsTest, cfg.RenameDirOverLimitModeFail)
+}
+
+func (t *RenameDirOverLimitFailTest) SetupTest() {
+	require.NoError(t.T(), t.createObjects(renameDirOverLimitTestObjects))
+}
+
+func (t *RenameDirOverLimitFailTest) TearDownTest() {
+	t.fsTest.TearDown()
+}
+
+func (t *RenameDirOverLimitFailTest) TearDownSuite() {
+	t.fsTest.TearDownTestSuite()
+}
+
+func (t *RenameDirOverLimitFailTest) TestRenameFails() {
+	oldDirPath := path.Join(mntDir, "foo")
+	newDirPath := path.Join(mntDir, "bar")
+
+	err := os.Rename(oldDirPath, newDirPath)
+
+	assert.ErrorContains(t.T(), err, "too many open files")
+	entries, err := os.ReadDir(oldDirPath)
+	require.NoError(t.T(), err)
+	assert.Len(t.T(), entries, 3)
+	_, err = os.Stat(newDirPath)
+	assert.True(t.T(), os.IsNotExist(err))
+}
+
+////////////////////////////////////////////////////////////////////////
+// RenameDirOverLimitCopyDeleteTest
+////////////////////////////////////////////////////////////////////////
+
+type RenameDirOverLimitCopyDeleteTest struct {
+	suite.Suite
+	fsTest
+}
+
+func TestRenameDirOverLimitCopyDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(RenameDirOverLimitCopyDeleteTest))
+}
+
+func (t *RenameDirOverLimitCopyDeleteTest) SetupSuite() {
+	setUpRenameDirOverLimitTest(&t.fsTest, cfg.RenameDirOverLimitModeCopyDelete)
+}
+
+func (t *RenameDirOverLimitCopyDeleteTest) SetupTest() {
+	require.NoError(t.T(), t.createObjects(renameDirOverLimitTestObjects))
+}
+
+func (t *RenameDirOverLimitCopyDeleteTest) TearDownTest() {
+	t.fsTest.TearDown()
+}
+
+func (t *RenameDirOverLimitCopyDeleteTest) TearDownSuite() {
+	t.fsTest.TearDownTestSuite()
+}
+
+func (t *RenameDirOverLimitCopyDeleteTest) TestRenameCompletes() {
+	oldDirPath := path.Join(mntDir, "foo")
+	newDirPath := path.Join(mntDir, "bar")
+
+	err := os.Rename(oldDirPath, newDirPath)
+
+	require.NoError(t.T(), err)
+	_, err = os.Stat(oldDirPath)
+	assert.True(t.T(), os.IsNotExist(err))
+	entries, err := os.ReadDir(newDirPath)
+	require.NoError(t.T(), err)
+	assert.Len(t.T(), entries, 3)
+	content, err := os.ReadFile(path.Join(newDirPath, "dir", "file3.txt"))
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), "enchilada", string(content))
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/inode"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/jacobsa/fuse/fuseops"
+)
+
+// retentionXattrName is the read-only extended attribute through which
+// applications check whether the object of a file is locked, without calling
+// the GCS API themselves. Its value holds one "key=value" line per hold or
+// retention applying to the object, e.g.
+//
+//	$ getfattr -n user.gcsfuse.retention <file>
+//	user.gcsfuse.retention="temporary_hold=true
+//	retention_mode=Locked
+//	retain_until=2030-01-01T00:00:00Z"
+//
+// and is empty when none applies.
+const retentionXattrName = "user.gcsfuse.retention"
+
+func (fs *fileSystem) getRetentionXattr(ctx context.Context, op *fuseops.GetXattrOp) error {
+	fs.mu.Lock()
+	in, ok := fs.inodes[op.Inode].(*inode.FileInode)
+	fs.mu.Unlock()
+	if !ok {
+		return syscall.ENOTSUP
+	}
+
+	value, err := fs.objectRetention(ctx, in)
+	if err != nil {
+		logger.Warnf("GetXattr: %s: %v", retentionXattrName, err)
+		return syscall.EIO
+	}
+
+	op.BytesRead = len(value)
+	if len(op.Dst) == 0 {
+		// The caller asks for the size of the value.
+		return nil
+	}
+	if len(op.Dst) < len(value) {
+		return syscall.ERANGE
+	}
+	copy(op.Dst, value)
+	return nil
+}
+
+// objectRetention returns the holds and retention of the object backing the
+// given inode, formatted as the value of the retention attribute. They are
+// fetched from GCS, as the cached object records leave them out.
+//
+// LOCKS_EXCLUDED(in)
+func (fs *fileSystem) objectRetention(ctx context.Context, in *inode.FileInode) (string, error) {
+	in.Lock()
+	local := in.IsLocal()
+	in.Unlock()
+	if local {
+		// The object isn't created yet, so nothing applies to it.
+		return "", nil
+	}
+
+	objectName := in.Name().GcsObjectName()
+	_, e, err := in.Bucket().StatObject(ctx, &gcs.StatObjectRequest{
+		Name:                           objectName,
+		ForceFetchFromGcs:              true,
+		ReturnExtendedObjectAttributes: true,
+	})
+	var notFoundErr *gcs.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("StatObject(%q): %w", objectName, err)
+	}
+	return formatRetention(e), nil
+}
+
+// formatRetention formats the holds and retention of an object, one per line.
+func formatRetention(e *gcs.ExtendedObjectAttributes) string {
+	var lines []string
+	if e.EventBasedHold {
+		lines = append(lines, "event_based_hold=true")
+	}
+	if e.TemporaryHold {
+		lines = append(lines, "temporary_hold=true")
+	}
+	if !e.RetentionExpirationTime.IsZero() {
+		lines = append(lines, "retention_expiration_time="+e.RetentionExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if e.RetentionMode != "" {
+		lines = append(lines, "retention_mode="+e.RetentionMode)
+		lines = append(lines, "retain_until="+e.RetainUntil.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(lines, "\n")
+}
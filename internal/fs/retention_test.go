@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tests reading the holds and retention of objects through the retention
+// extended attribute.
+package fs_test
+
+import (
+	"context"
+	"path"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const retentionXattrName = "user.gcsfuse.retention"
+
+// retentionBucket reports the holds and retention of its objects, which the
+// fake bucket doesn't support setting.
+type retentionBucket struct {
+	gcs.Bucket
+	retention map[string]gcs.ExtendedObjectAttributes
+}
+
+func (b *retentionBucket) StatObject(ctx context.Context, req *gcs.StatObjectRequest) (*gcs.MinObject, *gcs.ExtendedObjectAttributes, error) {
+	m, e, err := b.Bucket.StatObject(ctx, req)
+	if r, ok := b.retention[req.Name]; ok && e != nil {
+		e.EventBasedHold = r.EventBasedHold
+		e.TemporaryHold = r.TemporaryHold
+		e.RetentionExpirationTime = r.RetentionExpirationTime
+		e.RetentionMode = r.RetentionMode
+		e.RetainUntil = r.RetainUntil
+	}
+	return m, e, err
+}
+
+func getXattr(path, name string) (string, error) {
+	buf := make([]byte, 1024)
+	n, err := syscall.Getxattr(path, name, buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+type RetentionXattrTest struct {
+	suite.Suite
+	fsTest
+}
+
+func TestRetentionXattrTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionXattrTest))
+}
+
+func (t *RetentionXattrTest) SetupSuite() {
+	retainUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	bucket = &retentionBucket{
+		Bucket: fake.NewFakeBucket(timeutil.RealClock(), "some_bucket", bucketType),
+		retention: map[string]gcs.ExtendedObjectAttributes{
+			"held": {
+				EventBasedHold: true,
+				TemporaryHold:  true,
+			},
+			"retained": {
+				RetentionExpirationTime: retainUntil,
+				RetentionMode:           "Locked",
+				RetainUntil:             retainUntil,
+			},
+		},
+	}
+	t.SetUpTestSuite()
+}
+
+func (t *RetentionXattrTest) SetupTest() {
+	require.NoError(t.T(), t.createObjects(map[string]string{
+		"held":     "taco",
+		"retained": "burrito",
+		"plain":    "enchilada",
+	}))
+}
+
+func (t *RetentionXattrTest) TearDownTest() {
+	t.fsTest.TearDown()
+}
+
+func (t *RetentionXattrTest) TearDownSuite() {
+	t.fsTest.TearDownTestSuite()
+}
+
+func (t *RetentionXattrTest) TestObjectsWithAndWithoutRetention() {
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "held",
+			want: "event_based_hold=true\ntemporary_hold=true",
+		},
+		{
+			name: "retained",
+			want: "retention_expiration_time=2030-01-02T03:04:05Z\nretention_mode=Locked\nretain_until=2030-01-02T03:04:05Z",
+		},
+		{
+			name: "plain",
+			want: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			value, err := getXattr(path.Join(mntDir, tc.name), retentionXattrName)
+
+			require.NoError(t.T(), err)
+			assert.Equal(t.T(), tc.want, value)
+		})
+	}
+}
+
+func (t *RetentionXattrTest) TestUnknownXattrIsMissing() {
+	_, err := getXattr(path.Join(mntDir, "plain"), "user.unknown")
+
+	assert.ErrorIs(t.T(), err, syscall.ENODATA)
+}
+
+func (t *RetentionXattrTest) TestDirectoryIsNotSupported() {
+	require.NoError(t.T(), t.createObjects(map[string]string{"dir/": ""}))
+
+	_, err := getXattr(path.Join(mntDir, "dir"), retentionXattrName)
+
+	assert.ErrorIs(t.T(), err, syscall.ENOTSUP)
+}
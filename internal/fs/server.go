@@ -31,6 +31,11 @@ func NewServer(ctx context.Context, cfg *ServerConfig) (fuse.Server, error) {
 		return nil, fmt.Errorf("create file system: %w", err)
 	}
 
+	if cfg.NewConfig.FileSystem.EnableStatusFile {
+		fs = wrappers.WithStatusFile(fs, cfg.Uid, cfg.Gid, func() ([]byte, error) {
+			return statusFileContent(cfg)
+		})
+	}
 	fs = wrappers.WithErrorMapping(fs)
 	if newcfg.IsTracingEnabled(cfg.NewConfig) {
 		fs = wrappers.WithTracing(fs, cfg.TraceHandle)
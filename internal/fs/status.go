@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
+	"gopkg.in/yaml.v3"
+)
+
+// mountStatus is the content of the status file exposed under the mount root.
+// Its fields are part of the interface with the tools reading the file, so
+// they may be added to but not renamed or removed.
+type mountStatus struct {
+	Profile           string                  `json:"profile"`
+	MachineType       string                  `json:"machine_type"`
+	BufferedRead      bufferedReadStatus      `json:"buffered_read"`
+	GarbageCollection garbageCollectionStatus `json:"garbage_collection"`
+
+	// Config is the resolved configuration, with the keys of the config file.
+	Config map[string]any `json:"config"`
+}
+
+type bufferedReadStatus struct {
+	Enabled              bool   `json:"enabled"`
+	BlockSizeMb          int64  `json:"block_size_mb"`
+	BlockAlignment       string `json:"block_alignment"`
+	GlobalMaxBlocks      int64  `json:"global_max_blocks"`
+	MaxBlocksPerHandle   int64  `json:"max_blocks_per_handle"`
+	StartBlocksPerHandle int64  `json:"start_blocks_per_handle"`
+	MinBlocksPerHandle   int64  `json:"min_blocks_per_handle"`
+	PrefetchPolicy       string `json:"prefetch_policy"`
+	DownloadDeadlineSecs int64  `json:"download_deadline_secs"`
+	DownloadMaxRetries   int64  `json:"download_max_retries"`
+}
+
+type garbageCollectionStatus struct {
+	StalenessThresholdSecs int64                           `json:"staleness_threshold_secs"`
+	PeriodSecs             int64                           `json:"period_secs"`
+	SkippedObjects         int                             `json:"skipped_objects"`
+	Buckets                []garbageCollectionBucketStatus `json:"buckets"`
+}
+
+// garbageCollectionBucketStatus describes the garbage collection runs of a
+// bucket. The times are left out until known.
+type garbageCollectionBucketStatus struct {
+	Bucket          string    `json:"bucket"`
+	LastRunTime     time.Time `json:"last_run_time,omitzero"`
+	LastSuccessTime time.Time `json:"last_success_time,omitzero"`
+	ObjectsDeleted  uint64    `json:"objects_deleted_last_run"`
+	ObjectsFailed   uint64    `json:"objects_failed_last_run"`
+	LastError       string    `json:"last_error,omitempty"`
+	NextRunTime     time.Time `json:"next_run_time,omitzero"`
+}
+
+// durationOrDefault returns d, or def if d isn't positive, like the bucket
+// manager does for the unset garbage collection durations.
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// newMountStatus returns the current status of the mount.
+func newMountStatus(c *cfg.Config, bm gcsx.BucketManager) (*mountStatus, error) {
+	// Round trip through YAML so that the keys are those of the config file.
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling config: %w", err)
+	}
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unmarshalling config: %w", err)
+	}
+
+	s := &mountStatus{
+		Profile:     c.Profile,
+		MachineType: c.MachineType,
+		BufferedRead: bufferedReadStatus{
+			Enabled:              c.Read.EnableBufferedRead,
+			BlockSizeMb:          c.Read.BlockSizeMb,
+			BlockAlignment:       c.Read.BlockAlignment,
+			GlobalMaxBlocks:      c.Read.GlobalMaxBlocks,
+			MaxBlocksPerHandle:   c.Read.MaxBlocksPerHandle,
+			StartBlocksPerHandle: c.Read.StartBlocksPerHandle,
+			MinBlocksPerHandle:   c.Read.MinBlocksPerHandle,
+			PrefetchPolicy:       c.Read.PrefetchPolicy,
+			DownloadDeadlineSecs: c.Read.DownloadDeadlineSecs,
+			DownloadMaxRetries:   c.Read.DownloadMaxRetries,
+		},
+		GarbageCollection: garbageCollectionStatus{
+			StalenessThresholdSecs: int64(durationOrDefault(c.GarbageCollection.TempObjectStaleness, gcsx.GarbageCollectionStalenessThreshold).Seconds()),
+			PeriodSecs:             int64(durationOrDefault(c.GarbageCollection.TempObjectPeriod, gcsx.GarbageCollectionPeriod).Seconds()),
+		},
+		Config: config,
+	}
+	if bm != nil {
+		if skipList := bm.GarbageCollectionSkipList(); skipList != nil {
+			s.GarbageCollection.SkippedObjects = len(skipList.Entries())
+		}
+		if state := bm.GarbageCollectionState(); state != nil {
+			for _, b := range state.Buckets() {
+				s.GarbageCollection.Buckets = append(s.GarbageCollection.Buckets, garbageCollectionBucketStatus{
+					Bucket:          b.BucketName,
+					LastRunTime:     b.LastRunTime,
+					LastSuccessTime: b.LastSuccessTime,
+					ObjectsDeleted:  b.ObjectsDeleted,
+					ObjectsFailed:   b.ObjectsFailed,
+					LastError:       b.LastError,
+					NextRunTime:     b.NextRunTime,
+				})
+			}
+		}
+	}
+	return s, nil
+}
+
+// statusFileContent returns the content of the status file exposed under the
+// mount root.
+func statusFileContent(serverCfg *ServerConfig) ([]byte, error) {
+	s, err := newMountStatus(serverCfg.NewConfig, serverCfg.BucketManager)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling status: %w", err)
+	}
+	return append(data, '\n'), nil
+}
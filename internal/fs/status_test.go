@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusFileContent(t *testing.T) {
+	c := &cfg.Config{
+		Profile:     cfg.ProfileAIMLTraining,
+		MachineType: "a3-highgpu-8g",
+		Read: cfg.ReadConfig{
+			EnableBufferedRead: true,
+			BlockSizeMb:        16,
+			GlobalMaxBlocks:    40,
+			PrefetchPolicy:     "adaptive",
+		},
+	}
+
+	data, err := statusFileContent(&ServerConfig{NewConfig: c})
+
+	require.NoError(t, err)
+	var got mountStatus
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, cfg.ProfileAIMLTraining, got.Profile)
+	assert.Equal(t, "a3-highgpu-8g", got.MachineType)
+	assert.True(t, got.BufferedRead.Enabled)
+	assert.Equal(t, int64(16), got.BufferedRead.BlockSizeMb)
+	assert.Equal(t, int64(40), got.BufferedRead.GlobalMaxBlocks)
+	assert.Equal(t, "adaptive", got.BufferedRead.PrefetchPolicy)
+	assert.Equal(t, int64(30*60), got.GarbageCollection.StalenessThresholdSecs)
+	assert.Equal(t, int64(10*60), got.GarbageCollection.PeriodSecs)
+	assert.Zero(t, got.GarbageCollection.SkippedObjects)
+	assert.Empty(t, got.GarbageCollection.Buckets)
+	require.Contains(t, got.Config, "read")
+	assert.Equal(t, float64(16), got.Config["read"].(map[string]any)["block-size-mb"])
+	assert.Equal(t, "a3-highgpu-8g", got.Config["machine-type"])
+}
+
+func TestStatusFileContentReportsConfiguredGarbageCollection(t *testing.T) {
+	c := &cfg.Config{
+		GarbageCollection: cfg.GarbageCollectionConfig{
+			TempObjectStaleness: 5 * time.Minute,
+			TempObjectPeriod:    time.Minute,
+		},
+	}
+
+	data, err := statusFileContent(&ServerConfig{NewConfig: c})
+
+	require.NoError(t, err)
+	var got mountStatus
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, int64(5*60), got.GarbageCollection.StalenessThresholdSecs)
+	assert.Equal(t, int64(60), got.GarbageCollection.PeriodSecs)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Tests of the listings of directories whose entries vanish between being
+// listed and being looked up.
+package fs_test
+
+import (
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/jacobsa/fuse/fusetesting"
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+)
+
+////////////////////////////////////////////////////////////////////////
+// Boilerplate
+////////////////////////////////////////////////////////////////////////
+
+// replayListingBucket records the last listing of the root directory, and
+// serves it again in place of the current one while replaying, so that the
+// listed entries may vanish before being looked up.
+type replayListingBucket struct {
+	gcs.Bucket
+
+	mu        sync.Mutex
+	replaying bool
+	last      *gcs.Listing
+}
+
+func (b *replayListingBucket) ListObjects(ctx context.Context, req *gcs.ListObjectsRequest) (*gcs.Listing, error) {
+	isRootListing := req.Prefix == "" && req.Delimiter == "/"
+	b.mu.Lock()
+	if isRootListing && b.replaying {
+		defer b.mu.Unlock()
+		return b.last, nil
+	}
+	b.mu.Unlock()
+
+	listing, err := b.Bucket.ListObjects(ctx, req)
+	if err == nil && isRootListing {
+		b.mu.Lock()
+		b.last = listing
+		b.mu.Unlock()
+	}
+	return listing, err
+}
+
+func (b *replayListingBucket) setReplaying(replaying bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.replaying = replaying
+}
+
+type vanishedEntryTest struct {
+	suite.Suite
+	fsTest
+	mode          string
+	replayListing *replayListingBucket
+}
+
+func (t *vanishedEntryTest) SetupSuite() {
+	t.replayListing = &replayListingBucket{Bucket: fake.NewFakeBucket(timeutil.RealClock(), "some_bucket", gcs.BucketType{})}
+	bucket = t.replayListing
+	t.mountCfg.EnableReaddirplus = true
+	t.serverCfg.NewConfig = &cfg.Config{
+		List: cfg.ListConfig{VanishedEntryMode: t.mode},
+	}
+	t.fsTest.SetUpTestSuite()
+}
+
+func (t *vanishedEntryTest) TearDownTest() {
+	t.replayListing.setReplaying(false)
+	t.fsTest.TearDown()
+}
+
+func (t *vanishedEntryTest) TearDownSuite() {
+	t.fsTest.TearDownTestSuite()
+}
+
+// listWithVanishedEntry lists the root directory with "foo" and "bar" while
+// the generation of "foo" listed was replaced, and looked up, since.
+func (t *vanishedEntryTest) listWithVanishedEntry() ([]os.FileInfo, error) {
+	require.NoError(t.T(), t.createObjects(map[string]string{"foo": "taco", "bar": "burrito"}))
+	_, err := fusetesting.ReadDirPlusPicky(mntDir)
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), t.createObjects(map[string]string{"foo": "enchilada"}))
+	_, err = os.Stat(path.Join(mntDir, "foo"))
+	require.NoError(t.T(), err)
+	t.replayListing.setReplaying(true)
+
+	return fusetesting.ReadDirPlusPicky(mntDir)
+}
+
+////////////////////////////////////////////////////////////////////////
+// Tests
+////////////////////////////////////////////////////////////////////////
+
+type vanishedEntrySkipTest struct {
+	vanishedEntryTest
+}
+
+func TestVanishedEntrySkip(t *testing.T) {
+	suite.Run(t, &vanishedEntrySkipTest{vanishedEntryTest{mode: cfg.VanishedEntryModeSkip}})
+}
+
+func (t *vanishedEntrySkipTest) TestListingLeavesOutVanishedEntry() {
+	entries, err := t.listWithVanishedEntry()
+
+	require.NoError(t.T(), err)
+	require.Len(t.T(), entries, 1)
+	assert.Equal(t.T(), "bar", entries[0].Name())
+}
+
+type vanishedEntryErrorTest struct {
+	vanishedEntryTest
+}
+
+func TestVanishedEntryError(t *testing.T) {
+	suite.Run(t, &vanishedEntryErrorTest{vanishedEntryTest{mode: cfg.VanishedEntryModeError}})
+}
+
+func (t *vanishedEntryErrorTest) TestListingFailsOnVanishedEntry() {
+	_, err := t.listWithVanishedEntry()
+
+	assert.Error(t.T(), err)
+}
@@ -24,6 +24,7 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/jacobsa/fuse/fuseops"
 	"github.com/jacobsa/fuse/fuseutil"
 	"google.golang.org/api/googleapi"
@@ -46,6 +47,12 @@ func errno(err error) error {
 		return syscall.ESTALE
 	}
 
+	// The request is denied for lack of permission or of valid credentials.
+	var permissionDeniedErr *gcs.PermissionDeniedError
+	if errors.As(err, &permissionDeniedErr) {
+		return syscall.EACCES
+	}
+
 	// Use existing em errno
 	var errno syscall.Errno
 	if errors.As(err, &errno) {
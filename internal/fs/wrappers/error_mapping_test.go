@@ -22,6 +22,7 @@ import (
 
 	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/fs/gcsfuse_errors"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/api/googleapi"
@@ -91,6 +92,14 @@ func (testSuite *ErrorMapping) TestUnAuthenticatedHttpGoogleApiError() {
 	assert.Equal(testSuite.T(), syscall.EACCES, fsErr)
 }
 
+func (testSuite *ErrorMapping) TestPermissionDeniedGCSError() {
+	permissionDeniedErr := &gcs.PermissionDeniedError{Err: &googleapi.Error{Code: http.StatusForbidden}}
+
+	fsErr := errno(fmt.Errorf("DownloadTask.Execute: while reader-creations: %w", permissionDeniedErr))
+
+	assert.Equal(testSuite.T(), syscall.EACCES, fsErr)
+}
+
 func (testSuite *ErrorMapping) TestFileClobberedError() {
 	clobberedErr := &gcsfuse_errors.FileClobberedError{
 		Err:        fmt.Errorf("some error"),
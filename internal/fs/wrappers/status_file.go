@@ -0,0 +1,408 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wrappers
+
+import (
+	"context"
+	"math"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jacobsa/fuse"
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/jacobsa/fuse/fuseutil"
+)
+
+const (
+	// StatusDirName is the name of the directory under the mount root holding
+	// the status file.
+	StatusDirName = ".gcsfuse"
+
+	// StatusFileName is the name of the status file.
+	StatusFileName = "status.json"
+
+	// Inode IDs of the status directory and file, taken from the top of the
+	// range so that they never collide with the IDs allocated by the wrapped
+	// file system, which counts up from the root.
+	statusDirInodeID  fuseops.InodeID = math.MaxUint64 - 1
+	statusFileInodeID fuseops.InodeID = math.MaxUint64
+
+	// Handle IDs of the status directory and file start at the top half of the
+	// range for the same reason.
+	statusHandleIDBase fuseops.HandleID = 1 << 63
+)
+
+// WithStatusFile wraps a FileSystem, exposing the read-only file
+// .gcsfuse/status.json under the mount root, whose content is returned by
+// status. The .gcsfuse directory isn't listed by the mount root, and shadows
+// any object under the same prefix. The content is taken when the file is
+// opened, so that reads through a handle are consistent, and each open returns
+// the current status.
+func WithStatusFile(wrapped fuseutil.FileSystem, uid, gid uint32, status func() ([]byte, error)) fuseutil.FileSystem {
+	return &statusFile{
+		FileSystem: wrapped,
+		uid:        uid,
+		gid:        gid,
+		status:     status,
+		handles:    make(map[fuseops.HandleID][]byte),
+	}
+}
+
+// statusFile forwards all the operations not involving the status directory or
+// file to the wrapped file system.
+type statusFile struct {
+	fuseutil.FileSystem
+
+	uid, gid uint32
+	status   func() ([]byte, error)
+
+	mu sync.Mutex
+
+	// GUARDED_BY(mu)
+	nextHandleID fuseops.HandleID
+
+	// Content of the open status file handles, and nil for the open status
+	// directory handles.
+	//
+	// GUARDED_BY(mu)
+	handles map[fuseops.HandleID][]byte
+}
+
+func isStatusInode(id fuseops.InodeID) bool {
+	return id == statusDirInodeID || id == statusFileInodeID
+}
+
+// isStatusEntry returns true if name under parent is the status directory or
+// one of its entries.
+func isStatusEntry(parent fuseops.InodeID, name string) bool {
+	return isStatusInode(parent) || (parent == fuseops.RootInodeID && name == StatusDirName)
+}
+
+func (sf *statusFile) attributes(id fuseops.InodeID) fuseops.InodeAttributes {
+	now := time.Now()
+	attrs := fuseops.InodeAttributes{
+		Nlink: 1,
+		Mode:  0444,
+		Atime: now,
+		Mtime: now,
+		Ctime: now,
+		Uid:   sf.uid,
+		Gid:   sf.gid,
+	}
+	if id == statusDirInodeID {
+		attrs.Nlink = 2
+		attrs.Mode = os.ModeDir | 0555
+		return attrs
+	}
+	// The content is taken again on open, so the size is only indicative: the
+	// file is opened in direct IO mode for reads not to be cut at this size.
+	if data, err := sf.status(); err == nil {
+		attrs.Size = uint64(len(data))
+	}
+	return attrs
+}
+
+func (sf *statusFile) entry(id fuseops.InodeID) fuseops.ChildInodeEntry {
+	return fuseops.ChildInodeEntry{
+		Child:      id,
+		Attributes: sf.attributes(id),
+	}
+}
+
+// LOCKS_EXCLUDED(sf.mu)
+func (sf *statusFile) newHandle(data []byte) fuseops.HandleID {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	id := statusHandleIDBase + sf.nextHandleID
+	sf.nextHandleID++
+	sf.handles[id] = data
+	return id
+}
+
+// releaseHandle forgets the given handle, returning false if it isn't a status
+// directory or file handle.
+//
+// LOCKS_EXCLUDED(sf.mu)
+func (sf *statusFile) releaseHandle(id fuseops.HandleID) bool {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	if _, ok := sf.handles[id]; !ok {
+		return false
+	}
+	delete(sf.handles, id)
+	return true
+}
+
+func (sf *statusFile) LookUpInode(ctx context.Context, op *fuseops.LookUpInodeOp) error {
+	switch {
+	case op.Parent == fuseops.RootInodeID && op.Name == StatusDirName:
+		op.Entry = sf.entry(statusDirInodeID)
+		return nil
+	case op.Parent == statusDirInodeID && op.Name == StatusFileName:
+		op.Entry = sf.entry(statusFileInodeID)
+		return nil
+	case isStatusInode(op.Parent):
+		return syscall.ENOENT
+	}
+	return sf.FileSystem.LookUpInode(ctx, op)
+}
+
+func (sf *statusFile) GetInodeAttributes(ctx context.Context, op *fuseops.GetInodeAttributesOp) error {
+	if !isStatusInode(op.Inode) {
+		return sf.FileSystem.GetInodeAttributes(ctx, op)
+	}
+	op.Attributes = sf.attributes(op.Inode)
+	return nil
+}
+
+func (sf *statusFile) SetInodeAttributes(ctx context.Context, op *fuseops.SetInodeAttributesOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.SetInodeAttributes(ctx, op)
+}
+
+func (sf *statusFile) ForgetInode(ctx context.Context, op *fuseops.ForgetInodeOp) error {
+	if isStatusInode(op.Inode) {
+		return nil
+	}
+	return sf.FileSystem.ForgetInode(ctx, op)
+}
+
+func (sf *statusFile) BatchForget(ctx context.Context, op *fuseops.BatchForgetOp) error {
+	entries := make([]fuseops.BatchForgetEntry, 0, len(op.Entries))
+	for _, e := range op.Entries {
+		if !isStatusInode(e.Inode) {
+			entries = append(entries, e)
+		}
+	}
+	op.Entries = entries
+	return sf.FileSystem.BatchForget(ctx, op)
+}
+
+func (sf *statusFile) MkDir(ctx context.Context, op *fuseops.MkDirOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.MkDir(ctx, op)
+}
+
+func (sf *statusFile) MkNode(ctx context.Context, op *fuseops.MkNodeOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.MkNode(ctx, op)
+}
+
+func (sf *statusFile) CreateFile(ctx context.Context, op *fuseops.CreateFileOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.CreateFile(ctx, op)
+}
+
+func (sf *statusFile) CreateLink(ctx context.Context, op *fuseops.CreateLinkOp) error {
+	if isStatusEntry(op.Parent, op.Name) || isStatusInode(op.Target) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.CreateLink(ctx, op)
+}
+
+func (sf *statusFile) CreateSymlink(ctx context.Context, op *fuseops.CreateSymlinkOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.CreateSymlink(ctx, op)
+}
+
+func (sf *statusFile) Rename(ctx context.Context, op *fuseops.RenameOp) error {
+	if isStatusEntry(op.OldParent, op.OldName) || isStatusEntry(op.NewParent, op.NewName) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.Rename(ctx, op)
+}
+
+func (sf *statusFile) RmDir(ctx context.Context, op *fuseops.RmDirOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.RmDir(ctx, op)
+}
+
+func (sf *statusFile) Unlink(ctx context.Context, op *fuseops.UnlinkOp) error {
+	if isStatusEntry(op.Parent, op.Name) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.Unlink(ctx, op)
+}
+
+func (sf *statusFile) OpenDir(ctx context.Context, op *fuseops.OpenDirOp) error {
+	switch op.Inode {
+	case statusDirInodeID:
+		op.Handle = sf.newHandle(nil)
+		return nil
+	case statusFileInodeID:
+		return syscall.ENOTDIR
+	}
+	return sf.FileSystem.OpenDir(ctx, op)
+}
+
+func (sf *statusFile) ReadDir(ctx context.Context, op *fuseops.ReadDirOp) error {
+	if op.Inode != statusDirInodeID {
+		return sf.FileSystem.ReadDir(ctx, op)
+	}
+	if op.Offset == 0 {
+		op.BytesRead = fuseutil.WriteDirent(op.Dst, sf.statusFileDirent())
+	}
+	return nil
+}
+
+func (sf *statusFile) ReadDirPlus(ctx context.Context, op *fuseops.ReadDirPlusOp) error {
+	if op.Inode != statusDirInodeID {
+		return sf.FileSystem.ReadDirPlus(ctx, op)
+	}
+	if op.Offset == 0 {
+		op.BytesRead = fuseutil.WriteDirentPlus(op.Dst, fuseutil.DirentPlus{
+			Dirent: sf.statusFileDirent(),
+			Entry:  sf.entry(statusFileInodeID),
+		})
+	}
+	return nil
+}
+
+func (sf *statusFile) statusFileDirent() fuseutil.Dirent {
+	return fuseutil.Dirent{
+		Offset: 1,
+		Inode:  statusFileInodeID,
+		Name:   StatusFileName,
+		Type:   fuseutil.DT_File,
+	}
+}
+
+func (sf *statusFile) ReleaseDirHandle(ctx context.Context, op *fuseops.ReleaseDirHandleOp) error {
+	if sf.releaseHandle(op.Handle) {
+		return nil
+	}
+	return sf.FileSystem.ReleaseDirHandle(ctx, op)
+}
+
+func (sf *statusFile) OpenFile(ctx context.Context, op *fuseops.OpenFileOp) error {
+	switch op.Inode {
+	case statusDirInodeID:
+		return syscall.EISDIR
+	case statusFileInodeID:
+		if !op.OpenFlags.IsReadOnly() {
+			return syscall.EROFS
+		}
+		data, err := sf.status()
+		if err != nil {
+			return err
+		}
+		op.Handle = sf.newHandle(data)
+		op.UseDirectIO = true
+		return nil
+	}
+	return sf.FileSystem.OpenFile(ctx, op)
+}
+
+func (sf *statusFile) ReadFile(ctx context.Context, op *fuseops.ReadFileOp) error {
+	if op.Inode != statusFileInodeID {
+		return sf.FileSystem.ReadFile(ctx, op)
+	}
+	sf.mu.Lock()
+	data, ok := sf.handles[op.Handle]
+	sf.mu.Unlock()
+	if !ok {
+		return syscall.EBADF
+	}
+	if op.Offset < int64(len(data)) {
+		op.BytesRead = copy(op.Dst, data[op.Offset:])
+	}
+	return nil
+}
+
+func (sf *statusFile) WriteFile(ctx context.Context, op *fuseops.WriteFileOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EBADF
+	}
+	return sf.FileSystem.WriteFile(ctx, op)
+}
+
+func (sf *statusFile) SyncFile(ctx context.Context, op *fuseops.SyncFileOp) error {
+	if isStatusInode(op.Inode) {
+		return nil
+	}
+	return sf.FileSystem.SyncFile(ctx, op)
+}
+
+func (sf *statusFile) FlushFile(ctx context.Context, op *fuseops.FlushFileOp) error {
+	if isStatusInode(op.Inode) {
+		return nil
+	}
+	return sf.FileSystem.FlushFile(ctx, op)
+}
+
+func (sf *statusFile) ReleaseFileHandle(ctx context.Context, op *fuseops.ReleaseFileHandleOp) error {
+	if sf.releaseHandle(op.Handle) {
+		return nil
+	}
+	return sf.FileSystem.ReleaseFileHandle(ctx, op)
+}
+
+func (sf *statusFile) ReadSymlink(ctx context.Context, op *fuseops.ReadSymlinkOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EINVAL
+	}
+	return sf.FileSystem.ReadSymlink(ctx, op)
+}
+
+func (sf *statusFile) RemoveXattr(ctx context.Context, op *fuseops.RemoveXattrOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.RemoveXattr(ctx, op)
+}
+
+func (sf *statusFile) GetXattr(ctx context.Context, op *fuseops.GetXattrOp) error {
+	if isStatusInode(op.Inode) {
+		return fuse.ENOATTR
+	}
+	return sf.FileSystem.GetXattr(ctx, op)
+}
+
+func (sf *statusFile) ListXattr(ctx context.Context, op *fuseops.ListXattrOp) error {
+	if isStatusInode(op.Inode) {
+		return nil
+	}
+	return sf.FileSystem.ListXattr(ctx, op)
+}
+
+func (sf *statusFile) SetXattr(ctx context.Context, op *fuseops.SetXattrOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.SetXattr(ctx, op)
+}
+
+func (sf *statusFile) Fallocate(ctx context.Context, op *fuseops.FallocateOp) error {
+	if isStatusInode(op.Inode) {
+		return syscall.EROFS
+	}
+	return sf.FileSystem.Fallocate(ctx, op)
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wrappers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/jacobsa/fuse/fuseutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFS records the inodes looked up and the entries forgotten through
+// it.
+type recordingFS struct {
+	fuseutil.NotImplementedFileSystem
+
+	lookedUp  []string
+	forgotten []fuseops.InodeID
+}
+
+func (fs *recordingFS) LookUpInode(ctx context.Context, op *fuseops.LookUpInodeOp) error {
+	fs.lookedUp = append(fs.lookedUp, op.Name)
+	return syscall.ENOENT
+}
+
+func (fs *recordingFS) BatchForget(ctx context.Context, op *fuseops.BatchForgetOp) error {
+	for _, e := range op.Entries {
+		fs.forgotten = append(fs.forgotten, e.Inode)
+	}
+	return nil
+}
+
+func newStatusFileForTest(status *int) (fuseutil.FileSystem, *recordingFS) {
+	inner := &recordingFS{}
+	return WithStatusFile(inner, 1000, 1001, func() ([]byte, error) {
+		return json.Marshal(map[string]int{"status": *status})
+	}), inner
+}
+
+func lookUpStatusFile(t *testing.T, fs fuseutil.FileSystem) fuseops.ChildInodeEntry {
+	t.Helper()
+	dirOp := &fuseops.LookUpInodeOp{Parent: fuseops.RootInodeID, Name: StatusDirName}
+	require.NoError(t, fs.LookUpInode(context.Background(), dirOp))
+	assert.True(t, dirOp.Entry.Attributes.Mode.IsDir())
+	fileOp := &fuseops.LookUpInodeOp{Parent: dirOp.Entry.Child, Name: StatusFileName}
+	require.NoError(t, fs.LookUpInode(context.Background(), fileOp))
+	return fileOp.Entry
+}
+
+func readStatusFile(t *testing.T, fs fuseutil.FileSystem, inode fuseops.InodeID) map[string]int {
+	t.Helper()
+	ctx := context.Background()
+	openOp := &fuseops.OpenFileOp{Inode: inode}
+	require.NoError(t, fs.OpenFile(ctx, openOp))
+	readOp := &fuseops.ReadFileOp{Inode: inode, Handle: openOp.Handle, Dst: make([]byte, 4096)}
+	require.NoError(t, fs.ReadFile(ctx, readOp))
+	require.NoError(t, fs.ReleaseFileHandle(ctx, &fuseops.ReleaseFileHandleOp{Handle: openOp.Handle}))
+	var got map[string]int
+	require.NoError(t, json.Unmarshal(readOp.Dst[:readOp.BytesRead], &got))
+	return got
+}
+
+func TestStatusFile_ReadReturnsCurrentStatus(t *testing.T) {
+	status := 1
+	fs, inner := newStatusFileForTest(&status)
+
+	entry := lookUpStatusFile(t, fs)
+	assert.Equal(t, os.FileMode(0444), entry.Attributes.Mode)
+	assert.Equal(t, uint32(1000), entry.Attributes.Uid)
+	assert.Equal(t, uint32(1001), entry.Attributes.Gid)
+	assert.Equal(t, uint64(len(`{"status":1}`)), entry.Attributes.Size)
+	assert.Equal(t, map[string]int{"status": 1}, readStatusFile(t, fs, entry.Child))
+	status = 2
+	assert.Equal(t, map[string]int{"status": 2}, readStatusFile(t, fs, entry.Child))
+	assert.Empty(t, inner.lookedUp)
+}
+
+func TestStatusFile_ReadThroughHandleIsConsistent(t *testing.T) {
+	status := 1
+	fs, _ := newStatusFileForTest(&status)
+	entry := lookUpStatusFile(t, fs)
+	ctx := context.Background()
+	openOp := &fuseops.OpenFileOp{Inode: entry.Child}
+	require.NoError(t, fs.OpenFile(ctx, openOp))
+
+	status = 2
+	readOp := &fuseops.ReadFileOp{Inode: entry.Child, Handle: openOp.Handle, Offset: 1, Dst: make([]byte, 4096)}
+	require.NoError(t, fs.ReadFile(ctx, readOp))
+
+	assert.True(t, openOp.UseDirectIO)
+	assert.Equal(t, `"status":1}`, string(readOp.Dst[:readOp.BytesRead]))
+}
+
+func TestStatusFile_IsReadOnly(t *testing.T) {
+	status := 1
+	fs, _ := newStatusFileForTest(&status)
+	entry := lookUpStatusFile(t, fs)
+	dir := fuseops.InodeID(statusDirInodeID)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, fs.OpenFile(ctx, &fuseops.OpenFileOp{Inode: entry.Child, OpenFlags: syscall.O_WRONLY}), syscall.EROFS)
+	assert.ErrorIs(t, fs.OpenFile(ctx, &fuseops.OpenFileOp{Inode: entry.Child, OpenFlags: syscall.O_RDWR}), syscall.EROFS)
+	assert.ErrorIs(t, fs.Unlink(ctx, &fuseops.UnlinkOp{Parent: dir, Name: StatusFileName}), syscall.EROFS)
+	assert.ErrorIs(t, fs.CreateFile(ctx, &fuseops.CreateFileOp{Parent: dir, Name: "foo"}), syscall.EROFS)
+	assert.ErrorIs(t, fs.RmDir(ctx, &fuseops.RmDirOp{Parent: fuseops.RootInodeID, Name: StatusDirName}), syscall.EROFS)
+	assert.ErrorIs(t, fs.Rename(ctx, &fuseops.RenameOp{OldParent: dir, OldName: StatusFileName, NewParent: fuseops.RootInodeID, NewName: "foo"}), syscall.EROFS)
+	assert.ErrorIs(t, fs.SetInodeAttributes(ctx, &fuseops.SetInodeAttributesOp{Inode: entry.Child}), syscall.EROFS)
+}
+
+func TestStatusFile_DirectoryListsStatusFile(t *testing.T) {
+	status := 1
+	fs, _ := newStatusFileForTest(&status)
+	ctx := context.Background()
+	dir := fuseops.InodeID(statusDirInodeID)
+	openOp := &fuseops.OpenDirOp{Inode: dir}
+	require.NoError(t, fs.OpenDir(ctx, openOp))
+
+	readOp := &fuseops.ReadDirOp{Inode: dir, Handle: openOp.Handle, Dst: make([]byte, 4096)}
+	require.NoError(t, fs.ReadDir(ctx, readOp))
+	want := make([]byte, 4096)
+	n := fuseutil.WriteDirent(want, fuseutil.Dirent{Offset: 1, Inode: statusFileInodeID, Name: StatusFileName, Type: fuseutil.DT_File})
+	assert.Equal(t, want[:n], readOp.Dst[:readOp.BytesRead])
+
+	readOp = &fuseops.ReadDirOp{Inode: dir, Handle: openOp.Handle, Offset: 1, Dst: make([]byte, 4096)}
+	require.NoError(t, fs.ReadDir(ctx, readOp))
+	assert.Zero(t, readOp.BytesRead)
+	require.NoError(t, fs.ReleaseDirHandle(ctx, &fuseops.ReleaseDirHandleOp{Handle: openOp.Handle}))
+}
+
+func TestStatusFile_ForwardsOtherOperations(t *testing.T) {
+	status := 1
+	fs, inner := newStatusFileForTest(&status)
+	ctx := context.Background()
+
+	err := fs.LookUpInode(ctx, &fuseops.LookUpInodeOp{Parent: fuseops.RootInodeID, Name: "foo"})
+	require.NoError(t, fs.BatchForget(ctx, &fuseops.BatchForgetOp{Entries: []fuseops.BatchForgetEntry{{Inode: 7}, {Inode: statusFileInodeID}}}))
+
+	assert.ErrorIs(t, err, syscall.ENOENT)
+	assert.Equal(t, []string{"foo"}, inner.lookedUp)
+	assert.Equal(t, []fuseops.InodeID{7}, inner.forgotten)
+	// Other operations on the status directory's parent are forwarded too.
+	assert.ErrorIs(t, fs.OpenDir(ctx, &fuseops.OpenDirOp{Inode: fuseops.RootInodeID}), syscall.ENOSYS)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
@@ -70,6 +71,51 @@ type BucketConfig struct {
 	ChunkTransferTimeoutSecs int64
 	TmpObjectPrefix          string
 
+	// UploadConfig selects how the full contents of objects are uploaded. The
+	// components of parallel composite uploads are temporary objects too, named
+	// with TmpObjectPrefix, following the template of its TmpObjectNamer if
+	// set, like the temporary objects of appends.
+	UploadConfig UploadConfig
+
+	// GarbageCollectionMode is how the stale temporary objects are deleted, one
+	// of cfg.GarbageCollectionModeClient or cfg.GarbageCollectionModeLifecycle.
+	GarbageCollectionMode string
+
+	// GarbageCollectionDeleteOpsPerSec limits the rate of the deletions of the
+	// garbage collection across the buckets, if positive.
+	GarbageCollectionDeleteOpsPerSec float64
+
+	// GarbageCollectionMaxDeleteBacklog is the maximum number of objects the
+	// garbage collection of a bucket lists ahead of their deletion, or
+	// DefaultGarbageCollectionMaxDeleteBacklog if not positive.
+	GarbageCollectionMaxDeleteBacklog int
+
+	// GarbageCollectionDeleteParallelism is the number of objects the garbage
+	// collection of a bucket deletes concurrently, or
+	// DefaultGarbageCollectionDeleteParallelism if not positive.
+	GarbageCollectionDeleteParallelism int
+
+	// GarbageCollectionStaleness is the age beyond which the garbage collection
+	// deletes temporary objects, or GarbageCollectionStalenessThreshold if not
+	// positive.
+	GarbageCollectionStaleness time.Duration
+
+	// GarbageCollectionPeriod is the interval between the garbage collection
+	// runs, or the default GarbageCollectionPeriod if not positive.
+	GarbageCollectionPeriod time.Duration
+
+	// GarbageCollectionExcludePatterns are the names, or path.Match patterns,
+	// of the temporary objects which the garbage collection never deletes.
+	GarbageCollectionExcludePatterns []string
+
+	// GarbageCollectionDryRun makes the garbage collection only log the stale
+	// temporary objects it would delete, leaving them in place.
+	GarbageCollectionDryRun bool
+
+	// GarbageCollectionFinalSweep makes the garbage collection run a last time
+	// on shut down.
+	GarbageCollectionFinalSweep bool
+
 	// Disable Initial ListObject API check during the mount operation.
 	DisableListAccessCheck bool
 
@@ -89,8 +135,21 @@ type BucketManager interface {
 		ctx context.Context,
 		name string, isMultibucketMount bool, metricHandle metrics.MetricHandle) (b SyncerBucket, err error)
 
+	// GarbageCollectionSkipList returns the objects skipped by the garbage
+	// collection of temporary objects after failing to delete them.
+	GarbageCollectionSkipList() *GarbageCollectionSkipList
+
+	// GarbageCollectionState returns the state of the garbage collection of
+	// the temporary objects of the buckets.
+	GarbageCollectionState() *GarbageCollectionState
+
 	// Shuts down the bucket manager and its buckets
 	ShutDown()
+
+	// ShutDownOnError shuts down the bucket manager and its buckets on the
+	// given error, e.g. a failed mount, skipping the final garbage collection
+	// sweeps.
+	ShutDownOnError(err error)
 }
 
 type bucketManager struct {
@@ -100,7 +159,19 @@ type bucketManager struct {
 
 	// Garbage collector
 	gcCtx                 context.Context
-	stopGarbageCollecting func()
+	stopGarbageCollecting context.CancelCauseFunc
+	gcSkipList            *GarbageCollectionSkipList
+	gcProtectList         *GarbageCollectionProtectList
+	gcState               *GarbageCollectionState
+
+	// gcDeleteThrottle limits the rate of the deletions of the garbage
+	// collection across the buckets of the mount. Nil if unlimited.
+	gcDeleteThrottle ratelimit.Throttle
+
+	// gcRunning tracks the garbage collection of the buckets, waited for on
+	// shut down with GarbageCollectionFinalSweep so that the final sweeps
+	// complete before the process exits.
+	gcRunning sync.WaitGroup
 }
 
 func NewBucketManager(config BucketConfig, storageHandle storage.StorageHandle) BucketManager {
@@ -113,8 +184,28 @@ func NewBucketManager(config BucketConfig, storageHandle storage.StorageHandle)
 		config:          config,
 		storageHandle:   storageHandle,
 		sharedStatCache: c,
+		gcSkipList:      NewGarbageCollectionSkipList(gcSkipListCapacity, gcSkipCooldown, timeutil.RealClock()),
+		gcProtectList:   NewGarbageCollectionProtectList(config.GarbageCollectionExcludePatterns...),
+		gcState:         NewGarbageCollectionState(),
+	}
+	monitor.RegisterDebugHandler("gc/protect-list", bm.gcProtectList)
+	if config.GarbageCollectionDeleteOpsPerSec > 0 {
+		// Without bursts, as their deletions are what the limit guards against.
+		bm.gcDeleteThrottle = ratelimit.NewThrottle(config.GarbageCollectionDeleteOpsPerSec, 1)
+	}
+	if config.GarbageCollectionMaxDeleteBacklog < 1 {
+		bm.config.GarbageCollectionMaxDeleteBacklog = DefaultGarbageCollectionMaxDeleteBacklog
 	}
-	bm.gcCtx, bm.stopGarbageCollecting = context.WithCancel(context.Background())
+	if config.GarbageCollectionDeleteParallelism < 1 {
+		bm.config.GarbageCollectionDeleteParallelism = DefaultGarbageCollectionDeleteParallelism
+	}
+	if config.GarbageCollectionStaleness <= 0 {
+		bm.config.GarbageCollectionStaleness = GarbageCollectionStalenessThreshold
+	}
+	if config.GarbageCollectionPeriod <= 0 {
+		bm.config.GarbageCollectionPeriod = GarbageCollectionPeriod
+	}
+	bm.gcCtx, bm.stopGarbageCollecting = context.WithCancelCause(context.Background())
 	return bm
 }
 
@@ -260,6 +351,7 @@ func (bm *bucketManager) SetUpBucket(
 		bm.config.ChunkRetryDeadlineSecs,
 		bm.config.ChunkTransferTimeoutSecs,
 		bm.config.TmpObjectPrefix,
+		bm.config.UploadConfig,
 		b)
 
 	// Fetch bucket type from storage layout api and set bucket type.
@@ -275,12 +367,56 @@ func (bm *bucketManager) SetUpBucket(
 		}
 	}
 
-	// Periodically garbage collect temporary objects
-	go garbageCollect(bm.gcCtx, bm.config.TmpObjectPrefix, sb)
+	go checkTmpObjectLifecycleRule(bm.gcCtx, sb, bm.config.TmpObjectPrefix, bm.config.GarbageCollectionMode)
+
+	// Periodically garbage collect temporary objects, unless they are deleted
+	// by a bucket lifecycle rule.
+	if bm.config.GarbageCollectionMode != cfg.GarbageCollectionModeLifecycle {
+		gc := newGarbageCollector(&garbageCollectorOptions{
+			Namer:             bm.config.UploadConfig.tmpObjectNamer(bm.config.TmpObjectPrefix),
+			Bucket:            sb,
+			MetricHandle:      metricHandle,
+			SkipList:          bm.gcSkipList,
+			ProtectList:       bm.gcProtectList,
+			State:             bm.gcState,
+			DeleteThrottle:    bm.gcDeleteThrottle,
+			MaxDeleteBacklog:  bm.config.GarbageCollectionMaxDeleteBacklog,
+			DeleteParallelism: bm.config.GarbageCollectionDeleteParallelism,
+			Staleness:         bm.config.GarbageCollectionStaleness,
+			Period:            bm.config.GarbageCollectionPeriod,
+			DryRun:            bm.config.GarbageCollectionDryRun,
+			FinalSweep:        bm.config.GarbageCollectionFinalSweep,
+		})
+		bm.gcRunning.Go(func() { garbageCollect(bm.gcCtx, gc) })
+	}
 
 	return
 }
 
+func (bm *bucketManager) GarbageCollectionSkipList() *GarbageCollectionSkipList {
+	return bm.gcSkipList
+}
+
+func (bm *bucketManager) GarbageCollectionState() *GarbageCollectionState {
+	return bm.gcState
+}
+
 func (bm *bucketManager) ShutDown() {
-	bm.stopGarbageCollecting()
+	// Cancelled without a cause, the shut down being graceful, so that the
+	// final sweeps run.
+	bm.shutDown(nil)
+}
+
+func (bm *bucketManager) ShutDownOnError(err error) {
+	bm.shutDown(err)
+}
+
+// shutDown stops the garbage collection of the buckets with the given cause,
+// the final sweeps only running without one, and waits for the garbage
+// collection to stop if the final sweep is configured.
+func (bm *bucketManager) shutDown(cause error) {
+	bm.stopGarbageCollecting(cause)
+	if bm.config.GarbageCollectionFinalSweep {
+		bm.gcRunning.Wait()
+	}
 }
@@ -101,11 +101,13 @@ func (t *BucketManagerTest) TestSetUpBucketMethod() {
 		EnableMonitoring:                   true,
 		AppendThreshold:                    2,
 		TmpObjectPrefix:                    "TmpObjectPrefix",
+		GarbageCollectionPeriod:            GarbageCollectionPeriod,
 	}
 	ctx := context.Background()
 	bm.storageHandle = t.storageHandle
 	bm.config = bucketConfig
 	bm.gcCtx = ctx
+	bm.gcState = NewGarbageCollectionState()
 
 	bucket, err := bm.SetUpBucket(context.Background(), TestBucketName, false, metrics.NewNoopMetrics())
 
@@ -125,11 +127,13 @@ func (t *BucketManagerTest) TestSetUpBucketMethod_IsMultiBucketMountTrue() {
 		EnableMonitoring:                   true,
 		AppendThreshold:                    2,
 		TmpObjectPrefix:                    "TmpObjectPrefix",
+		GarbageCollectionPeriod:            GarbageCollectionPeriod,
 	}
 	ctx := context.Background()
 	bm.storageHandle = t.storageHandle
 	bm.config = bucketConfig
 	bm.gcCtx = ctx
+	bm.gcState = NewGarbageCollectionState()
 
 	bucket, err := bm.SetUpBucket(context.Background(), TestBucketName, true, metrics.NewNoopMetrics())
 
@@ -149,11 +153,13 @@ func (t *BucketManagerTest) TestSetUpBucketMethodWhenBucketDoesNotExist() {
 		EnableMonitoring:                   true,
 		AppendThreshold:                    2,
 		TmpObjectPrefix:                    "TmpObjectPrefix",
+		GarbageCollectionPeriod:            GarbageCollectionPeriod,
 	}
 	ctx := context.Background()
 	bm.storageHandle = t.storageHandle
 	bm.config = bucketConfig
 	bm.gcCtx = ctx
+	bm.gcState = NewGarbageCollectionState()
 
 	bucket, err := bm.SetUpBucket(context.Background(), invalidBucketName, false, metrics.NewNoopMetrics())
 
@@ -174,11 +180,13 @@ func (t *BucketManagerTest) TestSetUpBucketMethodWhenBucketDoesNotExist_IsMultiB
 		EnableMonitoring:                   true,
 		AppendThreshold:                    2,
 		TmpObjectPrefix:                    "TmpObjectPrefix",
+		GarbageCollectionPeriod:            GarbageCollectionPeriod,
 	}
 	ctx := context.Background()
 	bm.storageHandle = t.storageHandle
 	bm.config = bucketConfig
 	bm.gcCtx = ctx
+	bm.gcState = NewGarbageCollectionState()
 
 	bucket, err := bm.SetUpBucket(context.Background(), invalidBucketName, true, metrics.NewNoopMetrics())
 
@@ -27,8 +27,8 @@ import (
 )
 
 // Create an objectCreator that accepts a source object and the contents that
-// should be "appended" to it, storing temporary objects using the supplied
-// prefix.
+// should be "appended" to it, storing temporary objects named by the supplied
+// namer.
 //
 // Note that the Create method will attempt to remove any temporary junk left
 // behind, but it may fail to do so. Users should arrange for garbage collection.
@@ -36,10 +36,10 @@ import (
 // Create guarantees to return *gcs.PreconditionError when the source object
 // has been clobbered.
 func newComposeObjectCreator(
-	prefix string,
+	namer *TmpObjectNamer,
 	bucket gcs.Bucket) (oc objectCreator) {
 	oc = &composeObjectCreator{
-		prefix: prefix,
+		namer:  namer,
 		bucket: bucket,
 	}
 
@@ -51,12 +51,30 @@ func newComposeObjectCreator(
 ////////////////////////////////////////////////////////////////////////
 
 type composeObjectCreator struct {
-	prefix string
+	namer  *TmpObjectNamer
 	bucket gcs.Bucket
 }
 
 func (oc *composeObjectCreator) chooseName() (name string, err error) {
-	// Generate a good 64-bit random number.
+	return oc.namer.Name(TmpObjectTypeCompose)
+}
+
+// chooseTmpObjectName returns a random name for a temporary object, beginning
+// with the given prefix.
+func chooseTmpObjectName(prefix string) (name string, err error) {
+	x, err := randomHex()
+	if err != nil {
+		return
+	}
+
+	// Turn it into a name.
+	name = prefix + x
+
+	return
+}
+
+// randomHex returns a good 64-bit random number, in hexadecimal.
+func randomHex() (x string, err error) {
 	var buf [8]byte
 	_, err = io.ReadFull(rand.Reader, buf[:])
 	if err != nil {
@@ -64,7 +82,7 @@ func (oc *composeObjectCreator) chooseName() (name string, err error) {
 		return
 	}
 
-	x := uint64(buf[0])<<0 |
+	n := uint64(buf[0])<<0 |
 		uint64(buf[1])<<8 |
 		uint64(buf[2])<<16 |
 		uint64(buf[3])<<24 |
@@ -73,8 +91,7 @@ func (oc *composeObjectCreator) chooseName() (name string, err error) {
 		uint64(buf[6])<<48 |
 		uint64(buf[7])<<56
 
-	// Turn it into a name.
-	name = fmt.Sprintf("%s%016x", oc.prefix, x)
+	x = fmt.Sprintf("%016x", n)
 
 	return
 }
@@ -84,7 +84,7 @@ func (t *ComposeObjectCreatorTest) SetUp(ti *TestInfo) {
 	t.bucket = storage.NewMockBucket(ti.MockController, "bucket")
 
 	// Create the creator.
-	t.creator = newComposeObjectCreator(prefix, t.bucket)
+	t.creator = newComposeObjectCreator(&TmpObjectNamer{prefix: prefix}, t.bucket)
 }
 
 func (t *ComposeObjectCreatorTest) call() (o *gcs.Object, err error) {
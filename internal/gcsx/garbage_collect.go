@@ -15,30 +15,102 @@
 package gcsx
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/clock"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
-	"golang.org/x/net/context"
+	"github.com/jacobsa/timeutil"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
 )
 
+const (
+	// GarbageCollectionStalenessThreshold is the age beyond which garbage
+	// collection deletes temporary objects, unless configured otherwise.
+	GarbageCollectionStalenessThreshold = 30 * time.Minute
+
+	// GarbageCollectionPeriod is the interval between garbage collection runs,
+	// unless configured otherwise.
+	GarbageCollectionPeriod = 10 * time.Minute
+
+	// Number of times a failed garbage collection run is retried before waiting
+	// for the next period.
+	gcRunRetries = 2
+
+	// Wait before retrying a failed garbage collection run, doubled after each
+	// retry.
+	gcRunRetryBackoff = 30 * time.Second
+
+	// DefaultGarbageCollectionMaxDeleteBacklog is the number of objects garbage
+	// collection lists ahead of their deletion, unless configured otherwise.
+	DefaultGarbageCollectionMaxDeleteBacklog = 100
+
+	// DefaultGarbageCollectionDeleteParallelism is the number of objects
+	// garbage collection deletes concurrently, unless configured otherwise.
+	DefaultGarbageCollectionDeleteParallelism = 4
+
+	// gcFinalSweepTimeout bounds the garbage collection run on unmount, which
+	// holds back the exit of the process.
+	gcFinalSweepTimeout = 30 * time.Second
+)
+
+// garbageCollectionResult is what a garbage collection run did to the
+// temporary objects.
+type garbageCollectionResult struct {
+	// scanned is the number of temporary objects listed.
+	scanned uint64
+
+	// stale is the number of listed objects old enough to be deleted, of the
+	// mount instance collected if any.
+	stale uint64
+
+	// skipped is the number of stale objects left in place as protected, or as
+	// their deletion recently failed.
+	skipped uint64
+
+	// deleted is the number of stale objects deleted, or which would be in a
+	// dry run.
+	deleted uint64
+
+	// failed is the number of stale objects whose deletion failed.
+	failed uint64
+}
+
+// garbageCollectOnce performs a garbage collection run of gc, deleting the
+// temporary objects under the prefix of its namer created more than its
+// staleness ago, as embedded in their name by the template of the namer, or
+// else last updated more than staleness ago. With instanceID set, only the
+// objects whose name embeds that mount instance ID are deleted. The objects
+// matching its protect-list, unless nil, are never deleted, the protect-list
+// being checked again right before each deletion so that its updates apply to
+// runs in progress. With dryRun, the stale objects are logged along with their
+// age and counted as deleted, but left in place. Each deletion first waits for
+// a token of deleteThrottle, unless nil, the time waited being recorded with
+// metricHandle. Up to deleteParallelism objects are deleted concurrently, the
+// run failing on the first deletion error. At most maxDeleteBacklog objects are
+// listed but not yet deleted at any time, the listing waiting for the deletions
+// to catch up beyond that, and the size of that backlog is recorded with
+// metricHandle. Returns what the run did, up to its failure if any.
 func garbageCollectOnce(
 	ctx context.Context,
-	tmpObjectPrefix string,
-	bucket gcs.Bucket) (objectsDeleted uint64, err error) {
-	const stalenessThreshold = 30 * time.Minute
+	gc *garbageCollector,
+	instanceID string) (result garbageCollectionResult, err error) {
 	group, ctx := errgroup.WithContext(ctx)
 
 	// List all objects with the temporary prefix.
 	minObjects := make(chan *gcs.MinObject, 100)
 	group.Go(func() (err error) {
 		defer close(minObjects)
-		err = storageutil.ListPrefix(ctx, bucket, tmpObjectPrefix, minObjects)
+		err = storageutil.ListPrefix(ctx, gc.bucket, gc.namer.Prefix(), minObjects)
 		if err != nil {
 			err = fmt.Errorf("ListPrefix: %w", err)
 			return
@@ -47,13 +119,43 @@ func garbageCollectOnce(
 		return
 	})
 
-	// Filter to the names of objects that are stale.
+	// Filter to the objects that are stale, and of the given instance if any,
+	// leaving out the protected ones and the ones whose deletion recently
+	// failed. Each object takes a slot of the backlog until its deletion
+	// completes, so that slow deletions hold back the filtering, and in turn
+	// the listing.
+	type staleObject struct {
+		name string
+		age  time.Duration
+	}
 	now := time.Now()
-	staleNames := make(chan string, 100)
+	backlog := make(chan struct{}, gc.maxDeleteBacklog)
+	staleObjects := make(chan staleObject, gc.maxDeleteBacklog)
 	group.Go(func() (err error) {
-		defer close(staleNames)
+		defer close(staleObjects)
 		for o := range minObjects {
-			if now.Sub(o.Updated) < stalenessThreshold {
+			result.scanned++
+			created := o.Updated
+			fields, parsed := gc.namer.Parse(o.Name)
+			if parsed && !fields.Created.IsZero() {
+				created = fields.Created
+			}
+			if instanceID != "" && (!parsed || fields.InstanceID != instanceID) {
+				continue
+			}
+			age := now.Sub(created)
+			if age < gc.staleness {
+				continue
+			}
+			result.stale++
+			if gc.protectList.protects(o.Name) {
+				logger.Infof("Garbage collection skips %q as it is protected.", o.Name)
+				atomic.AddUint64(&result.skipped, 1)
+				continue
+			}
+			if gc.skipList.shouldSkip(gc.bucket.Name(), o.Name) {
+				logger.Tracef("Garbage collection skips %q as its deletion recently failed.", o.Name)
+				atomic.AddUint64(&result.skipped, 1)
 				continue
 			}
 
@@ -62,73 +164,352 @@ func garbageCollectOnce(
 				err = ctx.Err()
 				return
 
-			case staleNames <- o.Name:
+			case backlog <- struct{}{}:
 			}
+			gc.metricHandle.GcDeleteBacklog(1)
+			// Never blocks, as there are no more objects queued than backlog
+			// slots.
+			staleObjects <- staleObject{name: o.Name, age: age}
 		}
 
 		return
 	})
 
 	// Delete those objects.
-	group.Go(func() (err error) {
-		for name := range staleNames {
-			err = bucket.DeleteObject(
-				ctx,
-				&gcs.DeleteObjectRequest{
-					Name:       name,
-					Generation: 0, // Latest generation of stale object.
-				})
+	deleteStale := func(o staleObject) error {
+		name := o.name
+		if gc.protectList.protects(name) {
+			logger.Infof("Garbage collection cancels the deletion of %q as it was protected meanwhile.", name)
+			atomic.AddUint64(&result.skipped, 1)
+			return nil
+		}
+		if gc.dryRun {
+			logger.Infof("Garbage collection would delete %q, created %v ago.", name, o.age.Round(time.Second))
+			atomic.AddUint64(&result.deleted, 1)
+			return nil
+		}
 
+		if gc.deleteThrottle != nil {
+			waitStart := time.Now()
+			err := gc.deleteThrottle.Wait(ctx, 1)
+			gc.metricHandle.GcDeleteThrottledTime(time.Since(waitStart).Microseconds())
 			if err != nil {
-				err = fmt.Errorf("DeleteObject(%q): %w", name, err)
-				return
+				return fmt.Errorf("waiting to delete %q: %w", name, err)
 			}
+		}
+
+		err := gc.bucket.DeleteObject(
+			ctx,
+			&gcs.DeleteObjectRequest{
+				Name:       name,
+				Generation: 0, // Latest generation of stale object.
+			})
 
-			atomic.AddUint64(&objectsDeleted, 1)
+		if err != nil {
+			if ctx.Err() == nil {
+				gc.skipList.recordFailure(gc.bucket.Name(), name, err)
+				atomic.AddUint64(&result.failed, 1)
+			}
+			return fmt.Errorf("DeleteObject(%q): %w", name, err)
 		}
 
-		return
-	})
+		gc.skipList.forget(gc.bucket.Name(), name)
+		atomic.AddUint64(&result.deleted, 1)
+		return nil
+	}
+	// The first failed deletion cancels ctx, stopping the other workers before
+	// their next deletion.
+	for range max(gc.deleteParallelism, 1) {
+		group.Go(func() (err error) {
+			for o := range staleObjects {
+				if err = ctx.Err(); err == nil {
+					err = deleteStale(o)
+				}
+				gc.metricHandle.GcDeleteBacklog(-1)
+				<-backlog
+				if err != nil {
+					return
+				}
+			}
+
+			return
+		})
+	}
 
 	err = group.Wait()
+	// The objects left queued by a failed run are no longer part of the
+	// backlog.
+	gc.metricHandle.GcDeleteBacklog(-int64(len(backlog)))
 	return
 }
 
-// Periodically delete stale temporary objects from the supplied bucket until
-// the context is cancelled.
+// GarbageCollectOnce runs a single garbage collection of the temporary objects
+// named by namer in the bucket, outside of any mount, deleting the ones older
+// than staleness, of the given mount instance only if instanceID is set. With
+// dryRun, nothing is deleted. Returns the number of objects deleted, or which
+// would be deleted with dryRun.
+func GarbageCollectOnce(
+	ctx context.Context,
+	bucket gcs.Bucket,
+	namer *TmpObjectNamer,
+	instanceID string,
+	staleness time.Duration,
+	dryRun bool) (objectsDeleted uint64, err error) {
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             namer,
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          NewGarbageCollectionSkipList(gcSkipListCapacity, gcSkipCooldown, timeutil.RealClock()),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         staleness,
+		DryRun:            dryRun,
+	})
+	result, err := garbageCollectOnce(ctx, gc, instanceID)
+	return result.deleted, err
+}
+
+// garbageCollector deletes stale temporary objects from a bucket. Runs are
+// never queued: a run requested while another one is still in progress is
+// skipped, preventing concurrent list/delete storms on the same prefix.
+// Objects whose deletion failed are skipped by the following runs until their
+// cooldown in the skip list expires, and the objects in the protect-list are
+// never deleted. The outcome of the runs is recorded in state, and with
+// metricHandle along with their duration. The deletions are limited by
+// deleteThrottle, unless nil, and run deleteParallelism at a time, the listing
+// running at most maxDeleteBacklog objects ahead of them. Runs happen every
+// period, and delete the objects older than staleness, or only log them with
+// dryRun. With finalSweep, a last run happens once the collection is stopped.
+type garbageCollector struct {
+	namer             *TmpObjectNamer
+	bucket            gcs.Bucket
+	metricHandle      metrics.MetricHandle
+	skipList          *GarbageCollectionSkipList
+	protectList       *GarbageCollectionProtectList
+	state             *GarbageCollectionState
+	deleteThrottle    ratelimit.Throttle
+	maxDeleteBacklog  int
+	deleteParallelism int
+	staleness         time.Duration
+	period            time.Duration
+	dryRun            bool
+	finalSweep        bool
+
+	// clock times the retries of failed runs.
+	clock clock.Clock
+
+	// running is true while a garbage collection run is in progress.
+	running atomic.Bool
+}
+
+// garbageCollectorOptions holds the dependencies and settings of a
+// garbageCollector.
+type garbageCollectorOptions struct {
+	Namer        *TmpObjectNamer
+	Bucket       gcs.Bucket
+	MetricHandle metrics.MetricHandle
+	SkipList     *GarbageCollectionSkipList
+	// ProtectList holds the objects never deleted. Optional; nil protects none.
+	ProtectList *GarbageCollectionProtectList
+	State       *GarbageCollectionState
+	// DeleteThrottle limits the deletions. Optional; nil means no limit.
+	DeleteThrottle    ratelimit.Throttle
+	MaxDeleteBacklog  int
+	DeleteParallelism int
+	Staleness         time.Duration
+	Period            time.Duration
+	DryRun            bool
+	FinalSweep        bool
+}
+
+func newGarbageCollector(opts *garbageCollectorOptions) *garbageCollector {
+	return &garbageCollector{
+		namer:             opts.Namer,
+		bucket:            opts.Bucket,
+		metricHandle:      opts.MetricHandle,
+		skipList:          opts.SkipList,
+		protectList:       opts.ProtectList,
+		state:             opts.State,
+		deleteThrottle:    opts.DeleteThrottle,
+		maxDeleteBacklog:  opts.MaxDeleteBacklog,
+		deleteParallelism: opts.DeleteParallelism,
+		staleness:         opts.Staleness,
+		period:            opts.Period,
+		dryRun:            opts.DryRun,
+		finalSweep:        opts.FinalSweep,
+		clock:             clock.RealClock{},
+	}
+}
+
+// run performs a single garbage collection run, unless one is already in
+// progress. Returns false if the run was skipped.
+func (gc *garbageCollector) run(ctx context.Context) bool {
+	ran, _ := gc.tryRun(ctx)
+	return ran
+}
+
+// runWithRetries performs a garbage collection run and retries it with backoff
+// up to gcRunRetries times while it fails, so that a transient failure doesn't
+// delay the cleanup by a full period. A run skipped as another one is in
+// progress isn't retried, the other run taking its place.
+func (gc *garbageCollector) runWithRetries(ctx context.Context) {
+	backoff := gcRunRetryBackoff
+	for retries := 0; ; retries++ {
+		ran, err := gc.tryRun(ctx)
+		if !ran || err == nil || retries == gcRunRetries {
+			return
+		}
+
+		logger.Infof("Retrying the failed garbage collection run in %v (%d/%d).", backoff, retries+1, gcRunRetries)
+		select {
+		case <-ctx.Done():
+			return
+		case <-gc.clock.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// tryRun is run, also returning the error of the run.
+func (gc *garbageCollector) tryRun(ctx context.Context) (ran bool, err error) {
+	if !gc.running.CompareAndSwap(false, true) {
+		gc.metricHandle.GcRunSkippedOverlapCount(1)
+		logger.Infof("Skipping garbage collection run as the previous run is still in progress.")
+		return false, nil
+	}
+	defer gc.running.Store(false)
+
+	logger.Info("Starting a garbage collection run.")
+
+	startTime := time.Now()
+	// The objects of all mount instances are collected, as those of the
+	// instances which are gone are left to any mount.
+	result, err := garbageCollectOnce(ctx, gc, "")
+	objectsDeleted := result.deleted
+	gc.state.recordRun(gc.bucket.Name(), time.Now(), objectsDeleted, result.failed, err)
+	gc.metricHandle.GcRunDuration(ctx, time.Since(startTime))
+	if !gc.dryRun {
+		gc.metricHandle.GcObjectsDeletedCount(int64(objectsDeleted))
+	}
+	if err != nil {
+		gc.metricHandle.GcRunFailedCount(1)
+	}
+
+	switch {
+	case gc.dryRun && err == nil:
+		logger.Infof(
+			"Garbage collection dry run succeeded after finding %d objects to delete in %v.",
+			objectsDeleted,
+			time.Since(startTime))
+	case err != nil:
+		logger.Infof(
+			"Garbage collection failed after deleting %d objects in %v, "+
+				"with error: %v",
+			objectsDeleted,
+			time.Since(startTime),
+			err)
+	default:
+		logger.Infof(
+			"Garbage collection succeeded after deleted %d objects in %v.",
+			objectsDeleted,
+			time.Since(startTime))
+	}
+	return true, err
+}
+
+// sweep performs the last garbage collection run of a stopped collection,
+// within gcFinalSweepTimeout. It is a regular run, deleting the objects older
+// than staleness only: the fresher ones may belong to writes in progress on
+// other mounts sharing the prefix, whatever the instance ID in their names.
+func (gc *garbageCollector) sweep(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, gcFinalSweepTimeout)
+	defer cancel()
+
+	logger.Info("Starting the final garbage collection sweep.")
+	startTime := time.Now()
+	result, err := garbageCollectOnce(ctx, gc, "")
+	gc.state.recordRun(gc.bucket.Name(), time.Now(), result.deleted, result.failed, err)
+	if err != nil {
+		logger.Warnf("Final garbage collection sweep failed after deleting %d objects in %v: %v", result.deleted, time.Since(startTime), err)
+		return
+	}
+	logger.Infof("Final garbage collection sweep deleted %d objects in %v.", result.deleted, time.Since(startTime))
+}
+
+// Periodically delete stale temporary objects using the supplied collector
+// until the context is cancelled, and then sweep them a last time if
+// configured. The sweep is left out if the context was cancelled with a cause,
+// e.g. the error failing the mount, rather than by a plain cancel.
 func garbageCollect(
 	ctx context.Context,
-	tmpObjectPrefix string,
-	bucket gcs.Bucket) {
-	const period = 10 * time.Minute
-	ticker := time.NewTicker(period)
+	gc *garbageCollector) {
+	ticker := time.NewTicker(gc.period)
 	defer ticker.Stop()
+	gc.state.scheduled(gc.bucket.Name(), time.Now().Add(gc.period))
 
 	for {
 		select {
 		case <-ctx.Done():
+			gc.state.scheduled(gc.bucket.Name(), time.Time{})
+			if !gc.finalSweep {
+				return
+			}
+			if cause := context.Cause(ctx); cause != context.Canceled {
+				logger.Warnf("Skipping the final garbage collection sweep as garbage collection stopped on: %v", cause)
+				return
+			}
+			gc.sweep(context.WithoutCancel(ctx))
 			return
 
-		case <-ticker.C:
+		case tick := <-ticker.C:
+			gc.state.scheduled(gc.bucket.Name(), tick.Add(gc.period))
 		}
 
-		logger.Info("Starting a garbage collection run.")
-
-		startTime := time.Now()
-		objectsDeleted, err := garbageCollectOnce(ctx, tmpObjectPrefix, bucket)
+		gc.runWithRetries(ctx)
+	}
+}
 
-		if err != nil {
-			logger.Infof(
-				"Garbage collection failed after deleting %d objects in %v, "+
-					"with error: %v",
-				objectsDeleted,
-				time.Since(startTime),
-				err)
-		} else {
-			logger.Infof(
-				"Garbage collection succeeded after deleted %d objects in %v.",
-				objectsDeleted,
-				time.Since(startTime))
+// findTmpObjectLifecycleRule returns the first of the given lifecycle rules
+// deleting the objects under tmpObjectPrefix. Rules deleting objects younger
+// than a day are left out, as they may delete the temporary objects of writes
+// in progress.
+func findTmpObjectLifecycleRule(rules []gcs.LifecycleRule, tmpObjectPrefix string) (gcs.LifecycleRule, bool) {
+	for _, r := range rules {
+		if r.ActionType != gcs.LifecycleDeleteAction || r.AgeInDays < 1 {
+			continue
+		}
+		if len(r.MatchesPrefix) == 0 {
+			return r, true
+		}
+		for _, p := range r.MatchesPrefix {
+			if strings.HasPrefix(tmpObjectPrefix, p) {
+				return r, true
+			}
 		}
 	}
+	return gcs.LifecycleRule{}, false
+}
+
+// checkTmpObjectLifecycleRule logs whether the bucket has a lifecycle rule
+// deleting the stale temporary objects, and what it means for the given
+// garbage collection mode. Returns true if there is such a rule.
+func checkTmpObjectLifecycleRule(ctx context.Context, bucket gcs.Bucket, tmpObjectPrefix string, mode string) bool {
+	rules, err := bucket.GetLifecycleRules(ctx)
+	if err != nil {
+		logger.Warnf("Couldn't check the lifecycle rules of bucket %q for the deletion of temporary objects: %v", bucket.Name(), err)
+		return false
+	}
+
+	rule, found := findTmpObjectLifecycleRule(rules, tmpObjectPrefix)
+	switch {
+	case found && mode == cfg.GarbageCollectionModeLifecycle:
+		logger.Infof("Garbage collection relies on the lifecycle rule of bucket %q deleting the temporary objects under %q after %d days.", bucket.Name(), tmpObjectPrefix, rule.AgeInDays)
+	case found:
+		logger.Infof("Bucket %q has a lifecycle rule deleting the temporary objects under %q after %d days; consider --garbage-collection-mode=%s to disable client-side garbage collection.", bucket.Name(), tmpObjectPrefix, rule.AgeInDays, cfg.GarbageCollectionModeLifecycle)
+	case mode == cfg.GarbageCollectionModeLifecycle:
+		logger.Warnf("Bucket %q has no lifecycle rule deleting the temporary objects under %q, stale temporary objects won't be garbage collected.", bucket.Name(), tmpObjectPrefix)
+	default:
+		logger.Infof("Bucket %q has no lifecycle rule deleting the temporary objects under %q, garbage collecting them from the client.", bucket.Name(), tmpObjectPrefix)
+	}
+	return found
 }
@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+)
+
+// gcProtectListMaxBytes bounds the size of a protect-list set over the debug
+// endpoint.
+const gcProtectListMaxBytes = 1 << 20
+
+// GarbageCollectionProtectList holds the names, or path.Match patterns, of the
+// temporary objects which garbage collection never deletes, however stale. It
+// is an emergency brake for operators, e.g. to keep the objects of an
+// incident around for investigation, updated at runtime without remounting.
+//
+// It is shared by the garbage collectors of all the buckets of a mount, and
+// served at the gc/protect-list debug endpoint: GET returns the patterns, one
+// per line, and PUT replaces them with those of the request body. The
+// patterns excluded from garbage collection at mount are protected on top of
+// them, and never replaced.
+type GarbageCollectionProtectList struct {
+	// excluded are the patterns excluded from garbage collection at mount.
+	excluded []string
+
+	mu sync.RWMutex
+
+	// GUARDED by (mu)
+	patterns []string
+}
+
+// NewGarbageCollectionProtectList returns an empty protect-list, protecting
+// the objects matching the given excluded patterns, assumed well-formed, on
+// top of its patterns.
+func NewGarbageCollectionProtectList(excluded ...string) *GarbageCollectionProtectList {
+	return &GarbageCollectionProtectList{excluded: slices.Clone(excluded)}
+}
+
+// Set replaces the patterns of the protect-list. Fails, leaving the
+// protect-list unchanged, if any pattern is malformed.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionProtectList) Set(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("malformed protect-list pattern %q: %w", p, err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.patterns = slices.Clone(patterns)
+	return nil
+}
+
+// Patterns returns a copy of the patterns of the protect-list.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionProtectList) Patterns() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.patterns)
+}
+
+// protects returns true if the name of the object matches any pattern,
+// excluded ones included. A nil protect-list protects nothing.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionProtectList) protects(objectName string) bool {
+	if l == nil {
+		return false
+	}
+	if matchesAny(l.excluded, objectName) {
+		return true
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return matchesAny(l.patterns, objectName)
+}
+
+// matchesAny returns true if the name matches any of the patterns, validated
+// beforehand.
+func matchesAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if matched, _ := path.Match(p, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves the patterns of the protect-list on GET, and replaces them
+// with those of the request body, one per line, on PUT. Empty lines and lines
+// starting with # are ignored.
+func (l *GarbageCollectionProtectList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var patterns []string
+		scanner := bufio.NewScanner(io.LimitReader(r.Body, gcProtectListMaxBytes))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, line)
+		}
+		if err := scanner.Err(); err != nil {
+			http.Error(w, fmt.Sprintf("reading protect-list: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := l.Set(patterns); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logger.Infof("Garbage collection protect-list set to %d patterns: %q.", len(patterns), patterns)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, p := range l.Patterns() {
+		fmt.Fprintln(w, p)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGarbageCollectionProtectListMatchesNamesAndPatterns(t *testing.T) {
+	protectList := NewGarbageCollectionProtectList()
+	require.NoError(t, protectList.Set([]string{".gcsfuse_tmp/exact", ".gcsfuse_tmp/job-*"}))
+
+	assert.True(t, protectList.protects(".gcsfuse_tmp/exact"))
+	assert.True(t, protectList.protects(".gcsfuse_tmp/job-42"))
+	assert.False(t, protectList.protects(".gcsfuse_tmp/exactly"))
+	assert.False(t, protectList.protects(".gcsfuse_tmp/other"))
+	var nilList *GarbageCollectionProtectList
+	assert.False(t, nilList.protects(".gcsfuse_tmp/exact"))
+}
+
+func TestGarbageCollectionProtectListKeepsExcludedPatterns(t *testing.T) {
+	protectList := NewGarbageCollectionProtectList(".gcsfuse_tmp/scratch-*")
+
+	require.NoError(t, protectList.Set([]string{".gcsfuse_tmp/exact"}))
+	assert.True(t, protectList.protects(".gcsfuse_tmp/scratch-1"))
+	assert.True(t, protectList.protects(".gcsfuse_tmp/exact"))
+	require.NoError(t, protectList.Set(nil))
+	assert.True(t, protectList.protects(".gcsfuse_tmp/scratch-1"))
+	assert.False(t, protectList.protects(".gcsfuse_tmp/exact"))
+	// The excluded patterns aren't those set at runtime.
+	assert.Empty(t, protectList.Patterns())
+}
+
+func TestGarbageCollectionProtectListServeHTTP(t *testing.T) {
+	protectList := NewGarbageCollectionProtectList()
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		protectList.ServeHTTP(rec, httptest.NewRequest(method, "/debug/gc/protect-list", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodPut, "# incident 1234\n.gcsfuse_tmp/a\n\n  .gcsfuse_tmp/b-*  \n")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ".gcsfuse_tmp/a\n.gcsfuse_tmp/b-*\n", rec.Body.String())
+	// A malformed pattern leaves the protect-list unchanged.
+	rec = serve(http.MethodPut, ".gcsfuse_tmp/[\n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, []string{".gcsfuse_tmp/a", ".gcsfuse_tmp/b-*"}, protectList.Patterns())
+	rec = serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ".gcsfuse_tmp/a\n.gcsfuse_tmp/b-*\n", rec.Body.String())
+	rec = serve(http.MethodPost, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	// An empty body clears the protect-list.
+	rec = serve(http.MethodPut, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, protectList.Patterns())
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/jacobsa/timeutil"
+)
+
+const (
+	// Maximum number of objects remembered by the garbage collection skip list.
+	gcSkipListCapacity = 1000
+
+	// How long garbage collection skips an object after failing to delete it.
+	gcSkipCooldown = time.Hour
+)
+
+// GarbageCollectionSkipEntry describes a temporary object skipped by garbage
+// collection after failing to delete it.
+type GarbageCollectionSkipEntry struct {
+	BucketName string
+	ObjectName string
+
+	// Failures is the number of consecutive failed deletions.
+	Failures int
+
+	// LastError is the error of the last failed deletion.
+	LastError string
+
+	// SkipUntil is the time until which garbage collection skips the object.
+	SkipUntil time.Time
+}
+
+type gcSkipKey struct {
+	bucketName string
+	objectName string
+}
+
+// GarbageCollectionSkipList remembers the temporary objects whose deletion
+// failed, so that garbage collection skips them for a cooldown period rather
+// than failing on them run after run. Once full, the least recently failed
+// object is forgotten.
+//
+// It is shared by the garbage collectors of all the buckets of a mount, and can
+// be inspected and cleared at runtime, e.g. after fixing the cause of the
+// failures, to make the next runs reconsider all the objects.
+type GarbageCollectionSkipList struct {
+	capacity int
+	cooldown time.Duration
+	clock    timeutil.Clock
+
+	mu sync.Mutex
+
+	// order holds the *GarbageCollectionSkipEntry values, the most recently
+	// failed first.
+	// GUARDED by (mu)
+	order *list.List
+
+	// GUARDED by (mu)
+	elements map[gcSkipKey]*list.Element
+}
+
+// NewGarbageCollectionSkipList returns an empty skip list remembering up to
+// capacity objects, each skipped for cooldown after a failed deletion.
+func NewGarbageCollectionSkipList(capacity int, cooldown time.Duration, clock timeutil.Clock) *GarbageCollectionSkipList {
+	return &GarbageCollectionSkipList{
+		capacity: capacity,
+		cooldown: cooldown,
+		clock:    clock,
+		order:    list.New(),
+		elements: make(map[gcSkipKey]*list.Element),
+	}
+}
+
+// shouldSkip returns true if the given object is in its cooldown period.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionSkipList) shouldSkip(bucketName, objectName string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.elements[gcSkipKey{bucketName, objectName}]
+	return ok && l.clock.Now().Before(e.Value.(*GarbageCollectionSkipEntry).SkipUntil)
+}
+
+// recordFailure starts the cooldown period of an object whose deletion failed
+// with err.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionSkipList) recordFailure(bucketName, objectName string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := gcSkipKey{bucketName, objectName}
+	e, ok := l.elements[key]
+	if !ok {
+		e = l.order.PushFront(&GarbageCollectionSkipEntry{BucketName: bucketName, ObjectName: objectName})
+		l.elements[key] = e
+		if l.order.Len() > l.capacity {
+			oldest := l.order.Remove(l.order.Back()).(*GarbageCollectionSkipEntry)
+			delete(l.elements, gcSkipKey{oldest.BucketName, oldest.ObjectName})
+		}
+	} else {
+		l.order.MoveToFront(e)
+	}
+	entry := e.Value.(*GarbageCollectionSkipEntry)
+	entry.Failures++
+	entry.LastError = err.Error()
+	entry.SkipUntil = l.clock.Now().Add(l.cooldown)
+}
+
+// forget removes an object, e.g. once it has been deleted.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionSkipList) forget(bucketName, objectName string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := gcSkipKey{bucketName, objectName}
+	if e, ok := l.elements[key]; ok {
+		l.order.Remove(e)
+		delete(l.elements, key)
+	}
+}
+
+// Entries returns a copy of the remembered objects, the most recently failed
+// first.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionSkipList) Entries() []GarbageCollectionSkipEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]GarbageCollectionSkipEntry, 0, l.order.Len())
+	for e := l.order.Front(); e != nil; e = e.Next() {
+		entries = append(entries, *e.Value.(*GarbageCollectionSkipEntry))
+	}
+	return entries
+}
+
+// Clear forgets all the objects, so that the next garbage collection runs
+// reconsider them. Returns the number of forgotten objects.
+// LOCKS_EXCLUDED(l.mu)
+func (l *GarbageCollectionSkipList) Clear() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.order.Len()
+	l.order.Init()
+	l.elements = make(map[gcSkipKey]*list.Element)
+	return n
+}
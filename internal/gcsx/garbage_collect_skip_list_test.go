@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGarbageCollectionSkipListCooldown(t *testing.T) {
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	skipList := NewGarbageCollectionSkipList(10, time.Hour, &clock)
+
+	skipList.recordFailure("bucket", "a", errors.New("first"))
+	clock.AdvanceTime(30 * time.Minute)
+	skipList.recordFailure("bucket", "a", errors.New("second"))
+
+	assert.True(t, skipList.shouldSkip("bucket", "a"))
+	assert.False(t, skipList.shouldSkip("other-bucket", "a"))
+	entries := skipList.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].Failures)
+	assert.Equal(t, "second", entries[0].LastError)
+	assert.Equal(t, clock.Now().Add(time.Hour), entries[0].SkipUntil)
+	clock.AdvanceTime(time.Hour)
+	assert.False(t, skipList.shouldSkip("bucket", "a"), "The cooldown restarted by the second failure has expired.")
+}
+
+func TestGarbageCollectionSkipListEvictsLeastRecentlyFailed(t *testing.T) {
+	skipList := NewGarbageCollectionSkipList(2, time.Hour, timeutil.RealClock())
+
+	skipList.recordFailure("bucket", "a", errors.New("err"))
+	skipList.recordFailure("bucket", "b", errors.New("err"))
+	skipList.recordFailure("bucket", "a", errors.New("err"))
+	skipList.recordFailure("bucket", "c", errors.New("err"))
+
+	entries := skipList.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "c", entries[0].ObjectName)
+	assert.Equal(t, "a", entries[1].ObjectName)
+	assert.False(t, skipList.shouldSkip("bucket", "b"))
+}
+
+func TestGarbageCollectionSkipListForgetAndClear(t *testing.T) {
+	skipList := NewGarbageCollectionSkipList(10, time.Hour, timeutil.RealClock())
+	skipList.recordFailure("bucket", "a", errors.New("err"))
+	skipList.recordFailure("bucket", "b", errors.New("err"))
+
+	skipList.forget("bucket", "a")
+
+	assert.False(t, skipList.shouldSkip("bucket", "a"))
+	assert.True(t, skipList.shouldSkip("bucket", "b"))
+	assert.Equal(t, 1, skipList.Clear())
+	assert.False(t, skipList.shouldSkip("bucket", "b"))
+	assert.Empty(t, skipList.Entries())
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GarbageCollectionBucketState describes the garbage collection of the
+// temporary objects of a bucket.
+type GarbageCollectionBucketState struct {
+	BucketName string
+
+	// LastRunTime is the time the last run completed, successfully or not.
+	// Zero if no run completed yet.
+	LastRunTime time.Time
+
+	// LastSuccessTime is the time the last successful run completed. Zero if
+	// no run succeeded yet.
+	LastSuccessTime time.Time
+
+	// ObjectsDeleted is the number of objects deleted by the last run.
+	ObjectsDeleted uint64
+
+	// ObjectsFailed is the number of objects the last run failed to delete.
+	ObjectsFailed uint64
+
+	// LastError is the error of the last run, empty if it succeeded.
+	LastError string
+
+	// NextRunTime is the time the next run is scheduled at. Zero if none is.
+	NextRunTime time.Time
+}
+
+// GarbageCollectionState tracks the garbage collection runs of the buckets of
+// a mount, so that operators can check that garbage collection is healthy.
+type GarbageCollectionState struct {
+	mu sync.Mutex
+
+	// GUARDED by (mu)
+	buckets map[string]*GarbageCollectionBucketState
+}
+
+// NewGarbageCollectionState returns a state without any bucket.
+func NewGarbageCollectionState() *GarbageCollectionState {
+	return &GarbageCollectionState{
+		buckets: make(map[string]*GarbageCollectionBucketState),
+	}
+}
+
+// bucketLocked returns the state of the given bucket, adding it if missing.
+// LOCKS_REQUIRED(s.mu)
+func (s *GarbageCollectionState) bucketLocked(bucketName string) *GarbageCollectionBucketState {
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		b = &GarbageCollectionBucketState{BucketName: bucketName}
+		s.buckets[bucketName] = b
+	}
+	return b
+}
+
+// scheduled records the time the next run of the given bucket is scheduled at.
+// LOCKS_EXCLUDED(s.mu)
+func (s *GarbageCollectionState) scheduled(bucketName string, next time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bucketLocked(bucketName).NextRunTime = next
+}
+
+// recordRun records the outcome of a run of the given bucket completed at end.
+// LOCKS_EXCLUDED(s.mu)
+func (s *GarbageCollectionState) recordRun(bucketName string, end time.Time, objectsDeleted, objectsFailed uint64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bucketLocked(bucketName)
+	b.LastRunTime = end
+	b.ObjectsDeleted = objectsDeleted
+	b.ObjectsFailed = objectsFailed
+	b.LastError = ""
+	if err != nil {
+		b.LastError = err.Error()
+	} else {
+		b.LastSuccessTime = end
+	}
+}
+
+// Buckets returns a copy of the state of the buckets, sorted by name.
+// LOCKS_EXCLUDED(s.mu)
+func (s *GarbageCollectionState) Buckets() []GarbageCollectionBucketState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buckets := make([]GarbageCollectionBucketState, 0, len(s.buckets))
+	for _, b := range s.buckets {
+		buckets = append(buckets, *b)
+	}
+	slices.SortFunc(buckets, func(a, b GarbageCollectionBucketState) int {
+		return strings.Compare(a.BucketName, b.BucketName)
+	})
+	return buckets
+}
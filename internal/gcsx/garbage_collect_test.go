@@ -0,0 +1,1141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/clock"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/jacobsa/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const gcTestTmpObjectPrefix = ".gcsfuse_tmp/"
+
+// newTestTmpObjectNamer returns a namer of random temporary object names
+// under gcTestTmpObjectPrefix.
+func newTestTmpObjectNamer() *TmpObjectNamer {
+	return &TmpObjectNamer{prefix: gcTestTmpObjectPrefix}
+}
+
+func newTestGcSkipList() *GarbageCollectionSkipList {
+	return NewGarbageCollectionSkipList(gcSkipListCapacity, gcSkipCooldown, timeutil.RealClock())
+}
+
+func TestGarbageCollectorSkipsOverlappingRuns(t *testing.T) {
+	ctx := context.Background()
+	origProvider := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(origProvider) })
+	reader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+	mh, err := metrics.NewOTelMetrics(ctx, 1, 100)
+	require.NoError(t, err)
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	listStarted := make(chan struct{})
+	unblockList := make(chan struct{})
+	// Block the listing of the first run until the overlapping run is attempted.
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		close(listStarted)
+		<-unblockList
+	}).Return(&gcs.Listing{}, nil).Once()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      mh,
+		SkipList:          newTestGcSkipList(),
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	firstRunDone := make(chan bool)
+	go func() { firstRunDone <- gc.run(ctx) }()
+	<-listStarted
+
+	overlappingRan := gc.run(ctx)
+	close(unblockList)
+
+	assert.False(t, overlappingRan)
+	assert.True(t, <-firstRunDone)
+	bucket.AssertExpectations(t)
+	metrics.VerifyCounterMetric(t, ctx, reader, "gc/run_skipped_overlap_count", attribute.NewSet(), 1)
+}
+
+func TestGarbageCollectorRunsAgainAfterPreviousRunCompletes(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{}, nil).Twice()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+
+	assert.True(t, gc.run(context.Background()))
+	assert.True(t, gc.run(context.Background()))
+	bucket.AssertExpectations(t)
+}
+
+func TestGarbageCollectorRunDeletesObjectsOlderThanStaleness(t *testing.T) {
+	const staleness = time.Second
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	// Written a second ago, by a write that didn't clean up.
+	clock.SetTime(time.Now().Add(-staleness))
+	bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+	_, err := storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+"stale", []byte("taco"))
+	require.NoError(t, err)
+	clock.SetTime(time.Now())
+	_, err = storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+"fresh", []byte("burrito"))
+	require.NoError(t, err)
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         staleness,
+		Period:            GarbageCollectionPeriod,
+	})
+
+	require.True(t, gc.run(ctx))
+
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Equal(t, uint64(1), buckets[0].ObjectsDeleted)
+	_, err = storageutil.ReadObject(ctx, bucket, gcTestTmpObjectPrefix+"stale")
+	var notFoundErr *gcs.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	contents, err := storageutil.ReadObject(ctx, bucket, gcTestTmpObjectPrefix+"fresh")
+	require.NoError(t, err)
+	assert.Equal(t, "burrito", string(contents))
+}
+
+func TestGarbageCollectorDryRunCountsStaleObjectsWithoutDeleting(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	staleObjects := []*gcs.MinObject{
+		{Name: gcTestTmpObjectPrefix + "stale1", Updated: time.Now().Add(-time.Hour)},
+		{Name: gcTestTmpObjectPrefix + "stale2", Updated: time.Now().Add(-2 * time.Hour)},
+	}
+	freshObject := &gcs.MinObject{Name: gcTestTmpObjectPrefix + "fresh", Updated: time.Now()}
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: append(staleObjects, freshObject)}, nil).Once()
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+		DryRun:            true,
+	})
+
+	require.True(t, gc.run(context.Background()))
+
+	bucket.AssertExpectations(t)
+	bucket.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Equal(t, uint64(2), buckets[0].ObjectsDeleted)
+	assert.Empty(t, buckets[0].LastError)
+}
+
+// runMetricHandle records the garbage collection run metrics.
+type runMetricHandle struct {
+	metrics.MetricHandle
+
+	objectsDeleted int64
+	failedRuns     int64
+	runDurations   []time.Duration
+}
+
+func (m *runMetricHandle) GcObjectsDeletedCount(inc int64) {
+	m.objectsDeleted += inc
+}
+
+func (m *runMetricHandle) GcRunFailedCount(inc int64) {
+	m.failedRuns += inc
+}
+
+func (m *runMetricHandle) GcRunDuration(_ context.Context, latency time.Duration) {
+	m.runDurations = append(m.runDurations, latency)
+}
+
+func TestGarbageCollectorRecordsRunMetrics(t *testing.T) {
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-time.Hour))
+	bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+	for _, name := range []string{"stale1", "stale2", "stale3"} {
+		_, err := storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+name, []byte("taco"))
+		require.NoError(t, err)
+	}
+	clock.SetTime(time.Now())
+	_, err := storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+"fresh", []byte("burrito"))
+	require.NoError(t, err)
+	mh := &runMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      mh,
+		SkipList:          newTestGcSkipList(),
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+
+	require.True(t, gc.run(ctx))
+
+	assert.Equal(t, int64(3), mh.objectsDeleted)
+	assert.Zero(t, mh.failedRuns)
+	assert.Len(t, mh.runDurations, 1)
+}
+
+func TestGarbageCollectorRecordsFailedRunMetrics(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return((*gcs.Listing)(nil), errors.New("persistent")).Times(1 + gcRunRetries)
+	mh := &runMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      mh,
+		SkipList:          newTestGcSkipList(),
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	gc.clock = &clock.FakeClock{WaitTime: time.Millisecond}
+
+	gc.runWithRetries(context.Background())
+
+	bucket.AssertExpectations(t)
+	assert.Zero(t, mh.objectsDeleted)
+	assert.Equal(t, int64(1+gcRunRetries), mh.failedRuns)
+	assert.Len(t, mh.runDurations, 1+gcRunRetries)
+}
+
+func TestGarbageCollectorRetriesFailedRun(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return((*gcs.Listing)(nil), errors.New("transient")).Once()
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{}, nil).Once()
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	gc.clock = &clock.FakeClock{WaitTime: time.Millisecond}
+
+	gc.runWithRetries(context.Background())
+
+	bucket.AssertExpectations(t)
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Equal(t, buckets[0].LastRunTime, buckets[0].LastSuccessTime)
+	assert.Empty(t, buckets[0].LastError)
+}
+
+func TestGarbageCollectorStopsRetryingFailedRun(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return((*gcs.Listing)(nil), errors.New("persistent")).Times(1 + gcRunRetries)
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	gc.clock = &clock.FakeClock{WaitTime: time.Millisecond}
+
+	gc.runWithRetries(context.Background())
+
+	bucket.AssertExpectations(t)
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Contains(t, buckets[0].LastError, "persistent")
+}
+
+func TestGarbageCollectorSkipsFailedObjectsUntilSkipListCleared(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	staleObject := &gcs.MinObject{Name: gcTestTmpObjectPrefix + "stale", Updated: time.Now().Add(-time.Hour)}
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: []*gcs.MinObject{staleObject}}, nil).Times(3)
+	bucket.On("DeleteObject", mock.Anything, mock.Anything).Return(errors.New("object is under retention")).Once()
+	skipList := newTestGcSkipList()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          skipList,
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	// The failed deletion populates the skip list.
+	require.True(t, gc.run(context.Background()))
+	entries := skipList.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "test-bucket", entries[0].BucketName)
+	assert.Equal(t, staleObject.Name, entries[0].ObjectName)
+	assert.Equal(t, 1, entries[0].Failures)
+	assert.Equal(t, "object is under retention", entries[0].LastError)
+	// The next run skips the object.
+	require.True(t, gc.run(context.Background()))
+	bucket.AssertNumberOfCalls(t, "DeleteObject", 1)
+	bucket.On("DeleteObject", mock.Anything, mock.MatchedBy(func(req *gcs.DeleteObjectRequest) bool {
+		return req.Name == staleObject.Name
+	})).Return(nil).Once()
+
+	assert.Equal(t, 1, skipList.Clear())
+	require.True(t, gc.run(context.Background()))
+
+	bucket.AssertExpectations(t)
+	bucket.AssertNumberOfCalls(t, "DeleteObject", 2)
+	assert.Empty(t, skipList.Entries())
+}
+
+func TestGarbageCollectorSkipsObjectsProtectedAtRuntime(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	protectedObject := &gcs.MinObject{Name: gcTestTmpObjectPrefix + "incident-1", Updated: time.Now().Add(-time.Hour)}
+	otherObject := &gcs.MinObject{Name: gcTestTmpObjectPrefix + "other", Updated: time.Now().Add(-time.Hour)}
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: []*gcs.MinObject{protectedObject, otherObject}}, nil).Times(2)
+	deletes := make(map[string]int)
+	bucket.On("DeleteObject", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deletes[args.Get(1).(*gcs.DeleteObjectRequest).Name]++
+	}).Return(nil)
+	protectList := NewGarbageCollectionProtectList()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		ProtectList:       protectList,
+		State:             NewGarbageCollectionState(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	require.True(t, gc.run(context.Background()))
+	assert.Equal(t, map[string]int{protectedObject.Name: 1, otherObject.Name: 1}, deletes)
+	rec := httptest.NewRecorder()
+
+	// Protect the object, listed again by the next run, e.g. as it was
+	// recreated meanwhile.
+	protectList.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/gc/protect-list", strings.NewReader(gcTestTmpObjectPrefix+"incident-*\n")))
+	require.True(t, gc.run(context.Background()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]int{protectedObject.Name: 1, otherObject.Name: 2}, deletes)
+	bucket.AssertExpectations(t)
+}
+
+func TestGarbageCollectOnceSparesExcludedObjects(t *testing.T) {
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+	for _, name := range []string{"keep-1", "keep-2", "stale-1", "stale-2", "stale-3"} {
+		_, err := storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+name, []byte("taco"))
+		require.NoError(t, err)
+	}
+	protectList := NewGarbageCollectionProtectList(gcTestTmpObjectPrefix + "keep-*")
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		ProtectList:       protectList,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), result.deleted)
+	listing, err := bucket.ListObjects(ctx, &gcs.ListObjectsRequest{})
+	require.NoError(t, err)
+	var remains []string
+	for _, o := range listing.MinObjects {
+		remains = append(remains, o.Name)
+	}
+	assert.ElementsMatch(t, []string{gcTestTmpObjectPrefix + "keep-1", gcTestTmpObjectPrefix + "keep-2"}, remains)
+}
+
+func TestGarbageCollectorRecordsRunInState(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	staleObjects := []*gcs.MinObject{
+		{Name: gcTestTmpObjectPrefix + "deletable", Updated: time.Now().Add(-time.Hour)},
+		{Name: gcTestTmpObjectPrefix + "retained", Updated: time.Now().Add(-time.Hour)},
+	}
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: staleObjects}, nil).Once()
+	// The deleted object is gone from the listing of the next run.
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: staleObjects[1:]}, nil).Once()
+	bucket.On("DeleteObject", mock.Anything, mock.MatchedBy(func(req *gcs.DeleteObjectRequest) bool {
+		return req.Name == staleObjects[0].Name
+	})).Return(nil).Once()
+	bucket.On("DeleteObject", mock.Anything, mock.MatchedBy(func(req *gcs.DeleteObjectRequest) bool {
+		return req.Name == staleObjects[1].Name
+	})).Return(errors.New("object is under retention")).Once()
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+	})
+	next := time.Now().Add(GarbageCollectionPeriod)
+	state.scheduled("test-bucket", next)
+	// The first run deletes one object, and fails to delete the other one.
+	beforeRun := time.Now()
+
+	require.True(t, gc.run(context.Background()))
+
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Equal(t, "test-bucket", buckets[0].BucketName)
+	assert.False(t, buckets[0].LastRunTime.Before(beforeRun))
+	assert.True(t, buckets[0].LastSuccessTime.IsZero())
+	assert.Equal(t, uint64(1), buckets[0].ObjectsDeleted)
+	assert.Equal(t, uint64(1), buckets[0].ObjectsFailed)
+	assert.Contains(t, buckets[0].LastError, "object is under retention")
+	assert.Equal(t, next, buckets[0].NextRunTime)
+	// The next run skips the failed object and succeeds.
+	require.True(t, gc.run(context.Background()))
+	buckets = state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.Equal(t, buckets[0].LastRunTime, buckets[0].LastSuccessTime)
+	assert.Zero(t, buckets[0].ObjectsDeleted)
+	assert.Zero(t, buckets[0].ObjectsFailed)
+	assert.Empty(t, buckets[0].LastError)
+	bucket.AssertExpectations(t)
+}
+
+// composeGatedBucket blocks object composition until resumed, holding the
+// temporary object of an append in progress.
+type composeGatedBucket struct {
+	gcs.Bucket
+	composing chan struct{}
+	resume    chan struct{}
+}
+
+func (b *composeGatedBucket) ComposeObjects(ctx context.Context, req *gcs.ComposeObjectsRequest) (*gcs.Object, error) {
+	close(b.composing)
+	<-b.resume
+	return b.Bucket.ComposeObjects(ctx, req)
+}
+
+func TestGarbageCollectOnceReportsResult(t *testing.T) {
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+	for _, name := range []string{gcTestTmpObjectPrefix + "stale-1", gcTestTmpObjectPrefix + "stale-2", gcTestTmpObjectPrefix + "protected", gcTestTmpObjectPrefix + "failed", "stale_outside_prefix"} {
+		_, err := storageutil.CreateObject(ctx, bucket, name, []byte("taco"))
+		require.NoError(t, err)
+	}
+	clock.SetTime(time.Now())
+	for _, name := range []string{gcTestTmpObjectPrefix + "fresh-1", gcTestTmpObjectPrefix + "fresh-2"} {
+		_, err := storageutil.CreateObject(ctx, bucket, name, []byte("burrito"))
+		require.NoError(t, err)
+	}
+	skipList := newTestGcSkipList()
+	skipList.recordFailure(bucket.Name(), gcTestTmpObjectPrefix+"failed", errors.New("object is under retention"))
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          skipList,
+		ProtectList:       NewGarbageCollectionProtectList(gcTestTmpObjectPrefix + "protected"),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, garbageCollectionResult{scanned: 6, stale: 4, skipped: 2, deleted: 2, failed: 0}, result)
+}
+
+func TestGarbageCollectionSparesTmpObjectOfWriteInProgress(t *testing.T) {
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+	_, err := storageutil.CreateObject(ctx, bucket, gcTestTmpObjectPrefix+"stale", []byte("leftover"))
+	require.NoError(t, err)
+	clock.SetTime(time.Now())
+	src, err := storageutil.CreateObject(ctx, bucket, "foo", []byte("taco"))
+	require.NoError(t, err)
+	gated := &composeGatedBucket{Bucket: bucket, composing: make(chan struct{}), resume: make(chan struct{})}
+	creator := newComposeObjectCreator(newTestTmpObjectNamer(), gated)
+	writeErr := make(chan error)
+	go func() {
+		_, err := creator.Create(ctx, src.Name, src, nil, 0, 0, strings.NewReader("burrito"))
+		writeErr <- err
+	}()
+	<-gated.composing
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+	close(gated.resume)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.deleted, "Only the stale temporary object must be deleted.")
+	require.NoError(t, <-writeErr)
+	contents, err := storageutil.ReadObject(ctx, bucket, src.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "tacoburrito", string(contents))
+}
+
+func TestGarbageCollectOnce(t *testing.T) {
+	const prefix = "scratch/"
+	const staleness = time.Hour
+	testCases := []struct {
+		name        string
+		dryRun      bool
+		wantRemains []string
+	}{
+		{
+			name:        "deletes_stale_objects",
+			wantRemains: []string{prefix + "fresh", "stale_outside_prefix"},
+		},
+		{
+			name:        "dry_run",
+			dryRun:      true,
+			wantRemains: []string{prefix + "fresh", prefix + "stale", "stale_outside_prefix"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var clock timeutil.SimulatedClock
+			clock.SetTime(time.Now().Add(-2 * staleness))
+			bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+			for _, name := range []string{prefix + "stale", "stale_outside_prefix"} {
+				_, err := storageutil.CreateObject(ctx, bucket, name, []byte("taco"))
+				require.NoError(t, err)
+			}
+			clock.SetTime(time.Now())
+			_, err := storageutil.CreateObject(ctx, bucket, prefix+"fresh", []byte("burrito"))
+			require.NoError(t, err)
+
+			objectsDeleted, err := GarbageCollectOnce(ctx, bucket, &TmpObjectNamer{prefix: prefix}, "", staleness, tc.dryRun)
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), objectsDeleted)
+			listing, err := bucket.ListObjects(ctx, &gcs.ListObjectsRequest{})
+			require.NoError(t, err)
+			var remains []string
+			for _, o := range listing.MinObjects {
+				remains = append(remains, o.Name)
+			}
+			assert.ElementsMatch(t, tc.wantRemains, remains)
+		})
+	}
+}
+
+func TestGarbageCollectOnceScopesDeletionByInstanceID(t *testing.T) {
+	const template = "{instance}/{type}-{timestamp}-{random}"
+	testCases := []struct {
+		name        string
+		instanceID  string
+		wantDeleted []string
+	}{
+		{
+			name:        "instance_a",
+			instanceID:  "bucket-a",
+			wantDeleted: []string{"stale_a"},
+		},
+		{
+			name:        "instance_b",
+			instanceID:  "bucket-b",
+			wantDeleted: []string{"stale_b"},
+		},
+		{
+			name:        "all_instances",
+			wantDeleted: []string{"stale_a", "stale_b", "stale_untemplated"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var clock timeutil.SimulatedClock
+			clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+			bucket := fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})
+			namerA, err := NewTmpObjectNamer(gcTestTmpObjectPrefix, template, "bucket-a")
+			require.NoError(t, err)
+			namerB, err := NewTmpObjectNamer(gcTestTmpObjectPrefix, template, "bucket-b")
+			require.NoError(t, err)
+			// The objects named by the template are as old as the timestamp in their
+			// names, whenever they were last updated.
+			stale := func() time.Time { return time.Now().Add(-2 * GarbageCollectionStalenessThreshold) }
+			names := make(map[string]string)
+			for _, o := range []struct {
+				key   string
+				namer *TmpObjectNamer
+				now   func() time.Time
+				typ   TmpObjectType
+			}{
+				{"stale_a", namerA, stale, TmpObjectTypeUpload},
+				{"fresh_a", namerA, time.Now, TmpObjectTypeCompose},
+				{"stale_b", namerB, stale, TmpObjectTypeCompose},
+				{"fresh_b", namerB, time.Now, TmpObjectTypeUpload},
+			} {
+				o.namer.now = o.now
+				names[o.key], err = o.namer.Name(o.typ)
+				require.NoError(t, err)
+			}
+			// Objects named before the template was set belong to no instance.
+			names["stale_untemplated"], err = chooseTmpObjectName(gcTestTmpObjectPrefix)
+			require.NoError(t, err)
+			_, err = storageutil.CreateObject(ctx, bucket, names["stale_untemplated"], []byte("taco"))
+			require.NoError(t, err)
+			clock.SetTime(time.Now())
+			for key, name := range names {
+				if key != "stale_untemplated" {
+					_, err = storageutil.CreateObject(ctx, bucket, name, []byte("taco"))
+					require.NoError(t, err)
+				}
+			}
+
+			gc := newGarbageCollector(&garbageCollectorOptions{
+				Namer:             namerA,
+				Bucket:            bucket,
+				MetricHandle:      metrics.NewNoopMetrics(),
+				SkipList:          newTestGcSkipList(),
+				MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+				DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+				Staleness:         GarbageCollectionStalenessThreshold,
+			})
+
+			result, err := garbageCollectOnce(ctx, gc, tc.instanceID)
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.wantDeleted)), result.deleted)
+			for key, name := range names {
+				_, _, err := bucket.StatObject(ctx, &gcs.StatObjectRequest{Name: name})
+				var notFoundErr *gcs.NotFoundError
+				assert.Equal(t, slices.Contains(tc.wantDeleted, key), errors.As(err, &notFoundErr), key)
+			}
+		})
+	}
+}
+
+// deleteTimesBucket records the times of the deletions of objects.
+type deleteTimesBucket struct {
+	gcs.Bucket
+	deleteTimes []time.Time
+}
+
+func (b *deleteTimesBucket) DeleteObject(ctx context.Context, req *gcs.DeleteObjectRequest) error {
+	b.deleteTimes = append(b.deleteTimes, time.Now())
+	return b.Bucket.DeleteObject(ctx, req)
+}
+
+func TestGarbageCollectOnceLimitsDeleteRate(t *testing.T) {
+	const deleteOpsPerSec = 20
+	const objectCount = 11
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := &deleteTimesBucket{Bucket: fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})}
+	for i := range objectCount {
+		_, err := storageutil.CreateObject(ctx, bucket, fmt.Sprintf("%sstale-%d", gcTestTmpObjectPrefix, i), []byte("taco"))
+		require.NoError(t, err)
+	}
+	throttle := ratelimit.NewThrottle(deleteOpsPerSec, 1)
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		DeleteThrottle:    throttle,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(objectCount), result.deleted)
+	require.Len(t, bucket.deleteTimes, objectCount)
+	// Without bursts, the first deletion goes through right away and each of the
+	// following ones waits for a token. Allow some slack for the timer.
+	window := bucket.deleteTimes[objectCount-1].Sub(bucket.deleteTimes[0])
+	assert.GreaterOrEqual(t, window, (objectCount-1)*time.Second/deleteOpsPerSec*9/10)
+}
+
+// slowDeleteBucket takes delay to delete each object.
+type slowDeleteBucket struct {
+	gcs.Bucket
+	delay time.Duration
+}
+
+func (b *slowDeleteBucket) DeleteObject(ctx context.Context, req *gcs.DeleteObjectRequest) error {
+	time.Sleep(b.delay)
+	return b.Bucket.DeleteObject(ctx, req)
+}
+
+// backlogMetricHandle tracks the garbage collection delete backlog, and the
+// largest it got.
+type backlogMetricHandle struct {
+	metrics.MetricHandle
+
+	mu         sync.Mutex
+	backlog    int64
+	maxBacklog int64
+}
+
+func (m *backlogMetricHandle) GcDeleteBacklog(inc int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backlog += inc
+	m.maxBacklog = max(m.maxBacklog, m.backlog)
+}
+
+func TestGarbageCollectOnceBoundsDeleteBacklog(t *testing.T) {
+	const maxDeleteBacklog = 3
+	const objectCount = 20
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := &slowDeleteBucket{Bucket: fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{}), delay: 5 * time.Millisecond}
+	for i := range objectCount {
+		_, err := storageutil.CreateObject(ctx, bucket, fmt.Sprintf("%sstale-%d", gcTestTmpObjectPrefix, i), []byte("taco"))
+		require.NoError(t, err)
+	}
+	mh := &backlogMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      mh,
+		SkipList:          newTestGcSkipList(),
+		MaxDeleteBacklog:  maxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(objectCount), result.deleted)
+	// The listing, way faster than the deletions, fills up the backlog without
+	// ever going beyond it.
+	assert.Equal(t, int64(maxDeleteBacklog), mh.maxBacklog)
+	assert.Equal(t, int64(0), mh.backlog)
+}
+
+func TestGarbageCollectOnceClearsDeleteBacklogOnFailure(t *testing.T) {
+	const objectCount = 20
+	ctx := context.Background()
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("Name").Return("test-bucket")
+	var objects []*gcs.MinObject
+	for i := range objectCount {
+		objects = append(objects, &gcs.MinObject{Name: fmt.Sprintf("%sstale-%d", gcTestTmpObjectPrefix, i), Updated: time.Now().Add(-2 * GarbageCollectionStalenessThreshold)})
+	}
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: objects}, nil)
+	bucket.On("DeleteObject", mock.Anything, mock.Anything).Return(errors.New("taco"))
+	mh := &backlogMetricHandle{MetricHandle: metrics.NewNoopMetrics()}
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      mh,
+		SkipList:          newTestGcSkipList(),
+		MaxDeleteBacklog:  5,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	_, err := garbageCollectOnce(ctx, gc, "")
+
+	require.Error(t, err)
+	assert.Equal(t, int64(0), mh.backlog)
+}
+
+// concurrentDeleteBucket tracks the concurrent deletions, and the most there
+// were at once.
+type concurrentDeleteBucket struct {
+	gcs.Bucket
+
+	mu            sync.Mutex
+	deleting      int
+	maxConcurrent int
+}
+
+func (b *concurrentDeleteBucket) DeleteObject(ctx context.Context, req *gcs.DeleteObjectRequest) error {
+	b.mu.Lock()
+	b.deleting++
+	b.maxConcurrent = max(b.maxConcurrent, b.deleting)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.deleting--
+		b.mu.Unlock()
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	return b.Bucket.DeleteObject(ctx, req)
+}
+
+func TestGarbageCollectOnceBoundsConcurrentDeletions(t *testing.T) {
+	const deleteParallelism = 3
+	const objectCount = 20
+	ctx := context.Background()
+	var clock timeutil.SimulatedClock
+	clock.SetTime(time.Now().Add(-2 * GarbageCollectionStalenessThreshold))
+	bucket := &concurrentDeleteBucket{Bucket: fake.NewFakeBucket(&clock, "bucket", gcs.BucketType{})}
+	for i := range objectCount {
+		_, err := storageutil.CreateObject(ctx, bucket, fmt.Sprintf("%sstale-%d", gcTestTmpObjectPrefix, i), []byte("taco"))
+		require.NoError(t, err)
+	}
+
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: deleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+	})
+
+	result, err := garbageCollectOnce(ctx, gc, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(objectCount), result.deleted)
+	assert.Equal(t, deleteParallelism, bucket.maxConcurrent)
+	listing, err := bucket.ListObjects(ctx, &gcs.ListObjectsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, listing.MinObjects)
+}
+
+// runGarbageCollectUntilCancelled runs garbageCollect with gc until cancel is
+// called, and waits for it to return.
+func runGarbageCollectUntilCancelled(ctx context.Context, gc *garbageCollector, cancel func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		garbageCollect(ctx, gc)
+	}()
+	cancel()
+	<-done
+}
+
+func TestGarbageCollectRunsFinalSweepOnceWhenCancelled(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	staleObject := &gcs.MinObject{Name: gcTestTmpObjectPrefix + "stale", Updated: time.Now().Add(-time.Hour)}
+	bucket.On("Name").Return("test-bucket")
+	bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{MinObjects: []*gcs.MinObject{staleObject}}, nil).Once()
+	bucket.On("DeleteObject", mock.Anything, &gcs.DeleteObjectRequest{Name: staleObject.Name}).Return(nil).Once()
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             newTestTmpObjectNamer(),
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+		FinalSweep:        true,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runGarbageCollectUntilCancelled(ctx, gc, cancel)
+
+	bucket.AssertExpectations(t)
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.False(t, buckets[0].LastRunTime.IsZero())
+	assert.Equal(t, uint64(1), buckets[0].ObjectsDeleted)
+}
+
+func TestGarbageCollectSkipsFinalSweep(t *testing.T) {
+	testCases := []struct {
+		name       string
+		finalSweep bool
+		cause      error
+	}{
+		{
+			name:       "disabled",
+			finalSweep: false,
+		},
+		{
+			name:       "cancelled_on_error",
+			finalSweep: true,
+			cause:      errors.New("deletions unsafe"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket := new(storage.TestifyMockBucket)
+			bucket.On("Name").Return("test-bucket")
+			state := NewGarbageCollectionState()
+			gc := newGarbageCollector(&garbageCollectorOptions{
+				Namer:             newTestTmpObjectNamer(),
+				Bucket:            bucket,
+				MetricHandle:      metrics.NewNoopMetrics(),
+				SkipList:          newTestGcSkipList(),
+				State:             state,
+				MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+				DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+				Staleness:         GarbageCollectionStalenessThreshold,
+				Period:            GarbageCollectionPeriod,
+				FinalSweep:        tc.finalSweep,
+			})
+			ctx, cancel := context.WithCancelCause(context.Background())
+
+			runGarbageCollectUntilCancelled(ctx, gc, func() { cancel(tc.cause) })
+
+			bucket.AssertNotCalled(t, "ListObjects", mock.Anything, mock.Anything)
+			buckets := state.Buckets()
+			require.Len(t, buckets, 1)
+			assert.True(t, buckets[0].LastRunTime.IsZero())
+		})
+	}
+}
+
+func TestBucketManagerShutDownFinalSweep(t *testing.T) {
+	testCases := []struct {
+		name      string
+		shutDown  func(bm BucketManager)
+		wantSweep bool
+	}{
+		{
+			name:      "shut_down",
+			shutDown:  func(bm BucketManager) { bm.ShutDown() },
+			wantSweep: true,
+		},
+		{
+			name:      "shut_down_on_error",
+			shutDown:  func(bm BucketManager) { bm.ShutDownOnError(errors.New("mount failed")) },
+			wantSweep: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket := new(storage.TestifyMockBucket)
+			bucket.On("Name").Return("test-bucket")
+			if tc.wantSweep {
+				bucket.On("ListObjects", mock.Anything, mock.Anything).Return(&gcs.Listing{}, nil).Once()
+			}
+			bm := NewBucketManager(BucketConfig{GarbageCollectionFinalSweep: true}, nil).(*bucketManager)
+			gc := newGarbageCollector(&garbageCollectorOptions{
+				Namer:             newTestTmpObjectNamer(),
+				Bucket:            bucket,
+				MetricHandle:      metrics.NewNoopMetrics(),
+				SkipList:          bm.gcSkipList,
+				State:             bm.gcState,
+				MaxDeleteBacklog:  bm.config.GarbageCollectionMaxDeleteBacklog,
+				DeleteParallelism: bm.config.GarbageCollectionDeleteParallelism,
+				Staleness:         bm.config.GarbageCollectionStaleness,
+				Period:            bm.config.GarbageCollectionPeriod,
+				FinalSweep:        true,
+			})
+			bm.gcRunning.Go(func() { garbageCollect(bm.gcCtx, gc) })
+
+			// Shutting down waits for the garbage collection to stop.
+			tc.shutDown(bm)
+
+			bucket.AssertExpectations(t)
+			if !tc.wantSweep {
+				bucket.AssertNotCalled(t, "ListObjects", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectFinalSweepSparesFreshObjects(t *testing.T) {
+	ctx := context.Background()
+	bucket := fake.NewFakeBucket(timeutil.RealClock(), "bucket", gcs.BucketType{})
+	namer, err := NewTmpObjectNamer(gcTestTmpObjectPrefix, "{instance}/{type}-{timestamp}-{random}", "instance-a")
+	require.NoError(t, err)
+	fresh, err := namer.Name(TmpObjectTypeUpload)
+	require.NoError(t, err)
+	_, err = storageutil.CreateObject(ctx, bucket, fresh, []byte("taco"))
+	require.NoError(t, err)
+	state := NewGarbageCollectionState()
+	gc := newGarbageCollector(&garbageCollectorOptions{
+		Namer:             namer,
+		Bucket:            bucket,
+		MetricHandle:      metrics.NewNoopMetrics(),
+		SkipList:          newTestGcSkipList(),
+		State:             state,
+		MaxDeleteBacklog:  DefaultGarbageCollectionMaxDeleteBacklog,
+		DeleteParallelism: DefaultGarbageCollectionDeleteParallelism,
+		Staleness:         GarbageCollectionStalenessThreshold,
+		Period:            GarbageCollectionPeriod,
+		FinalSweep:        true,
+	})
+	gcCtx, cancel := context.WithCancel(ctx)
+
+	runGarbageCollectUntilCancelled(gcCtx, gc, cancel)
+
+	// Like in the periodic runs, the fresh object is kept whatever the instance
+	// ID in its name.
+	_, err = storageutil.ReadObject(ctx, bucket, fresh)
+	assert.NoError(t, err)
+	buckets := state.Buckets()
+	require.Len(t, buckets, 1)
+	assert.False(t, buckets[0].LastRunTime.IsZero())
+	assert.Zero(t, buckets[0].ObjectsDeleted)
+}
+
+func TestCheckTmpObjectLifecycleRule(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rules []gcs.LifecycleRule
+		want  bool
+	}{
+		{
+			name: "no_rules",
+			want: false,
+		},
+		{
+			name:  "rule_matching_tmp_prefix",
+			rules: []gcs.LifecycleRule{{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 1, MatchesPrefix: []string{"logs/", gcTestTmpObjectPrefix}}},
+			want:  true,
+		},
+		{
+			name:  "rule_matching_parent_of_tmp_prefix",
+			rules: []gcs.LifecycleRule{{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 1, MatchesPrefix: []string{".gcsfuse"}}},
+			want:  true,
+		},
+		{
+			name:  "rule_matching_all_objects",
+			rules: []gcs.LifecycleRule{{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 7}},
+			want:  true,
+		},
+		{
+			name:  "rule_matching_other_prefix",
+			rules: []gcs.LifecycleRule{{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 1, MatchesPrefix: []string{"logs/"}}},
+			want:  false,
+		},
+		{
+			name:  "rule_deleting_objects_of_any_age",
+			rules: []gcs.LifecycleRule{{ActionType: gcs.LifecycleDeleteAction, MatchesPrefix: []string{gcTestTmpObjectPrefix}}},
+			want:  false,
+		},
+		{
+			name:  "rule_not_deleting",
+			rules: []gcs.LifecycleRule{{ActionType: "SetStorageClass", AgeInDays: 1, MatchesPrefix: []string{gcTestTmpObjectPrefix}}},
+			want:  false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket := fake.NewFakeBucketWithLifecycleRules(timeutil.RealClock(), "bucket", gcs.BucketType{}, tc.rules)
+
+			for _, mode := range []string{cfg.GarbageCollectionModeClient, cfg.GarbageCollectionModeLifecycle} {
+				assert.Equal(t, tc.want, checkTmpObjectLifecycleRule(context.Background(), bucket, gcTestTmpObjectPrefix, mode), mode)
+			}
+		})
+	}
+}
+
+func TestCheckTmpObjectLifecycleRuleFailsToGetRules(t *testing.T) {
+	bucket := new(storage.TestifyMockBucket)
+	bucket.On("GetLifecycleRules", mock.Anything).Return(nil, errors.New("permission denied"))
+	bucket.On("Name").Return("bucket")
+
+	found := checkTmpObjectLifecycleRule(context.Background(), bucket, gcTestTmpObjectPrefix, cfg.GarbageCollectionModeClient)
+
+	assert.False(t, found)
+	bucket.AssertExpectations(t)
+}
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
@@ -33,6 +34,7 @@ import (
 	. "github.com/jacobsa/oglematchers"
 	. "github.com/jacobsa/ogletest"
 	"github.com/jacobsa/timeutil"
+	"github.com/spf13/viper"
 )
 
 func TestIntegration(t *testing.T) { RunTests(t) }
@@ -95,6 +97,7 @@ func (t *IntegrationTest) SetUp(ti *TestInfo) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		tmpObjectPrefix,
+		gcsx.UploadConfig{},
 		t.bucket)
 }
 
@@ -242,6 +245,51 @@ func (t *IntegrationTest) SyncNonEmptyLocalFile() {
 	ExpectEq("test", objects[0].Name)
 }
 
+func (t *IntegrationTest) SyncLargeLocalFileUnderCheckpointingProfile() {
+	// Resolve the upload strategy of the checkpointing profile.
+	c := cfg.Config{Profile: cfg.ProfileAIMLCheckpointing}
+	c.Write.UploadStrategy = cfg.UploadStrategySimple
+	c.ApplyOptimizations(viper.New(), nil)
+	AssertEq(cfg.UploadStrategyParallelComposite, c.Write.UploadStrategy)
+	// Keep the components small, for the contents to span several of them.
+	const componentSize = 1 << 20
+	t.syncer = gcsx.NewSyncer(
+		0,
+		120,
+		10,
+		".gcsfuse_tmp/",
+		gcsx.UploadConfig{
+			ParallelComposite: c.Write.UploadStrategy == cfg.UploadStrategyParallelComposite,
+			ComponentSize:     componentSize,
+		},
+		t.bucket)
+	tf, err := gcsx.NewTempFile(io.NopCloser(strings.NewReader("")), "", &t.clock)
+	AssertEq(nil, err)
+	expected := randBytes(5*componentSize + 4)
+	_, err = tf.WriteAt(expected, 0)
+	AssertEq(nil, err)
+
+	// Sync should compose the object in GCS from its components.
+	newObj, err := t.syncer.SyncObject(t.ctx, "test", nil, tf)
+
+	AssertEq(nil, err)
+	ExpectEq(t.objectGeneration("test"), newObj.Generation)
+	ExpectEq(6, newObj.ComponentCount)
+	// Read via the bucket.
+	contents, err := storageutil.ReadObject(t.ctx, t.bucket, "test")
+	AssertEq(nil, err)
+	ExpectTrue(bytes.Equal(expected, contents))
+	// The components should have been deleted.
+	objects, runs, err := storageutil.ListAll(
+		t.ctx,
+		t.bucket,
+		&gcs.ListObjectsRequest{})
+	AssertEq(nil, err)
+	AssertEq(1, len(objects))
+	AssertEq(0, len(runs))
+	ExpectEq("test", objects[0].Name)
+}
+
 func (t *IntegrationTest) WriteThenSync() {
 	// Create.
 	o, err := storageutil.CreateObject(t.ctx, t.bucket, "foo", []byte("taco"))
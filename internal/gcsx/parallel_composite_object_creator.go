@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"golang.org/x/net/context"
+	"golang.org/x/sync/errgroup"
+)
+
+// UploadConfig selects how the syncer uploads the full contents of objects.
+type UploadConfig struct {
+	// ParallelComposite uploads the contents spanning at least two components
+	// as temporary component objects in parallel, composed into the object.
+	// Otherwise, the contents are uploaded at once.
+	ParallelComposite bool
+
+	// ComponentSize is the size of the components of parallel composite
+	// uploads. Contents with more components than a single compose request
+	// accepts are split into larger components.
+	ComponentSize int64
+
+	// TmpObjectNamer names the temporary objects of appends and of parallel
+	// composite uploads. Nil names them randomly under the temporary object
+	// prefix.
+	TmpObjectNamer *TmpObjectNamer
+}
+
+// tmpObjectNamer returns the namer of the temporary objects under prefix.
+func (c UploadConfig) tmpObjectNamer(prefix string) *TmpObjectNamer {
+	if c.TmpObjectNamer != nil {
+		return c.TmpObjectNamer
+	}
+	return &TmpObjectNamer{prefix: prefix}
+}
+
+// parallelCompositeObjectCreator uploads the full contents of an object as
+// temporary component objects in parallel, storing them named by the supplied
+// namer, and composes them into the object.
+//
+// The components are deleted once composed, or once the upload fails. Those
+// left behind when interrupted are deleted by garbage collection, like the
+// temporary objects of appends.
+type parallelCompositeObjectCreator struct {
+	namer         *TmpObjectNamer
+	bucket        gcs.Bucket
+	componentSize int64
+}
+
+func newParallelCompositeObjectCreator(
+	namer *TmpObjectNamer,
+	componentSize int64,
+	bucket gcs.Bucket) *parallelCompositeObjectCreator {
+	return &parallelCompositeObjectCreator{
+		namer:         namer,
+		bucket:        bucket,
+		componentSize: componentSize,
+	}
+}
+
+// worthwhile returns true if contents of the given size span at least two
+// components.
+func (oc *parallelCompositeObjectCreator) worthwhile(size int64) bool {
+	return size >= 2*oc.componentSize
+}
+
+// Create uploads the given contents as a new generation of the object, or as
+// a new object if srcObject is nil, failing with *gcs.PreconditionError if
+// the source generation is no longer current.
+func (oc *parallelCompositeObjectCreator) Create(
+	ctx context.Context,
+	objectName string,
+	srcObject *gcs.Object,
+	mtime *time.Time,
+	chunkRetryDeadlineSecs int64,
+	chunkTransferTimeoutSecs int64,
+	content *io.SectionReader) (o *gcs.Object, err error) {
+	size := content.Size()
+	componentSize := max(oc.componentSize, (size+gcs.MaxSourcesPerComposeRequest-1)/gcs.MaxSourcesPerComposeRequest)
+	componentCount := int((size + componentSize - 1) / componentSize)
+
+	// Upload the components in parallel.
+	components := make([]*gcs.Object, componentCount)
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i := range componentCount {
+		group.Go(func() error {
+			name, err := oc.namer.Name(TmpObjectTypeUpload)
+			if err != nil {
+				return fmt.Errorf("choosing a temporary object name: %w", err)
+			}
+			start := int64(i) * componentSize
+			req := gcs.NewCreateObjectRequest(nil, name, nil, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs)
+			req.Contents = io.NewSectionReader(content, start, min(componentSize, size-start))
+			components[i], err = oc.bucket.CreateObject(groupCtx, req)
+			if err != nil {
+				return fmt.Errorf("CreateObject(%q): %w", name, err)
+			}
+			return nil
+		})
+	}
+	err = group.Wait()
+
+	// Attempt to delete the components when we're done, whatever the outcome.
+	defer func() {
+		for _, c := range components {
+			if c == nil {
+				continue
+			}
+			deleteErr := oc.bucket.DeleteObject(
+				ctx,
+				&gcs.DeleteObjectRequest{
+					Name:       c.Name,
+					Generation: 0, // Delete the latest generation of temporary object.
+				})
+			if deleteErr != nil {
+				logger.Warnf("Parallel composite upload of %q failed to delete component %q, leaving it to garbage collection: %v", objectName, c.Name, deleteErr)
+			}
+		}
+	}()
+	if err != nil {
+		return
+	}
+
+	// Compose the components into the object, with the attributes a simple
+	// upload would have given it.
+	createReq := gcs.NewCreateObjectRequest(srcObject, objectName, mtime, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs)
+	composeReq := &gcs.ComposeObjectsRequest{
+		DstName:                       createReq.Name,
+		DstGenerationPrecondition:     createReq.GenerationPrecondition,
+		DstMetaGenerationPrecondition: createReq.MetaGenerationPrecondition,
+		Metadata:                      createReq.Metadata,
+		CacheControl:                  createReq.CacheControl,
+		ContentDisposition:            createReq.ContentDisposition,
+		ContentEncoding:               createReq.ContentEncoding,
+		ContentType:                   createReq.ContentType,
+		CustomTime:                    createReq.CustomTime,
+		EventBasedHold:                createReq.EventBasedHold,
+		StorageClass:                  createReq.StorageClass,
+	}
+	for _, c := range components {
+		composeReq.Sources = append(composeReq.Sources, gcs.ComposeSource{Name: c.Name, Generation: c.Generation})
+	}
+	o, err = oc.bucket.ComposeObjects(ctx, composeReq)
+	if err != nil {
+		// A not found error means that either the source object was clobbered or
+		// a component was. The latter is unlikely, so we signal a precondition
+		// error.
+		var notFoundErr *gcs.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = &gcs.PreconditionError{
+				Err: err,
+			}
+		}
+
+		err = fmt.Errorf("ComposeObjects: %w", err)
+		return
+	}
+
+	return
+}
@@ -294,6 +294,38 @@ func (b *prefixBucket) GetFolder(ctx context.Context, req *gcs.GetFolderRequest)
 	return f, err
 }
 
+// GetLifecycleRules returns the lifecycle rules of the wrapped bucket applying
+// to objects under the prefix, with their prefixes relative to it.
+func (b *prefixBucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	rules, err := b.wrapped.GetLifecycleRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var local []gcs.LifecycleRule
+	for _, r := range rules {
+		if len(r.MatchesPrefix) == 0 {
+			local = append(local, r)
+			continue
+		}
+		var prefixes []string
+		for _, p := range r.MatchesPrefix {
+			switch {
+			case strings.HasPrefix(p, b.prefix):
+				prefixes = append(prefixes, b.localName(p))
+			case strings.HasPrefix(b.prefix, p):
+				// The rule applies to all the objects under the prefix.
+				prefixes = append(prefixes, "")
+			}
+		}
+		if len(prefixes) > 0 {
+			r.MatchesPrefix = prefixes
+			local = append(local, r)
+		}
+	}
+	return local, nil
+}
+
 func (b *prefixBucket) CreateFolder(ctx context.Context, folderName string) (*gcs.Folder, error) {
 	mFolderName := b.wrappedName(folderName)
 	f, err := b.wrapped.CreateFolder(ctx, mFolderName)
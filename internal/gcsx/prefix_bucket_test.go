@@ -835,3 +835,23 @@ func TestMoveObject(t *testing.T) {
 	assert.True(t, errors.As(err, &notFoundErr))
 	assert.Nil(t, m)
 }
+
+func TestPrefixBucket_GetLifecycleRules(t *testing.T) {
+	wrapped := fake.NewFakeBucketWithLifecycleRules(timeutil.RealClock(), "some_bucket", gcs.BucketType{}, []gcs.LifecycleRule{
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 1},
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 2, MatchesPrefix: []string{"foo_tmp/", "bar/"}},
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 3, MatchesPrefix: []string{"fo"}},
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 4, MatchesPrefix: []string{"bar/"}},
+	})
+	bucket, err := gcsx.NewPrefixBucket("foo_", wrapped)
+	require.NoError(t, err)
+
+	rules, err := bucket.GetLifecycleRules(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []gcs.LifecycleRule{
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 1},
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 2, MatchesPrefix: []string{"tmp/"}},
+		{ActionType: gcs.LifecycleDeleteAction, AgeInDays: 3, MatchesPrefix: []string{""}},
+	}, rules)
+}
@@ -17,17 +17,24 @@ package read_manager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/jacobsa/fuse/fuseops"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/block"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/bufferedread"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/file"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/cache/util"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx"
 	clientReaders "github.com/googlecloudplatform/gcsfuse/v3/internal/gcsx/client_readers"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/ratelimit"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/workerpool"
 	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
@@ -38,16 +45,42 @@ import (
 type ReadManager struct {
 	gcsx.ReadManager
 	object *gcs.MinObject
+	bucket gcs.Bucket
+
+	// mu guards the readers against the release of the buffered reader, which
+	// must not be destroyed while reads are in progress.
+	mu sync.RWMutex
 
 	// readers holds a list of data readers, prioritized for reading.
 	// e.g., File cache reader, GCS reader.
+	// GUARDED by (mu)
 	readers []gcsx.Reader
 
+	// preferCacheHandler is set if the file cache is preferred after a
+	// full-object download, in which case bufferedReader is released once the
+	// object is fully cached.
+	preferCacheHandler *file.CacheHandler
+
+	// bufferedReader is the buffered reader among the readers, if any and if
+	// it's to be released once the object is fully cached.
+	// GUARDED by (mu)
+	bufferedReader *bufferedread.BufferedReader
+
+	// firstReadDone is true once the first read of the manager started.
+	firstReadDone atomic.Bool
+
 	// readTypeClassifier tracks the read access pattern (e.g., sequential, random)
 	// across all readers for a file handle to optimize read strategies.
 	readTypeClassifier *gcsx.ReadTypeClassifier
 
-	traceHandle tracing.TraceHandle
+	// fullyCached is true if buffered read is enabled and the object was fully
+	// present in the file cache when the manager was created, in which case no
+	// buffered reader is created and reads are served straight from the cache
+	// file.
+	fullyCached bool
+
+	metricHandle metrics.MetricHandle
+	traceHandle  tracing.TraceHandle
 }
 
 // ReadManagerConfig holds the configuration parameters for creating a new ReadManager.
@@ -62,17 +95,35 @@ type ReadManagerConfig struct {
 	MrdWrapper              *gcsx.MultiRangeDownloaderWrapper
 	Config                  *cfg.Config
 	GlobalMaxBlocksSem      *semaphore.Weighted
+	PrefetchFilesSem        *semaphore.Weighted
+	SharedBlockRegistry     *bufferedread.SharedBlockRegistry
+	BufferedReadStats       *bufferedread.Stats
+	BlockChecksumManifest   *bufferedread.ChecksumManifest
+	PinnedBlockStore        *bufferedread.PinnedBlockStore
+	ReadBlockArena          *block.PrefetchBlockArena
+	ObjectDownloadLimiter   *bufferedread.ObjectDownloadLimiter
+	DownloadThrottle        ratelimit.Throttle
+	ObjectResidentLimiter   *bufferedread.ObjectResidentBlockLimiter
+	DownloadCircuitBreaker  *bufferedread.DownloadCircuitBreaker
+	HotFileTracker          *bufferedread.HotFileTracker
+	OpenObjectRegistry      *bufferedread.OpenObjectRegistry
+	BucketType              gcs.BucketType
 	WorkerPool              workerpool.WorkerPool
 	HandleID                fuseops.HandleID
 	InitialOffset           int64
 }
 
+// defaultReadSourceOrder is the order in which the readers are consulted if
+// none is configured.
+var defaultReadSourceOrder = []string{cfg.ReadSourceCache, cfg.ReadSourcePool, cfg.ReadSourceGCS}
+
 // NewReadManager creates a new ReadManager for the given GCS object,
 // using the provided configuration. It initializes the manager with a
-// file cache reader and a GCS reader, prioritizing the file cache reader if available.
+// file cache reader, a buffered reader and a GCS reader, consulted in the
+// configured read source order.
 func NewReadManager(object *gcs.MinObject, bucket gcs.Bucket, config *ReadManagerConfig) *ReadManager {
-	// Create a slice to hold all readers. The file cache reader will be added first if it exists.
-	var readers []gcsx.Reader
+	// Readers by read source, nil for the sources not available.
+	sourceReaders := make(map[string]gcsx.Reader, len(defaultReadSourceOrder))
 
 	if config.TraceHandle == nil {
 		config.TraceHandle = tracing.NewNoopTracer()
@@ -89,49 +140,95 @@ func NewReadManager(object *gcs.MinObject, bucket gcs.Bucket, config *ReadManage
 			config.TraceHandle,
 			config.HandleID,
 		)
-		readers = append(readers, reader)
+		sourceReaders[cfg.ReadSourceCache] = reader
 	} else if config.FileCacheHandler != nil {
-		// For traditional cache handler, use FileCacheReader
+		// For traditional cache handler, use FileCacheReader. The object is
+		// downloaded in full whatever the offset of the first read if the cache
+		// is preferred after full-object downloads.
 		fileCacheReader := gcsx.NewFileCacheReader(
 			object,
 			bucket,
 			config.FileCacheHandler,
-			config.CacheFileForRangeRead,
+			config.CacheFileForRangeRead || config.Config.FileCache.PreferAfterFullDownload,
 			config.MetricHandle,
 			config.TraceHandle,
 			config.HandleID,
 		)
-		readers = append(readers, fileCacheReader)
+		sourceReaders[cfg.ReadSourceCache] = fileCacheReader
 	}
 
 	readClassifier := gcsx.NewReadTypeClassifier(int64(config.SequentialReadSizeMB), config.InitialOffset)
 
+	// A fully cached object is read from the cache file, so there is no point in
+	// reserving blocks for it.
+	fullyCached := config.Config.Read.EnableBufferedRead && config.FileCacheHandler != nil &&
+		config.FileCacheHandler.IsFullyCached(object, bucket)
+
+	var preferCacheHandler *file.CacheHandler
+	var releasableBufferedReader *bufferedread.BufferedReader
 	// If buffered read is enabled, initialize the buffered reader and add it to the readers.
-	if config.Config.Read.EnableBufferedRead {
+	if config.Config.Read.EnableBufferedRead && !fullyCached {
 		readConfig := config.Config.Read
 		bufferedReadConfig := &bufferedread.BufferedReadConfig{
-			MaxPrefetchBlockCnt:     readConfig.MaxBlocksPerHandle,
-			PrefetchBlockSizeBytes:  readConfig.BlockSizeMb * util.MiB,
-			InitialPrefetchBlockCnt: readConfig.StartBlocksPerHandle,
-			MinBlocksPerHandle:      readConfig.MinBlocksPerHandle,
-			RandomSeekThreshold:     readConfig.RandomSeekThreshold,
+			MaxPrefetchBlockCnt:       readConfig.MaxBlocksPerHandle,
+			PrefetchBlockSizeBytes:    readConfig.BlockSizeMb * util.MiB,
+			DemandBlockSizeBytes:      readConfig.DemandBlockSizeKb * util.KiB,
+			InitialPrefetchBlockCnt:   readConfig.StartBlocksPerHandle,
+			MinBlocksPerHandle:        readConfig.MinBlocksPerHandle,
+			RandomSeekThreshold:       readConfig.RandomSeekThreshold,
+			PrefetchPolicy:            readConfig.PrefetchPolicy,
+			BlockAlignment:            readConfig.BlockAlignment,
+			DownloadDeadline:          time.Duration(readConfig.DownloadDeadlineSecs) * time.Second,
+			DownloadMaxRetries:        readConfig.DownloadMaxRetries,
+			DownloadRetryBackoff:      readConfig.DownloadRetryBackoff,
+			DownloadReschedules:       readConfig.DownloadReschedules,
+			DownloadRescheduleBackoff: readConfig.DownloadRescheduleBackoff,
+			FallbackOnDownloadFailure: readConfig.FallbackOnDownloadFailure,
+			FanOutMaxBlocks:           readConfig.FanOutMaxBlocks,
+			HotFileResidentBlocks:     readConfig.HotFileResidentBlocks,
+			SlidingWindowBlocks:       readConfig.SlidingWindowBlocks,
+			ReadTimeout:               readConfig.Timeout,
+			ReadLatestGeneration:      readConfig.GenerationStrategy == cfg.GenerationStrategyLatestAlways,
+			CancelledDownloadPolicy:   readConfig.CancelledDownloadPolicy,
+			VerifyObjectChecksum:      readConfig.VerifyObjectChecksum,
+			ObjectMetadataKeys:        readConfig.ObjectMetadataKeys,
+			PhaseDetectionWindow:      readConfig.PhaseDetectionWindow,
+			PhaseRandomThreshold:      readConfig.PhaseRandomThreshold,
+			PhaseSequentialThreshold:  readConfig.PhaseSequentialThreshold,
 		}
 		opts := &bufferedread.BufferedReaderOptions{
-			Object:             object,
-			Bucket:             bucket,
-			Config:             bufferedReadConfig,
-			GlobalMaxBlocksSem: config.GlobalMaxBlocksSem,
-			WorkerPool:         config.WorkerPool,
-			MetricHandle:       config.MetricHandle,
-			TraceHandle:        config.TraceHandle,
-			ReadTypeClassifier: readClassifier,
-			HandleID:           config.HandleID,
+			Object:                     object,
+			Bucket:                     bucket,
+			Config:                     bufferedReadConfig,
+			GlobalMaxBlocksSem:         config.GlobalMaxBlocksSem,
+			WorkerPool:                 config.WorkerPool,
+			MetricHandle:               config.MetricHandle,
+			TraceHandle:                config.TraceHandle,
+			ReadTypeClassifier:         readClassifier,
+			HandleID:                   config.HandleID,
+			PrefetchFilesSem:           config.PrefetchFilesSem,
+			SharedBlockRegistry:        config.SharedBlockRegistry,
+			Stats:                      config.BufferedReadStats,
+			ChecksumManifest:           config.BlockChecksumManifest,
+			PinnedBlockStore:           config.PinnedBlockStore,
+			BlockArena:                 config.ReadBlockArena,
+			ObjectDownloadLimiter:      config.ObjectDownloadLimiter,
+			DownloadThrottle:           config.DownloadThrottle,
+			ObjectResidentBlockLimiter: config.ObjectResidentLimiter,
+			DownloadCircuitBreaker:     config.DownloadCircuitBreaker,
+			HotFileTracker:             config.HotFileTracker,
+			OpenObjectRegistry:         config.OpenObjectRegistry,
+			BucketType:                 config.BucketType,
 		}
 		bufferedReader, err := bufferedread.NewBufferedReader(opts)
 		if err != nil {
 			logger.Tracef("Failed to create bufferedReader: %v. Buffered reading will be disabled for this file handle.", err)
 		} else {
-			readers = append(readers, bufferedReader)
+			sourceReaders[cfg.ReadSourcePool] = bufferedReader
+			if config.Config.FileCache.PreferAfterFullDownload && config.FileCacheHandler != nil {
+				preferCacheHandler = config.FileCacheHandler
+				releasableBufferedReader = bufferedReader
+			}
 		}
 	}
 
@@ -147,13 +244,28 @@ func NewReadManager(object *gcs.MinObject, bucket gcs.Bucket, config *ReadManage
 			ReadTypeClassifier: readClassifier,
 		},
 	)
-	// Add the GCS reader as a fallback.
-	readers = append(readers, gcsReader)
+	sourceReaders[cfg.ReadSourceGCS] = gcsReader
+
+	order := config.Config.Read.SourceOrder
+	if len(order) == 0 {
+		order = defaultReadSourceOrder
+	}
+	var readers []gcsx.Reader
+	for _, source := range order {
+		if r := sourceReaders[source]; r != nil {
+			readers = append(readers, r)
+		}
+	}
 
 	return &ReadManager{
 		object:             object,
-		readers:            readers, // Readers are prioritized: file cache first, then GCS.
+		bucket:             bucket,
+		readers:            readers, // Readers are prioritized in the read source order, GCS last.
+		preferCacheHandler: preferCacheHandler,
+		bufferedReader:     releasableBufferedReader,
 		readTypeClassifier: readClassifier,
+		fullyCached:        fullyCached,
+		metricHandle:       config.MetricHandle,
 		traceHandle:        config.TraceHandle,
 	}
 }
@@ -167,13 +279,16 @@ func (rr *ReadManager) Object() *gcs.MinObject {
 }
 
 func (rr *ReadManager) CheckInvariants() {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
 	for _, r := range rr.readers {
 		r.CheckInvariants()
 	}
 }
 
 // ReadAt attempts to read data from the provided offset, using the configured readers.
-// It prioritizes readers in the order they are defined (file cache first, then GCS).
+// It prioritizes readers in the configured read source order (by default file
+// cache first, then buffered reader, then GCS).
 // If a reader returns a FallbackToAnotherReader error, it tries the next reader.
 func (rr *ReadManager) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (gcsx.ReadResponse, error) {
 	var readResponse gcsx.ReadResponse
@@ -191,28 +306,89 @@ func (rr *ReadManager) ReadAt(ctx context.Context, req *gcsx.ReadRequest) (gcsx.
 	// based on the access pattern.
 	req.ReadInfo = rr.readTypeClassifier.GetReadInfo(req.Offset, false)
 
+	if rr.preferCacheHandler != nil && rr.firstReadDone.CompareAndSwap(false, true) {
+		// The file cache reader downloads the object in full on the first read.
+		rr.metricHandle.FileCacheFullObjectDownloadCount(1)
+	}
+
+	rr.mu.RLock()
 	var err error
-	for _, r := range rr.readers {
+	for i, r := range rr.readers {
 		ctx, span := rr.traceHandle.StartSpan(ctx, r.ReaderName())
 		readResponse, err = r.ReadAt(ctx, req)
 		rr.traceHandle.EndSpan(span)
 		if err == nil {
+			rr.mu.RUnlock()
 			rr.readTypeClassifier.RecordRead(req.Offset, int64(readResponse.Size))
+			if rr.fullyCached && i == 0 {
+				rr.metricHandle.FileCacheDirectReadCount(1)
+			}
+			if _, ok := r.(*gcsx.FileCacheReader); ok {
+				rr.releaseBufferedReaderIfFullyCached()
+			}
 			return readResponse, nil
 		}
 		if !errors.Is(err, gcsx.FallbackToAnotherReader) {
 			// Non-fallback error, return it.
+			rr.mu.RUnlock()
 			return readResponse, err
 		}
 		// Fallback to the next reader.
 	}
+	rr.mu.RUnlock()
 
 	// If all readers failed with FallbackToAnotherReader, return the last response and error.
 	// This case should not happen as the last reader should always succeed.
 	return readResponse, err
 }
 
+// releaseBufferedReaderIfFullyCached destroys the buffered reader, freeing its
+// blocks, if the file cache is preferred after full-object downloads and the
+// object is fully cached, the following reads being served from the cache.
+// LOCKS_EXCLUDED(rr.mu)
+func (rr *ReadManager) releaseBufferedReaderIfFullyCached() {
+	rr.mu.RLock()
+	br := rr.bufferedReader
+	rr.mu.RUnlock()
+	if br == nil || !rr.preferCacheHandler.IsFullyCached(rr.object, rr.bucket) {
+		return
+	}
+
+	rr.mu.Lock()
+	if rr.bufferedReader != br {
+		// Released by a concurrent read.
+		rr.mu.Unlock()
+		return
+	}
+	rr.readers = slices.DeleteFunc(slices.Clone(rr.readers), func(r gcsx.Reader) bool { return r == gcsx.Reader(br) })
+	rr.bufferedReader = nil
+	rr.mu.Unlock()
+
+	logger.Tracef("Releasing the buffered read blocks of fully cached object %q.", rr.object.Name)
+	br.Destroy()
+}
+
+// Advise forwards the advice to the readers acting on it.
+// LOCKS_EXCLUDED(rr.mu)
+func (rr *ReadManager) Advise(advice gcsx.Advice, offset, length int64) error {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	for _, r := range rr.readers {
+		adviser, ok := r.(gcsx.RangeAdviser)
+		if !ok {
+			continue
+		}
+		if err := adviser.Advise(advice, offset, length); err != nil {
+			return fmt.Errorf("%s: %w", r.ReaderName(), err)
+		}
+	}
+	return nil
+}
+
+// LOCKS_EXCLUDED(rr.mu)
 func (rr *ReadManager) Destroy() {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
 	for _, r := range rr.readers {
 		r.Destroy()
 	}
@@ -44,7 +44,11 @@ import (
 	"github.com/googlecloudplatform/gcsfuse/v3/tracing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -201,6 +205,7 @@ func (t *readManagerTest) Test_NewReadManager_WithBufferedRead() {
 func (t *readManagerTest) Test_NewReadManager_WithFileCacheAndBufferedRead() {
 	config := t.readManagerConfig(true, true)
 	defer os.RemoveAll(path.Join(os.Getenv("HOME"), "test_cache_dir"))
+	t.mockBucket.On("Name").Return("test-bucket").Maybe()
 
 	rm := NewReadManager(t.object, t.mockBucket, config)
 
@@ -214,6 +219,62 @@ func (t *readManagerTest) Test_NewReadManager_WithFileCacheAndBufferedRead() {
 	assert.True(t.T(), ok3, "Third reader should be GCSReader")
 }
 
+func (t *readManagerTest) Test_ReadAt_ReadSourceOrder() {
+	testCases := []struct {
+		name                  string
+		order                 []string
+		expectServedFromCache bool
+	}{
+		{
+			name:                  "cache_first",
+			order:                 []string{cfg.ReadSourceCache, cfg.ReadSourcePool, cfg.ReadSourceGCS},
+			expectServedFromCache: true,
+		},
+		{
+			name:                  "pool_first",
+			order:                 []string{cfg.ReadSourcePool, cfg.ReadSourceCache, cfg.ReadSourceGCS},
+			expectServedFromCache: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func() {
+			t.mockBucket = new(storage.TestifyMockBucket)
+			config := t.readManagerConfig(true, true)
+			defer os.RemoveAll(path.Join(os.Getenv("HOME"), "test_cache_dir"))
+			config.Config.Read.SourceOrder = tc.order
+			t.mockBucket.On("Name").Return("test-bucket").Maybe()
+			t.mockBucket.On("BucketType").Return(t.bucketType).Maybe()
+			expectedData := testUtil.GenerateRandomBytes(int(t.object.Size))
+			// The object is downloaded once, by whichever source serves the read.
+			t.mockNewReaderWithHandleCallForTestBucket(0, t.object.Size, &fake.FakeReader{ReadCloser: getReadCloser(expectedData)})
+			rm := NewReadManager(t.object, t.mockBucket, config)
+			defer rm.Destroy()
+			require.Len(t.T(), rm.readers, 3)
+			_, cacheFirst := rm.readers[0].(*gcsx.FileCacheReader)
+			_, poolFirst := rm.readers[0].(*bufferedread.BufferedReader)
+			_, gcsLast := rm.readers[2].(*clientReaders.GCSReader)
+			assert.Equal(t.T(), tc.expectServedFromCache, cacheFirst)
+			assert.Equal(t.T(), !tc.expectServedFromCache, poolFirst)
+			assert.True(t.T(), gcsLast)
+			buf := make([]byte, t.object.Size)
+
+			resp, err := rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: 0})
+
+			require.NoError(t.T(), err)
+			// The buffered reader returns its blocks rather than filling buf.
+			if resp.Data != nil {
+				buf = testUtil.ConvertReadResponseToBytes(resp.Data, resp.Size)
+				resp.Callback()
+			}
+			assert.Equal(t.T(), expectedData, buf)
+			// Only a read served from the cache populates the cache.
+			assert.Equal(t.T(), tc.expectServedFromCache, config.FileCacheHandler.IsFullyCached(t.object, t.mockBucket))
+			t.mockBucket.AssertExpectations(t.T())
+		})
+	}
+}
+
 func (t *readManagerTest) Test_NewReadManager_BufferedReaderCreationFails() {
 	config := t.readManagerConfig(false, true)
 	// Exhaust the semaphore
@@ -332,6 +393,90 @@ func (t *readManagerTest) Test_ReadAt_FullObjectFromCache() {
 	t.mockBucket.AssertExpectations(t.T())
 }
 
+func (t *readManagerTest) Test_ReadAt_FullyCachedObjectBypassesBlocks() {
+	objectSize := int(t.object.Size)
+	expectedData := testUtil.GenerateRandomBytes(objectSize)
+	// The object is downloaded once, to populate the cache.
+	t.mockNewReaderWithHandleCallForTestBucket(0, t.object.Size, &fake.FakeReader{ReadCloser: getReadCloser(expectedData)})
+	t.mockBucket.On("Name").Return("test-bucket").Maybe()
+	t.mockBucket.On("BucketType").Return(t.bucketType).Maybe()
+	config := t.readManagerConfig(true, true)
+	defer os.RemoveAll(path.Join(os.Getenv("HOME"), "test_cache_dir"))
+	config.Config.Read.EnableBufferedRead = false
+	rm := NewReadManager(t.object, t.mockBucket, config)
+	_, err := rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, objectSize), Offset: 0})
+	require.NoError(t.T(), err)
+	rm.Destroy()
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	config.MetricHandle = mh
+	config.Config.Read.EnableBufferedRead = true
+	globalMaxBlocksSem := semaphore.NewWeighted(2)
+	config.GlobalMaxBlocksSem = globalMaxBlocksSem
+	rm = NewReadManager(t.object, t.mockBucket, config)
+	defer rm.Destroy()
+	buf := make([]byte, 10)
+
+	resp, err := rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: 5})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 10, resp.Size)
+	assert.Equal(t.T(), expectedData[5:15], buf)
+	assert.Len(t.T(), rm.readers, 2) // FileCacheReader, GCSReader
+	for _, r := range rm.readers {
+		_, ok := r.(*bufferedread.BufferedReader)
+		assert.False(t.T(), ok, "No BufferedReader should be created for a fully cached object")
+	}
+	assert.True(t.T(), globalMaxBlocksSem.TryAcquire(2), "No block should be reserved for a fully cached object")
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "file_cache/direct_read_count", attribute.NewSet(), 1)
+	t.mockBucket.AssertExpectations(t.T())
+}
+
+func (t *readManagerTest) Test_ReadAt_PreferFileCacheAfterFullDownloadReleasesBlocks() {
+	objectSize := int(t.object.Size)
+	expectedData := testUtil.GenerateRandomBytes(objectSize)
+	// The object is downloaded once, into the cache.
+	t.mockNewReaderWithHandleCallForTestBucket(0, t.object.Size, &fake.FakeReader{ReadCloser: getReadCloser(expectedData)})
+	t.mockBucket.On("Name").Return("test-bucket").Maybe()
+	t.mockBucket.On("BucketType").Return(t.bucketType).Maybe()
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	config := t.readManagerConfig(true, true)
+	defer os.RemoveAll(path.Join(os.Getenv("HOME"), "test_cache_dir"))
+	config.MetricHandle = mh
+	config.Config.FileCache.PreferAfterFullDownload = true
+	globalMaxBlocksSem := semaphore.NewWeighted(2)
+	config.GlobalMaxBlocksSem = globalMaxBlocksSem
+	rm := NewReadManager(t.object, t.mockBucket, config)
+	defer rm.Destroy()
+	require.Len(t.T(), rm.readers, 3) // FileCacheReader, BufferedReader, GCSReader
+	// The object fits in a single block, the only one the buffered reader reserves.
+	require.False(t.T(), globalMaxBlocksSem.TryAcquire(2), "The buffered reader must reserve its block")
+	// The first read downloads the object in full into the cache.
+	_, err = rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: make([]byte, objectSize), Offset: 0})
+	require.NoError(t.T(), err)
+	buf := make([]byte, 10)
+
+	resp, err := rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: 5})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), 10, resp.Size)
+	assert.Equal(t.T(), expectedData[5:15], buf)
+	assert.Len(t.T(), rm.readers, 2) // FileCacheReader, GCSReader
+	for _, r := range rm.readers {
+		_, ok := r.(*bufferedread.BufferedReader)
+		assert.False(t.T(), ok, "The BufferedReader must be released once the object is fully cached")
+	}
+	assert.True(t.T(), globalMaxBlocksSem.TryAcquire(2), "The blocks of the BufferedReader must be freed")
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "file_cache/full_object_download_count", attribute.NewSet(), 1)
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "file_cache/read_count", attribute.NewSet(attribute.Bool("cache_hit", true), attribute.String("read_type", "Sequential")), 1)
+	t.mockBucket.AssertExpectations(t.T())
+}
+
 func (t *readManagerTest) Test_ReadAt_R1FailsR2Succeeds() {
 	offset := int64(0)
 	buf := make([]byte, 10)
@@ -361,6 +506,35 @@ func (t *readManagerTest) Test_ReadAt_R1FailsR2Succeeds() {
 	mockReader2.AssertExpectations(t.T())
 }
 
+func (t *readManagerTest) Test_ReadAt_FailedBlockDownloadFallsBackToGCSReader() {
+	expectedData := testUtil.GenerateRandomBytes(int(t.object.Size))
+	// The download of the block fails, and the GCS reader reads the range in
+	// its place.
+	t.mockBucket.On("NewReaderWithReadHandle", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+	t.mockNewReaderWithHandleCallForTestBucket(0, t.object.Size, &fake.FakeReader{ReadCloser: getReadCloser(expectedData)})
+	t.mockBucket.On("Name").Return("test-bucket").Maybe()
+	t.mockBucket.On("BucketType").Return(t.bucketType).Maybe()
+	metricReader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+	mh, err := metrics.NewOTelMetrics(t.ctx, 1, 100)
+	require.NoError(t.T(), err)
+	config := t.readManagerConfig(false, true)
+	config.MetricHandle = mh
+	config.Config.Read.FallbackOnDownloadFailure = true
+	rm := NewReadManager(t.object, t.mockBucket, config)
+	defer rm.Destroy()
+	require.Len(t.T(), rm.readers, 2) // BufferedReader, GCSReader
+	buf := make([]byte, t.object.Size)
+
+	resp, err := rm.ReadAt(t.ctx, &gcsx.ReadRequest{Buffer: buf, Offset: 0})
+
+	require.NoError(t.T(), err)
+	assert.Equal(t.T(), int(t.object.Size), resp.Size)
+	assert.Equal(t.T(), expectedData, buf)
+	metrics.VerifyCounterMetric(t.T(), t.ctx, metricReader, "buffered_read/fallback_trigger_count", attribute.NewSet(attribute.String("reason", "download_failed")), 1)
+	t.mockBucket.AssertExpectations(t.T())
+}
+
 func (t *readManagerTest) Test_ReadAt_BufferedReaderFallsBack() {
 	offset := int64(0)
 	buf := make([]byte, 10)
@@ -103,6 +103,14 @@ func (vrm *VisualReadManager) Object() *gcs.MinObject {
 	return vrm.wrapped.Object()
 }
 
+// Advise delegates the advice to the wrapped ReadManager, if it acts on it.
+func (vrm *VisualReadManager) Advise(advice gcsx.Advice, offset, length int64) error {
+	if adviser, ok := vrm.wrapped.(gcsx.RangeAdviser); ok {
+		return adviser.Advise(advice, offset, length)
+	}
+	return nil
+}
+
 func (vrm *VisualReadManager) CheckInvariants() {
 	vrm.wrapped.CheckInvariants()
 }
@@ -101,6 +101,25 @@ type Reader interface {
 	Destroy()
 }
 
+// Advice is an access pattern hint for a byte range of a file, mirroring the
+// advice values of posix_fadvise(2).
+type Advice int
+
+const (
+	// AdviceWillNeed indicates the range will be read soon.
+	AdviceWillNeed Advice = iota
+	// AdviceDontNeed indicates the range won't be read in the near future.
+	AdviceDontNeed
+)
+
+// RangeAdviser is implemented by readers which adapt their prefetching or
+// caching to access pattern advice given by the application.
+type RangeAdviser interface {
+	// Advise applies the advice to the byte range [offset, offset+length) of
+	// the object.
+	Advise(advice Advice, offset, length int64) error
+}
+
 // ReadManager is generally used in higher-level components that need access to object metadata.
 // File handle will contain a ReadManager instance and will handle read operations.
 type ReadManager interface {
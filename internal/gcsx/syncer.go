@@ -45,7 +45,11 @@ type Syncer interface {
 // object's size is at least composeThreshold, we will "append" to it by writing
 // out a temporary blob and composing it with the source object.
 //
-// Temporary blobs have names beginning with tmpObjectPrefix. We make an effort
+// The full contents of objects are uploaded as configured by uploadConfig,
+// in parallel composite uploads of temporary component objects if enabled.
+//
+// Temporary blobs have names beginning with tmpObjectPrefix, following the
+// template of uploadConfig.TmpObjectNamer if set. We make an effort
 // to delete them, but if we are interrupted for some reason we may not be able
 // to do so. Therefore the user should arrange for garbage collection.
 func NewSyncer(
@@ -53,6 +57,7 @@ func NewSyncer(
 	chunkRetryDeadlineSecs int64,
 	chunkTransferTimeoutSecs int64,
 	tmpObjectPrefix string,
+	uploadConfig UploadConfig,
 	bucket gcs.Bucket) (os Syncer) {
 	// Create the object creators.
 	fullCreator := &fullObjectCreator{
@@ -62,14 +67,22 @@ func NewSyncer(
 	// Zonal buckets do not currently support Compose, so we always write objects
 	// in their entirety.
 	var composeCreator objectCreator
+	var parallelCompositeCreator *parallelCompositeObjectCreator
 	if !bucket.BucketType().Zonal {
+		namer := uploadConfig.tmpObjectNamer(tmpObjectPrefix)
 		composeCreator = newComposeObjectCreator(
-			tmpObjectPrefix,
+			namer,
 			bucket)
+		if uploadConfig.ParallelComposite {
+			parallelCompositeCreator = newParallelCompositeObjectCreator(
+				namer,
+				uploadConfig.ComponentSize,
+				bucket)
+		}
 	}
 
 	// And the syncer.
-	os = newSyncer(composeThreshold, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs, fullCreator, composeCreator)
+	os = newSyncer(composeThreshold, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs, fullCreator, composeCreator, parallelCompositeCreator)
 
 	return
 }
@@ -126,6 +139,9 @@ type objectCreator interface {
 //   - composeCreator accepts the source object and the contents that should be
 //     "appended" to it.
 //
+// If parallelCompositeCreator is non-nil, it replaces fullCreator for the
+// contents large enough to be worth uploading in parallel.
+//
 // composeThreshold controls the source object length at which we consider it
 // worthwhile to make the append optimization. It should be set to a value on
 // the order of the bandwidth to GCS times three times the round trip latency
@@ -135,13 +151,15 @@ func newSyncer(
 	chunkRetryDeadlineSecs int64,
 	chunkTransferTimeoutSecs int64,
 	fullCreator objectCreator,
-	composeCreator objectCreator) (os Syncer) {
+	composeCreator objectCreator,
+	parallelCompositeCreator *parallelCompositeObjectCreator) (os Syncer) {
 	os = &syncer{
 		composeThreshold:         composeThreshold,
 		chunkRetryDeadlineSecs:   chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs: chunkTransferTimeoutSecs,
 		fullCreator:              fullCreator,
 		composeCreator:           composeCreator,
+		parallelCompositeCreator: parallelCompositeCreator,
 	}
 
 	return
@@ -153,6 +171,7 @@ type syncer struct {
 	chunkTransferTimeoutSecs int64
 	fullCreator              objectCreator
 	composeCreator           objectCreator
+	parallelCompositeCreator *parallelCompositeObjectCreator
 }
 
 func (os *syncer) SyncObject(
@@ -177,7 +196,7 @@ func (os *syncer) SyncObject(
 			err = fmt.Errorf("error in seeking: %w", err)
 			return
 		}
-		return os.fullCreator.Create(ctx, objectName, srcObject, sr.Mtime, os.chunkRetryDeadlineSecs, os.chunkTransferTimeoutSecs, content)
+		return os.createFull(ctx, objectName, srcObject, sr, content)
 	}
 
 	// Make sure the dirty threshold makes sense.
@@ -225,7 +244,7 @@ func (os *syncer) SyncObject(
 			return
 		}
 
-		o, err = os.fullCreator.Create(ctx, objectName, srcObject, sr.Mtime, os.chunkRetryDeadlineSecs, os.chunkTransferTimeoutSecs, content)
+		o, err = os.createFull(ctx, objectName, srcObject, sr, content)
 	}
 
 	// Deal with errors.
@@ -236,3 +255,18 @@ func (os *syncer) SyncObject(
 
 	return
 }
+
+// createFull writes out the full content as a new generation of the object, in
+// a parallel composite upload if enabled and worthwhile.
+func (os *syncer) createFull(
+	ctx context.Context,
+	objectName string,
+	srcObject *gcs.Object,
+	sr StatResult,
+	content TempFile) (o *gcs.Object, err error) {
+	if os.parallelCompositeCreator != nil && os.parallelCompositeCreator.worthwhile(sr.Size) {
+		return os.parallelCompositeCreator.Create(ctx, objectName, srcObject, sr.Mtime, os.chunkRetryDeadlineSecs, os.chunkTransferTimeoutSecs, io.NewSectionReader(content, 0, sr.Size))
+	}
+
+	return os.fullCreator.Create(ctx, objectName, srcObject, sr.Mtime, os.chunkRetryDeadlineSecs, os.chunkTransferTimeoutSecs, content)
+}
@@ -30,8 +30,9 @@ func NewSyncerBucket(
 	chunkRetryDeadlineSecs int64,
 	chunkTransferTimeoutSecs int64,
 	tmpObjectPrefix string,
+	uploadConfig UploadConfig,
 	bucket gcs.Bucket,
 ) SyncerBucket {
-	syncer := NewSyncer(appendThreshold, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs, tmpObjectPrefix, bucket)
+	syncer := NewSyncer(appendThreshold, chunkRetryDeadlineSecs, chunkTransferTimeoutSecs, tmpObjectPrefix, uploadConfig, bucket)
 	return SyncerBucket{bucket, syncer}
 }
@@ -307,7 +307,8 @@ func (t *SyncerTest) SetUp(ti *TestInfo) {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		&t.fullCreator,
-		&t.appendCreator)
+		&t.appendCreator,
+		nil)
 
 	t.clock.SetTime(time.Date(2015, 4, 5, 2, 15, 0, 0, time.Local))
 
@@ -428,7 +429,8 @@ func (t *SyncerTest) SourceTooShortForAppend() {
 		chunkRetryDeadlineSecs,
 		chunkTransferTimeoutSecs,
 		&t.fullCreator,
-		&t.appendCreator)
+		&t.appendCreator,
+		nil)
 
 	// Extend the length of the content.
 	err = t.content.Truncate(int64(len(srcObjectContents) + 1))
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TmpObjectType tells which write staged a temporary object.
+type TmpObjectType string
+
+const (
+	// TmpObjectTypeUpload is the type of the components of parallel composite
+	// uploads.
+	TmpObjectTypeUpload TmpObjectType = "upload"
+
+	// TmpObjectTypeCompose is the type of the contents appended to objects by
+	// composing them.
+	TmpObjectTypeCompose TmpObjectType = "compose"
+)
+
+// The placeholders of the templates of temporary object names.
+const (
+	tmpObjectInstancePlaceholder  = "instance"
+	tmpObjectTimestampPlaceholder = "timestamp"
+	tmpObjectTypePlaceholder      = "type"
+	tmpObjectRandomPlaceholder    = "random"
+)
+
+// tmpObjectTimestampLayout is the layout of the creation times embedded in
+// temporary object names.
+const tmpObjectTimestampLayout = "20060102T150405Z"
+
+// tmpObjectPlaceholderPatterns match the values each placeholder is replaced
+// with.
+var tmpObjectPlaceholderPatterns = map[string]string{
+	tmpObjectInstancePlaceholder:  `[^/]+`,
+	tmpObjectTimestampPlaceholder: `[0-9]{8}T[0-9]{6}Z`,
+	tmpObjectTypePlaceholder:      `upload|compose`,
+	tmpObjectRandomPlaceholder:    `[0-9a-f]{16}`,
+}
+
+// TmpObjectName holds the fields embedded in the name of a temporary object.
+// The fields whose placeholder is missing from the template are left empty.
+type TmpObjectName struct {
+	InstanceID string
+	Type       TmpObjectType
+	Created    time.Time
+}
+
+// tmpObjectNameSegment is either a literal part or a placeholder of a
+// template.
+type tmpObjectNameSegment struct {
+	literal     string
+	placeholder string
+}
+
+// TmpObjectNamer names the temporary objects staged by writes, under the
+// temporary object prefix, following a template whose fields garbage
+// collection parses back. Without a template, the names are random.
+type TmpObjectNamer struct {
+	prefix     string
+	instanceID string
+
+	// segments are the parts of the template. Empty without a template.
+	segments []tmpObjectNameSegment
+
+	// pattern matches the names following the template, capturing the values
+	// of its placeholders. Nil without a template.
+	pattern *regexp.Regexp
+
+	now func() time.Time
+}
+
+// NewTmpObjectNamer returns a namer of the temporary objects under prefix
+// following template, whose {instance} placeholder is replaced with
+// instanceID. An empty template names the objects randomly. Fails unless the
+// names produced by the template are unique, beginning with prefix and parsed
+// back.
+func NewTmpObjectNamer(prefix, template, instanceID string) (*TmpObjectNamer, error) {
+	n := &TmpObjectNamer{
+		prefix:     prefix,
+		instanceID: instanceID,
+		now:        time.Now,
+	}
+	if template == "" {
+		return n, nil
+	}
+	if strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("temporary object name template %q must not begin with /", template)
+	}
+
+	counts := make(map[string]int)
+	pattern := "^" + regexp.QuoteMeta(prefix)
+	for rest := template; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			n.segments = append(n.segments, tmpObjectNameSegment{literal: rest})
+			pattern += regexp.QuoteMeta(rest)
+			break
+		}
+		if open > 0 {
+			n.segments = append(n.segments, tmpObjectNameSegment{literal: rest[:open]})
+			pattern += regexp.QuoteMeta(rest[:open])
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("temporary object name template %q has an unterminated placeholder", template)
+		}
+		placeholder := rest[open+1 : open+end]
+		placeholderPattern, ok := tmpObjectPlaceholderPatterns[placeholder]
+		if !ok {
+			return nil, fmt.Errorf("temporary object name template %q has unknown placeholder {%s}", template, placeholder)
+		}
+		if counts[placeholder]++; counts[placeholder] > 1 {
+			return nil, fmt.Errorf("temporary object name template %q has placeholder {%s} more than once", template, placeholder)
+		}
+		n.segments = append(n.segments, tmpObjectNameSegment{placeholder: placeholder})
+		pattern += fmt.Sprintf("(?P<%s>%s)", placeholder, placeholderPattern)
+		rest = rest[open+end+1:]
+	}
+	pattern += "$"
+
+	// The random number keeps the names of the objects staged at once unique.
+	if counts[tmpObjectRandomPlaceholder] == 0 {
+		return nil, fmt.Errorf("temporary object name template %q must contain {%s}", template, tmpObjectRandomPlaceholder)
+	}
+	if counts[tmpObjectInstancePlaceholder] > 0 && !regexp.MustCompile("^"+tmpObjectPlaceholderPatterns[tmpObjectInstancePlaceholder]+"$").MatchString(instanceID) {
+		return nil, fmt.Errorf("mount instance ID %q can't be embedded in temporary object names", instanceID)
+	}
+	var err error
+	if n.pattern, err = regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("temporary object name template %q: %w", template, err)
+	}
+
+	// Make sure the names produced are prefix-matching and parsed back into
+	// their fields.
+	for _, objectType := range []TmpObjectType{TmpObjectTypeUpload, TmpObjectTypeCompose} {
+		name, err := n.Name(objectType)
+		if err != nil {
+			return nil, err
+		}
+		fields, ok := n.Parse(name)
+		if !ok ||
+			(counts[tmpObjectInstancePlaceholder] > 0 && fields.InstanceID != instanceID) ||
+			(counts[tmpObjectTypePlaceholder] > 0 && fields.Type != objectType) {
+			return nil, fmt.Errorf("temporary object name template %q produces names which can't be parsed back, e.g. %q", template, name)
+		}
+	}
+	return n, nil
+}
+
+// Prefix returns the prefix of the names of the temporary objects.
+func (n *TmpObjectNamer) Prefix() string {
+	return n.prefix
+}
+
+// Name returns a new name for a temporary object of the given type.
+func (n *TmpObjectNamer) Name(objectType TmpObjectType) (name string, err error) {
+	if n.pattern == nil {
+		return chooseTmpObjectName(n.prefix)
+	}
+
+	var b strings.Builder
+	b.WriteString(n.prefix)
+	for _, s := range n.segments {
+		switch s.placeholder {
+		case "":
+			b.WriteString(s.literal)
+		case tmpObjectInstancePlaceholder:
+			b.WriteString(n.instanceID)
+		case tmpObjectTimestampPlaceholder:
+			b.WriteString(n.now().UTC().Format(tmpObjectTimestampLayout))
+		case tmpObjectTypePlaceholder:
+			b.WriteString(string(objectType))
+		case tmpObjectRandomPlaceholder:
+			r, err := randomHex()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(r)
+		}
+	}
+	return b.String(), nil
+}
+
+// Parse returns the fields embedded in the name of a temporary object, and
+// false if the name doesn't follow the template, e.g. if it was named before
+// the template was set.
+func (n *TmpObjectNamer) Parse(name string) (fields TmpObjectName, ok bool) {
+	if n.pattern == nil {
+		return
+	}
+	m := n.pattern.FindStringSubmatch(name)
+	if m == nil {
+		return
+	}
+	for i, placeholder := range n.pattern.SubexpNames() {
+		switch placeholder {
+		case tmpObjectInstancePlaceholder:
+			fields.InstanceID = m[i]
+		case tmpObjectTypePlaceholder:
+			fields.Type = TmpObjectType(m[i])
+		case tmpObjectTimestampPlaceholder:
+			created, err := time.Parse(tmpObjectTimestampLayout, m[i])
+			if err != nil {
+				return TmpObjectName{}, false
+			}
+			fields.Created = created
+		}
+	}
+	return fields, true
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTmpObjectNamerNamesFollowTemplate(t *testing.T) {
+	namer, err := NewTmpObjectNamer(".gcsfuse_tmp/", "{instance}/{type}-{timestamp}-{random}", "bucket-1234")
+	require.NoError(t, err)
+	created := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	namer.now = func() time.Time { return created }
+
+	name, err := namer.Name(TmpObjectTypeCompose)
+	require.NoError(t, err)
+	other, err := namer.Name(TmpObjectTypeCompose)
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^\.gcsfuse_tmp/bucket-1234/compose-20260314T150926Z-[0-9a-f]{16}$`, name)
+	assert.NotEqual(t, name, other)
+	fields, ok := namer.Parse(name)
+	require.True(t, ok)
+	assert.Equal(t, TmpObjectName{InstanceID: "bucket-1234", Type: TmpObjectTypeCompose, Created: created}, fields)
+}
+
+func TestTmpObjectNamerParsesNamesOfOtherInstances(t *testing.T) {
+	const template = "{type}/{random}.{instance}"
+	namer, err := NewTmpObjectNamer(".gcsfuse_tmp/", template, "bucket-a")
+	require.NoError(t, err)
+	other, err := NewTmpObjectNamer(".gcsfuse_tmp/", template, "bucket-b")
+	require.NoError(t, err)
+	name, err := other.Name(TmpObjectTypeUpload)
+	require.NoError(t, err)
+
+	fields, ok := namer.Parse(name)
+
+	require.True(t, ok)
+	assert.Equal(t, TmpObjectName{InstanceID: "bucket-b", Type: TmpObjectTypeUpload}, fields)
+	_, ok = namer.Parse(".gcsfuse_tmp/0123456789abcdef")
+	assert.False(t, ok)
+	_, ok = namer.Parse("elsewhere/upload/0123456789abcdef.bucket-b")
+	assert.False(t, ok)
+}
+
+func TestTmpObjectNamerWithoutTemplate(t *testing.T) {
+	namer, err := NewTmpObjectNamer(".gcsfuse_tmp/", "", "bucket-1234")
+	require.NoError(t, err)
+
+	name, err := namer.Name(TmpObjectTypeUpload)
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^\.gcsfuse_tmp/[0-9a-f]{16}$`, name)
+	_, ok := namer.Parse(name)
+	assert.False(t, ok)
+}
+
+func TestNewTmpObjectNamerRejectsInvalidTemplates(t *testing.T) {
+	testCases := []struct {
+		name       string
+		template   string
+		instanceID string
+		wantErr    string
+	}{
+		{
+			name:     "not_unique",
+			template: "{instance}/{type}-{timestamp}",
+			wantErr:  "must contain {random}",
+		},
+		{
+			name:     "leading_slash",
+			template: "/{random}",
+			wantErr:  "must not begin with /",
+		},
+		{
+			name:     "unknown_placeholder",
+			template: "{host}-{random}",
+			wantErr:  "unknown placeholder {host}",
+		},
+		{
+			name:     "unterminated_placeholder",
+			template: "{random}-{type",
+			wantErr:  "unterminated placeholder",
+		},
+		{
+			name:     "repeated_placeholder",
+			template: "{random}-{random}",
+			wantErr:  "more than once",
+		},
+		{
+			name:       "instance_id_with_slash",
+			template:   "{instance}-{random}",
+			instanceID: "bucket/1234",
+			wantErr:    "can't be embedded",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instanceID := tc.instanceID
+			if instanceID == "" {
+				instanceID = "bucket-1234"
+			}
+
+			_, err := NewTmpObjectNamer(".gcsfuse_tmp/", tc.template, instanceID)
+
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), tc.wantErr), err.Error())
+		})
+	}
+}
@@ -37,15 +37,24 @@ func CaptureMultiRangeDownloaderMetrics(ctx context.Context, metricHandle metric
 
 // NewMonitoringBucket returns a gcs.Bucket that exports metrics for monitoring
 func NewMonitoringBucket(b gcs.Bucket, m metrics.MetricHandle) gcs.Bucket {
-	return &monitoringBucket{
+	mb := &monitoringBucket{
 		wrapped:      b,
 		metricHandle: m,
 	}
+	// Read handles are only used by zonal buckets.
+	if b.BucketType().Zonal {
+		mb.readHandleStats = newReadHandleStats(b.Name(), m)
+	}
+	return mb
 }
 
 type monitoringBucket struct {
 	wrapped      gcs.Bucket
 	metricHandle metrics.MetricHandle
+
+	// readHandleStats tracks the reuse of read handles. Nil for non-zonal
+	// buckets.
+	readHandleStats *readHandleStats
 }
 
 func (mb *monitoringBucket) Name() string {
@@ -62,6 +71,9 @@ func setupReader(ctx context.Context, mb *monitoringBucket, req *gcs.ReadObjectR
 	rc, err := mb.wrapped.NewReaderWithReadHandle(ctx, req)
 
 	if err == nil {
+		if mb.readHandleStats != nil && req.ReadHandle != nil {
+			mb.readHandleStats.record(req.ReadHandle, rc.ReadHandle())
+		}
 		rc = newMonitoringReadCloser(ctx, req.Name, rc, mb.metricHandle)
 	}
 
@@ -203,6 +215,13 @@ func (mb *monitoringBucket) RenameFolder(ctx context.Context, folderName string,
 	return
 }
 
+func (mb *monitoringBucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	startTime := time.Now()
+	rules, err := mb.wrapped.GetLifecycleRules(ctx)
+	recordRequest(ctx, mb.metricHandle, metrics.GcsMethodGetLifecycleRulesAttr, startTime)
+	return rules, err
+}
+
 func (mb *monitoringBucket) NewMultiRangeDownloader(
 	ctx context.Context, req *gcs.MultiRangeDownloaderRequest) (mrd gcs.MultiRangeDownloader, err error) {
 	startTime := time.Now()
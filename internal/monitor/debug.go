@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// debugPathPrefix is the path under which the debug endpoints are served by
+// the Prometheus exporter.
+const debugPathPrefix = "/debug/"
+
+var (
+	debugHandlersMu sync.RWMutex
+	// debugHandlers holds the handlers of the debug endpoints by name.
+	// GUARDED by (debugHandlersMu)
+	debugHandlers = make(map[string]http.Handler)
+)
+
+// RegisterDebugHandler serves a debug endpoint at /debug/<name> of the
+// Prometheus exporter, if enabled, replacing any handler of the same name,
+// e.g. of a previous file system. The handler decides which methods it
+// accepts.
+func RegisterDebugHandler(name string, handler http.Handler) {
+	debugHandlersMu.Lock()
+	defer debugHandlersMu.Unlock()
+	debugHandlers[name] = handler
+}
+
+// serveDebug serves the debug endpoint named by the path of the request.
+func serveDebug(w http.ResponseWriter, r *http.Request) {
+	debugHandlersMu.RLock()
+	handler, ok := debugHandlers[strings.TrimPrefix(r.URL.Path, debugPathPrefix)]
+	debugHandlersMu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/exemplar"
@@ -56,6 +57,9 @@ func SetupOTelMetricExporters(ctx context.Context, c *cfg.Config, mountID string
 	opts = setupCloudMonitoring(c.Metrics.CloudMetricsExportIntervalSecs)
 	options = append(options, opts...)
 
+	opts = setupOTLP(ctx, &c.Metrics)
+	options = append(options, opts...)
+
 	res, err := getResource(ctx, mountID)
 	if err != nil {
 		logger.Errorf("Error while fetching resource: %v", err)
@@ -108,6 +112,28 @@ func setupCloudMonitoring(secs int64) []metric.Option {
 	return []metric.Option{metric.WithReader(reader)}
 }
 
+// newOTLPExporter creates the exporter of the metrics to the OpenTelemetry
+// collector at the given URL.
+var newOTLPExporter = func(ctx context.Context, endpointURL string) (metric.Exporter, error) {
+	return otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpointURL(endpointURL))
+}
+
+// setupOTLP exports the metrics over OTLP if the otlp metrics exporter is
+// selected. The metrics are exported every minute, unless overridden by the
+// OTEL_METRIC_EXPORT_INTERVAL environment variable.
+func setupOTLP(ctx context.Context, m *cfg.MetricsConfig) []metric.Option {
+	if m.Exporter != cfg.MetricsExporterOTLP {
+		return nil
+	}
+	exporter, err := newOTLPExporter(ctx, m.OtlpEndpoint)
+	if err != nil {
+		logger.Errorf("Error while creating OTLP exporter:%v", err)
+		return nil
+	}
+	logger.Infof("Exporting metrics over OTLP to %s", m.OtlpEndpoint)
+	return []metric.Option{metric.WithReader(metric.NewPeriodicReader(exporter))}
+}
+
 // permissionAwareExporter wraps a metric.Exporter and disables itself if it encounters
 // a PermissionDenied error. This prevents log spam when the environment lacks
 // necessary permissions.
@@ -168,6 +194,7 @@ func serveMetrics(port int64, shutdownCh <-chan context.Context, done chan<- any
 	logger.Infof("Serving metrics at localhost:%d/metrics", port)
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc(debugPathPrefix, serveDebug)
 	prometheusServer := &http.Server{
 		Addr:           fmt.Sprintf(":%d", port),
 		Handler:        mux,
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -47,6 +48,14 @@ func (m *mockExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+func (m *mockExporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(k)
+}
+
+func (m *mockExporter) Aggregation(k metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(k)
+}
+
 func TestPermissionAwareExporter_ExportSuccess(t *testing.T) {
 	mock := &mockExporter{}
 	exporter := &permissionAwareExporter{Exporter: mock}
@@ -89,3 +98,55 @@ func TestPermissionAwareExporter_ExportOtherError(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, exporter.disabled.Load())
 }
+
+func TestSetupOTLP_NotSelected(t *testing.T) {
+	opts := setupOTLP(context.Background(), &cfg.MetricsConfig{OtlpEndpoint: "http://localhost:4317"})
+
+	assert.Empty(t, opts)
+}
+
+func TestSetupOTLP_ExporterInitFailure(t *testing.T) {
+	origNewOTLPExporter := newOTLPExporter
+	t.Cleanup(func() { newOTLPExporter = origNewOTLPExporter })
+	newOTLPExporter = func(context.Context, string) (metric.Exporter, error) {
+		return nil, errors.New("init failure")
+	}
+
+	opts := setupOTLP(context.Background(), &cfg.MetricsConfig{Exporter: cfg.MetricsExporterOTLP, OtlpEndpoint: "http://localhost:4317"})
+
+	assert.Empty(t, opts)
+}
+
+func TestSetupOTLP_MetricsRouteThroughExporter(t *testing.T) {
+	ctx := context.Background()
+	var exported []string
+	mock := &mockExporter{
+		exportFunc: func(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					exported = append(exported, m.Name)
+				}
+			}
+			return nil
+		},
+	}
+	var gotEndpointURL string
+	origNewOTLPExporter := newOTLPExporter
+	t.Cleanup(func() { newOTLPExporter = origNewOTLPExporter })
+	newOTLPExporter = func(_ context.Context, endpointURL string) (metric.Exporter, error) {
+		gotEndpointURL = endpointURL
+		return mock, nil
+	}
+	opts := setupOTLP(ctx, &cfg.MetricsConfig{Exporter: cfg.MetricsExporterOTLP, OtlpEndpoint: "http://localhost:4317"})
+	require.Len(t, opts, 1)
+	meterProvider := metric.NewMeterProvider(opts...)
+	counter, err := meterProvider.Meter("test").Int64Counter("fs/ops_count")
+	require.NoError(t, err)
+
+	counter.Add(ctx, 1)
+	// Shutting down exports the pending metrics.
+	require.NoError(t, meterProvider.Shutdown(ctx))
+
+	assert.Equal(t, "http://localhost:4317", gotEndpointURL)
+	assert.Contains(t, exported, "fs/ops_count")
+}
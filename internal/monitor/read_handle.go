@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+)
+
+const (
+	// readHandleReuseWindow is the number of reads carrying a read handle over
+	// which the share of the handles reused is computed.
+	readHandleReuseWindow = 100
+
+	// readHandleReuseMinPercent is the share of the read handles reused within
+	// a window below which the reuse is considered low.
+	readHandleReuseMinPercent = 50
+
+	// readHandleReuseLowWindows is the number of consecutive windows with a low
+	// reuse after which the read handle optimization is considered broken.
+	readHandleReuseLowWindows = 3
+)
+
+// readHandleStats counts the reads of a zonal bucket carrying the read handle
+// of a previous read, which GCS either reuses, skipping the auth and metadata
+// checks, or rejects. It warns once the handles are persistently rejected, as
+// the reads then cost as much as without the optimization.
+type readHandleStats struct {
+	bucketName   string
+	metricHandle metrics.MetricHandle
+
+	mu sync.Mutex
+
+	// reused and rejected are the number of reads of the current window whose
+	// read handle was reused or rejected.
+	// GUARDED by (mu)
+	reused   int64
+	rejected int64
+
+	// lowWindows is the number of consecutive windows with a low reuse.
+	// GUARDED by (mu)
+	lowWindows int
+}
+
+func newReadHandleStats(bucketName string, metricHandle metrics.MetricHandle) *readHandleStats {
+	return &readHandleStats{
+		bucketName:   bucketName,
+		metricHandle: metricHandle,
+	}
+}
+
+// record records a read sent with the given read handle, whose reader came
+// back with the returned one. GCS issues a new handle when it rejects the one
+// sent, so the handle counts as reused only if it came back unchanged.
+func (s *readHandleStats) record(sent, returned []byte) {
+	reused := bytes.Equal(sent, returned)
+	if reused {
+		s.metricHandle.GcsReadHandleCount(1, metrics.ReadHandleOutcomeReusedAttr)
+	} else {
+		s.metricHandle.GcsReadHandleCount(1, metrics.ReadHandleOutcomeRejectedAttr)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reused {
+		s.reused++
+	} else {
+		s.rejected++
+	}
+	total := s.reused + s.rejected
+	if total < readHandleReuseWindow {
+		return
+	}
+
+	if s.reused*100 < readHandleReuseMinPercent*total {
+		s.lowWindows++
+		// Warn once per streak of low windows, rather than on every window.
+		if s.lowWindows == readHandleReuseLowWindows {
+			logger.Warnf("Read handles of bucket %q were rejected for %d out of the last %d reads carrying one, over %d consecutive windows: these reads go through the full auth and metadata checks, so the read handle optimization isn't effective.",
+				s.bucketName, s.rejected, total, s.lowWindows)
+		}
+	} else {
+		s.lowWindows = 0
+	}
+	s.reused, s.rejected = 0, 0
+}
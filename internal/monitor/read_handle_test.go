@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/fake"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readHandleBucket is a zonal bucket whose readers come back with a new read
+// handle if rejectHandles is set, and with the handle sent otherwise.
+type readHandleBucket struct {
+	gcs.Bucket
+	rejectHandles bool
+}
+
+func (b *readHandleBucket) Name() string {
+	return "bucket"
+}
+
+func (b *readHandleBucket) BucketType() gcs.BucketType {
+	return gcs.BucketType{Zonal: true}
+}
+
+func (b *readHandleBucket) NewReaderWithReadHandle(ctx context.Context, req *gcs.ReadObjectRequest) (gcs.StorageReader, error) {
+	handle := req.ReadHandle
+	if handle == nil || b.rejectHandles {
+		handle = []byte("new-handle")
+	}
+	return &fake.FakeReader{ReadCloser: io.NopCloser(strings.NewReader("")), Handle: handle}, nil
+}
+
+func readWithHandle(t *testing.T, b gcs.Bucket, count int) {
+	t.Helper()
+	for range count {
+		_, err := b.NewReaderWithReadHandle(context.Background(), &gcs.ReadObjectRequest{Name: "object", ReadHandle: []byte("handle")})
+		require.NoError(t, err)
+	}
+}
+
+func TestFrequentReadHandleRejectionsWarn(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	b := NewMonitoringBucket(&readHandleBucket{rejectHandles: true}, metrics.NewNoopMetrics())
+
+	readWithHandle(t, b, readHandleReuseLowWindows*readHandleReuseWindow-1)
+	// A low reuse within fewer windows may be transient.
+	assert.NotContains(t, buf.String(), "read handle optimization")
+	readWithHandle(t, b, 1)
+
+	assert.Contains(t, buf.String(), "were rejected for 100 out of the last 100 reads")
+	assert.Equal(t, 1, strings.Count(buf.String(), "read handle optimization"))
+}
+
+func TestReusedReadHandlesDoNotWarn(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	b := NewMonitoringBucket(&readHandleBucket{}, metrics.NewNoopMetrics())
+	// Reads without a handle, e.g. the first read of a file, aren't counted.
+	for range 2 * readHandleReuseLowWindows * readHandleReuseWindow {
+		_, err := b.NewReaderWithReadHandle(context.Background(), &gcs.ReadObjectRequest{Name: "object"})
+		require.NoError(t, err)
+	}
+
+	readWithHandle(t, b, 2*readHandleReuseLowWindows*readHandleReuseWindow)
+
+	assert.NotContains(t, buf.String(), "read handle optimization")
+}
+
+func TestReadHandleStatsLowReuseStreakResetByHealthyWindow(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(os.Stdout)
+	s := newReadHandleStats("bucket", metrics.NewNoopMetrics())
+	recordWindows := func(windows int, reused bool) {
+		for range windows * readHandleReuseWindow {
+			if reused {
+				s.record([]byte("handle"), []byte("handle"))
+			} else {
+				s.record([]byte("handle"), []byte("new-handle"))
+			}
+		}
+	}
+
+	recordWindows(readHandleReuseLowWindows-1, false)
+	recordWindows(1, true)
+	recordWindows(readHandleReuseLowWindows-1, false)
+
+	assert.NotContains(t, buf.String(), "read handle optimization")
+	assert.Equal(t, readHandleReuseLowWindows-1, s.lowWindows)
+}
@@ -21,6 +21,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"cloud.google.com/go/storage/control/apiv2/controlpb"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
 	"google.golang.org/api/iterator"
@@ -46,6 +48,11 @@ type bucketHandle struct {
 	controlClient  StorageControlClient
 	billingProject string
 	writeConfig    *cfg.WriteConfig
+
+	// readBucket, if non-nil, is the handle through which objects are read,
+	// e.g. through a regional endpoint. The reads failing through it are
+	// retried through bucket.
+	readBucket *storage.BucketHandle
 }
 
 func (bh *bucketHandle) Name() string {
@@ -78,7 +85,32 @@ func (bh *bucketHandle) NewReaderWithReadHandle(
 		length = end - start
 	}
 
-	obj := bh.bucket.Object(req.Name)
+	var storageReader *storage.Reader
+	if bh.readBucket != nil {
+		storageReader, err = readObjectHandle(bh.readBucket, req).NewRangeReader(ctx, start, length)
+		switch {
+		case err == nil:
+			reader = newGCSFullReadCloser(storageReader)
+			return
+		case errors.Is(err, storage.ErrObjectNotExist) || ctx.Err() != nil:
+			// Reading through the default endpoint would fail the same way.
+			return
+		}
+		logger.Warnf("Reading object %q through the default endpoint, as reading it through the regional endpoint failed: %v", req.Name, err)
+	}
+
+	// NewRangeReader creates a "storage.Reader" object which is also io.ReadCloser since it contains both Read() and Close() methods present in io.ReadCloser interface.
+	storageReader, err = readObjectHandle(bh.bucket, req).NewRangeReader(ctx, start, length)
+	if err == nil {
+		reader = newGCSFullReadCloser(storageReader)
+	}
+	return
+}
+
+// readObjectHandle returns the handle through the given bucket of the object
+// version read by req.
+func readObjectHandle(bucket *storage.BucketHandle, req *gcs.ReadObjectRequest) *storage.ObjectHandle {
+	obj := bucket.Object(req.Name)
 
 	// Switching to the requested generation of object.
 	if req.Generation != 0 {
@@ -96,13 +128,7 @@ func (bh *bucketHandle) NewReaderWithReadHandle(
 	if req.ReadHandle != nil {
 		obj = obj.ReadHandle(req.ReadHandle)
 	}
-
-	// NewRangeReader creates a "storage.Reader" object which is also io.ReadCloser since it contains both Read() and Close() methods present in io.ReadCloser interface.
-	storageReader, err := obj.NewRangeReader(ctx, start, length)
-	if err == nil {
-		reader = newGCSFullReadCloser(storageReader)
-	}
-	return
+	return obj
 }
 
 func (bh *bucketHandle) DeleteObject(ctx context.Context, req *gcs.DeleteObjectRequest) (err error) {
@@ -635,6 +661,21 @@ func (bh *bucketHandle) GetFolder(ctx context.Context, req *gcs.GetFolderRequest
 	return
 }
 
+func (bh *bucketHandle) GetLifecycleRules(ctx context.Context) (rules []gcs.LifecycleRule, err error) {
+	defer func() {
+		err = gcs.GetGCSError(err)
+	}()
+
+	attrs, err := bh.bucket.Attrs(ctx)
+	if err != nil {
+		err = fmt.Errorf("error getting attributes of bucket: %s, %w", bh.bucketName, err)
+		return
+	}
+
+	rules = gcs.GCSLifecycleRules(attrs.Lifecycle)
+	return
+}
+
 func (bh *bucketHandle) CreateFolder(ctx context.Context, folderName string) (folder *gcs.Folder, err error) {
 	defer func() {
 		err = gcs.GetGCSError(err)
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -122,6 +123,31 @@ func (testSuite *BucketHandleTest) TestNewReaderWithReadHandleMethodWithComplete
 	assert.Equal(testSuite.T(), ContentInTestObject, string(buf[:]))
 }
 
+func (testSuite *BucketHandleTest) TestNewReaderWithReadHandleFallsBackFromFailingReadBucket() {
+	createBucketHandle(testSuite, &controlpb.StorageLayout{})
+	// Nothing listens on the endpoint of the read bucket.
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint("http://127.0.0.1:1/storage/v1/"), option.WithoutAuthentication())
+	require.NoError(testSuite.T(), err)
+	defer client.Close()
+	testSuite.bucketHandle.readBucket = client.Bucket(TestBucketName).Retryer(storage.WithPolicy(storage.RetryNever))
+
+	rc, err := testSuite.bucketHandle.NewReaderWithReadHandle(context.Background(),
+		&gcs.ReadObjectRequest{
+			Name: TestObjectName,
+			Range: &gcs.ByteRange{
+				Start: uint64(0),
+				Limit: uint64(len(ContentInTestObject)),
+			},
+		})
+
+	require.NoError(testSuite.T(), err)
+	defer rc.Close()
+	buf := make([]byte, len(ContentInTestObject))
+	_, err = rc.Read(buf)
+	assert.Nil(testSuite.T(), err)
+	assert.Equal(testSuite.T(), ContentInTestObject, string(buf[:]))
+}
+
 func (testSuite *BucketHandleTest) TestNewReaderWithReadHandleMethodWithRangeRead() {
 	createBucketHandle(testSuite, &controlpb.StorageLayout{})
 	start := uint64(2)
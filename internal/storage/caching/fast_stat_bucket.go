@@ -598,6 +598,10 @@ func (b *fastStatBucket) getFolderFromGCS(ctx context.Context, req *gcs.GetFolde
 	return nil, err
 }
 
+func (b *fastStatBucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	return b.wrapped.GetLifecycleRules(ctx)
+}
+
 func (b *fastStatBucket) CreateFolder(ctx context.Context, folderName string) (f *gcs.Folder, err error) {
 	f, err = b.wrapped.CreateFolder(ctx, folderName)
 	// Throw away any existing record for this folder even if there was an error but do it after the API call.
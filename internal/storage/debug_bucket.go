@@ -327,6 +327,14 @@ func (b *debugBucket) RenameFolder(ctx context.Context, folderName string, desti
 	return o, err
 }
 
+func (b *debugBucket) GetLifecycleRules(ctx context.Context) (rules []gcs.LifecycleRule, err error) {
+	id, desc, start := b.startRequest("GetLifecycleRules()")
+	defer b.finishRequest(id, desc, start, &err)
+
+	rules, err = b.wrapped.GetLifecycleRules(ctx)
+	return
+}
+
 type debugMultiRangeDownloader struct {
 	object    string
 	bucket    *debugBucket
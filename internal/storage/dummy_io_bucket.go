@@ -220,6 +220,12 @@ func (d *dummyIOBucket) CreateFolder(ctx context.Context, folderName string) (*g
 	return d.wrapped.CreateFolder(ctx, folderName)
 }
 
+// GetLifecycleRules returns the lifecycle rules of the bucket.
+// Directly delegates to wrapped bucket.
+func (d *dummyIOBucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	return d.wrapped.GetLifecycleRules(ctx)
+}
+
 // GCSName returns the original GCS name for the object.
 // Directly delegates to wrapped bucket.
 func (d *dummyIOBucket) GCSName(object *gcs.MinObject) string {
@@ -45,6 +45,14 @@ func NewFakeBucket(clock timeutil.Clock, name string, bucketType gcs.BucketType)
 	return b
 }
 
+// NewFakeBucketWithLifecycleRules returns a fake bucket reporting the given
+// lifecycle rules.
+func NewFakeBucketWithLifecycleRules(clock timeutil.Clock, name string, bucketType gcs.BucketType, lifecycleRules []gcs.LifecycleRule) gcs.Bucket {
+	b := &bucket{clock: clock, name: name, bucketType: bucketType, lifecycleRules: lifecycleRules}
+	b.mu = syncutil.NewInvariantMutex(b.checkInvariants)
+	return b
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Helper types
 ////////////////////////////////////////////////////////////////////////
@@ -179,6 +187,9 @@ type bucket struct {
 	//
 	// INVARIANT: This is an upper bound for generation numbers in objects.
 	prevGeneration int64 // GUARDED_BY(mu)
+
+	// The lifecycle rules reported by the bucket.
+	lifecycleRules []gcs.LifecycleRule
 }
 
 func checkName(name string) (err error) {
@@ -1271,3 +1282,7 @@ func (b *bucket) NewMultiRangeDownloader(
 func (b *bucket) GCSName(obj *gcs.MinObject) string {
 	return obj.Name
 }
+
+func (b *bucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	return b.lifecycleRules, nil
+}
@@ -197,6 +197,13 @@ type Bucket interface {
 
 	CreateFolder(ctx context.Context, folderName string) (*Folder, error)
 
+	// GetLifecycleRules returns the lifecycle rules of the bucket applying to
+	// live objects based on their age and name only.
+	//
+	// Official documentation:
+	//     https://cloud.google.com/storage/docs/json_api/v1/buckets/get
+	GetLifecycleRules(ctx context.Context) ([]LifecycleRule, error)
+
 	// GCSName returns the original GCS name for the object.
 	//
 	// Some Bucket implementations modify the Name field of the MinObject before
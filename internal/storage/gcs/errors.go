@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/googleapi"
@@ -45,6 +46,35 @@ func (pe *PreconditionError) Error() string {
 	return fmt.Sprintf("gcs.PreconditionError: %v", pe.Err)
 }
 
+// A *RequesterPaysError value is an error that indicates the bucket has
+// requester pays enabled, but the request didn't specify a project to bill.
+type RequesterPaysError struct {
+	Err error
+}
+
+func (rpe *RequesterPaysError) Error() string {
+	return fmt.Sprintf("gcs.RequesterPaysError: the bucket has requester pays enabled, set --billing-project to the project to bill for accessing it: %v", rpe.Err)
+}
+
+// A *PermissionDeniedError value is an error that indicates the request was
+// denied for lack of permission or of valid credentials. Unlike transient
+// errors, retrying the request doesn't help.
+type PermissionDeniedError struct {
+	Err error
+}
+
+func (pde *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("gcs.PermissionDeniedError: %v", pde.Err)
+}
+
+// requesterPaysErrorMessage is part of the message of the error returned by GCS
+// for requests to a requester pays bucket not specifying a billing project.
+const requesterPaysErrorMessage = "requester pays bucket but no user project provided"
+
+func isRequesterPaysErrorMessage(msg string) bool {
+	return strings.Contains(strings.ToLower(msg), requesterPaysErrorMessage)
+}
+
 // GetGCSError converts an error returned by go-sdk into gcsfuse specific common gcs error.
 func GetGCSError(err error) error {
 	if err == nil {
@@ -59,6 +89,12 @@ func GetGCSError(err error) error {
 			return &NotFoundError{Err: err}
 		case http.StatusPreconditionFailed:
 			return &PreconditionError{Err: err}
+		case http.StatusForbidden, http.StatusUnauthorized:
+			return &PermissionDeniedError{Err: err}
+		case http.StatusBadRequest:
+			if isRequesterPaysErrorMessage(gErr.Message) {
+				return &RequesterPaysError{Err: err}
+			}
 		}
 	}
 
@@ -69,6 +105,12 @@ func GetGCSError(err error) error {
 			return &NotFoundError{Err: err}
 		case codes.FailedPrecondition:
 			return &PreconditionError{Err: err}
+		case codes.PermissionDenied, codes.Unauthenticated:
+			return &PermissionDeniedError{Err: err}
+		case codes.InvalidArgument:
+			if isRequesterPaysErrorMessage(rpcErr.Message()) {
+				return &RequesterPaysError{Err: err}
+			}
 		}
 	}
 
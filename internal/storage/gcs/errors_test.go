@@ -64,6 +64,16 @@ func TestGetGCSError(t *testing.T) {
 			inputErr:    &googleapi.Error{Code: http.StatusPreconditionFailed},
 			expectedErr: &PreconditionError{Err: &googleapi.Error{Code: http.StatusPreconditionFailed}},
 		},
+		{
+			name:        "googleapi.Error_Forbidden",
+			inputErr:    &googleapi.Error{Code: http.StatusForbidden},
+			expectedErr: &PermissionDeniedError{Err: &googleapi.Error{Code: http.StatusForbidden}},
+		},
+		{
+			name:        "googleapi.Error_Unauthorized",
+			inputErr:    &googleapi.Error{Code: http.StatusUnauthorized},
+			expectedErr: &PermissionDeniedError{Err: &googleapi.Error{Code: http.StatusUnauthorized}},
+		},
 		{
 			name:        "googleapi.Error_other_code",
 			inputErr:    &googleapi.Error{Code: http.StatusBadRequest},
@@ -74,6 +84,21 @@ func TestGetGCSError(t *testing.T) {
 			inputErr:    fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusNotFound}),
 			expectedErr: &NotFoundError{Err: fmt.Errorf("wrapped: %w", &googleapi.Error{Code: http.StatusNotFound})},
 		},
+		{
+			name:        "googleapi.Error_requester_pays",
+			inputErr:    &googleapi.Error{Code: http.StatusBadRequest, Message: "Bucket is a requester pays bucket but no user project provided."},
+			expectedErr: &RequesterPaysError{Err: &googleapi.Error{Code: http.StatusBadRequest, Message: "Bucket is a requester pays bucket but no user project provided."}},
+		},
+		{
+			name:        "grpc_status_requester_pays",
+			inputErr:    status.Error(codes.InvalidArgument, "Bucket is a requester pays bucket but no user project provided."),
+			expectedErr: &RequesterPaysError{Err: status.Error(codes.InvalidArgument, "Bucket is a requester pays bucket but no user project provided.")},
+		},
+		{
+			name:        "grpc_status_InvalidArgument_other_message",
+			inputErr:    status.Error(codes.InvalidArgument, "invalid argument"),
+			expectedErr: status.Error(codes.InvalidArgument, "invalid argument"),
+		},
 		{
 			name:        "grpc_status_NotFound",
 			inputErr:    status.Error(codes.NotFound, "not found"),
@@ -84,6 +109,16 @@ func TestGetGCSError(t *testing.T) {
 			inputErr:    status.Error(codes.FailedPrecondition, "failed precondition"),
 			expectedErr: &PreconditionError{Err: status.Error(codes.FailedPrecondition, "failed precondition")},
 		},
+		{
+			name:        "grpc_status_PermissionDenied",
+			inputErr:    status.Error(codes.PermissionDenied, "permission denied"),
+			expectedErr: &PermissionDeniedError{Err: status.Error(codes.PermissionDenied, "permission denied")},
+		},
+		{
+			name:        "grpc_status_Unauthenticated",
+			inputErr:    status.Error(codes.Unauthenticated, "unauthenticated"),
+			expectedErr: &PermissionDeniedError{Err: status.Error(codes.Unauthenticated, "unauthenticated")},
+		},
 		{
 			name:        "grpc_status_other_code",
 			inputErr:    status.Error(codes.Internal, "internal error"),
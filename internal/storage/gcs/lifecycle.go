@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// LifecycleDeleteAction is the type of the lifecycle action deleting objects.
+const LifecycleDeleteAction = storage.DeleteAction
+
+// LifecycleRule is a lifecycle rule of a bucket, applying an action to the
+// live objects older than AgeInDays whose name starts with one of
+// MatchesPrefix.
+type LifecycleRule struct {
+	// ActionType is the type of the action, e.g. LifecycleDeleteAction.
+	ActionType string
+
+	// AgeInDays is the age of the objects the rule applies to. Zero means any
+	// age.
+	AgeInDays int64
+
+	// MatchesPrefix holds the prefixes of the names of the objects the rule
+	// applies to. Empty means any name.
+	MatchesPrefix []string
+}
+
+// GCSLifecycleRules returns the rules of the given bucket lifecycle which
+// apply to live objects based on their age and name only. Rules with other
+// conditions, e.g. on the storage class or the creation date, are left out,
+// as they don't apply to all the objects matching their name.
+func GCSLifecycleRules(lifecycle storage.Lifecycle) []LifecycleRule {
+	var rules []LifecycleRule
+	for _, r := range lifecycle.Rules {
+		c := r.Condition
+		if c.Liveness == storage.Archived ||
+			!c.CreatedBefore.Equal(time.Time{}) ||
+			!c.CustomTimeBefore.Equal(time.Time{}) ||
+			!c.NoncurrentTimeBefore.Equal(time.Time{}) ||
+			c.DaysSinceCustomTime != 0 ||
+			c.DaysSinceNoncurrentTime != 0 ||
+			c.NumNewerVersions != 0 ||
+			len(c.MatchesStorageClasses) != 0 ||
+			len(c.MatchesSuffix) != 0 {
+			continue
+		}
+		rules = append(rules, LifecycleRule{
+			ActionType:    r.Action.Type,
+			AgeInDays:     c.AgeInDays,
+			MatchesPrefix: c.MatchesPrefix,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCSLifecycleRules(t *testing.T) {
+	deleteAction := storage.LifecycleAction{Type: storage.DeleteAction}
+	lifecycle := storage.Lifecycle{Rules: []storage.LifecycleRule{
+		{Action: deleteAction, Condition: storage.LifecycleCondition{AgeInDays: 1, MatchesPrefix: []string{".gcsfuse_tmp/"}}},
+		{Action: deleteAction, Condition: storage.LifecycleCondition{AgeInDays: 2, Liveness: storage.Live}},
+		{Action: deleteAction, Condition: storage.LifecycleCondition{AgeInDays: 1, Liveness: storage.Archived}},
+		{Action: deleteAction, Condition: storage.LifecycleCondition{CreatedBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Action: deleteAction, Condition: storage.LifecycleCondition{AgeInDays: 1, MatchesSuffix: []string{".log"}}},
+		{Action: deleteAction, Condition: storage.LifecycleCondition{AgeInDays: 1, NumNewerVersions: 3}},
+		{Action: storage.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "NEARLINE"}, Condition: storage.LifecycleCondition{AgeInDays: 30}},
+	}}
+
+	rules := GCSLifecycleRules(lifecycle)
+
+	assert.Equal(t, []LifecycleRule{
+		{ActionType: LifecycleDeleteAction, AgeInDays: 1, MatchesPrefix: []string{".gcsfuse_tmp/"}},
+		{ActionType: LifecycleDeleteAction, AgeInDays: 2},
+		{ActionType: storage.SetStorageClassAction, AgeInDays: 30},
+	}, rules)
+}
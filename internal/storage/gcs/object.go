@@ -72,7 +72,17 @@ type Object struct {
 	ContentDisposition string
 	CustomTime         string
 	EventBasedHold     bool
+	TemporaryHold      bool
 	Acl                []*storagev1.ObjectAccessControl
+
+	// RetentionExpirationTime is the earliest time the retention period of the
+	// bucket's retention policy expires for the object. Zero if none applies.
+	RetentionExpirationTime time.Time
+
+	// RetentionMode and RetainUntil describe the retention configuration of the
+	// object itself. RetentionMode is empty if the object has none.
+	RetentionMode string
+	RetainUntil   time.Time
 }
 
 // MinObject is a record representing subset of properties of a particular
@@ -107,7 +117,17 @@ type ExtendedObjectAttributes struct {
 	ContentDisposition string
 	CustomTime         string
 	EventBasedHold     bool
+	TemporaryHold      bool
 	Acl                []*storagev1.ObjectAccessControl
+
+	// RetentionExpirationTime is the earliest time the retention period of the
+	// bucket's retention policy expires for the object. Zero if none applies.
+	RetentionExpirationTime time.Time
+
+	// RetentionMode and RetainUntil describe the retention configuration of the
+	// object itself. RetentionMode is empty if the object has none.
+	RetentionMode string
+	RetainUntil   time.Time
 }
 
 func (mo MinObject) HasContentEncodingGzip() bool {
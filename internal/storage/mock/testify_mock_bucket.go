@@ -140,6 +140,14 @@ func (m *TestifyMockBucket) RenameFolder(ctx context.Context, folderName string,
 	return nil, args.Error(1)
 }
 
+func (m *TestifyMockBucket) GetLifecycleRules(ctx context.Context) ([]gcs.LifecycleRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) != nil {
+		return args.Get(0).([]gcs.LifecycleRule), nil
+	}
+	return nil, args.Error(1)
+}
+
 func (m *TestifyMockBucket) CreateFolder(ctx context.Context, folderName string) (*gcs.Folder, error) {
 	args := m.Called(ctx, folderName)
 	if args.Get(0) != nil {
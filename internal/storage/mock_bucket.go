@@ -520,6 +520,33 @@ func (m *mockBucket) GetFolder(
 	return
 }
 
+func (m *mockBucket) GetLifecycleRules(ctx context.Context) (o0 []gcs.LifecycleRule, o1 error) {
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"GetLifecycleRules",
+		file,
+		line,
+		[]any{ctx})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockBucket.GetLifecycleRules: invalid return values: %v", retVals))
+	}
+
+	if retVals[0] != nil {
+		o0 = retVals[0].([]gcs.LifecycleRule)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+	return
+}
+
 func (m *mockBucket) CreateFolder(ctx context.Context, prefix string) (o0 *gcs.Folder, o1 error) {
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+)
+
+const (
+	dualRegionLocationType  = "dual-region"
+	multiRegionLocationType = "multi-region"
+)
+
+// regionDetector returns the region gcsfuse runs in.
+type regionDetector func(ctx context.Context) (string, error)
+
+// detectGCERegion returns the region of the GCE zone gcsfuse runs in, as per
+// the metadata server.
+func detectGCERegion(ctx context.Context) (string, error) {
+	if !metadata.OnGCE() {
+		return "", errors.New("not running on GCE")
+	}
+	zone, err := metadata.ZoneWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching the zone from the metadata server: %w", err)
+	}
+	// Zones are named after their region, e.g. us-central1-a in us-central1.
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 {
+		return "", fmt.Errorf("unexpected zone %q", zone)
+	}
+	return zone[:i], nil
+}
+
+// predefinedDualRegions maps the predefined dual-region locations to their
+// regions.
+var predefinedDualRegions = map[string][]string{
+	"ASIA1": {"asia-northeast1", "asia-northeast2"},
+	"EUR4":  {"europe-north1", "europe-west4"},
+	"NAM4":  {"us-central1", "us-east1"},
+}
+
+// multiRegionPrefixes maps the multi-region locations to the prefix of the names
+// of their regions.
+var multiRegionPrefixes = map[string]string{
+	"ASIA": "asia-",
+	"EU":   "europe-",
+	"US":   "us-",
+}
+
+// nonEUEuropeRegions are the European regions outside of the EU multi-region.
+var nonEUEuropeRegions = []string{"europe-west2", "europe-west6"}
+
+// bucketLocation is where the data of a bucket is stored, as per its storage
+// layout.
+type bucketLocation struct {
+	// locationType is e.g. region, dual-region or multi-region.
+	locationType string
+	// location is e.g. us-central1, NAM4 or US.
+	location string
+	// dataLocations are the regions of a configurable dual-region bucket.
+	dataLocations []string
+}
+
+// contains returns whether the data of the bucket is stored in the given
+// region.
+func (l bucketLocation) contains(region string) bool {
+	switch l.locationType {
+	case dualRegionLocationType:
+		regions := l.dataLocations
+		if len(regions) == 0 {
+			regions = predefinedDualRegions[strings.ToUpper(l.location)]
+		}
+		return slices.ContainsFunc(regions, func(r string) bool {
+			return strings.EqualFold(r, region)
+		})
+	case multiRegionLocationType:
+		location := strings.ToUpper(l.location)
+		prefix, ok := multiRegionPrefixes[location]
+		if !ok || !strings.HasPrefix(region, prefix) {
+			return false
+		}
+		return location != "EU" || !slices.Contains(nonEUEuropeRegions, region)
+	}
+	return false
+}
+
+// regionalReadEndpoint returns the JSON API endpoint of the given region.
+func regionalReadEndpoint(region string) string {
+	return fmt.Sprintf("https://storage.%s.rep.googleapis.com/storage/v1/", region)
+}
+
+// selectReadEndpoint returns the endpoint of the region detected with detect
+// for the reads of a bucket in the given location. Only dual-region and
+// multi-region buckets storing their data in the detected region have their
+// reads sent to a regional endpoint. Returns the empty string, along with the
+// reason, if the reads should use the default endpoint.
+func selectReadEndpoint(ctx context.Context, detect regionDetector, location bucketLocation) (string, error) {
+	if location.locationType != dualRegionLocationType && location.locationType != multiRegionLocationType {
+		return "", fmt.Errorf("bucket location type is %q", location.locationType)
+	}
+	region, err := detect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("detecting the region: %w", err)
+	}
+	if !location.contains(region) {
+		return "", fmt.Errorf("region %q is outside of the bucket location %q", region, location.location)
+	}
+	return regionalReadEndpoint(region), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/gcs"
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/storage/storageutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedRegion(region string, err error) regionDetector {
+	return func(context.Context) (string, error) {
+		return region, err
+	}
+}
+
+func TestSelectReadEndpoint(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clientConfig storageutil.StorageClientConfig
+		bucketType   gcs.BucketType
+		location     bucketLocation
+		detect       regionDetector
+		want         string
+	}{
+		{
+			name:         "multi_region_bucket",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "US"},
+			detect:       fixedRegion("us-central1", nil),
+			want:         "https://storage.us-central1.rep.googleapis.com/storage/v1/",
+		},
+		{
+			name:         "dual_region_bucket",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP2},
+			location:     bucketLocation{locationType: dualRegionLocationType, location: "EUR4"},
+			detect:       fixedRegion("europe-west4", nil),
+			want:         "https://storage.europe-west4.rep.googleapis.com/storage/v1/",
+		},
+		{
+			name:         "configurable_dual_region_bucket",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: dualRegionLocationType, location: "US", dataLocations: []string{"US-EAST1", "US-EAST4"}},
+			detect:       fixedRegion("us-east4", nil),
+			want:         "https://storage.us-east4.rep.googleapis.com/storage/v1/",
+		},
+		{
+			name:         "region_outside_of_multi_region",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "US"},
+			detect:       fixedRegion("europe-west1", nil),
+		},
+		{
+			name:         "region_outside_of_eu_multi_region",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "EU"},
+			detect:       fixedRegion("europe-west2", nil),
+		},
+		{
+			name:         "region_outside_of_dual_region",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: dualRegionLocationType, location: "NAM4"},
+			detect:       fixedRegion("us-west1", nil),
+		},
+		{
+			name:         "region_outside_of_configurable_dual_region",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: dualRegionLocationType, location: "US", dataLocations: []string{"US-EAST1", "US-EAST4"}},
+			detect:       fixedRegion("us-central1", nil),
+		},
+		{
+			name:         "region_bucket",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: "region", location: "us-central1"},
+			detect:       fixedRegion("us-central1", nil),
+		},
+		{
+			name:         "region_detection_failure",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "US"},
+			detect:       fixedRegion("", errors.New("not running on GCE")),
+		},
+		{
+			name:         "custom_endpoint",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1, CustomEndpoint: "https://localhost:9000"},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "US"},
+			detect:       fixedRegion("us-central1", nil),
+		},
+		{
+			name:         "grpc_client_protocol",
+			clientConfig: storageutil.StorageClientConfig{ClientProtocol: cfg.GRPC},
+			location:     bucketLocation{locationType: multiRegionLocationType, location: "US"},
+			detect:       fixedRegion("us-central1", nil),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sh := &storageClient{clientConfig: tc.clientConfig, detectRegion: tc.detect}
+
+			endpoint, err := sh.selectReadEndpoint(context.Background(), &tc.bucketType, tc.location)
+
+			assert.Equal(t, tc.want, endpoint)
+			// Reads fall back to the default endpoint for a reason.
+			assert.Equal(t, tc.want == "", err != nil)
+		})
+	}
+}
+
+func TestReadBucketHandleDisabled(t *testing.T) {
+	sh := &storageClient{detectRegion: fixedRegion("us-central1", nil)}
+
+	readBucket := sh.readBucketHandle(context.Background(), "bucket", "", &gcs.BucketType{}, bucketLocation{locationType: multiRegionLocationType, location: "US"})
+
+	assert.Nil(t, readBucket)
+}
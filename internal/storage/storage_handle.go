@@ -23,6 +23,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -82,6 +83,13 @@ type storageClient struct {
 	rawStorageControlClientWithGaxRetries *control.StorageControlClient
 	// storageControlClient is with retry for GetStorageLayout and with handling for billing project.
 	storageControlClient StorageControlClient
+	// regionalReadClient reads the objects of dual-region and multi-region
+	// buckets through the endpoint of the region detected with detectRegion,
+	// if RegionAwareReadEndpoint is set. Created on first use, guarded by
+	// regionalReadClientMu.
+	regionalReadClient   *storage.Client
+	regionalReadClientMu sync.Mutex
+	detectRegion         regionDetector
 }
 
 // Return clientOpts for both gRPC client and control client.
@@ -342,9 +350,11 @@ func createHTTPClientHandle(ctx context.Context, clientConfig *storageutil.Stora
 	return
 }
 
-func (sh *storageClient) lookupBucketType(bucketName string) (*gcs.BucketType, error) {
+// lookupBucketType returns the type of the bucket, along with its location,
+// if known.
+func (sh *storageClient) lookupBucketType(bucketName string) (*gcs.BucketType, bucketLocation, error) {
 	if sh.storageControlClient == nil {
-		return &gcs.BucketType{}, nil // Assume defaults
+		return &gcs.BucketType{}, bucketLocation{}, nil // Assume defaults
 	}
 
 	startTime := time.Now()
@@ -353,7 +363,7 @@ func (sh *storageClient) lookupBucketType(bucketName string) (*gcs.BucketType, e
 	duration := time.Since(startTime)
 
 	if err != nil {
-		return nil, err
+		return nil, bucketLocation{}, err
 	}
 
 	logger.Infof("GetStorageLayout -> (%s) %v msec", bucketName, duration.Milliseconds())
@@ -362,6 +372,10 @@ func (sh *storageClient) lookupBucketType(bucketName string) (*gcs.BucketType, e
 		Hierarchical: storageLayout.GetHierarchicalNamespace().GetEnabled(),
 		Zonal:        storageLayout.GetLocationType() == zonalLocationType,
 		Pirlo:        sh.clientConfig.ExperimentalEnablePirlo,
+	}, bucketLocation{
+		locationType:  storageLayout.GetLocationType(),
+		location:      storageLayout.GetLocation(),
+		dataLocations: storageLayout.GetCustomPlacementConfig().GetDataLocations(),
 	}, nil
 }
 
@@ -423,6 +437,7 @@ func NewStorageHandle(ctx context.Context, clientConfig storageutil.StorageClien
 		rawStorageControlClientWithGaxRetries:    rawStorageControlClientWithGaxRetries,
 		storageControlClient:                     controlClient,
 		clientConfig:                             clientConfig,
+		detectRegion:                             detectGCERegion,
 	}
 	return
 }
@@ -506,7 +521,7 @@ func (sh *storageClient) controlClientForBucketHandle(bucketType *gcs.BucketType
 
 func (sh *storageClient) BucketHandle(ctx context.Context, bucketName string, billingProject string) (bh *bucketHandle, err error) {
 	var client *storage.Client
-	bucketType, err := sh.lookupBucketType(bucketName)
+	bucketType, location, err := sh.lookupBucketType(bucketName)
 	if err != nil {
 		return nil, fmt.Errorf("storageLayout call failed: %s", err)
 	}
@@ -524,6 +539,7 @@ func (sh *storageClient) BucketHandle(ctx context.Context, bucketName string, bi
 
 	bh = &bucketHandle{
 		bucket:         storageBucketHandle,
+		readBucket:     sh.readBucketHandle(ctx, bucketName, billingProject, bucketType, location),
 		bucketName:     bucketName,
 		controlClient:  controlClient,
 		bucketType:     bucketType,
@@ -533,3 +549,66 @@ func (sh *storageClient) BucketHandle(ctx context.Context, bucketName string, bi
 
 	return
 }
+
+// readBucketHandle returns the handle of the bucket through the endpoint of the
+// region gcsfuse runs in, if RegionAwareReadEndpoint is set and the endpoint
+// suits the bucket, or nil for the reads to go through the default endpoint.
+func (sh *storageClient) readBucketHandle(ctx context.Context, bucketName string, billingProject string, bucketType *gcs.BucketType, location bucketLocation) *storage.BucketHandle {
+	if !sh.clientConfig.RegionAwareReadEndpoint {
+		return nil
+	}
+
+	endpoint, err := sh.selectReadEndpoint(ctx, bucketType, location)
+	if err != nil {
+		logger.Infof("Reads of bucket %q use the default endpoint: %v", bucketName, err)
+		return nil
+	}
+	client, err := sh.getRegionalReadClient(ctx, endpoint)
+	if err != nil {
+		logger.Warnf("Reads of bucket %q use the default endpoint, as creating a client for %q failed: %v", bucketName, endpoint, err)
+		return nil
+	}
+	logger.Infof("Reads of bucket %q use the regional endpoint %q.", bucketName, endpoint)
+
+	readBucket := client.Bucket(bucketName)
+	if billingProject != "" {
+		readBucket = readBucket.UserProject(billingProject)
+	}
+	return readBucket
+}
+
+// getRegionalReadClient returns the client of the given regional endpoint,
+// creating it on first use. The region being that of the machine, all the
+// buckets share the client.
+func (sh *storageClient) getRegionalReadClient(ctx context.Context, endpoint string) (*storage.Client, error) {
+	sh.regionalReadClientMu.Lock()
+	defer sh.regionalReadClientMu.Unlock()
+
+	if sh.regionalReadClient == nil {
+		clientConfig := sh.clientConfig
+		clientConfig.CustomEndpoint = endpoint
+		client, err := createHTTPClientHandle(ctx, &clientConfig)
+		if err != nil {
+			return nil, err
+		}
+		sh.regionalReadClient = client
+	}
+	return sh.regionalReadClient, nil
+}
+
+// selectReadEndpoint returns the regional endpoint for the reads of the
+// bucket, or an error giving the reason for the reads to go through the
+// default endpoint.
+func (sh *storageClient) selectReadEndpoint(ctx context.Context, bucketType *gcs.BucketType, location bucketLocation) (string, error) {
+	switch {
+	case sh.clientConfig.CustomEndpoint != "":
+		return "", fmt.Errorf("custom endpoint %q is set", sh.clientConfig.CustomEndpoint)
+	case bucketType.IsRapid() || sh.clientConfig.ClientProtocol == cfg.GRPC:
+		return "", errors.New("regional endpoints are only used with the HTTP client protocols")
+	}
+	detect := sh.detectRegion
+	if detect == nil {
+		detect = detectGCERegion
+	}
+	return selectReadEndpoint(ctx, detect, location)
+}
@@ -17,12 +17,16 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/storage"
 	control "cloud.google.com/go/storage/control/apiv2"
 	"cloud.google.com/go/storage/control/apiv2/controlpb"
 	"github.com/googlecloudplatform/gcsfuse/v3/cfg"
@@ -34,6 +38,8 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 )
@@ -180,7 +186,7 @@ func (testSuite *StorageHandleTest) TestLookupBucketType_PirloEnabled() {
 	client.storageControlClient = testSuite.mockClient
 	testSuite.mockStorageLayout(gcs.BucketType{Zonal: true})
 
-	bt, err := client.lookupBucketType(TestBucketName)
+	bt, _, err := client.lookupBucketType(TestBucketName)
 
 	assert.NoError(testSuite.T(), err)
 	assert.True(testSuite.T(), bt.Pirlo)
@@ -1109,3 +1115,58 @@ func (testSuite *StorageHandleTest) TestControlClientForBucketHandle_NonZonalBuc
 	assert.True(testSuite.T(), controlClientWithRetry.enableRetriesOnStorageLayoutAPI, "Retries should be enabled for storage layout API on zonal buckets")
 	assert.Same(testSuite.T(), mockRawControlClientWithoutRetries, controlClientWithRetry.raw)
 }
+
+// userProjectRecorder records the project billed for the requests it forwards
+// to the fake storage server, passed as a query parameter to the JSON API and
+// as a header to the XML API.
+type userProjectRecorder struct {
+	base         http.RoundTripper
+	mu           sync.Mutex
+	userProjects []string
+}
+
+func (r *userProjectRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	userProject := req.URL.Query().Get("userProject")
+	if userProject == "" {
+		userProject = req.Header.Get("X-Goog-User-Project")
+	}
+	r.userProjects = append(r.userProjects, userProject)
+	r.mu.Unlock()
+	return r.base.RoundTrip(req)
+}
+
+func (testSuite *StorageHandleTest) TestBucketHandleWithBillingProjectBillsReadsAndDeletes() {
+	server, err := createFakeStorageServer(getTestFakeStorageObject())
+	require.NoError(testSuite.T(), err)
+	defer server.Stop()
+	recorder := &userProjectRecorder{base: server.HTTPClient().Transport}
+	client, err := storage.NewClient(testSuite.ctx, option.WithHTTPClient(&http.Client{Transport: recorder}), option.WithCredentials(&google.Credentials{}))
+	require.NoError(testSuite.T(), err)
+	sh := &storageClient{
+		httpClient:           client,
+		storageControlClient: testSuite.mockClient,
+		clientConfig:         storageutil.StorageClientConfig{ClientProtocol: cfg.HTTP1, WriteConfig: &cfg.WriteConfig{}},
+	}
+	testSuite.mockStorageLayout(gcs.BucketType{})
+	bh, err := sh.BucketHandle(testSuite.ctx, TestBucketName, projectID)
+	require.NoError(testSuite.T(), err)
+
+	// Buffered reads download ranges of objects, and garbage collection deletes
+	// them.
+	reader, err := bh.NewReaderWithReadHandle(testSuite.ctx, &gcs.ReadObjectRequest{Name: TestObjectName, Range: &gcs.ByteRange{Start: 0, Limit: 5}})
+	require.NoError(testSuite.T(), err)
+	content, err := io.ReadAll(reader)
+	require.NoError(testSuite.T(), err)
+	require.NoError(testSuite.T(), reader.Close())
+	err = bh.DeleteObject(testSuite.ctx, &gcs.DeleteObjectRequest{Name: TestSubObjectName})
+
+	require.NoError(testSuite.T(), err)
+	assert.Equal(testSuite.T(), ContentInTestObject[:5], string(content))
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	require.NotEmpty(testSuite.T(), recorder.userProjects)
+	for _, userProject := range recorder.userProjects {
+		assert.Equal(testSuite.T(), projectID, userProject)
+	}
+}
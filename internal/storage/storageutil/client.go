@@ -90,6 +90,10 @@ type StorageClientConfig struct {
 
 	EnableHTTPDNSCache bool
 
+	// RegionAwareReadEndpoint sends the reads of dual-region and multi-region
+	// buckets to the endpoint of the region gcsfuse runs in.
+	RegionAwareReadEndpoint bool
+
 	EnableGrpcMetrics bool
 
 	// IsGKE inspects the mountPoint and indicates if running in a GKE environment.
@@ -78,30 +78,41 @@ func ObjectAttrsToBucketObject(attrs *storage.ObjectAttrs) *gcs.Object {
 	// Making a local copy of crc to avoid keeping a reference to attrs instance.
 	crc := attrs.CRC32C
 
+	var retentionMode string
+	var retainUntil time.Time
+	if attrs.Retention != nil {
+		retentionMode = attrs.Retention.Mode
+		retainUntil = attrs.Retention.RetainUntil
+	}
+
 	// Setting the parameters in Object and doing conversions as necessary.
 	return &gcs.Object{
-		Name:               attrs.Name,
-		ContentType:        attrs.ContentType,
-		ContentLanguage:    attrs.ContentLanguage,
-		CacheControl:       attrs.CacheControl,
-		Owner:              attrs.Owner,
-		Size:               uint64(attrs.Size),
-		ContentEncoding:    attrs.ContentEncoding,
-		MD5:                &md5,
-		CRC32C:             &crc,
-		MediaLink:          attrs.MediaLink,
-		Metadata:           attrs.Metadata,
-		Generation:         attrs.Generation,
-		MetaGeneration:     attrs.Metageneration,
-		StorageClass:       attrs.StorageClass,
-		Deleted:            attrs.Deleted,
-		Updated:            attrs.Updated,
-		Finalized:          attrs.Finalized,
-		ComponentCount:     attrs.ComponentCount,
-		ContentDisposition: attrs.ContentDisposition,
-		CustomTime:         string(attrs.CustomTime.Format(time.RFC3339)),
-		EventBasedHold:     attrs.EventBasedHold,
-		Acl:                acl,
+		Name:                    attrs.Name,
+		ContentType:             attrs.ContentType,
+		ContentLanguage:         attrs.ContentLanguage,
+		CacheControl:            attrs.CacheControl,
+		Owner:                   attrs.Owner,
+		Size:                    uint64(attrs.Size),
+		ContentEncoding:         attrs.ContentEncoding,
+		MD5:                     &md5,
+		CRC32C:                  &crc,
+		MediaLink:               attrs.MediaLink,
+		Metadata:                attrs.Metadata,
+		Generation:              attrs.Generation,
+		MetaGeneration:          attrs.Metageneration,
+		StorageClass:            attrs.StorageClass,
+		Deleted:                 attrs.Deleted,
+		Updated:                 attrs.Updated,
+		Finalized:               attrs.Finalized,
+		ComponentCount:          attrs.ComponentCount,
+		ContentDisposition:      attrs.ContentDisposition,
+		CustomTime:              string(attrs.CustomTime.Format(time.RFC3339)),
+		EventBasedHold:          attrs.EventBasedHold,
+		TemporaryHold:           attrs.TemporaryHold,
+		Acl:                     acl,
+		RetentionExpirationTime: attrs.RetentionExpirationTime,
+		RetentionMode:           retentionMode,
+		RetainUntil:             retainUntil,
 	}
 }
 
@@ -184,19 +195,23 @@ func ConvertObjToExtendedObjectAttributes(o *gcs.Object) *gcs.ExtendedObjectAttr
 	}
 
 	return &gcs.ExtendedObjectAttributes{
-		ContentType:        o.ContentType,
-		ContentLanguage:    o.ContentLanguage,
-		CacheControl:       o.CacheControl,
-		Owner:              o.Owner,
-		MD5:                o.MD5,
-		MediaLink:          o.MediaLink,
-		StorageClass:       o.StorageClass,
-		Deleted:            o.Deleted,
-		ComponentCount:     o.ComponentCount,
-		ContentDisposition: o.ContentDisposition,
-		CustomTime:         o.CustomTime,
-		EventBasedHold:     o.EventBasedHold,
-		Acl:                o.Acl,
+		ContentType:             o.ContentType,
+		ContentLanguage:         o.ContentLanguage,
+		CacheControl:            o.CacheControl,
+		Owner:                   o.Owner,
+		MD5:                     o.MD5,
+		MediaLink:               o.MediaLink,
+		StorageClass:            o.StorageClass,
+		Deleted:                 o.Deleted,
+		ComponentCount:          o.ComponentCount,
+		ContentDisposition:      o.ContentDisposition,
+		CustomTime:              o.CustomTime,
+		EventBasedHold:          o.EventBasedHold,
+		TemporaryHold:           o.TemporaryHold,
+		Acl:                     o.Acl,
+		RetentionExpirationTime: o.RetentionExpirationTime,
+		RetentionMode:           o.RetentionMode,
+		RetainUntil:             o.RetainUntil,
 	}
 }
 
@@ -207,28 +222,32 @@ func ConvertMinObjectAndExtendedObjectAttributesToObject(m *gcs.MinObject,
 	}
 
 	return &gcs.Object{
-		Name:               m.Name,
-		Size:               m.Size,
-		Generation:         m.Generation,
-		MetaGeneration:     m.MetaGeneration,
-		Updated:            m.Updated,
-		Finalized:          m.Finalized,
-		Metadata:           m.Metadata,
-		ContentEncoding:    m.ContentEncoding,
-		ContentType:        e.ContentType,
-		ContentLanguage:    e.ContentLanguage,
-		CacheControl:       e.CacheControl,
-		Owner:              e.Owner,
-		MD5:                e.MD5,
-		CRC32C:             m.CRC32C,
-		MediaLink:          e.MediaLink,
-		StorageClass:       e.StorageClass,
-		Deleted:            e.Deleted,
-		ComponentCount:     e.ComponentCount,
-		ContentDisposition: e.ContentDisposition,
-		CustomTime:         e.CustomTime,
-		EventBasedHold:     e.EventBasedHold,
-		Acl:                e.Acl,
+		Name:                    m.Name,
+		Size:                    m.Size,
+		Generation:              m.Generation,
+		MetaGeneration:          m.MetaGeneration,
+		Updated:                 m.Updated,
+		Finalized:               m.Finalized,
+		Metadata:                m.Metadata,
+		ContentEncoding:         m.ContentEncoding,
+		ContentType:             e.ContentType,
+		ContentLanguage:         e.ContentLanguage,
+		CacheControl:            e.CacheControl,
+		Owner:                   e.Owner,
+		MD5:                     e.MD5,
+		CRC32C:                  m.CRC32C,
+		MediaLink:               e.MediaLink,
+		StorageClass:            e.StorageClass,
+		Deleted:                 e.Deleted,
+		ComponentCount:          e.ComponentCount,
+		ContentDisposition:      e.ContentDisposition,
+		CustomTime:              e.CustomTime,
+		EventBasedHold:          e.EventBasedHold,
+		TemporaryHold:           e.TemporaryHold,
+		Acl:                     e.Acl,
+		RetentionExpirationTime: e.RetentionExpirationTime,
+		RetentionMode:           e.RetentionMode,
+		RetainUntil:             e.RetainUntil,
 	}
 }
 
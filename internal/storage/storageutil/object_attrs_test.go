@@ -105,6 +105,7 @@ func (t objectAttrsTest) TestObjectAttrsToBucketObjectMethod() {
 		Etag:                    "Etag",
 		CustomTime:              timeAttr,
 		ComponentCount:          7,
+		Retention:               &storage.ObjectRetention{Mode: "Locked", RetainUntil: timeAttr},
 	}
 	customeTimeExpected := string(attrs.CustomTime.Format(time.RFC3339))
 
@@ -139,6 +140,10 @@ func (t objectAttrsTest) TestObjectAttrsToBucketObjectMethod() {
 	ExpectEq(object.ContentDisposition, attrs.ContentDisposition)
 	ExpectEq(object.CustomTime, customeTimeExpected)
 	ExpectEq(object.EventBasedHold, attrs.EventBasedHold)
+	ExpectEq(object.TemporaryHold, attrs.TemporaryHold)
+	ExpectEq(object.RetentionExpirationTime.String(), attrs.RetentionExpirationTime.String())
+	ExpectEq(object.RetentionMode, attrs.Retention.Mode)
+	ExpectEq(object.RetainUntil.String(), attrs.Retention.RetainUntil.String())
 	ExpectEq(object.Acl, acl)
 	ExpectEq(object.ComponentCount, attrs.ComponentCount)
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
 )
@@ -33,12 +34,25 @@ type staticWorkerPool struct {
 	// Stop channel to notify all the workers to stop.
 	stop chan bool
 
+	// mu guards stopped. Schedule holds it in read mode while sending a task, so
+	// that Stop never closes the task channels under an in-flight Schedule.
+	mu sync.RWMutex
+
+	// GUARDED by (mu)
+	stopped bool
+
 	// Wait group to wait for all workers to finish.
 	wg sync.WaitGroup
 
 	// Channels for normal and priority tasks.
 	priorityCh chan Task
 	normalCh   chan Task
+
+	// watchdog, if non-nil, detects the pool being stuck.
+	watchdog *watchdog
+
+	// running is the number of tasks being executed.
+	running atomic.Int64
 }
 
 // NewStaticWorkerPool creates a new thread pool
@@ -67,13 +81,14 @@ func NewStaticWorkerPool(priorityWorker uint32, normalWorker uint32, readGlobalM
 
 // NewStaticWorkerPoolForCurrentCPU creates and starts a new worker pool. The
 // number of workers is determined based on the number of available CPUs and
-// the provided readGlobalMaxBlocks.
-func NewStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks int64) (WorkerPool, error) {
-	return newStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks, runtime.NumCPU)
+// the provided readGlobalMaxBlocks. The pool is watched as per
+// watchdogConfig.
+func NewStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks int64, watchdogConfig WatchdogConfig) (WorkerPool, error) {
+	return newStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks, watchdogConfig, runtime.NumCPU)
 }
 
 // newStaticWorkerPoolForCurrentCPU is an unexported helper for testing.
-func newStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks int64, numCPU func() int) (WorkerPool, error) {
+func newStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks int64, watchdogConfig WatchdogConfig, numCPU func() int) (WorkerPool, error) {
 	// It's a general heuristic to use 2-3 times the number of CPUs for I/O-bound tasks.
 	// We use 3x here as a balance between parallelism and resource consumption.
 	const workersPerCPU = 3
@@ -95,10 +110,20 @@ func newStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks int64, numCPU func() i
 		return nil, err
 	}
 
+	wp.EnableWatchdog(watchdogConfig)
 	wp.Start()
 	return wp, nil
 }
 
+// EnableWatchdog watches the pool, once started, for being stuck as per
+// config. It must be called before Start. A zero StuckTimeout is a no-op.
+func (swp *staticWorkerPool) EnableWatchdog(config WatchdogConfig) {
+	if config.StuckTimeout <= 0 {
+		return
+	}
+	swp.watchdog = newWatchdog(config, func() int { return len(swp.priorityCh) + len(swp.normalCh) })
+}
+
 // Start all the workers and wait till they start receiving requests
 func (swp *staticWorkerPool) Start() {
 	for i := uint32(0); i < swp.priorityWorker; i++ {
@@ -110,10 +135,26 @@ func (swp *staticWorkerPool) Start() {
 		swp.wg.Add(1)
 		go swp.do(false)
 	}
+
+	if swp.watchdog != nil {
+		swp.watchdog.start()
+	}
 }
 
 // Stop all the workers threads and wait for them to finish processing.
 func (swp *staticWorkerPool) Stop() {
+	swp.mu.Lock()
+	if swp.stopped {
+		swp.mu.Unlock()
+		return
+	}
+	swp.stopped = true
+	swp.mu.Unlock()
+
+	if swp.watchdog != nil {
+		swp.watchdog.stopAndWait()
+	}
+
 	// Notify all workers to stop.
 	logger.Infof("staticWorkerPool: stopping all the workers.")
 	close(swp.stop)
@@ -126,8 +167,15 @@ func (swp *staticWorkerPool) Stop() {
 }
 
 // Schedule schedules tasks to the worker pool.
-// Pass urgent as true for priority scheduling.
-func (swp *staticWorkerPool) Schedule(urgent bool, task Task) {
+// Pass urgent as true for priority scheduling. Returns ErrPoolStopped if the
+// pool is stopped.
+func (swp *staticWorkerPool) Schedule(urgent bool, task Task) error {
+	swp.mu.RLock()
+	defer swp.mu.RUnlock()
+	if swp.stopped {
+		return ErrPoolStopped
+	}
+
 	// urgent specifies the priority of this task.
 	// true means high priority and false means low priority
 	if urgent {
@@ -135,6 +183,7 @@ func (swp *staticWorkerPool) Schedule(urgent bool, task Task) {
 	} else {
 		swp.normalCh <- task
 	}
+	return nil
 }
 
 // do is the core routine that runs in each worker thread.
@@ -153,7 +202,7 @@ func (swp *staticWorkerPool) do(priority bool) {
 				case <-swp.stop:
 					return
 				case task := <-swp.priorityCh:
-					task.Execute()
+					swp.execute(task)
 				}
 			}
 		}
@@ -164,17 +213,39 @@ func (swp *staticWorkerPool) do(priority bool) {
 			case <-swp.stop:
 				return
 			case task := <-swp.priorityCh:
-				task.Execute()
+				swp.execute(task)
 			default:
 				select {
 				case <-swp.stop:
 					return
 				case task := <-swp.priorityCh:
-					task.Execute()
+					swp.execute(task)
 				case task := <-swp.normalCh:
-					task.Execute()
+					swp.execute(task)
 				}
 			}
 		}
 	}
 }
+
+// execute executes the task, tracked by the watchdog if any.
+func (swp *staticWorkerPool) execute(task Task) {
+	swp.running.Add(1)
+	defer swp.running.Add(-1)
+	if swp.watchdog == nil {
+		task.Execute()
+		return
+	}
+	r := swp.watchdog.begin(task)
+	defer swp.watchdog.end(r)
+	task.Execute()
+}
+
+// Stats returns the current utilization of the pool.
+func (swp *staticWorkerPool) Stats() Stats {
+	return Stats{
+		Workers: int(swp.priorityWorker + swp.normalWorker),
+		Running: swp.running.Load(),
+		Queued:  len(swp.priorityCh) + len(swp.normalCh),
+	}
+}
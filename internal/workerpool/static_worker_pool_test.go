@@ -172,7 +172,19 @@ func TestStaticWorkerPool_ScheduleAfterStop(t *testing.T) {
 
 	pool.Stop()
 
-	assert.Panics(t, func() { pool.Schedule(true, &dummyTask{}) }, "Should panic when scheduling after cancel.")
+	dt := &dummyTask{}
+	assert.ErrorIs(t, pool.Schedule(true, dt), ErrPoolStopped)
+	assert.ErrorIs(t, pool.Schedule(false, dt), ErrPoolStopped)
+	assert.False(t, dt.executed)
+}
+
+func TestStaticWorkerPool_StopTwice(t *testing.T) {
+	pool, err := NewStaticWorkerPool(2, 3, 5)
+	require.NoError(t, err)
+	pool.Start()
+	pool.Stop()
+
+	assert.NotPanics(t, pool.Stop)
 }
 
 func TestStaticWorkerPool_Stop(t *testing.T) {
@@ -192,7 +204,7 @@ func TestStaticWorkerPool_Stop(t *testing.T) {
 func TestNewStaticWorkerPoolForCurrentCPU(t *testing.T) {
 	readGlobalMaxBlocks := int64(100)
 
-	pool, err := NewStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks)
+	pool, err := NewStaticWorkerPoolForCurrentCPU(readGlobalMaxBlocks, WatchdogConfig{})
 
 	require.NoError(t, err)
 	require.NotNil(t, pool)
@@ -243,7 +255,7 @@ func Test_newStaticWorkerPoolForCurrentCPU(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			pool, err := newStaticWorkerPoolForCurrentCPU(tc.readGlobalMaxBlocks, tc.mockNumCPU)
+			pool, err := newStaticWorkerPoolForCurrentCPU(tc.readGlobalMaxBlocks, WatchdogConfig{}, tc.mockNumCPU)
 
 			require.NoError(t, err)
 			require.NotNil(t, pool)
@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workerpool
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/internal/logger"
+)
+
+// CancellableTask is a task which the watchdog of a pool can cancel when it is
+// stuck, e.g. a download blocked on a hung connection, for the worker running
+// it to return to the queued tasks.
+type CancellableTask interface {
+	Task
+	Cancel()
+}
+
+// WatchdogConfig configures the watchdog of a worker pool.
+type WatchdogConfig struct {
+	// StuckTimeout is how long the pool may go without completing any task,
+	// while tasks are queued, before it is deemed stuck. Zero disables the
+	// watchdog.
+	StuckTimeout time.Duration
+
+	// CancelStuckTasks, if true, cancels the tasks running in a stuck pool,
+	// none of which completed for StuckTimeout, oldest first, so that the
+	// workers pick up the queued tasks again. Otherwise the stuck tasks are
+	// only logged.
+	CancelStuckTasks bool
+}
+
+// runningTask is a task being executed by a worker.
+type runningTask struct {
+	task    Task
+	started time.Time
+}
+
+// watchdog detects a pool whose workers are all blocked, e.g. on downloads
+// from a hung backend without deadline, which would otherwise leave all the
+// queued tasks, and the reads waiting on them, hanging forever.
+type watchdog struct {
+	config WatchdogConfig
+
+	// queued returns the number of tasks queued in the pool.
+	queued func() int
+
+	now func() time.Time
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	mu sync.Mutex
+
+	// running holds the tasks being executed.
+	// GUARDED by (mu)
+	running map[*runningTask]struct{}
+
+	// lastProgress is the time the last task completed, or the pool became
+	// busy, whichever is latest.
+	// GUARDED by (mu)
+	lastProgress time.Time
+
+	// detections is the number of times the pool was found stuck.
+	detections atomic.Int64
+}
+
+func newWatchdog(config WatchdogConfig, queued func() int) *watchdog {
+	return &watchdog{
+		config:  config,
+		queued:  queued,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+		running: make(map[*runningTask]struct{}),
+	}
+}
+
+// start checks the pool periodically until stopped.
+func (w *watchdog) start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(max(w.config.StuckTimeout/4, time.Millisecond))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.check()
+			}
+		}
+	}()
+}
+
+// stopAndWait stops the periodic checks.
+func (w *watchdog) stopAndWait() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+// begin records the start of the execution of a task.
+// LOCKS_EXCLUDED(w.mu)
+func (w *watchdog) begin(task Task) *runningTask {
+	r := &runningTask{task: task, started: w.now()}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.running) == 0 {
+		// The time spent idle doesn't count towards being stuck.
+		w.lastProgress = r.started
+	}
+	w.running[r] = struct{}{}
+	return r
+}
+
+// end records the completion of a task started with begin.
+// LOCKS_EXCLUDED(w.mu)
+func (w *watchdog) end(r *runningTask) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.running, r)
+	w.lastProgress = w.now()
+}
+
+// check logs the tasks of the pool if no task completed for StuckTimeout
+// despite tasks being queued, and cancels the stuck ones if configured to.
+// LOCKS_EXCLUDED(w.mu)
+func (w *watchdog) check() {
+	queued := w.queued()
+	now := w.now()
+
+	w.mu.Lock()
+	if queued == 0 || len(w.running) == 0 || now.Sub(w.lastProgress) < w.config.StuckTimeout {
+		w.mu.Unlock()
+		return
+	}
+	stalled := now.Sub(w.lastProgress)
+	running := make([]*runningTask, 0, len(w.running))
+	for r := range w.running {
+		running = append(running, r)
+	}
+	// Reported once per StuckTimeout for as long as the pool stays stuck.
+	w.lastProgress = now
+	w.mu.Unlock()
+
+	w.detections.Add(1)
+	slices.SortFunc(running, func(a, b *runningTask) int { return cmp.Compare(a.started.UnixNano(), b.started.UnixNano()) })
+	var dump strings.Builder
+	for _, r := range running {
+		fmt.Fprintf(&dump, "\n  %s, running for %v", DescribeTask(r.task), now.Sub(r.started))
+	}
+	logger.Errorf("staticWorkerPool: watchdog: no task completed for %v with %d tasks queued; %d tasks running:%s", stalled, queued, len(running), dump.String())
+
+	if !w.config.CancelStuckTasks {
+		return
+	}
+	for _, r := range running {
+		if t, ok := r.task.(CancellableTask); ok {
+			logger.Warnf("staticWorkerPool: watchdog: cancelling %s, running for %v.", DescribeTask(r.task), now.Sub(r.started))
+			t.Cancel()
+		}
+	}
+}
+
+// DescribeTask returns the description of a task for logging: its String
+// method if it has one, and its type otherwise.
+func DescribeTask(task Task) string {
+	if s, ok := task.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", task)
+}
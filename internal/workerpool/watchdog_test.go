@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stuckTask blocks its worker until cancelled, like a download from a hung
+// backend without deadline.
+type stuckTask struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started chan struct{}
+}
+
+func newStuckTask() *stuckTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &stuckTask{ctx: ctx, cancel: cancel, started: make(chan struct{})}
+}
+
+func (s *stuckTask) Execute() {
+	close(s.started)
+	<-s.ctx.Done()
+}
+
+func (s *stuckTask) Cancel() {
+	s.cancel()
+}
+
+func (s *stuckTask) String() string {
+	return "stuck task"
+}
+
+// completedTask records its execution.
+type completedTask struct {
+	executed atomic.Bool
+}
+
+func (c *completedTask) Execute() {
+	c.executed.Store(true)
+}
+
+// startStuckPool returns a started pool of two workers, both blocked on stuck
+// tasks, with another task queued behind them.
+func startStuckPool(t *testing.T, config WatchdogConfig) (*staticWorkerPool, []*stuckTask, *completedTask) {
+	t.Helper()
+	pool, err := NewStaticWorkerPool(0, 2, 10)
+	require.NoError(t, err)
+	pool.EnableWatchdog(config)
+	pool.Start()
+	stuck := []*stuckTask{newStuckTask(), newStuckTask()}
+	for _, s := range stuck {
+		require.NoError(t, pool.Schedule(false, s))
+		<-s.started
+	}
+	queued := &completedTask{}
+	require.NoError(t, pool.Schedule(false, queued))
+	return pool, stuck, queued
+}
+
+func TestWatchdogCancelsStuckTasks(t *testing.T) {
+	pool, stuck, queued := startStuckPool(t, WatchdogConfig{StuckTimeout: 50 * time.Millisecond, CancelStuckTasks: true})
+	defer pool.Stop()
+
+	// The queued task runs once the stuck ones are cancelled.
+	assert.Eventually(t, queued.executed.Load, 2*time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, pool.watchdog.detections.Load(), int64(1))
+	for _, s := range stuck {
+		assert.ErrorIs(t, s.ctx.Err(), context.Canceled)
+	}
+}
+
+func TestWatchdogOnlyLogsStuckTasksWithoutCancel(t *testing.T) {
+	pool, stuck, queued := startStuckPool(t, WatchdogConfig{StuckTimeout: 50 * time.Millisecond})
+
+	assert.Eventually(t, func() bool { return pool.watchdog.detections.Load() > 0 }, 2*time.Second, 10*time.Millisecond)
+	assert.False(t, queued.executed.Load())
+	for _, s := range stuck {
+		assert.NoError(t, s.ctx.Err())
+		s.Cancel()
+	}
+	pool.Stop()
+}
+
+func TestWatchdogIgnoresIdlePool(t *testing.T) {
+	pool, err := NewStaticWorkerPool(0, 1, 10)
+	require.NoError(t, err)
+	pool.EnableWatchdog(WatchdogConfig{StuckTimeout: 10 * time.Millisecond, CancelStuckTasks: true})
+	pool.Start()
+	defer pool.Stop()
+	// A long-running task with nothing queued behind it isn't stuck.
+	s := newStuckTask()
+	require.NoError(t, pool.Schedule(false, s))
+	<-s.started
+
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, int64(0), pool.watchdog.detections.Load())
+	assert.NoError(t, s.ctx.Err())
+	s.Cancel()
+}
+
+func TestEnableWatchdogWithoutTimeout(t *testing.T) {
+	pool, err := NewStaticWorkerPool(0, 1, 10)
+	require.NoError(t, err)
+
+	pool.EnableWatchdog(WatchdogConfig{})
+
+	assert.Nil(t, pool.watchdog)
+}
@@ -14,6 +14,12 @@
 
 package workerpool
 
+import "errors"
+
+// ErrPoolStopped is returned when scheduling a task on a stopped worker pool,
+// e.g. by a read racing with unmount.
+var ErrPoolStopped = errors.New("worker pool is stopped")
+
 // Task interface defines the contract for a runnable task.
 type Task interface {
 	Execute()
@@ -24,8 +30,26 @@ type WorkerPool interface {
 	Start()
 
 	// Stop gracefully shuts down the worker pool, waiting for all tasks to complete.
+	// Stopping an already stopped pool is a no-op.
 	Stop()
 
-	// Schedule adds a task to the worker pool for execution.
-	Schedule(urgent bool, task Task)
+	// Schedule adds a task to the worker pool for execution. It returns
+	// ErrPoolStopped, without executing the task, if the pool is stopped.
+	Schedule(urgent bool, task Task) error
+}
+
+// Stats is a snapshot of the utilization of a worker pool.
+type Stats struct {
+	// Workers is the number of workers of the pool.
+	Workers int
+	// Running is the number of tasks being executed, and Queued the number of
+	// tasks waiting for a worker.
+	Running int64
+	Queued  int
+}
+
+// StatsReporter is implemented by the worker pools reporting their
+// utilization, e.g. for diagnostics.
+type StatsReporter interface {
+	Stats() Stats
 }
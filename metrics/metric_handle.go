@@ -20,6 +20,25 @@ import (
 	"time"
 )
 
+// BlockSize is a custom type for the block_size attribute.
+type BlockSize string
+
+const (
+	BlockSizeLargeAttr  BlockSize = "large"
+	BlockSizeMediumAttr BlockSize = "medium"
+	BlockSizeSmallAttr  BlockSize = "small"
+)
+
+// DownloadClass is a custom type for the download_class attribute.
+type DownloadClass string
+
+const (
+	DownloadClassCacheThroughAttr DownloadClass = "cache_through"
+	DownloadClassDemandAttr       DownloadClass = "demand"
+	DownloadClassPrefetchAttr     DownloadClass = "prefetch"
+	DownloadClassWarmupAttr       DownloadClass = "warmup"
+)
+
 // FsErrorCategory is a custom type for the fs_error_category attribute.
 type FsErrorCategory string
 
@@ -88,6 +107,7 @@ const (
 	GcsMethodFinalizeUploadAttr               GcsMethod = "FinalizeUpload"
 	GcsMethodFlushPendingWritesAttr           GcsMethod = "FlushPendingWrites"
 	GcsMethodGetFolderAttr                    GcsMethod = "GetFolder"
+	GcsMethodGetLifecycleRulesAttr            GcsMethod = "GetLifecycleRules"
 	GcsMethodListObjectsAttr                  GcsMethod = "ListObjects"
 	GcsMethodMoveObjectAttr                   GcsMethod = "MoveObject"
 	GcsMethodMultiRangeDownloaderAddAttr      GcsMethod = "MultiRangeDownloader::Add"
@@ -117,6 +137,22 @@ const (
 	OpenModeWriteOnlyAppendAttr OpenMode = "write_only_append"
 )
 
+// PrefetchMode is a custom type for the prefetch_mode attribute.
+type PrefetchMode string
+
+const (
+	PrefetchModeDemandOnlyAttr PrefetchMode = "demand_only"
+	PrefetchModePrefetchAttr   PrefetchMode = "prefetch"
+)
+
+// ReadHandleOutcome is a custom type for the read_handle_outcome attribute.
+type ReadHandleOutcome string
+
+const (
+	ReadHandleOutcomeRejectedAttr ReadHandleOutcome = "rejected"
+	ReadHandleOutcomeReusedAttr   ReadHandleOutcome = "reused"
+)
+
 // ReadType is a custom type for the read_type attribute.
 type ReadType string
 
@@ -132,6 +168,7 @@ const (
 type Reason string
 
 const (
+	ReasonDownloadFailedAttr     Reason = "download_failed"
 	ReasonInsufficientMemoryAttr Reason = "insufficient_memory"
 	ReasonRandomReadDetectedAttr Reason = "random_read_detected"
 )
@@ -152,6 +189,23 @@ const (
 	RetryErrorCategorySTALLEDREADREQUESTAttr RetryErrorCategory = "STALLED_READ_REQUEST"
 )
 
+// Status is a custom type for the status attribute.
+type Status string
+
+const (
+	StatusFailedAttr    Status = "failed"
+	StatusRetriedAttr   Status = "retried"
+	StatusSucceededAttr Status = "succeeded"
+)
+
+// WarmupState is a custom type for the warmup_state attribute.
+type WarmupState string
+
+const (
+	WarmupStateDoneAttr  WarmupState = "done"
+	WarmupStateTotalAttr WarmupState = "total"
+)
+
 // WriteFallbackReason is a custom type for the write_fallback_reason attribute.
 type WriteFallbackReason string
 
@@ -166,12 +220,54 @@ const (
 // The methods of this interface are auto-generated from metrics.yaml.
 // Each method corresponds to a metric defined in metrics.yaml.
 type MetricHandle interface {
-	// BufferedReadFallbackTriggerCount - The cumulative number of times the BufferedReader falls back to a different reader, along with the reason: random_read_detected or insufficient_memory.
+	// BufferedReadBlockReadCount - The cumulative number of blocks read by the buffered reader, counted once per block, along with cache hit - true for the blocks prefetched ahead of the read needing them, false for the blocks downloaded on demand, e.g. after a seek or a failed download.
+	BufferedReadBlockReadCount(inc int64, cacheHit bool)
+
+	// BufferedReadDownloadBlockLatency - The cumulative distribution of the latencies of successful buffered read block downloads, along with the size of the blocks: small below 4 MiB, medium below 16 MiB and large from 16 MiB.
+	BufferedReadDownloadBlockLatency(ctx context.Context, latency time.Duration, blockSize BlockSize)
+
+	// BufferedReadDownloadBytesCount - The cumulative number of bytes downloaded by the buffered reader, along with the class of the download: demand for blocks read right away, prefetch for speculatively prefetched blocks, warmup for blocks of ranges advised as needed, and cache_through for blocks kept in the pinned block store.
+	BufferedReadDownloadBytesCount(inc int64, downloadClass DownloadClass)
+
+	// BufferedReadDownloadCount - The cumulative number of buffered read block download attempts, along with their status: succeeded, failed for the attempts failing the block, and retried for the failed attempts followed by another one.
+	BufferedReadDownloadCount(inc int64, status Status)
+
+	// BufferedReadDownloadsQueuedForInflightLimit - The number of buffered read block downloads currently queued because the limit of downloads in flight across the mount was reached.
+	BufferedReadDownloadsQueuedForInflightLimit(inc int64)
+
+	// BufferedReadDownloadsWaitingForObjectLimit - The number of buffered read block downloads currently waiting for a slot of the per-object concurrent download limit of their object.
+	BufferedReadDownloadsWaitingForObjectLimit(inc int64)
+
+	// BufferedReadFallbackTriggerCount - The cumulative number of times the BufferedReader falls back to a different reader, along with the reason: download_failed, random_read_detected or insufficient_memory.
 	BufferedReadFallbackTriggerCount(inc int64, reason Reason)
 
+	// BufferedReadObjectResidentBlocks - The cumulative distribution of the number of blocks of an object held in the buffered read block pool across its file handles, recorded whenever a block of the object is taken from the pool.
+	BufferedReadObjectResidentBlocks(ctx context.Context, value int64)
+
+	// BufferedReadPinnedBytes - The number of bytes of the blocks of the pinned objects currently kept in memory.
+	BufferedReadPinnedBytes(inc int64)
+
+	// BufferedReadPreallocatedBytes - The number of bytes of the buffered read blocks preallocated at mount time. Zero if preallocation is disabled or failed.
+	BufferedReadPreallocatedBytes(inc int64)
+
+	// BufferedReadPrefetchCancelledBySeekCount - The cumulative number of in-flight prefetch downloads cancelled because the read seeked backward before the prefetched blocks.
+	BufferedReadPrefetchCancelledBySeekCount(inc int64)
+
+	// BufferedReadPrefetchModeFiles - The number of files currently open for buffered reads, along with whether they are prefetching or served demand-only because the prefetch file limit was reached.
+	BufferedReadPrefetchModeFiles(inc int64, prefetchMode PrefetchMode)
+
 	// BufferedReadReadLatency - The cumulative distribution of latencies for ReadAt calls served by the buffered reader.
 	BufferedReadReadLatency(ctx context.Context, latency time.Duration)
 
+	// BufferedReadWarmupObjects - The number of objects of the warm state file whose blocks are pinned again at mount, along with whether the count is of all the objects (total) or of those done.
+	BufferedReadWarmupObjects(inc int64, warmupState WarmupState)
+
+	// FileCacheDirectReadCount - The cumulative number of reads of fully cached files served from the file cache without using buffered read blocks.
+	FileCacheDirectReadCount(inc int64)
+
+	// FileCacheFullObjectDownloadCount - The cumulative number of full-object downloads into the file cache triggered by the first reads of objects not fully cached, with the file cache preferred after full-object downloads.
+	FileCacheFullObjectDownloadCount(inc int64)
+
 	// FileCacheReadBytesCount - The cumulative number of bytes read from file cache along with read type - Sequential/Random
 	FileCacheReadBytesCount(inc int64, readType ReadType)
 
@@ -181,6 +277,9 @@ type MetricHandle interface {
 	// FileCacheReadLatencies - The cumulative distribution of the file cache read latencies along with cache hit - true/false.
 	FileCacheReadLatencies(ctx context.Context, latency time.Duration, cacheHit bool)
 
+	// FsOpenRejectedCount - The cumulative number of file opens rejected because the file reached the maximum number of open handles.
+	FsOpenRejectedCount(inc int64)
+
 	// FsOpsCount - The cumulative number of ops processed by the file system.
 	FsOpsCount(inc int64, fsOp FsOp)
 
@@ -193,6 +292,24 @@ type MetricHandle interface {
 	// FsStreamingWriteFallbackCount - The cumulative number of streaming write fallbacks with reason attached
 	FsStreamingWriteFallbackCount(inc int64, openMode OpenMode, writeFallbackReason WriteFallbackReason)
 
+	// GcDeleteBacklog - The number of stale temporary objects currently listed by garbage collection but not yet deleted.
+	GcDeleteBacklog(inc int64)
+
+	// GcDeleteThrottledTime - The cumulative time garbage collection runs of temporary objects waited for the delete rate limit before deleting objects.
+	GcDeleteThrottledTime(inc int64)
+
+	// GcObjectsDeletedCount - The cumulative number of stale temporary objects deleted by garbage collection runs.
+	GcObjectsDeletedCount(inc int64)
+
+	// GcRunDuration - The cumulative distribution of the durations of garbage collection runs of temporary objects, successful or not.
+	GcRunDuration(ctx context.Context, latency time.Duration)
+
+	// GcRunFailedCount - The cumulative number of garbage collection runs of temporary objects which failed, retries included.
+	GcRunFailedCount(inc int64)
+
+	// GcRunSkippedOverlapCount - The cumulative number of garbage collection runs of temporary objects skipped because the previous run was still in progress.
+	GcRunSkippedOverlapCount(inc int64)
+
 	// GcsDownloadBytesCount - The cumulative number of bytes downloaded from GCS along with type - Sequential/Random
 	GcsDownloadBytesCount(inc int64, readType ReadType)
 
@@ -202,6 +319,9 @@ type MetricHandle interface {
 	// GcsReadCount - Specifies the number of gcs reads made along with type - Sequential/Random
 	GcsReadCount(inc int64, readType ReadType)
 
+	// GcsReadHandleCount - The cumulative number of GCS reads of zonal bucket objects carrying the read handle of a previous read, along with whether the handle was reused, or rejected in which case the read went through the full auth and metadata checks.
+	GcsReadHandleCount(inc int64, readHandleOutcome ReadHandleOutcome)
+
 	// GcsReaderCount - The cumulative number of GCS object readers opened or closed.
 	GcsReaderCount(inc int64, ioMethod IoMethod)
 
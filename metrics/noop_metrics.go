@@ -22,10 +22,39 @@ import (
 
 type noopMetrics struct{}
 
+func (*noopMetrics) BufferedReadBlockReadCount(inc int64, cacheHit bool) {}
+
+func (*noopMetrics) BufferedReadDownloadBlockLatency(ctx context.Context, latency time.Duration, blockSize BlockSize) {
+}
+
+func (*noopMetrics) BufferedReadDownloadBytesCount(inc int64, downloadClass DownloadClass) {}
+
+func (*noopMetrics) BufferedReadDownloadCount(inc int64, status Status) {}
+
+func (*noopMetrics) BufferedReadDownloadsQueuedForInflightLimit(inc int64) {}
+
+func (*noopMetrics) BufferedReadDownloadsWaitingForObjectLimit(inc int64) {}
+
 func (*noopMetrics) BufferedReadFallbackTriggerCount(inc int64, reason Reason) {}
 
+func (*noopMetrics) BufferedReadObjectResidentBlocks(ctx context.Context, value int64) {}
+
+func (*noopMetrics) BufferedReadPinnedBytes(inc int64) {}
+
+func (*noopMetrics) BufferedReadPreallocatedBytes(inc int64) {}
+
+func (*noopMetrics) BufferedReadPrefetchCancelledBySeekCount(inc int64) {}
+
+func (*noopMetrics) BufferedReadPrefetchModeFiles(inc int64, prefetchMode PrefetchMode) {}
+
 func (*noopMetrics) BufferedReadReadLatency(ctx context.Context, latency time.Duration) {}
 
+func (*noopMetrics) BufferedReadWarmupObjects(inc int64, warmupState WarmupState) {}
+
+func (*noopMetrics) FileCacheDirectReadCount(inc int64) {}
+
+func (*noopMetrics) FileCacheFullObjectDownloadCount(inc int64) {}
+
 func (*noopMetrics) FileCacheReadBytesCount(inc int64, readType ReadType) {}
 
 func (*noopMetrics) FileCacheReadCount(inc int64, cacheHit bool, readType ReadType) {}
@@ -33,6 +62,8 @@ func (*noopMetrics) FileCacheReadCount(inc int64, cacheHit bool, readType ReadTy
 func (*noopMetrics) FileCacheReadLatencies(ctx context.Context, latency time.Duration, cacheHit bool) {
 }
 
+func (*noopMetrics) FsOpenRejectedCount(inc int64) {}
+
 func (*noopMetrics) FsOpsCount(inc int64, fsOp FsOp) {}
 
 func (*noopMetrics) FsOpsErrorCount(inc int64, fsErrorCategory FsErrorCategory, fsOp FsOp) {}
@@ -42,12 +73,26 @@ func (*noopMetrics) FsOpsLatency(ctx context.Context, latency time.Duration, fsO
 func (*noopMetrics) FsStreamingWriteFallbackCount(inc int64, openMode OpenMode, writeFallbackReason WriteFallbackReason) {
 }
 
+func (*noopMetrics) GcDeleteBacklog(inc int64) {}
+
+func (*noopMetrics) GcDeleteThrottledTime(inc int64) {}
+
+func (*noopMetrics) GcObjectsDeletedCount(inc int64) {}
+
+func (*noopMetrics) GcRunDuration(ctx context.Context, latency time.Duration) {}
+
+func (*noopMetrics) GcRunFailedCount(inc int64) {}
+
+func (*noopMetrics) GcRunSkippedOverlapCount(inc int64) {}
+
 func (*noopMetrics) GcsDownloadBytesCount(inc int64, readType ReadType) {}
 
 func (*noopMetrics) GcsReadBytesCount(inc int64) {}
 
 func (*noopMetrics) GcsReadCount(inc int64, readType ReadType) {}
 
+func (*noopMetrics) GcsReadHandleCount(inc int64, readHandleOutcome ReadHandleOutcome) {}
+
 func (*noopMetrics) GcsReaderCount(inc int64, ioMethod IoMethod) {}
 
 func (*noopMetrics) GcsRequestCount(inc int64, gcsMethod GcsMethod) {}
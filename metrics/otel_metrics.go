@@ -33,8 +33,25 @@ const logInterval = 5 * time.Minute
 
 var (
 	unrecognizedAttr                                                                                       atomic.Value
+	bufferedReadBlockReadCountCacheHitTrueAttrSet                                                          = metric.WithAttributeSet(attribute.NewSet(attribute.Bool("cache_hit", true)))
+	bufferedReadBlockReadCountCacheHitFalseAttrSet                                                         = metric.WithAttributeSet(attribute.NewSet(attribute.Bool("cache_hit", false)))
+	bufferedReadDownloadBlockLatencyBlockSizeLargeAttrSet                                                  = metric.WithAttributeSet(attribute.NewSet(attribute.String("block_size", "large")))
+	bufferedReadDownloadBlockLatencyBlockSizeMediumAttrSet                                                 = metric.WithAttributeSet(attribute.NewSet(attribute.String("block_size", "medium")))
+	bufferedReadDownloadBlockLatencyBlockSizeSmallAttrSet                                                  = metric.WithAttributeSet(attribute.NewSet(attribute.String("block_size", "small")))
+	bufferedReadDownloadBytesCountDownloadClassCacheThroughAttrSet                                         = metric.WithAttributeSet(attribute.NewSet(attribute.String("download_class", "cache_through")))
+	bufferedReadDownloadBytesCountDownloadClassDemandAttrSet                                               = metric.WithAttributeSet(attribute.NewSet(attribute.String("download_class", "demand")))
+	bufferedReadDownloadBytesCountDownloadClassPrefetchAttrSet                                             = metric.WithAttributeSet(attribute.NewSet(attribute.String("download_class", "prefetch")))
+	bufferedReadDownloadBytesCountDownloadClassWarmupAttrSet                                               = metric.WithAttributeSet(attribute.NewSet(attribute.String("download_class", "warmup")))
+	bufferedReadDownloadCountStatusFailedAttrSet                                                           = metric.WithAttributeSet(attribute.NewSet(attribute.String("status", "failed")))
+	bufferedReadDownloadCountStatusRetriedAttrSet                                                          = metric.WithAttributeSet(attribute.NewSet(attribute.String("status", "retried")))
+	bufferedReadDownloadCountStatusSucceededAttrSet                                                        = metric.WithAttributeSet(attribute.NewSet(attribute.String("status", "succeeded")))
+	bufferedReadFallbackTriggerCountReasonDownloadFailedAttrSet                                            = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "download_failed")))
 	bufferedReadFallbackTriggerCountReasonInsufficientMemoryAttrSet                                        = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "insufficient_memory")))
 	bufferedReadFallbackTriggerCountReasonRandomReadDetectedAttrSet                                        = metric.WithAttributeSet(attribute.NewSet(attribute.String("reason", "random_read_detected")))
+	bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAttrSet                                             = metric.WithAttributeSet(attribute.NewSet(attribute.String("prefetch_mode", "demand_only")))
+	bufferedReadPrefetchModeFilesPrefetchModePrefetchAttrSet                                               = metric.WithAttributeSet(attribute.NewSet(attribute.String("prefetch_mode", "prefetch")))
+	bufferedReadWarmupObjectsWarmupStateDoneAttrSet                                                        = metric.WithAttributeSet(attribute.NewSet(attribute.String("warmup_state", "done")))
+	bufferedReadWarmupObjectsWarmupStateTotalAttrSet                                                       = metric.WithAttributeSet(attribute.NewSet(attribute.String("warmup_state", "total")))
 	fileCacheReadBytesCountReadTypeParallelAttrSet                                                         = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Parallel")))
 	fileCacheReadBytesCountReadTypeRandomAttrSet                                                           = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Random")))
 	fileCacheReadBytesCountReadTypeSequentialAttrSet                                                       = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Sequential")))
@@ -527,6 +544,8 @@ var (
 	gcsReadCountReadTypeRandomAttrSet                                                                      = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Random")))
 	gcsReadCountReadTypeSequentialAttrSet                                                                  = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Sequential")))
 	gcsReadCountReadTypeUnknownAttrSet                                                                     = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_type", "Unknown")))
+	gcsReadHandleCountReadHandleOutcomeRejectedAttrSet                                                     = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_handle_outcome", "rejected")))
+	gcsReadHandleCountReadHandleOutcomeReusedAttrSet                                                       = metric.WithAttributeSet(attribute.NewSet(attribute.String("read_handle_outcome", "reused")))
 	gcsReaderCountIoMethodClosedAttrSet                                                                    = metric.WithAttributeSet(attribute.NewSet(attribute.String("io_method", "closed")))
 	gcsReaderCountIoMethodOpenedAttrSet                                                                    = metric.WithAttributeSet(attribute.NewSet(attribute.String("io_method", "opened")))
 	gcsRequestCountGcsMethodComposeObjectsAttrSet                                                          = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "ComposeObjects")))
@@ -540,6 +559,7 @@ var (
 	gcsRequestCountGcsMethodFinalizeUploadAttrSet                                                          = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "FinalizeUpload")))
 	gcsRequestCountGcsMethodFlushPendingWritesAttrSet                                                      = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "FlushPendingWrites")))
 	gcsRequestCountGcsMethodGetFolderAttrSet                                                               = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "GetFolder")))
+	gcsRequestCountGcsMethodGetLifecycleRulesAttrSet                                                       = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "GetLifecycleRules")))
 	gcsRequestCountGcsMethodListObjectsAttrSet                                                             = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "ListObjects")))
 	gcsRequestCountGcsMethodMoveObjectAttrSet                                                              = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "MoveObject")))
 	gcsRequestCountGcsMethodMultiRangeDownloaderAddAttrSet                                                 = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "MultiRangeDownloader::Add")))
@@ -559,6 +579,7 @@ var (
 	gcsRequestLatenciesGcsMethodFinalizeUploadAttrSet                                                      = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "FinalizeUpload")))
 	gcsRequestLatenciesGcsMethodFlushPendingWritesAttrSet                                                  = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "FlushPendingWrites")))
 	gcsRequestLatenciesGcsMethodGetFolderAttrSet                                                           = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "GetFolder")))
+	gcsRequestLatenciesGcsMethodGetLifecycleRulesAttrSet                                                   = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "GetLifecycleRules")))
 	gcsRequestLatenciesGcsMethodListObjectsAttrSet                                                         = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "ListObjects")))
 	gcsRequestLatenciesGcsMethodMoveObjectAttrSet                                                          = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "MoveObject")))
 	gcsRequestLatenciesGcsMethodMultiRangeDownloaderAddAttrSet                                             = metric.WithAttributeSet(attribute.NewSet(attribute.String("gcs_method", "MultiRangeDownloader::Add")))
@@ -583,8 +604,29 @@ type histogramRecord struct {
 type otelMetrics struct {
 	ch                                                                                                    chan histogramRecord
 	wg                                                                                                    *sync.WaitGroup
+	bufferedReadBlockReadCountCacheHitTrueAtomic                                                          *atomic.Int64
+	bufferedReadBlockReadCountCacheHitFalseAtomic                                                         *atomic.Int64
+	bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic                                         *atomic.Int64
+	bufferedReadDownloadBytesCountDownloadClassDemandAtomic                                               *atomic.Int64
+	bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic                                             *atomic.Int64
+	bufferedReadDownloadBytesCountDownloadClassWarmupAtomic                                               *atomic.Int64
+	bufferedReadDownloadCountStatusFailedAtomic                                                           *atomic.Int64
+	bufferedReadDownloadCountStatusRetriedAtomic                                                          *atomic.Int64
+	bufferedReadDownloadCountStatusSucceededAtomic                                                        *atomic.Int64
+	bufferedReadDownloadsQueuedForInflightLimitAtomic                                                     *atomic.Int64
+	bufferedReadDownloadsWaitingForObjectLimitAtomic                                                      *atomic.Int64
+	bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic                                            *atomic.Int64
 	bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic                                        *atomic.Int64
 	bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic                                        *atomic.Int64
+	bufferedReadPinnedBytesAtomic                                                                         *atomic.Int64
+	bufferedReadPreallocatedBytesAtomic                                                                   *atomic.Int64
+	bufferedReadPrefetchCancelledBySeekCountAtomic                                                        *atomic.Int64
+	bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic                                             *atomic.Int64
+	bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic                                               *atomic.Int64
+	bufferedReadWarmupObjectsWarmupStateDoneAtomic                                                        *atomic.Int64
+	bufferedReadWarmupObjectsWarmupStateTotalAtomic                                                       *atomic.Int64
+	fileCacheDirectReadCountAtomic                                                                        *atomic.Int64
+	fileCacheFullObjectDownloadCountAtomic                                                                *atomic.Int64
 	fileCacheReadBytesCountReadTypeParallelAtomic                                                         *atomic.Int64
 	fileCacheReadBytesCountReadTypeRandomAtomic                                                           *atomic.Int64
 	fileCacheReadBytesCountReadTypeSequentialAtomic                                                       *atomic.Int64
@@ -597,6 +639,7 @@ type otelMetrics struct {
 	fileCacheReadCountCacheHitFalseReadTypeRandomAtomic                                                   *atomic.Int64
 	fileCacheReadCountCacheHitFalseReadTypeSequentialAtomic                                               *atomic.Int64
 	fileCacheReadCountCacheHitFalseReadTypeUnknownAtomic                                                  *atomic.Int64
+	fsOpenRejectedCountAtomic                                                                             *atomic.Int64
 	fsOpsCountFsOpBatchForgetAtomic                                                                       *atomic.Int64
 	fsOpsCountFsOpCreateFileAtomic                                                                        *atomic.Int64
 	fsOpsCountFsOpCreateLinkAtomic                                                                        *atomic.Int64
@@ -1042,6 +1085,11 @@ type otelMetrics struct {
 	fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonExistingFileAtomic             *atomic.Int64
 	fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOtherAtomic                    *atomic.Int64
 	fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOutOfOrderAtomic               *atomic.Int64
+	gcDeleteBacklogAtomic                                                                                 *atomic.Int64
+	gcDeleteThrottledTimeAtomic                                                                           *atomic.Int64
+	gcObjectsDeletedCountAtomic                                                                           *atomic.Int64
+	gcRunFailedCountAtomic                                                                                *atomic.Int64
+	gcRunSkippedOverlapCountAtomic                                                                        *atomic.Int64
 	gcsDownloadBytesCountReadTypeBufferedAtomic                                                           *atomic.Int64
 	gcsDownloadBytesCountReadTypeParallelAtomic                                                           *atomic.Int64
 	gcsDownloadBytesCountReadTypeRandomAtomic                                                             *atomic.Int64
@@ -1051,6 +1099,8 @@ type otelMetrics struct {
 	gcsReadCountReadTypeRandomAtomic                                                                      *atomic.Int64
 	gcsReadCountReadTypeSequentialAtomic                                                                  *atomic.Int64
 	gcsReadCountReadTypeUnknownAtomic                                                                     *atomic.Int64
+	gcsReadHandleCountReadHandleOutcomeRejectedAtomic                                                     *atomic.Int64
+	gcsReadHandleCountReadHandleOutcomeReusedAtomic                                                       *atomic.Int64
 	gcsReaderCountIoMethodClosedAtomic                                                                    *atomic.Int64
 	gcsReaderCountIoMethodOpenedAtomic                                                                    *atomic.Int64
 	gcsRequestCountGcsMethodComposeObjectsAtomic                                                          *atomic.Int64
@@ -1064,6 +1114,7 @@ type otelMetrics struct {
 	gcsRequestCountGcsMethodFinalizeUploadAtomic                                                          *atomic.Int64
 	gcsRequestCountGcsMethodFlushPendingWritesAtomic                                                      *atomic.Int64
 	gcsRequestCountGcsMethodGetFolderAtomic                                                               *atomic.Int64
+	gcsRequestCountGcsMethodGetLifecycleRulesAtomic                                                       *atomic.Int64
 	gcsRequestCountGcsMethodListObjectsAtomic                                                             *atomic.Int64
 	gcsRequestCountGcsMethodMoveObjectAtomic                                                              *atomic.Int64
 	gcsRequestCountGcsMethodMultiRangeDownloaderAddAtomic                                                 *atomic.Int64
@@ -1077,13 +1128,101 @@ type otelMetrics struct {
 	testUpdownCounterAtomic                                                                               *atomic.Int64
 	testUpdownCounterWithAttrsRequestTypeAttr1Atomic                                                      *atomic.Int64
 	testUpdownCounterWithAttrsRequestTypeAttr2Atomic                                                      *atomic.Int64
+	bufferedReadDownloadBlockLatency                                                                      metric.Int64Histogram
+	bufferedReadObjectResidentBlocks                                                                      metric.Int64Histogram
 	bufferedReadReadLatency                                                                               metric.Int64Histogram
 	fileCacheReadLatencies                                                                                metric.Int64Histogram
 	fsOpsLatency                                                                                          metric.Int64Histogram
+	gcRunDuration                                                                                         metric.Int64Histogram
 	gcsRequestLatencies                                                                                   metric.Int64Histogram
 	readBlockSizes                                                                                        metric.Int64Histogram
 }
 
+func (o *otelMetrics) BufferedReadBlockReadCount(
+	inc int64, cacheHit bool) {
+	if inc < 0 {
+		logger.Errorf("Counter metric buffered_read/block_read_count received a negative increment: %d", inc)
+		return
+	}
+	switch cacheHit {
+	case true:
+		o.bufferedReadBlockReadCountCacheHitTrueAtomic.Add(inc)
+	case false:
+		o.bufferedReadBlockReadCountCacheHitFalseAtomic.Add(inc)
+	}
+}
+
+func (o *otelMetrics) BufferedReadDownloadBlockLatency(
+	ctx context.Context, latency time.Duration, blockSize BlockSize) {
+	var record histogramRecord
+	switch blockSize {
+	case BlockSizeLargeAttr:
+		record = histogramRecord{ctx: ctx, instrument: o.bufferedReadDownloadBlockLatency, value: latency.Microseconds(), attributes: bufferedReadDownloadBlockLatencyBlockSizeLargeAttrSet}
+	case BlockSizeMediumAttr:
+		record = histogramRecord{ctx: ctx, instrument: o.bufferedReadDownloadBlockLatency, value: latency.Microseconds(), attributes: bufferedReadDownloadBlockLatencyBlockSizeMediumAttrSet}
+	case BlockSizeSmallAttr:
+		record = histogramRecord{ctx: ctx, instrument: o.bufferedReadDownloadBlockLatency, value: latency.Microseconds(), attributes: bufferedReadDownloadBlockLatencyBlockSizeSmallAttrSet}
+	default:
+		updateUnrecognizedAttribute(string(blockSize))
+		return
+	}
+
+	select {
+	case o.ch <- record: // Do nothing
+	default: // Unblock writes to channel if it's full.
+	}
+}
+
+func (o *otelMetrics) BufferedReadDownloadBytesCount(
+	inc int64, downloadClass DownloadClass) {
+	if inc < 0 {
+		logger.Errorf("Counter metric buffered_read/download_bytes_count received a negative increment: %d", inc)
+		return
+	}
+	switch downloadClass {
+	case DownloadClassCacheThroughAttr:
+		o.bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic.Add(inc)
+	case DownloadClassDemandAttr:
+		o.bufferedReadDownloadBytesCountDownloadClassDemandAtomic.Add(inc)
+	case DownloadClassPrefetchAttr:
+		o.bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic.Add(inc)
+	case DownloadClassWarmupAttr:
+		o.bufferedReadDownloadBytesCountDownloadClassWarmupAtomic.Add(inc)
+	default:
+		updateUnrecognizedAttribute(string(downloadClass))
+		return
+	}
+}
+
+func (o *otelMetrics) BufferedReadDownloadCount(
+	inc int64, status Status) {
+	if inc < 0 {
+		logger.Errorf("Counter metric buffered_read/download_count received a negative increment: %d", inc)
+		return
+	}
+	switch status {
+	case StatusFailedAttr:
+		o.bufferedReadDownloadCountStatusFailedAtomic.Add(inc)
+	case StatusRetriedAttr:
+		o.bufferedReadDownloadCountStatusRetriedAtomic.Add(inc)
+	case StatusSucceededAttr:
+		o.bufferedReadDownloadCountStatusSucceededAtomic.Add(inc)
+	default:
+		updateUnrecognizedAttribute(string(status))
+		return
+	}
+}
+
+func (o *otelMetrics) BufferedReadDownloadsQueuedForInflightLimit(
+	inc int64) {
+	o.bufferedReadDownloadsQueuedForInflightLimitAtomic.Add(inc)
+}
+
+func (o *otelMetrics) BufferedReadDownloadsWaitingForObjectLimit(
+	inc int64) {
+	o.bufferedReadDownloadsWaitingForObjectLimitAtomic.Add(inc)
+}
+
 func (o *otelMetrics) BufferedReadFallbackTriggerCount(
 	inc int64, reason Reason) {
 	if inc < 0 {
@@ -1091,6 +1230,8 @@ func (o *otelMetrics) BufferedReadFallbackTriggerCount(
 		return
 	}
 	switch reason {
+	case ReasonDownloadFailedAttr:
+		o.bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic.Add(inc)
 	case ReasonInsufficientMemoryAttr:
 		o.bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic.Add(inc)
 	case ReasonRandomReadDetectedAttr:
@@ -1101,6 +1242,48 @@ func (o *otelMetrics) BufferedReadFallbackTriggerCount(
 	}
 }
 
+func (o *otelMetrics) BufferedReadObjectResidentBlocks(
+	ctx context.Context, value int64) {
+	record := histogramRecord{ctx: ctx, instrument: o.bufferedReadObjectResidentBlocks, value: value}
+
+	select {
+	case o.ch <- record: // Do nothing
+	default: // Unblock writes to channel if it's full.
+	}
+}
+
+func (o *otelMetrics) BufferedReadPinnedBytes(
+	inc int64) {
+	o.bufferedReadPinnedBytesAtomic.Add(inc)
+}
+
+func (o *otelMetrics) BufferedReadPreallocatedBytes(
+	inc int64) {
+	o.bufferedReadPreallocatedBytesAtomic.Add(inc)
+}
+
+func (o *otelMetrics) BufferedReadPrefetchCancelledBySeekCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric buffered_read/prefetch_cancelled_by_seek_count received a negative increment: %d", inc)
+		return
+	}
+	o.bufferedReadPrefetchCancelledBySeekCountAtomic.Add(inc)
+}
+
+func (o *otelMetrics) BufferedReadPrefetchModeFiles(
+	inc int64, prefetchMode PrefetchMode) {
+	switch prefetchMode {
+	case PrefetchModeDemandOnlyAttr:
+		o.bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic.Add(inc)
+	case PrefetchModePrefetchAttr:
+		o.bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic.Add(inc)
+	default:
+		updateUnrecognizedAttribute(string(prefetchMode))
+		return
+	}
+}
+
 func (o *otelMetrics) BufferedReadReadLatency(
 	ctx context.Context, latency time.Duration) {
 	record := histogramRecord{ctx: ctx, instrument: o.bufferedReadReadLatency, value: latency.Microseconds()}
@@ -1111,6 +1294,37 @@ func (o *otelMetrics) BufferedReadReadLatency(
 	}
 }
 
+func (o *otelMetrics) BufferedReadWarmupObjects(
+	inc int64, warmupState WarmupState) {
+	switch warmupState {
+	case WarmupStateDoneAttr:
+		o.bufferedReadWarmupObjectsWarmupStateDoneAtomic.Add(inc)
+	case WarmupStateTotalAttr:
+		o.bufferedReadWarmupObjectsWarmupStateTotalAtomic.Add(inc)
+	default:
+		updateUnrecognizedAttribute(string(warmupState))
+		return
+	}
+}
+
+func (o *otelMetrics) FileCacheDirectReadCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric file_cache/direct_read_count received a negative increment: %d", inc)
+		return
+	}
+	o.fileCacheDirectReadCountAtomic.Add(inc)
+}
+
+func (o *otelMetrics) FileCacheFullObjectDownloadCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric file_cache/full_object_download_count received a negative increment: %d", inc)
+		return
+	}
+	o.fileCacheFullObjectDownloadCountAtomic.Add(inc)
+}
+
 func (o *otelMetrics) FileCacheReadBytesCount(
 	inc int64, readType ReadType) {
 	if inc < 0 {
@@ -1186,6 +1400,15 @@ func (o *otelMetrics) FileCacheReadLatencies(
 	}
 }
 
+func (o *otelMetrics) FsOpenRejectedCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric fs/open_rejected_count received a negative increment: %d", inc)
+		return
+	}
+	o.fsOpenRejectedCountAtomic.Add(inc)
+}
+
 func (o *otelMetrics) FsOpsCount(
 	inc int64, fsOp FsOp) {
 	if inc < 0 {
@@ -2306,6 +2529,57 @@ func (o *otelMetrics) FsStreamingWriteFallbackCount(
 	}
 }
 
+func (o *otelMetrics) GcDeleteBacklog(
+	inc int64) {
+	o.gcDeleteBacklogAtomic.Add(inc)
+}
+
+func (o *otelMetrics) GcDeleteThrottledTime(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric gc/delete_throttled_time received a negative increment: %d", inc)
+		return
+	}
+	o.gcDeleteThrottledTimeAtomic.Add(inc)
+}
+
+func (o *otelMetrics) GcObjectsDeletedCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric gc/objects_deleted_count received a negative increment: %d", inc)
+		return
+	}
+	o.gcObjectsDeletedCountAtomic.Add(inc)
+}
+
+func (o *otelMetrics) GcRunDuration(
+	ctx context.Context, latency time.Duration) {
+	record := histogramRecord{ctx: ctx, instrument: o.gcRunDuration, value: latency.Milliseconds()}
+
+	select {
+	case o.ch <- record: // Do nothing
+	default: // Unblock writes to channel if it's full.
+	}
+}
+
+func (o *otelMetrics) GcRunFailedCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric gc/run_failed_count received a negative increment: %d", inc)
+		return
+	}
+	o.gcRunFailedCountAtomic.Add(inc)
+}
+
+func (o *otelMetrics) GcRunSkippedOverlapCount(
+	inc int64) {
+	if inc < 0 {
+		logger.Errorf("Counter metric gc/run_skipped_overlap_count received a negative increment: %d", inc)
+		return
+	}
+	o.gcRunSkippedOverlapCountAtomic.Add(inc)
+}
+
 func (o *otelMetrics) GcsDownloadBytesCount(
 	inc int64, readType ReadType) {
 	if inc < 0 {
@@ -2357,6 +2631,23 @@ func (o *otelMetrics) GcsReadCount(
 	}
 }
 
+func (o *otelMetrics) GcsReadHandleCount(
+	inc int64, readHandleOutcome ReadHandleOutcome) {
+	if inc < 0 {
+		logger.Errorf("Counter metric gcs/read_handle_count received a negative increment: %d", inc)
+		return
+	}
+	switch readHandleOutcome {
+	case ReadHandleOutcomeRejectedAttr:
+		o.gcsReadHandleCountReadHandleOutcomeRejectedAtomic.Add(inc)
+	case ReadHandleOutcomeReusedAttr:
+		o.gcsReadHandleCountReadHandleOutcomeReusedAtomic.Add(inc)
+	default:
+		updateUnrecognizedAttribute(string(readHandleOutcome))
+		return
+	}
+}
+
 func (o *otelMetrics) GcsReaderCount(
 	inc int64, ioMethod IoMethod) {
 	if inc < 0 {
@@ -2403,6 +2694,8 @@ func (o *otelMetrics) GcsRequestCount(
 		o.gcsRequestCountGcsMethodFlushPendingWritesAtomic.Add(inc)
 	case GcsMethodGetFolderAttr:
 		o.gcsRequestCountGcsMethodGetFolderAtomic.Add(inc)
+	case GcsMethodGetLifecycleRulesAttr:
+		o.gcsRequestCountGcsMethodGetLifecycleRulesAtomic.Add(inc)
 	case GcsMethodListObjectsAttr:
 		o.gcsRequestCountGcsMethodListObjectsAtomic.Add(inc)
 	case GcsMethodMoveObjectAttr:
@@ -2451,6 +2744,8 @@ func (o *otelMetrics) GcsRequestLatencies(
 		record = histogramRecord{ctx: ctx, instrument: o.gcsRequestLatencies, value: latency.Milliseconds(), attributes: gcsRequestLatenciesGcsMethodFlushPendingWritesAttrSet}
 	case GcsMethodGetFolderAttr:
 		record = histogramRecord{ctx: ctx, instrument: o.gcsRequestLatencies, value: latency.Milliseconds(), attributes: gcsRequestLatenciesGcsMethodGetFolderAttrSet}
+	case GcsMethodGetLifecycleRulesAttr:
+		record = histogramRecord{ctx: ctx, instrument: o.gcsRequestLatencies, value: latency.Milliseconds(), attributes: gcsRequestLatenciesGcsMethodGetLifecycleRulesAttrSet}
 	case GcsMethodListObjectsAttr:
 		record = histogramRecord{ctx: ctx, instrument: o.gcsRequestLatencies, value: latency.Milliseconds(), attributes: gcsRequestLatenciesGcsMethodListObjectsAttrSet}
 	case GcsMethodMoveObjectAttr:
@@ -2541,9 +2836,42 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		}()
 	}
 	meter := otel.Meter("gcsfuse")
-	var bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic,
+	var bufferedReadBlockReadCountCacheHitTrueAtomic,
+		bufferedReadBlockReadCountCacheHitFalseAtomic atomic.Int64
+
+	var bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic,
+		bufferedReadDownloadBytesCountDownloadClassDemandAtomic,
+		bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic,
+		bufferedReadDownloadBytesCountDownloadClassWarmupAtomic atomic.Int64
+
+	var bufferedReadDownloadCountStatusFailedAtomic,
+		bufferedReadDownloadCountStatusRetriedAtomic,
+		bufferedReadDownloadCountStatusSucceededAtomic atomic.Int64
+
+	var bufferedReadDownloadsQueuedForInflightLimitAtomic atomic.Int64
+
+	var bufferedReadDownloadsWaitingForObjectLimitAtomic atomic.Int64
+
+	var bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic,
+		bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic,
 		bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic atomic.Int64
 
+	var bufferedReadPinnedBytesAtomic atomic.Int64
+
+	var bufferedReadPreallocatedBytesAtomic atomic.Int64
+
+	var bufferedReadPrefetchCancelledBySeekCountAtomic atomic.Int64
+
+	var bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic,
+		bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic atomic.Int64
+
+	var bufferedReadWarmupObjectsWarmupStateDoneAtomic,
+		bufferedReadWarmupObjectsWarmupStateTotalAtomic atomic.Int64
+
+	var fileCacheDirectReadCountAtomic atomic.Int64
+
+	var fileCacheFullObjectDownloadCountAtomic atomic.Int64
+
 	var fileCacheReadBytesCountReadTypeParallelAtomic,
 		fileCacheReadBytesCountReadTypeRandomAtomic,
 		fileCacheReadBytesCountReadTypeSequentialAtomic,
@@ -2558,6 +2886,8 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		fileCacheReadCountCacheHitFalseReadTypeSequentialAtomic,
 		fileCacheReadCountCacheHitFalseReadTypeUnknownAtomic atomic.Int64
 
+	var fsOpenRejectedCountAtomic atomic.Int64
+
 	var fsOpsCountFsOpBatchForgetAtomic,
 		fsOpsCountFsOpCreateFileAtomic,
 		fsOpsCountFsOpCreateLinkAtomic,
@@ -3006,6 +3336,16 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOtherAtomic,
 		fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOutOfOrderAtomic atomic.Int64
 
+	var gcDeleteBacklogAtomic atomic.Int64
+
+	var gcDeleteThrottledTimeAtomic atomic.Int64
+
+	var gcObjectsDeletedCountAtomic atomic.Int64
+
+	var gcRunFailedCountAtomic atomic.Int64
+
+	var gcRunSkippedOverlapCountAtomic atomic.Int64
+
 	var gcsDownloadBytesCountReadTypeBufferedAtomic,
 		gcsDownloadBytesCountReadTypeParallelAtomic,
 		gcsDownloadBytesCountReadTypeRandomAtomic,
@@ -3018,6 +3358,9 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		gcsReadCountReadTypeSequentialAtomic,
 		gcsReadCountReadTypeUnknownAtomic atomic.Int64
 
+	var gcsReadHandleCountReadHandleOutcomeRejectedAtomic,
+		gcsReadHandleCountReadHandleOutcomeReusedAtomic atomic.Int64
+
 	var gcsReaderCountIoMethodClosedAtomic,
 		gcsReaderCountIoMethodOpenedAtomic atomic.Int64
 
@@ -3032,6 +3375,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		gcsRequestCountGcsMethodFinalizeUploadAtomic,
 		gcsRequestCountGcsMethodFlushPendingWritesAtomic,
 		gcsRequestCountGcsMethodGetFolderAtomic,
+		gcsRequestCountGcsMethodGetLifecycleRulesAtomic,
 		gcsRequestCountGcsMethodListObjectsAtomic,
 		gcsRequestCountGcsMethodMoveObjectAtomic,
 		gcsRequestCountGcsMethodMultiRangeDownloaderAddAtomic,
@@ -3049,21 +3393,136 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 	var testUpdownCounterWithAttrsRequestTypeAttr1Atomic,
 		testUpdownCounterWithAttrsRequestTypeAttr2Atomic atomic.Int64
 
-	_, err0 := meter.Int64ObservableCounter("buffered_read/fallback_trigger_count",
-		metric.WithDescription("The cumulative number of times the BufferedReader falls back to a different reader, along with the reason: random_read_detected or insufficient_memory."),
+	_, err0 := meter.Int64ObservableCounter("buffered_read/block_read_count",
+		metric.WithDescription("The cumulative number of blocks read by the buffered reader, counted once per block, along with cache hit - true for the blocks prefetched ahead of the read needing them, false for the blocks downloaded on demand, e.g. after a seek or a failed download."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &bufferedReadBlockReadCountCacheHitTrueAtomic, bufferedReadBlockReadCountCacheHitTrueAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadBlockReadCountCacheHitFalseAtomic, bufferedReadBlockReadCountCacheHitFalseAttrSet)
+			return nil
+		}))
+
+	bufferedReadDownloadBlockLatency, err1 := meter.Int64Histogram("buffered_read/download_block_latency",
+		metric.WithDescription("The cumulative distribution of the latencies of successful buffered read block downloads, along with the size of the blocks: small below 4 MiB, medium below 16 MiB and large from 16 MiB."),
+		metric.WithUnit("us"),
+		metric.WithExplicitBucketBoundaries(50, 100, 200, 400, 800, 1500, 3000, 5000, 10000, 20000, 50000, 100000, 200000, 500000, 1000000, 2000000, 5000000, 10000000, 20000000, 50000000, 100000000, 200000000, 500000000))
+
+	_, err2 := meter.Int64ObservableCounter("buffered_read/download_bytes_count",
+		metric.WithDescription("The cumulative number of bytes downloaded by the buffered reader, along with the class of the download: demand for blocks read right away, prefetch for speculatively prefetched blocks, warmup for blocks of ranges advised as needed, and cache_through for blocks kept in the pinned block store."),
+		metric.WithUnit("By"),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic, bufferedReadDownloadBytesCountDownloadClassCacheThroughAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadDownloadBytesCountDownloadClassDemandAtomic, bufferedReadDownloadBytesCountDownloadClassDemandAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic, bufferedReadDownloadBytesCountDownloadClassPrefetchAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadDownloadBytesCountDownloadClassWarmupAtomic, bufferedReadDownloadBytesCountDownloadClassWarmupAttrSet)
+			return nil
+		}))
+
+	_, err3 := meter.Int64ObservableCounter("buffered_read/download_count",
+		metric.WithDescription("The cumulative number of buffered read block download attempts, along with their status: succeeded, failed for the attempts failing the block, and retried for the failed attempts followed by another one."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &bufferedReadDownloadCountStatusFailedAtomic, bufferedReadDownloadCountStatusFailedAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadDownloadCountStatusRetriedAtomic, bufferedReadDownloadCountStatusRetriedAttrSet)
+			conditionallyObserve(obsrv, &bufferedReadDownloadCountStatusSucceededAtomic, bufferedReadDownloadCountStatusSucceededAttrSet)
+			return nil
+		}))
+
+	_, err4 := meter.Int64ObservableUpDownCounter("buffered_read/downloads_queued_for_inflight_limit",
+		metric.WithDescription("The number of buffered read block downloads currently queued because the limit of downloads in flight across the mount was reached."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadDownloadsQueuedForInflightLimitAtomic)
+			return nil
+		}))
+
+	_, err5 := meter.Int64ObservableUpDownCounter("buffered_read/downloads_waiting_for_object_limit",
+		metric.WithDescription("The number of buffered read block downloads currently waiting for a slot of the per-object concurrent download limit of their object."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadDownloadsWaitingForObjectLimitAtomic)
+			return nil
+		}))
+
+	_, err6 := meter.Int64ObservableCounter("buffered_read/fallback_trigger_count",
+		metric.WithDescription("The cumulative number of times the BufferedReader falls back to a different reader, along with the reason: download_failed, random_read_detected or insufficient_memory."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic, bufferedReadFallbackTriggerCountReasonDownloadFailedAttrSet)
 			conditionallyObserve(obsrv, &bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic, bufferedReadFallbackTriggerCountReasonInsufficientMemoryAttrSet)
 			conditionallyObserve(obsrv, &bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic, bufferedReadFallbackTriggerCountReasonRandomReadDetectedAttrSet)
 			return nil
 		}))
 
-	bufferedReadReadLatency, err1 := meter.Int64Histogram("buffered_read/read_latency",
+	bufferedReadObjectResidentBlocks, err7 := meter.Int64Histogram("buffered_read/object_resident_blocks",
+		metric.WithDescription("The cumulative distribution of the number of blocks of an object held in the buffered read block pool across its file handles, recorded whenever a block of the object is taken from the pool."),
+		metric.WithUnit(""),
+		metric.WithExplicitBucketBoundaries(1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024))
+
+	_, err8 := meter.Int64ObservableUpDownCounter("buffered_read/pinned_bytes",
+		metric.WithDescription("The number of bytes of the blocks of the pinned objects currently kept in memory."),
+		metric.WithUnit("By"),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadPinnedBytesAtomic)
+			return nil
+		}))
+
+	_, err9 := meter.Int64ObservableUpDownCounter("buffered_read/preallocated_bytes",
+		metric.WithDescription("The number of bytes of the buffered read blocks preallocated at mount time. Zero if preallocation is disabled or failed."),
+		metric.WithUnit("By"),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadPreallocatedBytesAtomic)
+			return nil
+		}))
+
+	_, err10 := meter.Int64ObservableCounter("buffered_read/prefetch_cancelled_by_seek_count",
+		metric.WithDescription("The cumulative number of in-flight prefetch downloads cancelled because the read seeked backward before the prefetched blocks."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &bufferedReadPrefetchCancelledBySeekCountAtomic)
+			return nil
+		}))
+
+	_, err11 := meter.Int64ObservableUpDownCounter("buffered_read/prefetch_mode_files",
+		metric.WithDescription("The number of files currently open for buffered reads, along with whether they are prefetching or served demand-only because the prefetch file limit was reached."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic, bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAttrSet)
+			observeUpDownCounter(obsrv, &bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic, bufferedReadPrefetchModeFilesPrefetchModePrefetchAttrSet)
+			return nil
+		}))
+
+	bufferedReadReadLatency, err12 := meter.Int64Histogram("buffered_read/read_latency",
 		metric.WithDescription("The cumulative distribution of latencies for ReadAt calls served by the buffered reader."),
 		metric.WithUnit("us"),
 		metric.WithExplicitBucketBoundaries(50, 100, 200, 400, 800, 1500, 3000, 5000, 10000, 20000, 50000, 100000, 200000, 500000, 1000000, 2000000, 5000000, 10000000, 20000000, 50000000, 100000000, 200000000, 500000000))
 
-	_, err2 := meter.Int64ObservableCounter("file_cache/read_bytes_count",
+	_, err13 := meter.Int64ObservableUpDownCounter("buffered_read/warmup_objects",
+		metric.WithDescription("The number of objects of the warm state file whose blocks are pinned again at mount, along with whether the count is of all the objects (total) or of those done."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &bufferedReadWarmupObjectsWarmupStateDoneAtomic, bufferedReadWarmupObjectsWarmupStateDoneAttrSet)
+			observeUpDownCounter(obsrv, &bufferedReadWarmupObjectsWarmupStateTotalAtomic, bufferedReadWarmupObjectsWarmupStateTotalAttrSet)
+			return nil
+		}))
+
+	_, err14 := meter.Int64ObservableCounter("file_cache/direct_read_count",
+		metric.WithDescription("The cumulative number of reads of fully cached files served from the file cache without using buffered read blocks."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &fileCacheDirectReadCountAtomic)
+			return nil
+		}))
+
+	_, err15 := meter.Int64ObservableCounter("file_cache/full_object_download_count",
+		metric.WithDescription("The cumulative number of full-object downloads into the file cache triggered by the first reads of objects not fully cached, with the file cache preferred after full-object downloads."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &fileCacheFullObjectDownloadCountAtomic)
+			return nil
+		}))
+
+	_, err16 := meter.Int64ObservableCounter("file_cache/read_bytes_count",
 		metric.WithDescription("The cumulative number of bytes read from file cache along with read type - Sequential/Random"),
 		metric.WithUnit("By"),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3074,7 +3533,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err3 := meter.Int64ObservableCounter("file_cache/read_count",
+	_, err17 := meter.Int64ObservableCounter("file_cache/read_count",
 		metric.WithDescription("Specifies the number of read requests made via file cache along with type - Sequential/Random and cache hit - true/false"),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3089,12 +3548,20 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	fileCacheReadLatencies, err4 := meter.Int64Histogram("file_cache/read_latencies",
+	fileCacheReadLatencies, err18 := meter.Int64Histogram("file_cache/read_latencies",
 		metric.WithDescription("The cumulative distribution of the file cache read latencies along with cache hit - true/false."),
 		metric.WithUnit("us"),
 		metric.WithExplicitBucketBoundaries(50, 100, 200, 400, 800, 1500, 3000, 5000, 10000, 20000, 50000, 100000, 200000, 500000, 1000000, 2000000, 5000000, 10000000, 20000000, 50000000, 100000000, 200000000, 500000000))
 
-	_, err5 := meter.Int64ObservableCounter("fs/ops_count",
+	_, err19 := meter.Int64ObservableCounter("fs/open_rejected_count",
+		metric.WithDescription("The cumulative number of file opens rejected because the file reached the maximum number of open handles."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &fsOpenRejectedCountAtomic)
+			return nil
+		}))
+
+	_, err20 := meter.Int64ObservableCounter("fs/ops_count",
 		metric.WithDescription("The cumulative number of ops processed by the file system."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3126,7 +3593,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err6 := meter.Int64ObservableCounter("fs/ops_error_count",
+	_, err21 := meter.Int64ObservableCounter("fs/ops_error_count",
 		metric.WithDescription("The cumulative number of errors generated by file system operations."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3533,12 +4000,12 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	fsOpsLatency, err7 := meter.Int64Histogram("fs/ops_latency",
+	fsOpsLatency, err22 := meter.Int64Histogram("fs/ops_latency",
 		metric.WithDescription("The cumulative distribution of file system operation latencies"),
 		metric.WithUnit("us"),
 		metric.WithExplicitBucketBoundaries(50, 100, 200, 400, 800, 1500, 3000, 5000, 10000, 20000, 50000, 100000, 200000, 500000, 1000000, 2000000, 5000000, 10000000, 20000000, 50000000, 100000000, 200000000, 500000000))
 
-	_, err8 := meter.Int64ObservableCounter("fs/streaming_write_fallback_count",
+	_, err23 := meter.Int64ObservableCounter("fs/streaming_write_fallback_count",
 		metric.WithDescription("The cumulative number of streaming write fallbacks with reason attached"),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3565,7 +4032,52 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err9 := meter.Int64ObservableCounter("gcs/download_bytes_count",
+	_, err24 := meter.Int64ObservableUpDownCounter("gc/delete_backlog",
+		metric.WithDescription("The number of stale temporary objects currently listed by garbage collection but not yet deleted."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			observeUpDownCounter(obsrv, &gcDeleteBacklogAtomic)
+			return nil
+		}))
+
+	_, err25 := meter.Int64ObservableCounter("gc/delete_throttled_time",
+		metric.WithDescription("The cumulative time garbage collection runs of temporary objects waited for the delete rate limit before deleting objects."),
+		metric.WithUnit("us"),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &gcDeleteThrottledTimeAtomic)
+			return nil
+		}))
+
+	_, err26 := meter.Int64ObservableCounter("gc/objects_deleted_count",
+		metric.WithDescription("The cumulative number of stale temporary objects deleted by garbage collection runs."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &gcObjectsDeletedCountAtomic)
+			return nil
+		}))
+
+	gcRunDuration, err27 := meter.Int64Histogram("gc/run_duration",
+		metric.WithDescription("The cumulative distribution of the durations of garbage collection runs of temporary objects, successful or not."),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(100, 1000, 5000, 10000, 30000, 60000, 120000, 300000, 600000, 1800000))
+
+	_, err28 := meter.Int64ObservableCounter("gc/run_failed_count",
+		metric.WithDescription("The cumulative number of garbage collection runs of temporary objects which failed, retries included."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &gcRunFailedCountAtomic)
+			return nil
+		}))
+
+	_, err29 := meter.Int64ObservableCounter("gc/run_skipped_overlap_count",
+		metric.WithDescription("The cumulative number of garbage collection runs of temporary objects skipped because the previous run was still in progress."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &gcRunSkippedOverlapCountAtomic)
+			return nil
+		}))
+
+	_, err30 := meter.Int64ObservableCounter("gcs/download_bytes_count",
 		metric.WithDescription("The cumulative number of bytes downloaded from GCS along with type - Sequential/Random"),
 		metric.WithUnit("By"),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3576,7 +4088,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err10 := meter.Int64ObservableCounter("gcs/read_bytes_count",
+	_, err31 := meter.Int64ObservableCounter("gcs/read_bytes_count",
 		metric.WithDescription("The cumulative number of bytes read from GCS objects."),
 		metric.WithUnit("By"),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3584,7 +4096,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err11 := meter.Int64ObservableCounter("gcs/read_count",
+	_, err32 := meter.Int64ObservableCounter("gcs/read_count",
 		metric.WithDescription("Specifies the number of gcs reads made along with type - Sequential/Random"),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3595,7 +4107,16 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err12 := meter.Int64ObservableCounter("gcs/reader_count",
+	_, err33 := meter.Int64ObservableCounter("gcs/read_handle_count",
+		metric.WithDescription("The cumulative number of GCS reads of zonal bucket objects carrying the read handle of a previous read, along with whether the handle was reused, or rejected in which case the read went through the full auth and metadata checks."),
+		metric.WithUnit(""),
+		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
+			conditionallyObserve(obsrv, &gcsReadHandleCountReadHandleOutcomeRejectedAtomic, gcsReadHandleCountReadHandleOutcomeRejectedAttrSet)
+			conditionallyObserve(obsrv, &gcsReadHandleCountReadHandleOutcomeReusedAtomic, gcsReadHandleCountReadHandleOutcomeReusedAttrSet)
+			return nil
+		}))
+
+	_, err34 := meter.Int64ObservableCounter("gcs/reader_count",
 		metric.WithDescription("The cumulative number of GCS object readers opened or closed."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3604,7 +4125,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err13 := meter.Int64ObservableCounter("gcs/request_count",
+	_, err35 := meter.Int64ObservableCounter("gcs/request_count",
 		metric.WithDescription("The cumulative number of GCS requests processed along with the GCS method."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3619,6 +4140,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodFinalizeUploadAtomic, gcsRequestCountGcsMethodFinalizeUploadAttrSet)
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodFlushPendingWritesAtomic, gcsRequestCountGcsMethodFlushPendingWritesAttrSet)
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodGetFolderAtomic, gcsRequestCountGcsMethodGetFolderAttrSet)
+			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodGetLifecycleRulesAtomic, gcsRequestCountGcsMethodGetLifecycleRulesAttrSet)
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodListObjectsAtomic, gcsRequestCountGcsMethodListObjectsAttrSet)
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodMoveObjectAtomic, gcsRequestCountGcsMethodMoveObjectAttrSet)
 			conditionallyObserve(obsrv, &gcsRequestCountGcsMethodMultiRangeDownloaderAddAtomic, gcsRequestCountGcsMethodMultiRangeDownloaderAddAttrSet)
@@ -3630,12 +4152,12 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	gcsRequestLatencies, err14 := meter.Int64Histogram("gcs/request_latencies",
+	gcsRequestLatencies, err36 := meter.Int64Histogram("gcs/request_latencies",
 		metric.WithDescription("The cumulative distribution of the GCS request latencies."),
 		metric.WithUnit("ms"),
 		metric.WithExplicitBucketBoundaries(100, 200, 400, 800, 1500, 3000, 5000, 10000, 20000, 50000, 100000, 200000, 500000))
 
-	_, err15 := meter.Int64ObservableCounter("gcs/retry_count",
+	_, err37 := meter.Int64ObservableCounter("gcs/retry_count",
 		metric.WithDescription("The cumulative number of retry requests made to GCS."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3644,12 +4166,12 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	readBlockSizes, err16 := meter.Int64Histogram("read/block_sizes",
+	readBlockSizes, err38 := meter.Int64Histogram("read/block_sizes",
 		metric.WithDescription("The cumulative distribution of read block sizes across different bucket boundaries"),
 		metric.WithUnit("By"),
 		metric.WithExplicitBucketBoundaries(0, 8192, 16384, 32768, 65536, 131072, 262144, 524288, 1048576, 2097152, 4194304, 8388608, 16777216, 33554432, 67108864, 134217728))
 
-	_, err17 := meter.Int64ObservableUpDownCounter("test/updown_counter",
+	_, err39 := meter.Int64ObservableUpDownCounter("test/updown_counter",
 		metric.WithDescription("Test metric for updown counters."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3657,7 +4179,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	_, err18 := meter.Int64ObservableUpDownCounter("test/updown_counter_with_attrs",
+	_, err40 := meter.Int64ObservableUpDownCounter("test/updown_counter_with_attrs",
 		metric.WithDescription("Test metric for updown counters with attributes."),
 		metric.WithUnit(""),
 		metric.WithInt64Callback(func(_ context.Context, obsrv metric.Int64Observer) error {
@@ -3666,7 +4188,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 			return nil
 		}))
 
-	errs := []error{err0, err1, err2, err3, err4, err5, err6, err7, err8, err9, err10, err11, err12, err13, err14, err15, err16, err17, err18}
+	errs := []error{err0, err1, err2, err3, err4, err5, err6, err7, err8, err9, err10, err11, err12, err13, err14, err15, err16, err17, err18, err19, err20, err21, err22, err23, err24, err25, err26, err27, err28, err29, err30, err31, err32, err33, err34, err35, err36, err37, err38, err39, err40}
 	if err := errors.Join(errs...); err != nil {
 		return nil, err
 	}
@@ -3674,9 +4196,32 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 	return &otelMetrics{
 		ch: ch,
 		wg: &wg,
-		bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic: &bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic,
-		bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic: &bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic,
+		bufferedReadBlockReadCountCacheHitTrueAtomic:                                       &bufferedReadBlockReadCountCacheHitTrueAtomic,
+		bufferedReadBlockReadCountCacheHitFalseAtomic:                                      &bufferedReadBlockReadCountCacheHitFalseAtomic,
+		bufferedReadDownloadBlockLatency:                                                   bufferedReadDownloadBlockLatency,
+		bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic:                      &bufferedReadDownloadBytesCountDownloadClassCacheThroughAtomic,
+		bufferedReadDownloadBytesCountDownloadClassDemandAtomic:                            &bufferedReadDownloadBytesCountDownloadClassDemandAtomic,
+		bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic:                          &bufferedReadDownloadBytesCountDownloadClassPrefetchAtomic,
+		bufferedReadDownloadBytesCountDownloadClassWarmupAtomic:                            &bufferedReadDownloadBytesCountDownloadClassWarmupAtomic,
+		bufferedReadDownloadCountStatusFailedAtomic:                                        &bufferedReadDownloadCountStatusFailedAtomic,
+		bufferedReadDownloadCountStatusRetriedAtomic:                                       &bufferedReadDownloadCountStatusRetriedAtomic,
+		bufferedReadDownloadCountStatusSucceededAtomic:                                     &bufferedReadDownloadCountStatusSucceededAtomic,
+		bufferedReadDownloadsQueuedForInflightLimitAtomic:                                  &bufferedReadDownloadsQueuedForInflightLimitAtomic,
+		bufferedReadDownloadsWaitingForObjectLimitAtomic:                                   &bufferedReadDownloadsWaitingForObjectLimitAtomic,
+		bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic:                         &bufferedReadFallbackTriggerCountReasonDownloadFailedAtomic,
+		bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic:                     &bufferedReadFallbackTriggerCountReasonInsufficientMemoryAtomic,
+		bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic:                     &bufferedReadFallbackTriggerCountReasonRandomReadDetectedAtomic,
+		bufferedReadObjectResidentBlocks:                                                   bufferedReadObjectResidentBlocks,
+		bufferedReadPinnedBytesAtomic:                                                      &bufferedReadPinnedBytesAtomic,
+		bufferedReadPreallocatedBytesAtomic:                                                &bufferedReadPreallocatedBytesAtomic,
+		bufferedReadPrefetchCancelledBySeekCountAtomic:                                     &bufferedReadPrefetchCancelledBySeekCountAtomic,
+		bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic:                          &bufferedReadPrefetchModeFilesPrefetchModeDemandOnlyAtomic,
+		bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic:                            &bufferedReadPrefetchModeFilesPrefetchModePrefetchAtomic,
 		bufferedReadReadLatency:                                                            bufferedReadReadLatency,
+		bufferedReadWarmupObjectsWarmupStateDoneAtomic:                                     &bufferedReadWarmupObjectsWarmupStateDoneAtomic,
+		bufferedReadWarmupObjectsWarmupStateTotalAtomic:                                    &bufferedReadWarmupObjectsWarmupStateTotalAtomic,
+		fileCacheDirectReadCountAtomic:                                                     &fileCacheDirectReadCountAtomic,
+		fileCacheFullObjectDownloadCountAtomic:                                             &fileCacheFullObjectDownloadCountAtomic,
 		fileCacheReadBytesCountReadTypeParallelAtomic:                                      &fileCacheReadBytesCountReadTypeParallelAtomic,
 		fileCacheReadBytesCountReadTypeRandomAtomic:                                        &fileCacheReadBytesCountReadTypeRandomAtomic,
 		fileCacheReadBytesCountReadTypeSequentialAtomic:                                    &fileCacheReadBytesCountReadTypeSequentialAtomic,
@@ -3690,6 +4235,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		fileCacheReadCountCacheHitFalseReadTypeSequentialAtomic:                            &fileCacheReadCountCacheHitFalseReadTypeSequentialAtomic,
 		fileCacheReadCountCacheHitFalseReadTypeUnknownAtomic:                               &fileCacheReadCountCacheHitFalseReadTypeUnknownAtomic,
 		fileCacheReadLatencies:                                                             fileCacheReadLatencies,
+		fsOpenRejectedCountAtomic:                                                          &fsOpenRejectedCountAtomic,
 		fsOpsCountFsOpBatchForgetAtomic:                                                    &fsOpsCountFsOpBatchForgetAtomic,
 		fsOpsCountFsOpCreateFileAtomic:                                                     &fsOpsCountFsOpCreateFileAtomic,
 		fsOpsCountFsOpCreateLinkAtomic:                                                     &fsOpsCountFsOpCreateLinkAtomic,
@@ -4136,15 +4682,23 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonExistingFileAtomic:             &fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonExistingFileAtomic,
 		fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOtherAtomic:                    &fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOtherAtomic,
 		fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOutOfOrderAtomic:               &fsStreamingWriteFallbackCountOpenModeWriteOnlyAppendWriteFallbackReasonOutOfOrderAtomic,
-		gcsDownloadBytesCountReadTypeBufferedAtomic:                                                           &gcsDownloadBytesCountReadTypeBufferedAtomic,
-		gcsDownloadBytesCountReadTypeParallelAtomic:                                                           &gcsDownloadBytesCountReadTypeParallelAtomic,
-		gcsDownloadBytesCountReadTypeRandomAtomic:                                                             &gcsDownloadBytesCountReadTypeRandomAtomic,
-		gcsDownloadBytesCountReadTypeSequentialAtomic:                                                         &gcsDownloadBytesCountReadTypeSequentialAtomic,
+		gcDeleteBacklogAtomic:                                      &gcDeleteBacklogAtomic,
+		gcDeleteThrottledTimeAtomic:                                &gcDeleteThrottledTimeAtomic,
+		gcObjectsDeletedCountAtomic:                                &gcObjectsDeletedCountAtomic,
+		gcRunDuration:                                              gcRunDuration,
+		gcRunFailedCountAtomic:                                     &gcRunFailedCountAtomic,
+		gcRunSkippedOverlapCountAtomic:                             &gcRunSkippedOverlapCountAtomic,
+		gcsDownloadBytesCountReadTypeBufferedAtomic:                &gcsDownloadBytesCountReadTypeBufferedAtomic,
+		gcsDownloadBytesCountReadTypeParallelAtomic:                &gcsDownloadBytesCountReadTypeParallelAtomic,
+		gcsDownloadBytesCountReadTypeRandomAtomic:                  &gcsDownloadBytesCountReadTypeRandomAtomic,
+		gcsDownloadBytesCountReadTypeSequentialAtomic:              &gcsDownloadBytesCountReadTypeSequentialAtomic,
 		gcsReadBytesCountAtomic:                                    &gcsReadBytesCountAtomic,
 		gcsReadCountReadTypeParallelAtomic:                         &gcsReadCountReadTypeParallelAtomic,
 		gcsReadCountReadTypeRandomAtomic:                           &gcsReadCountReadTypeRandomAtomic,
 		gcsReadCountReadTypeSequentialAtomic:                       &gcsReadCountReadTypeSequentialAtomic,
 		gcsReadCountReadTypeUnknownAtomic:                          &gcsReadCountReadTypeUnknownAtomic,
+		gcsReadHandleCountReadHandleOutcomeRejectedAtomic:          &gcsReadHandleCountReadHandleOutcomeRejectedAtomic,
+		gcsReadHandleCountReadHandleOutcomeReusedAtomic:            &gcsReadHandleCountReadHandleOutcomeReusedAtomic,
 		gcsReaderCountIoMethodClosedAtomic:                         &gcsReaderCountIoMethodClosedAtomic,
 		gcsReaderCountIoMethodOpenedAtomic:                         &gcsReaderCountIoMethodOpenedAtomic,
 		gcsRequestCountGcsMethodComposeObjectsAtomic:               &gcsRequestCountGcsMethodComposeObjectsAtomic,
@@ -4158,6 +4712,7 @@ func NewOTelMetrics(ctx context.Context, workers int, bufferSize int) (*otelMetr
 		gcsRequestCountGcsMethodFinalizeUploadAtomic:               &gcsRequestCountGcsMethodFinalizeUploadAtomic,
 		gcsRequestCountGcsMethodFlushPendingWritesAtomic:           &gcsRequestCountGcsMethodFlushPendingWritesAtomic,
 		gcsRequestCountGcsMethodGetFolderAtomic:                    &gcsRequestCountGcsMethodGetFolderAtomic,
+		gcsRequestCountGcsMethodGetLifecycleRulesAtomic:            &gcsRequestCountGcsMethodGetLifecycleRulesAtomic,
 		gcsRequestCountGcsMethodListObjectsAtomic:                  &gcsRequestCountGcsMethodListObjectsAtomic,
 		gcsRequestCountGcsMethodMoveObjectAtomic:                   &gcsRequestCountGcsMethodMoveObjectAtomic,
 		gcsRequestCountGcsMethodMultiRangeDownloaderAddAtomic:      &gcsRequestCountGcsMethodMultiRangeDownloaderAddAtomic,
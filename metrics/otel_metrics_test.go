@@ -128,6 +128,343 @@ func gatherNonZeroCounterMetrics(ctx context.Context, t *testing.T, rd *metric.M
 	return results
 }
 
+func TestBufferedReadBlockReadCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "cache_hit_true",
+			f: func(m *otelMetrics) {
+				m.BufferedReadBlockReadCount(5, true)
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.Bool("cache_hit", true)): 5,
+			},
+		},
+		{
+			name: "cache_hit_false",
+			f: func(m *otelMetrics) {
+				m.BufferedReadBlockReadCount(5, false)
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.Bool("cache_hit", false)): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadBlockReadCount(5, true)
+				m.BufferedReadBlockReadCount(2, false)
+				m.BufferedReadBlockReadCount(3, true)
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.Bool("cache_hit", true)): 8,
+				attribute.NewSet(attribute.Bool("cache_hit", false)): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.BufferedReadBlockReadCount(-5, true)
+				m.BufferedReadBlockReadCount(2, true)
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.Bool("cache_hit", true)): 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/block_read_count"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "buffered_read/block_read_count metric should not be found")
+				return
+			}
+			require.True(t, ok, "buffered_read/block_read_count metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
+func TestBufferedReadDownloadBlockLatency(t *testing.T) {
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		blockSize BlockSize
+	}{
+		{
+			name:      "block_size_large",
+			latencies: []time.Duration{100 * time.Microsecond, 200 * time.Microsecond},
+			blockSize: "large",
+		},
+		{
+			name:      "block_size_medium",
+			latencies: []time.Duration{100 * time.Microsecond, 200 * time.Microsecond},
+			blockSize: "medium",
+		},
+		{
+			name:      "block_size_small",
+			latencies: []time.Duration{100 * time.Microsecond, 200 * time.Microsecond},
+			blockSize: "small",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+			var totalLatency time.Duration
+
+			for _, latency := range tc.latencies {
+				m.BufferedReadDownloadBlockLatency(ctx, latency, tc.blockSize)
+				totalLatency += latency
+			}
+			waitForMetricsProcessing()
+
+			metrics := gatherHistogramMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/download_block_latency"]
+			require.True(t, ok, "buffered_read/download_block_latency metric not found")
+
+			attrs := []attribute.KeyValue{
+				attribute.String("block_size", string(tc.blockSize)),
+			}
+			s := attribute.NewSet(attrs...)
+			expectedKey := s.Encoded(encoder)
+			dp, ok := metric[expectedKey]
+			require.True(t, ok, "DataPoint not found for key: %s", expectedKey)
+			assert.Equal(t, uint64(len(tc.latencies)), dp.Count)
+			assert.Equal(t, totalLatency.Microseconds(), dp.Sum)
+		})
+	}
+}
+
+func TestBufferedReadDownloadBytesCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "download_class_cache_through",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(5, "cache_through")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("download_class", "cache_through")): 5,
+			},
+		},
+		{
+			name: "download_class_demand",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(5, "demand")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("download_class", "demand")): 5,
+			},
+		},
+		{
+			name: "download_class_prefetch",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(5, "prefetch")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("download_class", "prefetch")): 5,
+			},
+		},
+		{
+			name: "download_class_warmup",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(5, "warmup")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("download_class", "warmup")): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(5, "cache_through")
+				m.BufferedReadDownloadBytesCount(2, "demand")
+				m.BufferedReadDownloadBytesCount(3, "cache_through")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("download_class", "cache_through")): 8,
+				attribute.NewSet(attribute.String("download_class", "demand")): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadBytesCount(-5, "cache_through")
+				m.BufferedReadDownloadBytesCount(2, "cache_through")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("download_class", "cache_through")): 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/download_bytes_count"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "buffered_read/download_bytes_count metric should not be found")
+				return
+			}
+			require.True(t, ok, "buffered_read/download_bytes_count metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
+func TestBufferedReadDownloadCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "status_failed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadCount(5, "failed")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("status", "failed")): 5,
+			},
+		},
+		{
+			name: "status_retried",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadCount(5, "retried")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("status", "retried")): 5,
+			},
+		},
+		{
+			name: "status_succeeded",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadCount(5, "succeeded")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("status", "succeeded")): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadCount(5, "failed")
+				m.BufferedReadDownloadCount(2, "retried")
+				m.BufferedReadDownloadCount(3, "failed")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("status", "failed")): 8,
+				attribute.NewSet(attribute.String("status", "retried")): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.BufferedReadDownloadCount(-5, "failed")
+				m.BufferedReadDownloadCount(2, "failed")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("status", "failed")): 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/download_count"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "buffered_read/download_count metric should not be found")
+				return
+			}
+			require.True(t, ok, "buffered_read/download_count metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
+func TestBufferedReadDownloadsQueuedForInflightLimit(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.BufferedReadDownloadsQueuedForInflightLimit(1024)
+	m.BufferedReadDownloadsQueuedForInflightLimit(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/downloads_queued_for_inflight_limit"]
+	require.True(t, ok, "buffered_read/downloads_queued_for_inflight_limit metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.BufferedReadDownloadsQueuedForInflightLimit(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["buffered_read/downloads_queued_for_inflight_limit"]
+	require.True(t, ok, "buffered_read/downloads_queued_for_inflight_limit metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 2972}, metric, "Negative increment should change the metric value.")
+}
+
+func TestBufferedReadDownloadsWaitingForObjectLimit(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.BufferedReadDownloadsWaitingForObjectLimit(1024)
+	m.BufferedReadDownloadsWaitingForObjectLimit(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/downloads_waiting_for_object_limit"]
+	require.True(t, ok, "buffered_read/downloads_waiting_for_object_limit metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.BufferedReadDownloadsWaitingForObjectLimit(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["buffered_read/downloads_waiting_for_object_limit"]
+	require.True(t, ok, "buffered_read/downloads_waiting_for_object_limit metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 2972}, metric, "Negative increment should change the metric value.")
+}
+
 func TestBufferedReadFallbackTriggerCount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -135,40 +472,312 @@ func TestBufferedReadFallbackTriggerCount(t *testing.T) {
 		expected map[attribute.Set]int64
 	}{
 		{
-			name: "reason_insufficient_memory",
+			name: "reason_download_failed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadFallbackTriggerCount(5, "download_failed")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("reason", "download_failed")): 5,
+			},
+		},
+		{
+			name: "reason_insufficient_memory",
+			f: func(m *otelMetrics) {
+				m.BufferedReadFallbackTriggerCount(5, "insufficient_memory")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("reason", "insufficient_memory")): 5,
+			},
+		},
+		{
+			name: "reason_random_read_detected",
+			f: func(m *otelMetrics) {
+				m.BufferedReadFallbackTriggerCount(5, "random_read_detected")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("reason", "random_read_detected")): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadFallbackTriggerCount(5, "download_failed")
+				m.BufferedReadFallbackTriggerCount(2, "insufficient_memory")
+				m.BufferedReadFallbackTriggerCount(3, "download_failed")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("reason", "download_failed")): 8,
+				attribute.NewSet(attribute.String("reason", "insufficient_memory")): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.BufferedReadFallbackTriggerCount(-5, "download_failed")
+				m.BufferedReadFallbackTriggerCount(2, "download_failed")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("reason", "download_failed")): 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/fallback_trigger_count"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "buffered_read/fallback_trigger_count metric should not be found")
+				return
+			}
+			require.True(t, ok, "buffered_read/fallback_trigger_count metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
+func TestBufferedReadObjectResidentBlocks(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+	var totalValue int64
+	values := []int64{100, 200}
+
+	for _, value := range values {
+		m.BufferedReadObjectResidentBlocks(ctx, value)
+		totalValue += value
+	}
+	waitForMetricsProcessing()
+
+	metrics := gatherHistogramMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/object_resident_blocks"]
+	require.True(t, ok, "buffered_read/object_resident_blocks metric not found")
+
+	s := attribute.NewSet()
+	expectedKey := s.Encoded(encoder)
+	dp, ok := metric[expectedKey]
+	require.True(t, ok, "DataPoint not found for key: %s", expectedKey)
+	assert.Equal(t, uint64(len(values)), dp.Count)
+	assert.Equal(t, totalValue, dp.Sum)
+}
+
+func TestBufferedReadPinnedBytes(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.BufferedReadPinnedBytes(1024)
+	m.BufferedReadPinnedBytes(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/pinned_bytes"]
+	require.True(t, ok, "buffered_read/pinned_bytes metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.BufferedReadPinnedBytes(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["buffered_read/pinned_bytes"]
+	require.True(t, ok, "buffered_read/pinned_bytes metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 2972}, metric, "Negative increment should change the metric value.")
+}
+
+func TestBufferedReadPreallocatedBytes(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.BufferedReadPreallocatedBytes(1024)
+	m.BufferedReadPreallocatedBytes(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/preallocated_bytes"]
+	require.True(t, ok, "buffered_read/preallocated_bytes metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.BufferedReadPreallocatedBytes(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["buffered_read/preallocated_bytes"]
+	require.True(t, ok, "buffered_read/preallocated_bytes metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 2972}, metric, "Negative increment should change the metric value.")
+}
+
+func TestBufferedReadPrefetchCancelledBySeekCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.BufferedReadPrefetchCancelledBySeekCount(1024)
+	m.BufferedReadPrefetchCancelledBySeekCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/prefetch_cancelled_by_seek_count"]
+	require.True(t, ok, "buffered_read/prefetch_cancelled_by_seek_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.BufferedReadPrefetchCancelledBySeekCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["buffered_read/prefetch_cancelled_by_seek_count"]
+	require.True(t, ok, "buffered_read/prefetch_cancelled_by_seek_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
+func TestBufferedReadPrefetchModeFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "prefetch_mode_demand_only",
+			f: func(m *otelMetrics) {
+				m.BufferedReadPrefetchModeFiles(5, "demand_only")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("prefetch_mode", "demand_only")): 5,
+			},
+		},
+		{
+			name: "prefetch_mode_prefetch",
+			f: func(m *otelMetrics) {
+				m.BufferedReadPrefetchModeFiles(5, "prefetch")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("prefetch_mode", "prefetch")): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.BufferedReadPrefetchModeFiles(5, "demand_only")
+				m.BufferedReadPrefetchModeFiles(2, "prefetch")
+				m.BufferedReadPrefetchModeFiles(3, "demand_only")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("prefetch_mode", "demand_only")): 8,
+				attribute.NewSet(attribute.String("prefetch_mode", "prefetch")): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.BufferedReadPrefetchModeFiles(-5, "demand_only")
+				m.BufferedReadPrefetchModeFiles(2, "demand_only")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("prefetch_mode", "demand_only")): -3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["buffered_read/prefetch_mode_files"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "buffered_read/prefetch_mode_files metric should not be found")
+				return
+			}
+			require.True(t, ok, "buffered_read/prefetch_mode_files metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
+func TestBufferedReadReadLatency(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+	var totalLatency time.Duration
+	latencies := []time.Duration{100 * time.Microsecond, 200 * time.Microsecond}
+
+	for _, latency := range latencies {
+		m.BufferedReadReadLatency(ctx, latency)
+		totalLatency += latency
+	}
+	waitForMetricsProcessing()
+
+	metrics := gatherHistogramMetrics(ctx, t, rd)
+	metric, ok := metrics["buffered_read/read_latency"]
+	require.True(t, ok, "buffered_read/read_latency metric not found")
+
+	s := attribute.NewSet()
+	expectedKey := s.Encoded(encoder)
+	dp, ok := metric[expectedKey]
+	require.True(t, ok, "DataPoint not found for key: %s", expectedKey)
+	assert.Equal(t, uint64(len(latencies)), dp.Count)
+	assert.Equal(t, totalLatency.Microseconds(), dp.Sum)
+}
+
+func TestBufferedReadWarmupObjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "warmup_state_done",
 			f: func(m *otelMetrics) {
-				m.BufferedReadFallbackTriggerCount(5, "insufficient_memory")
+				m.BufferedReadWarmupObjects(5, "done")
 			},
 			expected: map[attribute.Set]int64{
-				attribute.NewSet(attribute.String("reason", "insufficient_memory")): 5,
+				attribute.NewSet(attribute.String("warmup_state", "done")): 5,
 			},
 		},
 		{
-			name: "reason_random_read_detected",
+			name: "warmup_state_total",
 			f: func(m *otelMetrics) {
-				m.BufferedReadFallbackTriggerCount(5, "random_read_detected")
+				m.BufferedReadWarmupObjects(5, "total")
 			},
 			expected: map[attribute.Set]int64{
-				attribute.NewSet(attribute.String("reason", "random_read_detected")): 5,
+				attribute.NewSet(attribute.String("warmup_state", "total")): 5,
 			},
 		}, {
 			name: "multiple_attributes_summed",
 			f: func(m *otelMetrics) {
-				m.BufferedReadFallbackTriggerCount(5, "insufficient_memory")
-				m.BufferedReadFallbackTriggerCount(2, "random_read_detected")
-				m.BufferedReadFallbackTriggerCount(3, "insufficient_memory")
+				m.BufferedReadWarmupObjects(5, "done")
+				m.BufferedReadWarmupObjects(2, "total")
+				m.BufferedReadWarmupObjects(3, "done")
 			},
-			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("reason", "insufficient_memory")): 8,
-				attribute.NewSet(attribute.String("reason", "random_read_detected")): 2,
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("warmup_state", "done")): 8,
+				attribute.NewSet(attribute.String("warmup_state", "total")): 2,
 			},
 		},
 		{
 			name: "negative_increment",
 			f: func(m *otelMetrics) {
-				m.BufferedReadFallbackTriggerCount(-5, "insufficient_memory")
-				m.BufferedReadFallbackTriggerCount(2, "insufficient_memory")
+				m.BufferedReadWarmupObjects(-5, "done")
+				m.BufferedReadWarmupObjects(2, "done")
 			},
-			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("reason", "insufficient_memory")): 2},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("warmup_state", "done")): -3},
 		},
 	}
 
@@ -182,12 +791,12 @@ func TestBufferedReadFallbackTriggerCount(t *testing.T) {
 			waitForMetricsProcessing()
 
 			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
-			metric, ok := metrics["buffered_read/fallback_trigger_count"]
+			metric, ok := metrics["buffered_read/warmup_objects"]
 			if len(tc.expected) == 0 {
-				assert.False(t, ok, "buffered_read/fallback_trigger_count metric should not be found")
+				assert.False(t, ok, "buffered_read/warmup_objects metric should not be found")
 				return
 			}
-			require.True(t, ok, "buffered_read/fallback_trigger_count metric not found")
+			require.True(t, ok, "buffered_read/warmup_objects metric not found")
 			expectedMap := make(map[string]int64)
 			for k, v := range tc.expected {
 				expectedMap[k.Encoded(encoder)] = v
@@ -197,29 +806,54 @@ func TestBufferedReadFallbackTriggerCount(t *testing.T) {
 	}
 }
 
-func TestBufferedReadReadLatency(t *testing.T) {
+func TestFileCacheDirectReadCount(t *testing.T) {
 	ctx := context.Background()
 	encoder := attribute.DefaultEncoder()
 	m, rd := setupOTel(ctx, t)
-	var totalLatency time.Duration
-	latencies := []time.Duration{100 * time.Microsecond, 200 * time.Microsecond}
 
-	for _, latency := range latencies {
-		m.BufferedReadReadLatency(ctx, latency)
-		totalLatency += latency
-	}
+	m.FileCacheDirectReadCount(1024)
+	m.FileCacheDirectReadCount(2048)
 	waitForMetricsProcessing()
 
-	metrics := gatherHistogramMetrics(ctx, t, rd)
-	metric, ok := metrics["buffered_read/read_latency"]
-	require.True(t, ok, "buffered_read/read_latency metric not found")
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["file_cache/direct_read_count"]
+	require.True(t, ok, "file_cache/direct_read_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.FileCacheDirectReadCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["file_cache/direct_read_count"]
+	require.True(t, ok, "file_cache/direct_read_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
 
+func TestFileCacheFullObjectDownloadCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.FileCacheFullObjectDownloadCount(1024)
+	m.FileCacheFullObjectDownloadCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["file_cache/full_object_download_count"]
+	require.True(t, ok, "file_cache/full_object_download_count metric not found")
 	s := attribute.NewSet()
-	expectedKey := s.Encoded(encoder)
-	dp, ok := metric[expectedKey]
-	require.True(t, ok, "DataPoint not found for key: %s", expectedKey)
-	assert.Equal(t, uint64(len(latencies)), dp.Count)
-	assert.Equal(t, totalLatency.Microseconds(), dp.Sum)
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.FileCacheFullObjectDownloadCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["file_cache/full_object_download_count"]
+	require.True(t, ok, "file_cache/full_object_download_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
 }
 
 func TestFileCacheReadBytesCount(t *testing.T) {
@@ -480,6 +1114,31 @@ func TestFileCacheReadLatencies(t *testing.T) {
 	}
 }
 
+func TestFsOpenRejectedCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.FsOpenRejectedCount(1024)
+	m.FsOpenRejectedCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["fs/open_rejected_count"]
+	require.True(t, ok, "fs/open_rejected_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.FsOpenRejectedCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["fs/open_rejected_count"]
+	require.True(t, ok, "fs/open_rejected_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
 func TestFsOpsCount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4801,6 +5460,156 @@ func TestFsStreamingWriteFallbackCount(t *testing.T) {
 	}
 }
 
+func TestGcDeleteBacklog(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.GcDeleteBacklog(1024)
+	m.GcDeleteBacklog(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/delete_backlog"]
+	require.True(t, ok, "gc/delete_backlog metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.GcDeleteBacklog(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["gc/delete_backlog"]
+	require.True(t, ok, "gc/delete_backlog metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 2972}, metric, "Negative increment should change the metric value.")
+}
+
+func TestGcDeleteThrottledTime(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.GcDeleteThrottledTime(1024)
+	m.GcDeleteThrottledTime(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/delete_throttled_time"]
+	require.True(t, ok, "gc/delete_throttled_time metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.GcDeleteThrottledTime(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["gc/delete_throttled_time"]
+	require.True(t, ok, "gc/delete_throttled_time metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
+func TestGcObjectsDeletedCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.GcObjectsDeletedCount(1024)
+	m.GcObjectsDeletedCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/objects_deleted_count"]
+	require.True(t, ok, "gc/objects_deleted_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.GcObjectsDeletedCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["gc/objects_deleted_count"]
+	require.True(t, ok, "gc/objects_deleted_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
+func TestGcRunDuration(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+	var totalLatency time.Duration
+	latencies := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+
+	for _, latency := range latencies {
+		m.GcRunDuration(ctx, latency)
+		totalLatency += latency
+	}
+	waitForMetricsProcessing()
+
+	metrics := gatherHistogramMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/run_duration"]
+	require.True(t, ok, "gc/run_duration metric not found")
+
+	s := attribute.NewSet()
+	expectedKey := s.Encoded(encoder)
+	dp, ok := metric[expectedKey]
+	require.True(t, ok, "DataPoint not found for key: %s", expectedKey)
+	assert.Equal(t, uint64(len(latencies)), dp.Count)
+	assert.Equal(t, totalLatency.Milliseconds(), dp.Sum)
+}
+
+func TestGcRunFailedCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.GcRunFailedCount(1024)
+	m.GcRunFailedCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/run_failed_count"]
+	require.True(t, ok, "gc/run_failed_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.GcRunFailedCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["gc/run_failed_count"]
+	require.True(t, ok, "gc/run_failed_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
+func TestGcRunSkippedOverlapCount(t *testing.T) {
+	ctx := context.Background()
+	encoder := attribute.DefaultEncoder()
+	m, rd := setupOTel(ctx, t)
+
+	m.GcRunSkippedOverlapCount(1024)
+	m.GcRunSkippedOverlapCount(2048)
+	waitForMetricsProcessing()
+
+	metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok := metrics["gc/run_skipped_overlap_count"]
+	require.True(t, ok, "gc/run_skipped_overlap_count metric not found")
+	s := attribute.NewSet()
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Positive increments should be summed.")
+
+	// Test negative increment
+	m.GcRunSkippedOverlapCount(-100)
+	waitForMetricsProcessing()
+
+	metrics = gatherNonZeroCounterMetrics(ctx, t, rd)
+	metric, ok = metrics["gc/run_skipped_overlap_count"]
+	require.True(t, ok, "gc/run_skipped_overlap_count metric not found after negative increment")
+	assert.Equal(t, map[string]int64{s.Encoded(encoder): 3072}, metric, "Negative increment should not change the metric value.")
+}
+
 func TestGcsDownloadBytesCount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -5000,6 +5809,75 @@ func TestGcsReadCount(t *testing.T) {
 	}
 }
 
+func TestGcsReadHandleCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(m *otelMetrics)
+		expected map[attribute.Set]int64
+	}{
+		{
+			name: "read_handle_outcome_rejected",
+			f: func(m *otelMetrics) {
+				m.GcsReadHandleCount(5, "rejected")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("read_handle_outcome", "rejected")): 5,
+			},
+		},
+		{
+			name: "read_handle_outcome_reused",
+			f: func(m *otelMetrics) {
+				m.GcsReadHandleCount(5, "reused")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("read_handle_outcome", "reused")): 5,
+			},
+		}, {
+			name: "multiple_attributes_summed",
+			f: func(m *otelMetrics) {
+				m.GcsReadHandleCount(5, "rejected")
+				m.GcsReadHandleCount(2, "reused")
+				m.GcsReadHandleCount(3, "rejected")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("read_handle_outcome", "rejected")): 8,
+				attribute.NewSet(attribute.String("read_handle_outcome", "reused")): 2,
+			},
+		},
+		{
+			name: "negative_increment",
+			f: func(m *otelMetrics) {
+				m.GcsReadHandleCount(-5, "rejected")
+				m.GcsReadHandleCount(2, "rejected")
+			},
+			expected: map[attribute.Set]int64{attribute.NewSet(attribute.String("read_handle_outcome", "rejected")): 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			encoder := attribute.DefaultEncoder()
+			m, rd := setupOTel(ctx, t)
+
+			tc.f(m)
+			waitForMetricsProcessing()
+
+			metrics := gatherNonZeroCounterMetrics(ctx, t, rd)
+			metric, ok := metrics["gcs/read_handle_count"]
+			if len(tc.expected) == 0 {
+				assert.False(t, ok, "gcs/read_handle_count metric should not be found")
+				return
+			}
+			require.True(t, ok, "gcs/read_handle_count metric not found")
+			expectedMap := make(map[string]int64)
+			for k, v := range tc.expected {
+				expectedMap[k.Encoded(encoder)] = v
+			}
+			assert.Equal(t, expectedMap, metric)
+		})
+	}
+}
+
 func TestGcsReaderCount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -5174,6 +6052,15 @@ func TestGcsRequestCount(t *testing.T) {
 				attribute.NewSet(attribute.String("gcs_method", "GetFolder")): 5,
 			},
 		},
+		{
+			name: "gcs_method_GetLifecycleRules",
+			f: func(m *otelMetrics) {
+				m.GcsRequestCount(5, "GetLifecycleRules")
+			},
+			expected: map[attribute.Set]int64{
+				attribute.NewSet(attribute.String("gcs_method", "GetLifecycleRules")): 5,
+			},
+		},
 		{
 			name: "gcs_method_ListObjects",
 			f: func(m *otelMetrics) {
@@ -5352,6 +6239,11 @@ func TestGcsRequestLatencies(t *testing.T) {
 			latencies: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
 			gcsMethod: "GetFolder",
 		},
+		{
+			name:      "gcs_method_GetLifecycleRules",
+			latencies: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			gcsMethod: "GetLifecycleRules",
+		},
 		{
 			name:      "gcs_method_ListObjects",
 			latencies: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
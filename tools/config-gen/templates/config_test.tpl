@@ -92,7 +92,7 @@ func TestApplyOptimizations(t *testing.T) {
 				userSetFlags:    map[string]any{},
 				input:           nil,
 				expectOptimized: {{if ne (printf "%v" .Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{.Value}},
+				expectedValue:   {{ formatValue .Value }},
 			},
 		{{- end }}
 		{{- range .Optimizations.MachineBasedOptimization }}
@@ -106,7 +106,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: {{if ne (printf "%v" $mbo.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$mbo.Value}},
+				expectedValue:   {{ formatValue $mbo.Value }},
 			},
 		{{- end }}
 		{{- range .Optimizations.BucketTypeOptimization }}
@@ -117,7 +117,7 @@ func TestApplyOptimizations(t *testing.T) {
 				userSetFlags: map[string]any{},
 				input:           &OptimizationInput{BucketType: BucketType{{ $bto.BucketType | title }}},
 				expectOptimized: {{if ne (printf "%v" $bto.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$bto.Value}},
+				expectedValue:   {{ formatValue $bto.Value }},
 			},
 		{{- end }}
 		{{- if and .Optimizations.Profiles .Optimizations.MachineBasedOptimization }}
@@ -132,7 +132,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: {{if ne (printf "%v" $profile.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$profile.Value}},
+				expectedValue:   {{ formatValue $profile.Value }},
 			},
 		{{- end }}
 		{{- if and .Optimizations.Profiles .Optimizations.BucketTypeOptimization }}
@@ -144,7 +144,7 @@ func TestApplyOptimizations(t *testing.T) {
 				userSetFlags: map[string]any{},
 				input:           &OptimizationInput{BucketType: BucketType{{ $bto.BucketType | title }}},
 				expectOptimized: {{if ne (printf "%v" $profile.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$profile.Value}},
+				expectedValue:   {{ formatValue $profile.Value }},
 			},
 		{{- end }}
 		{{- if and .Optimizations.MachineBasedOptimization .Optimizations.BucketTypeOptimization }}
@@ -159,7 +159,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           &OptimizationInput{BucketType: BucketType{{ $bto.BucketType | title }}},
 				expectOptimized: {{if ne (printf "%v" $mbo.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$mbo.Value}},
+				expectedValue:   {{ formatValue $mbo.Value }},
 			},
 		{{- end }}
 		{{- if .Optimizations.MachineBasedOptimization }}
@@ -173,7 +173,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: {{if ne (printf "%v" $mbo.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$mbo.Value}},
+				expectedValue:   {{ formatValue $mbo.Value }},
 			},
 			{{- $unrelatedProfile := "aiml-training" -}}
 			{{- $hasRuleForUnrelatedProfile := false -}}
@@ -191,7 +191,7 @@ func TestApplyOptimizations(t *testing.T) {
 				},
 				input:           nil,
 				expectOptimized: {{if ne (printf "%v" $mbo.Value) (printf "%v" $flag.DefaultValue)}}true{{else}}false{{end}},
-				expectedValue:   {{$mbo.Value}},
+				expectedValue:   {{ formatValue $mbo.Value }},
 			},
 			{{- end }}
 		{{- end }}
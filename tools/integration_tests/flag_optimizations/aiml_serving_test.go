@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag_optimizations
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/client"
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/operations"
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/setup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// fileCacheHitMsg is logged by the file cache reader when a read is served
+	// from the local cache.
+	fileCacheHitMsg = "cacheHit: true"
+
+	// gcsReadMsg is logged by the debug bucket whenever a reader is opened
+	// against GCS.
+	gcsReadMsg = "ReadWithReadHandle("
+
+	servingObjectSize   = 1024 * 1024
+	servingReadOffset   = 4096
+	servingReadSize     = 64 * 1024
+	servingRepeatedRead = 5
+)
+
+////////////////////////////////////////////////////////////////////////
+// Test Functions
+////////////////////////////////////////////////////////////////////////
+
+// TestAIMLServingRepeatedReadsServedFromCache verifies that with the
+// aiml-serving profile, repeated reads of the same range of a small object are
+// served from the file cache after the first read, without going back to GCS.
+func TestAIMLServingRepeatedReadsServedFromCache(t *testing.T) {
+	flagsSet := setup.BuildFlagSets(testEnv.cfg, testEnv.bucketType, t.Name())
+	for _, flags := range flagsSet {
+		t.Run(strings.Join(flags, "_"), func(t *testing.T) {
+			mustMountGCSFuseAndSetupTestDir(flags, testEnv.ctx, testEnv.storageClient)
+			defer tearDownOptimizationTest(t)
+
+			// Arrange
+			fileName := "servingFile" + setup.GenerateRandomString(5)
+			content := setup.GenerateRandomString(servingObjectSize)
+			client.CreateObjectInGCSTestDir(testEnv.ctx, testEnv.storageClient, testDirName, fileName, content, t)
+			defer func() {
+				err := client.DeleteAllObjectsWithPrefix(testEnv.ctx, testEnv.storageClient, path.Join(testDirName, fileName))
+				require.NoError(t, err)
+			}()
+			mountedFilePath := path.Join(testEnv.testDirPath, fileName)
+			expected := []byte(content[servingReadOffset : servingReadOffset+servingReadSize])
+			// The first read populates the cache.
+			chunk, err := operations.ReadChunkFromFile(mountedFilePath, servingReadSize, servingReadOffset, os.O_RDONLY|syscall.O_DIRECT)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(expected, chunk), "Content mismatch on first read of %q", mountedFilePath)
+			require.NoError(t, os.Truncate(setup.LogFile(), 0), "Failed to truncate log file")
+
+			// Act
+			for i := range servingRepeatedRead {
+				chunk, err = operations.ReadChunkFromFile(mountedFilePath, servingReadSize, servingReadOffset, os.O_RDONLY|syscall.O_DIRECT)
+				require.NoError(t, err)
+				require.True(t, bytes.Equal(expected, chunk), "Content mismatch on repeated read %d of %q", i, mountedFilePath)
+			}
+
+			// Assert
+			logContent, err := os.ReadFile(setup.LogFile())
+			require.NoError(t, err, "Failed to read log file")
+			assert.Contains(t, string(logContent), fileCacheHitMsg, "Expected repeated reads to be served from file cache")
+			for _, line := range strings.Split(string(logContent), "\n") {
+				assert.False(t, strings.Contains(line, gcsReadMsg) && strings.Contains(line, fileName), "Repeated reads unexpectedly re-downloaded %q from GCS: %s", fileName, line)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag_optimizations
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/client"
+	"github.com/googlecloudplatform/gcsfuse/v3/tools/integration_tests/util/setup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// gcsStatMsg is logged by the debug bucket whenever an object is statted
+	// against GCS.
+	gcsStatMsg = "StatObject("
+
+	metadataCacheEntries = 50
+)
+
+// profileStatCacheSizesMb are the stat cache sizes set by the profiles, as
+// logged at startup.
+var profileStatCacheSizesMb = map[string]int64{
+	"aiml-training":      4096,
+	"aiml-serving":       2048,
+	"aiml-inference":     2048,
+	"aiml-checkpointing": 64,
+}
+
+////////////////////////////////////////////////////////////////////////
+// Helpers
+////////////////////////////////////////////////////////////////////////
+
+// profileOf returns the profile passed in the flags, if any.
+func profileOf(flags []string) string {
+	for _, flag := range flags {
+		if profile, ok := strings.CutPrefix(flag, "--profile="); ok {
+			return profile
+		}
+	}
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////
+// Test Functions
+////////////////////////////////////////////////////////////////////////
+
+// TestProfileMetadataCacheSizes verifies that a profile sizes the stat cache
+// as logged at startup, and that the stat cache holds the entries of all the
+// objects of a directory, serving their repeated stats without going back to
+// GCS.
+func TestProfileMetadataCacheSizes(t *testing.T) {
+	flagsSet := setup.BuildFlagSets(testEnv.cfg, testEnv.bucketType, t.Name())
+	for _, flags := range flagsSet {
+		t.Run(strings.Join(flags, "_"), func(t *testing.T) {
+			mustMountGCSFuseAndSetupTestDir(flags, testEnv.ctx, testEnv.storageClient)
+			defer tearDownOptimizationTest(t)
+			expectedSizeMb, ok := profileStatCacheSizesMb[profileOf(flags)]
+			require.True(t, ok, "No expected stat cache size for flags %v", flags)
+			logContent, err := os.ReadFile(setup.LogFile())
+			require.NoError(t, err, "Failed to read log file")
+			require.Contains(t, string(logContent), fmt.Sprintf("stat-cache-max-size-mb %d", expectedSizeMb))
+
+			// Arrange
+			dirName := "metadataCacheDir" + setup.GenerateRandomString(5)
+			var fileNames []string
+			for i := range metadataCacheEntries {
+				fileName := fmt.Sprintf("file%d", i)
+				client.CreateObjectInGCSTestDir(testEnv.ctx, testEnv.storageClient, path.Join(testDirName, dirName), fileName, "", t)
+				fileNames = append(fileNames, fileName)
+			}
+			defer func() {
+				err := client.DeleteAllObjectsWithPrefix(testEnv.ctx, testEnv.storageClient, path.Join(testDirName, dirName))
+				require.NoError(t, err)
+			}()
+			mountedDirPath := path.Join(testEnv.testDirPath, dirName)
+			// The first stats populate the stat cache.
+			for _, fileName := range fileNames {
+				_, err := os.Stat(path.Join(mountedDirPath, fileName))
+				require.NoError(t, err)
+			}
+			require.NoError(t, os.Truncate(setup.LogFile(), 0), "Failed to truncate log file")
+
+			// Act
+			for _, fileName := range fileNames {
+				_, err := os.Stat(path.Join(mountedDirPath, fileName))
+				require.NoError(t, err)
+			}
+
+			// Assert
+			logContent, err = os.ReadFile(setup.LogFile())
+			require.NoError(t, err, "Failed to read log file")
+			for _, line := range strings.Split(string(logContent), "\n") {
+				assert.False(t, strings.Contains(line, gcsStatMsg) && strings.Contains(line, dirName), "Repeated stat unexpectedly went to GCS: %s", line)
+			}
+		})
+	}
+}
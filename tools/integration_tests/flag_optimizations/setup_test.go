@@ -96,8 +96,8 @@ func TestMain(m *testing.M) {
 		cfg.FlagOptimizations[0].TestBucket = setup.TestBucket()
 		cfg.FlagOptimizations[0].GKEMountedDirectory = setup.MountedDirectory()
 		cfg.FlagOptimizations[0].LogFile = setup.LogFile()
-		// Initialize the slice to hold 12 specific test configurations
-		cfg.FlagOptimizations[0].Configs = make([]test_suite.ConfigItem, 12)
+		// Initialize the slice to hold 14 specific test configurations
+		cfg.FlagOptimizations[0].Configs = make([]test_suite.ConfigItem, 14)
 		cfg.FlagOptimizations[0].Configs[0].Run = "TestMountFails"
 		cfg.FlagOptimizations[0].Configs[0].Flags = []string{"--profile=unknown-profile"}
 		cfg.FlagOptimizations[0].Configs[0].Compatible = map[string]bool{"flat": true, "hns": true, "zonal": true}
@@ -107,7 +107,7 @@ func TestMain(m *testing.M) {
 		cfg.FlagOptimizations[0].Configs[1].Compatible = map[string]bool{"flat": true, "hns": false, "zonal": false}
 		cfg.FlagOptimizations[0].Configs[1].RunOnGKE = true
 		cfg.FlagOptimizations[0].Configs[2].Run = "TestRenameDirLimitNotSet"
-		cfg.FlagOptimizations[0].Configs[2].Flags = []string{"--machine-type=low-end-machine", "--profile=aiml-training", "--profile=aiml-serving"}
+		cfg.FlagOptimizations[0].Configs[2].Flags = []string{"--machine-type=low-end-machine", "--profile=aiml-training", "--profile=aiml-serving", "--profile=aiml-inference"}
 		cfg.FlagOptimizations[0].Configs[2].Compatible = map[string]bool{"flat": true, "hns": false, "zonal": false}
 		cfg.FlagOptimizations[0].Configs[2].RunOnGKE = true
 		cfg.FlagOptimizations[0].Configs[3].Run = "TestImplicitDirsEnabled"
@@ -115,9 +115,11 @@ func TestMain(m *testing.M) {
 			"--machine-type=a3-highgpu-8g",
 			"--profile=aiml-training",
 			"--profile=aiml-serving",
+			"--profile=aiml-inference",
 			"--profile=aiml-checkpointing",
 			"--machine-type=low-end-machine --profile=aiml-training",
 			"--machine-type=low-end-machine --profile=aiml-serving",
+			"--machine-type=low-end-machine --profile=aiml-inference",
 			"--machine-type=low-end-machine --profile=aiml-checkpointing",
 		}
 		cfg.FlagOptimizations[0].Configs[3].Compatible = map[string]bool{"flat": true, "hns": false, "zonal": false}
@@ -170,6 +172,21 @@ func TestMain(m *testing.M) {
 		cfg.FlagOptimizations[0].Configs[11].Flags = []string{"--implicit-dirs --log-severity=trace"}
 		cfg.FlagOptimizations[0].Configs[11].Compatible = map[string]bool{"flat": false, "hns": false, "zonal": true}
 		cfg.FlagOptimizations[0].Configs[11].RunOnGKE = false
+
+		cfg.FlagOptimizations[0].Configs[12].Run = "TestAIMLServingRepeatedReadsServedFromCache"
+		cfg.FlagOptimizations[0].Configs[12].Flags = []string{"--profile=aiml-serving --log-severity=trace --cache-dir=/gcsfuse-tmp/TestAIMLServingRepeatedReadsServedFromCache"}
+		cfg.FlagOptimizations[0].Configs[12].Compatible = map[string]bool{"flat": true, "hns": true, "zonal": false}
+		cfg.FlagOptimizations[0].Configs[12].RunOnGKE = false
+
+		cfg.FlagOptimizations[0].Configs[13].Run = "TestProfileMetadataCacheSizes"
+		cfg.FlagOptimizations[0].Configs[13].Flags = []string{
+			"--profile=aiml-training --log-severity=trace",
+			"--profile=aiml-serving --log-severity=trace",
+			"--profile=aiml-inference --log-severity=trace",
+			"--profile=aiml-checkpointing --log-severity=trace",
+		}
+		cfg.FlagOptimizations[0].Configs[13].Compatible = map[string]bool{"flat": true, "hns": true, "zonal": false}
+		cfg.FlagOptimizations[0].Configs[13].RunOnGKE = false
 	}
 
 	testEnv.ctx = context.Background()
@@ -43,6 +43,9 @@ func (o *noopTracer) SetCacheReadAttributes(span trace.Span, isCacheHit bool, by
 
 func (o *noopTracer) SetUploadAttributes(span trace.Span, bytesUploaded int64, objectName string) {}
 
+func (o *noopTracer) SetDownloadAttributes(span trace.Span, bytesDownloaded int64, correlationID string) {
+}
+
 func (*noopTracer) TraceUpload(ctx context.Context, name string, objName string, bytes *int64, err *error) (context.Context, func()) {
 	return ctx, emptyFinisher
 }
@@ -30,11 +30,13 @@ type otelTracer struct {
 }
 
 var (
-	bytesReadKey     = attribute.Key(BYTES_READ)
-	bytesUploadedKey = attribute.Key(BYTES_UPLOADED)
-	objectNameKey    = attribute.Key(OBJECT_NAME)
-	cacheHit         = attribute.Bool(IS_CACHE_HIT, true)
-	cacheMiss        = attribute.Bool(IS_CACHE_HIT, false)
+	bytesReadKey       = attribute.Key(BYTES_READ)
+	bytesUploadedKey   = attribute.Key(BYTES_UPLOADED)
+	objectNameKey      = attribute.Key(OBJECT_NAME)
+	bytesDownloadedKey = attribute.Key(BYTES_DOWNLOADED)
+	correlationIDKey   = attribute.Key(CORRELATION_ID)
+	cacheHit           = attribute.Bool(IS_CACHE_HIT, true)
+	cacheMiss          = attribute.Bool(IS_CACHE_HIT, false)
 )
 
 func (o *otelTracer) StartSpan(ctx context.Context, traceName string) (context.Context, trace.Span) {
@@ -76,6 +78,19 @@ func (o *otelTracer) SetUploadAttributes(span trace.Span, bytesUploaded int64, o
 	span.SetAttributes(attrSet...)
 }
 
+func (o *otelTracer) SetDownloadAttributes(span trace.Span, bytesDownloaded int64, correlationID string) {
+	attrSetPtr := o.slicePool.Get().(*[]attribute.KeyValue)
+	attrSet := *attrSetPtr
+	defer o.slicePool.Put(attrSetPtr)
+	attrSet[0] = bytesDownloadedKey.Int64(bytesDownloaded)
+	if correlationID == "" {
+		span.SetAttributes(attrSet[0])
+		return
+	}
+	attrSet[1] = correlationIDKey.String(correlationID)
+	span.SetAttributes(attrSet...)
+}
+
 func (o *otelTracer) TraceUpload(ctx context.Context, name string, objName string, bytes *int64, err *error) (context.Context, func()) {
 	ctx, span := o.StartSpan(ctx, name)
 
@@ -124,6 +124,46 @@ func TestOtelTracer_SetCacheReadAttributes(t *testing.T) {
 	}
 }
 
+func TestOtelTracer_SetDownloadAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	tracer := NewOTELTracer()
+	spanName := "test-download-span"
+	bytesDownloaded := int64(123)
+
+	testCases := []struct {
+		name          string
+		correlationID string
+		wantAttrs     []attribute.KeyValue
+	}{
+		{
+			name:          "with_correlation_id",
+			correlationID: "epoch-3",
+			wantAttrs:     []attribute.KeyValue{attribute.Int64(BYTES_DOWNLOADED, bytesDownloaded), attribute.String(CORRELATION_ID, "epoch-3")},
+		},
+		{
+			name:          "without_correlation_id",
+			correlationID: "",
+			wantAttrs:     []attribute.KeyValue{attribute.Int64(BYTES_DOWNLOADED, bytesDownloaded)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder.Reset()
+
+			_, span := tracer.StartSpan(context.Background(), spanName)
+			tracer.SetDownloadAttributes(span, bytesDownloaded, tc.correlationID)
+			tracer.EndSpan(span)
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.ElementsMatch(t, tc.wantAttrs, spans[0].Attributes())
+		})
+	}
+}
+
 func TestOtelTracer_PropagateTraceContext(t *testing.T) {
 	recorder := tracetest.NewSpanRecorder()
 	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
@@ -15,8 +15,10 @@
 package tracing
 
 const (
-	IS_CACHE_HIT   = "cache.hit"         // Indicates if the response was served from cache or not.
-	BYTES_READ     = "read.size"         // Indicates the number of bytes read from the given reader
-	BYTES_UPLOADED = "write.chunk.size"  // Indicates the number of bytes uploaded
-	OBJECT_NAME    = "write.object_name" // Indicates the object name uploaded
+	IS_CACHE_HIT     = "cache.hit"               // Indicates if the response was served from cache or not.
+	BYTES_READ       = "read.size"               // Indicates the number of bytes read from the given reader
+	BYTES_UPLOADED   = "write.chunk.size"        // Indicates the number of bytes uploaded
+	OBJECT_NAME      = "write.object_name"       // Indicates the object name uploaded
+	BYTES_DOWNLOADED = "download.block.size"     // Indicates the number of bytes downloaded to a block
+	CORRELATION_ID   = "download.correlation_id" // Indicates the higher-level operation a download belongs to
 )
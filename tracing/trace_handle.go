@@ -46,6 +46,9 @@ type TraceHandle interface {
 	// A handle interface method to set attributes for upload
 	SetUploadAttributes(span trace.Span, bytesUploaded int64, objectName string)
 
+	// A handle interface method to set attributes for a block download
+	SetDownloadAttributes(span trace.Span, bytesDownloaded int64, correlationID string)
+
 	// TraceUpload starts a span and returns a finisher function that can set upload attributes, record error and end span
 	TraceUpload(ctx context.Context, name string, objName string, bytes *int64, err *error) (context.Context, func())
 